@@ -0,0 +1,195 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Command that runs simulated driver stations against Cheesy Arena, for rehearsing matches without robots.
+//
+// Real driver stations connect to the FMS at 10.0.100.5; to run the simulator on the same machine as Cheesy Arena,
+// either add that address to the loopback interface or point -fms at another address that the arena listens on. The
+// arena sends control packets to the address each driver station connects from, so simulating more than one team on
+// the same machine requires -loopback-addresses (Linux only), which gives each team its own 127.TE.AM.1 address.
+//
+// While running, commands are read from standard input; type "help" to list them.
+
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"github.com/Team254/cheesy-arena/dssim"
+	"github.com/Team254/cheesy-arena/network"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const reconnectPeriodSec = 1
+
+const helpText = `Commands (use "all" in place of a team number to apply to every team):
+  battery <team> <volts>      Sets the reported robot battery voltage.
+  trip <team> <ms>            Sets the reported round-trip time to the robot.
+  loss <team> <fraction>      Drops the given fraction (0-1) of status packets.
+  link <team> <on|off>        Connects or disconnects the robot.
+  flap <team> <duration>      Disconnects the robot for the given duration (e.g. 2s).
+  estop <team> <on|off>       Presses or releases the E-stop.
+  astop <team> <on|off>       Presses or releases the A-stop.
+  status                      Shows the last control packet received by each team.
+  quit                        Exits.`
+
+func main() {
+	teamsFlag := flag.String("teams", "", "comma-separated list of team numbers to simulate")
+	fmsAddress := flag.String("fms", network.ServerIpAddress, "address of the FMS")
+	loopbackAddresses := flag.Bool(
+		"loopback-addresses", false, "connect each team from its own 127.TE.AM.1 address (Linux only)",
+	)
+	useLiteUdpPort := flag.Bool("lite-udp-port", false, "receive control packets on the port used by FMS Lite")
+	batteryVoltage := flag.Float64("battery", 12.5, "initial robot battery voltage")
+	tripTimeMs := flag.Int("trip-time-ms", 2, "initial round-trip time to the robot in milliseconds")
+	packetLossRate := flag.Float64("packet-loss", 0, "initial fraction (0-1) of status packets to drop")
+	flapInterval := flag.Duration("flap-every", 0, "interval at which to briefly disconnect each robot; 0 disables")
+	flapDuration := flag.Duration("flap-for", time.Second, "length of each robot disconnection when flapping")
+	flag.Parse()
+
+	var driverStations []*dssim.DriverStation
+	teamIds := make(map[*dssim.DriverStation]int)
+	for _, teamString := range strings.Split(*teamsFlag, ",") {
+		teamId, err := strconv.Atoi(strings.TrimSpace(teamString))
+		if err != nil || teamId <= 0 {
+			log.Fatalf("Invalid team number %q; specify the teams to simulate with -teams.", teamString)
+		}
+		config := dssim.Config{TeamId: teamId, FmsAddress: *fmsAddress, UseLiteUdpPort: *useLiteUdpPort}
+		if *loopbackAddresses {
+			config.LocalAddress = fmt.Sprintf("127.%d.%d.1", teamId/100, teamId%100)
+		}
+		ds := dssim.New(config)
+		ds.SetBatteryVoltage(*batteryVoltage)
+		ds.SetTripTimeMs(*tripTimeMs)
+		ds.SetPacketLossRate(*packetLossRate)
+		if *flapInterval > 0 {
+			ds.SetRobotLinkFlapping(*flapInterval, *flapDuration)
+		}
+		driverStations = append(driverStations, ds)
+		teamIds[ds] = teamId
+	}
+	if len(driverStations) > 1 && !*loopbackAddresses {
+		log.Fatalln("Simulating more than one team requires -loopback-addresses.")
+	}
+
+	for _, ds := range driverStations {
+		go stayConnected(ds, teamIds[ds])
+	}
+
+	fmt.Println(helpText)
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		if quit := handleCommand(strings.Fields(scanner.Text()), driverStations, teamIds); quit {
+			break
+		}
+	}
+	for _, ds := range driverStations {
+		ds.Close()
+	}
+}
+
+// Keeps the given driver station connected to the FMS, retrying like a real one while the team isn't in the current
+// or next match.
+func stayConnected(ds *dssim.DriverStation, teamId int) {
+	for {
+		if err := ds.Connect(); err != nil {
+			time.Sleep(time.Second * reconnectPeriodSec)
+			continue
+		}
+		<-ds.Disconnected()
+		log.Printf("Team %d disconnected from the FMS; reconnecting.", teamId)
+		ds.Close()
+	}
+}
+
+// Applies the given command to the matching driver stations and returns true if the simulator should exit.
+func handleCommand(fields []string, driverStations []*dssim.DriverStation, teamIds map[*dssim.DriverStation]int) bool {
+	if len(fields) == 0 {
+		return false
+	}
+	switch fields[0] {
+	case "quit", "exit":
+		return true
+	case "help":
+		fmt.Println(helpText)
+		return false
+	case "status":
+		for _, ds := range driverStations {
+			station, _ := ds.AllianceStation()
+			if packet, ok := ds.LastControlPacket(); ok {
+				fmt.Printf(
+					"Team %d (%s): auto=%t enabled=%t estop=%t astop=%t match=%d remaining=%ds\n",
+					teamIds[ds],
+					station,
+					packet.Auto,
+					packet.Enabled,
+					packet.EStop,
+					packet.AStop,
+					packet.MatchNumber,
+					packet.MatchSecsRemaining,
+				)
+			} else {
+				fmt.Printf("Team %d: not connected\n", teamIds[ds])
+			}
+		}
+		return false
+	}
+
+	if len(fields) != 3 {
+		fmt.Println("Invalid command; type \"help\" to list the commands.")
+		return false
+	}
+	var targets []*dssim.DriverStation
+	for _, ds := range driverStations {
+		if fields[1] == "all" || fields[1] == strconv.Itoa(teamIds[ds]) {
+			targets = append(targets, ds)
+		}
+	}
+	if len(targets) == 0 {
+		fmt.Printf("Team %s is not being simulated.\n", fields[1])
+		return false
+	}
+
+	var apply func(ds *dssim.DriverStation)
+	var err error
+	switch fields[0] {
+	case "battery":
+		var voltage float64
+		voltage, err = strconv.ParseFloat(fields[2], 64)
+		apply = func(ds *dssim.DriverStation) { ds.SetBatteryVoltage(voltage) }
+	case "trip":
+		var tripTimeMs int
+		tripTimeMs, err = strconv.Atoi(fields[2])
+		apply = func(ds *dssim.DriverStation) { ds.SetTripTimeMs(tripTimeMs) }
+	case "loss":
+		var rate float64
+		rate, err = strconv.ParseFloat(fields[2], 64)
+		apply = func(ds *dssim.DriverStation) { ds.SetPacketLossRate(rate) }
+	case "link":
+		apply = func(ds *dssim.DriverStation) { ds.SetRobotLinked(fields[2] == "on") }
+	case "flap":
+		var duration time.Duration
+		duration, err = time.ParseDuration(fields[2])
+		apply = func(ds *dssim.DriverStation) { ds.FlapRobotLink(duration) }
+	case "estop":
+		apply = func(ds *dssim.DriverStation) { ds.SetEStop(fields[2] == "on") }
+	case "astop":
+		apply = func(ds *dssim.DriverStation) { ds.SetAStop(fields[2] == "on") }
+	default:
+		fmt.Println("Invalid command; type \"help\" to list the commands.")
+		return false
+	}
+	if err != nil {
+		fmt.Printf("Invalid value %q: %v\n", fields[2], err)
+		return false
+	}
+	for _, ds := range targets {
+		apply(ds)
+	}
+	return false
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Server configuration loaded from command-line flags and an optional YAML config file.
+
+package config
+
+import (
+	"flag"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+const (
+	defaultPort     = 8080
+	defaultDataDir  = "."
+	defaultAssetDir = "."
+	dbFilename      = "event.db"
+	logsDir         = "static/logs"
+)
+
+// Settings that govern how the server process runs, as opposed to the per-event settings stored in the database.
+type Config struct {
+	// Path to the event database file; defaults to "event.db" within the data directory.
+	DbPath string `yaml:"db_path"`
+	// Address of the interface on which to serve the web interface; blank means all interfaces.
+	BindAddress string `yaml:"bind_address"`
+	Port        int    `yaml:"port"`
+	// Directory in which the database, its backups, and the match logs are stored by default.
+	DataDir string `yaml:"data_dir"`
+	// Directory in which driver station match logs are written; defaults to "static/logs" within the data directory.
+	LogDir string `yaml:"log_dir"`
+	// Directory containing the templates, static assets, and schedules that ship with the software.
+	AssetDir string `yaml:"asset_dir"`
+	// Certificate and private key files for serving the web interface over HTTPS instead of HTTP.
+	TlsCertFile string `yaml:"tls_cert_file"`
+	TlsKeyFile  string `yaml:"tls_key_file"`
+	// Whether to run without connecting to the PLC, access point, switches, or team signs (e.g. for training).
+	NoFieldHardware bool `yaml:"no_field_hardware"`
+}
+
+// Returns a configuration containing the default values used when neither a flag nor the config file specify one.
+func Default() *Config {
+	return &Config{Port: defaultPort, DataDir: defaultDataDir, AssetDir: defaultAssetDir}
+}
+
+// Builds the configuration from the given command-line arguments (excluding the program name). Values given as flags
+// take precedence over those in the config file named by the "-config" flag, which in turn take precedence over the
+// defaults.
+func Parse(args []string, output io.Writer) (*Config, error) {
+	config := Default()
+	var configPath string
+	if err := parseFlags(args, output, config, &configPath); err != nil {
+		return nil, err
+	}
+
+	if configPath != "" {
+		// Start over from the contents of the config file and then apply the flags on top of them.
+		var err error
+		if config, err = Load(configPath); err != nil {
+			return nil, err
+		}
+		if err = parseFlags(args, io.Discard, config, &configPath); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := config.resolve(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// Reads the YAML config file at the given path, using the defaults for any values it doesn't specify.
+func Load(path string) (*Config, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	config := Default()
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err = decoder.Decode(config); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("error parsing config file %s: %v", path, err)
+	}
+	return config, nil
+}
+
+// Returns the address on which the web server should listen.
+func (config *Config) ListenAddress() string {
+	return net.JoinHostPort(config.BindAddress, strconv.Itoa(config.Port))
+}
+
+// Returns true if the web interface should be served over HTTPS.
+func (config *Config) TlsEnabled() bool {
+	return config.TlsCertFile != ""
+}
+
+// Parses the given arguments into the given configuration, using its existing values as the defaults.
+func parseFlags(args []string, output io.Writer, config *Config, configPath *string) error {
+	flagSet := flag.NewFlagSet("cheesy-arena", flag.ContinueOnError)
+	flagSet.SetOutput(output)
+	flagSet.StringVar(configPath, "config", *configPath, "path to a YAML config file")
+	flagSet.StringVar(&config.DbPath, "db", config.DbPath, "path to the event database file (default <data-dir>/event.db)")
+	flagSet.StringVar(&config.BindAddress, "bind", config.BindAddress, "address on which to serve the web interface")
+	flagSet.IntVar(&config.Port, "port", config.Port, "port on which to serve the web interface")
+	flagSet.StringVar(&config.DataDir, "data-dir", config.DataDir, "directory in which to store event data")
+	flagSet.StringVar(
+		&config.LogDir, "log-dir", config.LogDir, "directory in which to write match logs (default <data-dir>/static/logs)",
+	)
+	flagSet.StringVar(
+		&config.AssetDir,
+		"asset-dir",
+		config.AssetDir,
+		"directory containing the templates, static assets, and schedules",
+	)
+	flagSet.StringVar(&config.TlsCertFile, "tls-cert", config.TlsCertFile, "TLS certificate file for serving HTTPS")
+	flagSet.StringVar(&config.TlsKeyFile, "tls-key", config.TlsKeyFile, "TLS private key file for serving HTTPS")
+	flagSet.BoolVar(
+		&config.NoFieldHardware,
+		"no-field-hardware",
+		config.NoFieldHardware,
+		"run without connecting to the PLC, access point, switches, or team signs",
+	)
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+	if flagSet.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q", flagSet.Arg(0))
+	}
+	return nil
+}
+
+// Validates the configuration and fills in the paths that default to being relative to the data directory.
+func (config *Config) resolve() error {
+	if config.Port <= 0 || config.Port > 65535 {
+		return fmt.Errorf("invalid port %d", config.Port)
+	}
+	if (config.TlsCertFile == "") != (config.TlsKeyFile == "") {
+		return fmt.Errorf("both a TLS certificate and a TLS key must be given to serve HTTPS")
+	}
+	if config.DataDir == "" {
+		config.DataDir = defaultDataDir
+	}
+	if config.AssetDir == "" {
+		config.AssetDir = defaultAssetDir
+	}
+	if config.DbPath == "" {
+		config.DbPath = filepath.Join(config.DataDir, dbFilename)
+	}
+	if config.LogDir == "" {
+		config.LogDir = filepath.Join(config.DataDir, logsDir)
+	}
+	return nil
+}
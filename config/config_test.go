@@ -0,0 +1,127 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package config
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseDefaults(t *testing.T) {
+	config, err := Parse([]string{}, io.Discard)
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		Config{DbPath: "event.db", Port: 8080, DataDir: ".", LogDir: "static/logs", AssetDir: "."},
+		*config,
+	)
+	assert.Equal(t, ":8080", config.ListenAddress())
+	assert.False(t, config.TlsEnabled())
+}
+
+func TestParseFlags(t *testing.T) {
+	config, err := Parse(
+		[]string{
+			"-bind", "127.0.0.1", "-port", "8081", "-data-dir", "/tmp/arena2", "-tls-cert", "cert.pem", "-tls-key",
+			"key.pem", "-no-field-hardware", "-asset-dir", "/opt/cheesy-arena",
+		},
+		io.Discard,
+	)
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		Config{
+			DbPath:          "/tmp/arena2/event.db",
+			BindAddress:     "127.0.0.1",
+			Port:            8081,
+			DataDir:         "/tmp/arena2",
+			LogDir:          "/tmp/arena2/static/logs",
+			AssetDir:        "/opt/cheesy-arena",
+			TlsCertFile:     "cert.pem",
+			TlsKeyFile:      "key.pem",
+			NoFieldHardware: true,
+		},
+		*config,
+	)
+	assert.Equal(t, "127.0.0.1:8081", config.ListenAddress())
+	assert.True(t, config.TlsEnabled())
+
+	config, err = Parse([]string{"-db", "other.db", "-log-dir", "/var/log/arena"}, io.Discard)
+	assert.Nil(t, err)
+	assert.Equal(t, "other.db", config.DbPath)
+	assert.Equal(t, "/var/log/arena", config.LogDir)
+}
+
+func TestParseConfigFile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "arena.yaml")
+	assert.Nil(
+		t,
+		os.WriteFile(
+			configPath,
+			[]byte(
+				"data_dir: /srv/arena\nport: 9000\nbind_address: 10.0.100.5\nno_field_hardware: true\n"+
+					"asset_dir: /usr/share/cheesy-arena\n",
+			),
+			0644,
+		),
+	)
+
+	config, err := Parse([]string{"-config", configPath}, io.Discard)
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		Config{
+			DbPath:          "/srv/arena/event.db",
+			BindAddress:     "10.0.100.5",
+			Port:            9000,
+			DataDir:         "/srv/arena",
+			LogDir:          "/srv/arena/static/logs",
+			AssetDir:        "/usr/share/cheesy-arena",
+			NoFieldHardware: true,
+		},
+		*config,
+	)
+
+	// Check that flags take precedence over the config file regardless of their order.
+	config, err = Parse([]string{"-port", "9001", "-config", configPath, "-no-field-hardware=false"}, io.Discard)
+	assert.Nil(t, err)
+	assert.Equal(t, 9001, config.Port)
+	assert.Equal(t, "/srv/arena", config.DataDir)
+	assert.False(t, config.NoFieldHardware)
+
+	// Check that an empty config file results in the defaults.
+	assert.Nil(t, os.WriteFile(configPath, []byte{}, 0644))
+	config, err = Parse([]string{"-config", configPath}, io.Discard)
+	assert.Nil(t, err)
+	assert.Equal(t, 8080, config.Port)
+}
+
+func TestParseErrors(t *testing.T) {
+	_, err := Parse([]string{"-bogus"}, io.Discard)
+	assert.NotNil(t, err)
+	_, err = Parse([]string{"extra"}, io.Discard)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "unexpected argument \"extra\"", err.Error())
+	}
+	_, err = Parse([]string{"-port", "70000"}, io.Discard)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "invalid port 70000", err.Error())
+	}
+	_, err = Parse([]string{"-tls-cert", "cert.pem"}, io.Discard)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "both a TLS certificate and a TLS key")
+	}
+	_, err = Parse([]string{"-config", filepath.Join(t.TempDir(), "missing.yaml")}, io.Discard)
+	assert.NotNil(t, err)
+
+	configPath := filepath.Join(t.TempDir(), "arena.yaml")
+	assert.Nil(t, os.WriteFile(configPath, []byte("prot: 9000\n"), 0644))
+	_, err = Parse([]string{"-config", configPath}, io.Discard)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "field prot not found")
+	}
+}
@@ -0,0 +1,422 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Simulated FRC Driver Station that connects to the FMS like a real one, for exercising the arena without robots.
+
+package dssim
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/network"
+	"io"
+	"log"
+	"math/rand"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	fmsTcpPort            = 1750
+	fmsUdpStatusPort      = 1160
+	dsUdpControlPort      = 1121
+	dsUdpControlPortLite  = 1120
+	statusPeriodMs        = 20
+	robotLogPeriodMs      = 250
+	defaultBatteryVoltage = 12.5
+	defaultTripTimeMs     = 2
+	tcpLinkTimeoutSec     = 5
+)
+
+// Settings governing how the simulated driver station connects to the FMS.
+type Config struct {
+	TeamId int
+	// Address of the FMS; defaults to the address that real driver stations connect to.
+	FmsAddress string
+	// Local address to connect from and to receive control packets on; blank means any. The FMS sends control packets
+	// to the address that the TCP connection comes from, so each simulated driver station on the same machine needs its
+	// own address (e.g. 127.2.54.1 for Team 254 on Linux).
+	LocalAddress string
+	// Whether to receive control packets on the port used by FMS Lite instead of the one used by the full FMS.
+	UseLiteUdpPort bool
+	// Overrides of the standard ports, for testing against an FMS that isn't listening on them.
+	FmsTcpPort       int
+	FmsUdpStatusPort int
+	UdpControlPort   int
+}
+
+// Simulated driver station and robot whose reported state can be manipulated while it is connected.
+type DriverStation struct {
+	config            Config
+	mutex             sync.Mutex
+	tcpConn           net.Conn
+	udpStatusConn     net.Conn
+	udpControlConn    *net.UDPConn
+	done              chan struct{}
+	disconnected      chan struct{}
+	waitGroup         sync.WaitGroup
+	allianceStation   string
+	wrongStation      bool
+	gameData          string
+	lastControl       *ControlPacket
+	controlCount      int
+	statusCount       int
+	batteryVoltage    float64
+	tripTimeMs        int
+	packetLossRate    float64
+	missedPacketCount int
+	robotLinked       bool
+	linkFlapInterval  time.Duration
+	linkFlapDuration  time.Duration
+	linkDownUntil     time.Time
+	nextLinkFlap      time.Time
+	eStop             bool
+	aStop             bool
+}
+
+// Creates a simulated driver station with a healthy robot; it doesn't connect to the FMS until Connect() is called.
+func New(config Config) *DriverStation {
+	if config.FmsAddress == "" {
+		config.FmsAddress = network.ServerIpAddress
+	}
+	if config.FmsTcpPort == 0 {
+		config.FmsTcpPort = fmsTcpPort
+	}
+	if config.FmsUdpStatusPort == 0 {
+		config.FmsUdpStatusPort = fmsUdpStatusPort
+	}
+	if config.UdpControlPort == 0 {
+		if config.UseLiteUdpPort {
+			config.UdpControlPort = dsUdpControlPortLite
+		} else {
+			config.UdpControlPort = dsUdpControlPort
+		}
+	}
+	return &DriverStation{
+		config:         config,
+		batteryVoltage: defaultBatteryVoltage,
+		tripTimeMs:     defaultTripTimeMs,
+		robotLinked:    true,
+	}
+}
+
+// Returns the configured local address combined with the given port (zero meaning an ephemeral port).
+func (ds *DriverStation) localAddress(port int) string {
+	return net.JoinHostPort(ds.config.LocalAddress, strconv.Itoa(port))
+}
+
+// Connects to the FMS and starts exchanging packets with it in the background. Returns an error if the FMS doesn't
+// accept the connection, which happens when the team isn't in the current or next match.
+func (ds *DriverStation) Connect() error {
+	udpControlAddress, err := net.ResolveUDPAddr("udp4", ds.localAddress(ds.config.UdpControlPort))
+	if err != nil {
+		return err
+	}
+	udpControlConn, err := net.ListenUDP("udp4", udpControlAddress)
+	if err != nil {
+		return fmt.Errorf("failed to listen for control packets: %v", err)
+	}
+
+	var localTcpAddress *net.TCPAddr
+	if ds.config.LocalAddress != "" {
+		localTcpAddress, _ = net.ResolveTCPAddr("tcp4", ds.localAddress(0))
+	}
+	dialer := net.Dialer{LocalAddr: localTcpAddress, Timeout: time.Second * tcpLinkTimeoutSec}
+	tcpConn, err := dialer.Dial(
+		"tcp4", net.JoinHostPort(ds.config.FmsAddress, strconv.Itoa(ds.config.FmsTcpPort)),
+	)
+	if err != nil {
+		udpControlConn.Close()
+		return err
+	}
+
+	// Identify the team and wait for the FMS to assign the driver station to an alliance station.
+	teamPacket := encodeTcpPacket(tcpTeamNumberPacketType, []byte{byte(ds.config.TeamId >> 8), byte(ds.config.TeamId)})
+	if _, err = tcpConn.Write(teamPacket); err != nil {
+		tcpConn.Close()
+		udpControlConn.Close()
+		return err
+	}
+	var stationPacket [5]byte
+	tcpConn.SetReadDeadline(time.Now().Add(time.Second * tcpLinkTimeoutSec))
+	if _, err = io.ReadFull(tcpConn, stationPacket[:]); err != nil {
+		tcpConn.Close()
+		udpControlConn.Close()
+		return fmt.Errorf("FMS did not accept connection for Team %d: %v", ds.config.TeamId, err)
+	}
+	if stationPacket[2] != tcpStationPacketType || stationPacket[3] >= byte(len(allianceStations)) {
+		tcpConn.Close()
+		udpControlConn.Close()
+		return fmt.Errorf("invalid station assignment packet received: %v", stationPacket)
+	}
+
+	udpStatusConn, err := net.Dial(
+		"udp4", net.JoinHostPort(ds.config.FmsAddress, strconv.Itoa(ds.config.FmsUdpStatusPort)),
+	)
+	if err != nil {
+		tcpConn.Close()
+		udpControlConn.Close()
+		return err
+	}
+
+	ds.mutex.Lock()
+	ds.tcpConn = tcpConn
+	ds.udpStatusConn = udpStatusConn
+	ds.udpControlConn = udpControlConn
+	ds.allianceStation = allianceStations[stationPacket[3]]
+	ds.wrongStation = stationPacket[4] == tcpStationStatusMismatch
+	ds.done = make(chan struct{})
+	ds.disconnected = make(chan struct{})
+	ds.mutex.Unlock()
+	log.Printf("Simulated driver station for Team %d assigned to station %s.", ds.config.TeamId, ds.allianceStation)
+
+	ds.waitGroup.Add(3)
+	go ds.receiveControlPackets()
+	go ds.receiveTcpPackets()
+	go ds.sendPackets()
+	return nil
+}
+
+// Disconnects from the FMS and waits for the background loops to finish.
+func (ds *DriverStation) Close() {
+	ds.mutex.Lock()
+	if ds.done == nil {
+		ds.mutex.Unlock()
+		return
+	}
+	close(ds.done)
+	ds.done = nil
+	ds.tcpConn.Close()
+	ds.udpStatusConn.Close()
+	ds.udpControlConn.Close()
+	ds.mutex.Unlock()
+	ds.waitGroup.Wait()
+}
+
+// Returns a channel that is closed when the FMS drops the connection, such as when the team is no longer in the
+// current or next match.
+func (ds *DriverStation) Disconnected() <-chan struct{} {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+	return ds.disconnected
+}
+
+// Returns the alliance station that the FMS assigned the driver station to, and whether the FMS reported that it is
+// plugged into a different station.
+func (ds *DriverStation) AllianceStation() (string, bool) {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+	return ds.allianceStation, ds.wrongStation
+}
+
+// Returns the most recent control packet received from the FMS, or false if none has been received.
+func (ds *DriverStation) LastControlPacket() (ControlPacket, bool) {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+	if ds.lastControl == nil {
+		return ControlPacket{}, false
+	}
+	return *ds.lastControl, true
+}
+
+// Returns the number of control packets received from the FMS.
+func (ds *DriverStation) ControlPacketCount() int {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+	return ds.controlCount
+}
+
+// Returns the most recent game-specific data sent by the FMS.
+func (ds *DriverStation) GameData() string {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+	return ds.gameData
+}
+
+// Waits until a control packet satisfying the given condition is received, returning it or an error on timeout.
+func (ds *DriverStation) WaitForControlPacket(
+	timeout time.Duration, condition func(packet ControlPacket) bool,
+) (ControlPacket, error) {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if packet, ok := ds.LastControlPacket(); ok && condition(packet) {
+			return packet, nil
+		}
+		time.Sleep(time.Millisecond * statusPeriodMs)
+	}
+	return ControlPacket{}, fmt.Errorf("timed out waiting for control packet for Team %d", ds.config.TeamId)
+}
+
+// Sets the robot battery voltage to report.
+func (ds *DriverStation) SetBatteryVoltage(voltage float64) {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+	ds.batteryVoltage = voltage
+}
+
+// Sets the round-trip time between the driver station and the robot to report.
+func (ds *DriverStation) SetTripTimeMs(tripTimeMs int) {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+	ds.tripTimeMs = tripTimeMs
+}
+
+// Sets the fraction (0 to 1) of status packets to drop, each of which is also counted as a packet missed by the robot.
+func (ds *DriverStation) SetPacketLossRate(rate float64) {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+	ds.packetLossRate = rate
+}
+
+// Sets whether the robot is connected to the driver station.
+func (ds *DriverStation) SetRobotLinked(linked bool) {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+	ds.robotLinked = linked
+}
+
+// Drops the robot link for the given duration, after which it is restored.
+func (ds *DriverStation) FlapRobotLink(duration time.Duration) {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+	ds.linkDownUntil = time.Now().Add(duration)
+}
+
+// Drops the robot link for the given duration every given interval; an interval of zero stops the flapping.
+func (ds *DriverStation) SetRobotLinkFlapping(interval, duration time.Duration) {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+	ds.linkFlapInterval = interval
+	ds.linkFlapDuration = duration
+	ds.nextLinkFlap = time.Now().Add(interval)
+}
+
+// Sets whether the driver station's E-stop is pressed.
+func (ds *DriverStation) SetEStop(pressed bool) {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+	ds.eStop = pressed
+}
+
+// Sets whether the driver station's A-stop is pressed.
+func (ds *DriverStation) SetAStop(pressed bool) {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+	ds.aStop = pressed
+}
+
+// Loops until closed, sending status packets over UDP and robot log packets over TCP at the rates of a real driver
+// station.
+func (ds *DriverStation) sendPackets() {
+	defer ds.waitGroup.Done()
+	statusTicker := time.NewTicker(time.Millisecond * statusPeriodMs)
+	defer statusTicker.Stop()
+	robotLogTicker := time.NewTicker(time.Millisecond * robotLogPeriodMs)
+	defer robotLogTicker.Stop()
+	done := ds.done
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-statusTicker.C:
+			if packet := ds.nextStatusPacket(); packet != nil {
+				ds.udpStatusConn.Write(packet)
+			}
+		case <-robotLogTicker.C:
+			// The FMS uses the robot log packets to trigger its own logging, and otherwise needs a keepalive.
+			packetType := byte(tcpKeepalivePacketType)
+			if _, ok := ds.LastControlPacket(); ok {
+				packetType = tcpRobotLogPacketType
+			}
+			if _, err := ds.tcpConn.Write(encodeTcpPacket(packetType, nil)); err != nil {
+				log.Printf("Simulated driver station for Team %d lost TCP connection: %v", ds.config.TeamId, err)
+				return
+			}
+		}
+	}
+}
+
+// Returns the next status packet to send, or nil if it is to be dropped to simulate packet loss.
+func (ds *DriverStation) nextStatusPacket() []byte {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+
+	now := time.Now()
+	if ds.linkFlapInterval > 0 && now.After(ds.nextLinkFlap) {
+		ds.linkDownUntil = now.Add(ds.linkFlapDuration)
+		ds.nextLinkFlap = now.Add(ds.linkFlapInterval)
+	}
+	robotLinked := ds.robotLinked && now.After(ds.linkDownUntil)
+
+	ds.statusCount++
+	if robotLinked && ds.packetLossRate > 0 && rand.Float64() < ds.packetLossRate {
+		ds.missedPacketCount++
+		return nil
+	}
+
+	status := StatusPacket{
+		PacketNumber:      ds.statusCount,
+		TeamId:            ds.config.TeamId,
+		EStop:             ds.eStop,
+		AStop:             ds.aStop,
+		RadioLinked:       true,
+		RioLinked:         robotLinked,
+		RobotLinked:       robotLinked,
+		MissedPacketCount: ds.missedPacketCount,
+	}
+	if robotLinked {
+		status.BatteryVoltage = ds.batteryVoltage
+		status.TripTimeMs = ds.tripTimeMs
+		if ds.lastControl != nil {
+			status.Auto = ds.lastControl.Auto
+			status.Enabled = ds.lastControl.Enabled && !ds.lastControl.EStop && !ds.lastControl.AStop
+		}
+	}
+	return EncodeStatusPacket(status)
+}
+
+// Loops until closed, decoding the control packets sent by the FMS.
+func (ds *DriverStation) receiveControlPackets() {
+	defer ds.waitGroup.Done()
+	data := make([]byte, 1500)
+	for {
+		count, err := ds.udpControlConn.Read(data)
+		if err != nil {
+			return
+		}
+		packet, err := DecodeControlPacket(data[:count])
+		if err != nil {
+			log.Printf("Simulated driver station for Team %d: %v", ds.config.TeamId, err)
+			continue
+		}
+		ds.mutex.Lock()
+		ds.lastControl = &packet
+		ds.controlCount++
+		ds.mutex.Unlock()
+	}
+}
+
+// Loops until closed, handling the TCP packets sent by the FMS.
+func (ds *DriverStation) receiveTcpPackets() {
+	defer ds.waitGroup.Done()
+	defer close(ds.disconnected)
+	for {
+		ds.tcpConn.SetReadDeadline(time.Time{})
+		var header [2]byte
+		if _, err := io.ReadFull(ds.tcpConn, header[:]); err != nil {
+			return
+		}
+		data := make([]byte, int(header[0])<<8+int(header[1]))
+		if _, err := io.ReadFull(ds.tcpConn, data); err != nil {
+			return
+		}
+		if len(data) >= 2 && data[0] == tcpGameDataPacketType {
+			size := min(int(data[1]), len(data)-2)
+			ds.mutex.Lock()
+			ds.gameData = string(data[2 : 2+size])
+			ds.mutex.Unlock()
+		}
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package dssim
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Minimal stand-in for the FMS side of the driver station protocol.
+type fakeFms struct {
+	tcpListener net.Listener
+	udpListener *net.UDPConn
+	tcpConn     net.Conn
+	mutex       sync.Mutex
+	statuses    [][]byte
+	tcpTypes    []byte
+}
+
+func setupFakeFms(t *testing.T) *fakeFms {
+	fms := new(fakeFms)
+	var err error
+	fms.tcpListener, err = net.Listen("tcp4", "127.0.0.1:0")
+	assert.Nil(t, err)
+	fms.udpListener, err = net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	assert.Nil(t, err)
+	t.Cleanup(func() {
+		fms.tcpListener.Close()
+		fms.udpListener.Close()
+	})
+
+	go func() {
+		data := make([]byte, 1500)
+		for {
+			count, err := fms.udpListener.Read(data)
+			if err != nil {
+				return
+			}
+			fms.mutex.Lock()
+			fms.statuses = append(fms.statuses, append([]byte{}, data[:count]...))
+			fms.mutex.Unlock()
+		}
+	}()
+	return fms
+}
+
+// Accepts the driver station's connection, checks its team number, and assigns it to the given station.
+func (fms *fakeFms) accept(t *testing.T, teamId int, station, stationStatus byte) {
+	var err error
+	fms.tcpConn, err = fms.tcpListener.Accept()
+	if !assert.Nil(t, err) {
+		return
+	}
+	t.Cleanup(func() { fms.tcpConn.Close() })
+	var teamPacket [5]byte
+	_, err = io.ReadFull(fms.tcpConn, teamPacket[:])
+	assert.Nil(t, err)
+	assert.Equal(t, [5]byte{0, 3, 24, byte(teamId >> 8), byte(teamId)}, teamPacket)
+	fms.tcpConn.Write([]byte{0, 3, 25, station, stationStatus})
+
+	go func() {
+		for {
+			var packet [3]byte
+			if _, err := io.ReadFull(fms.tcpConn, packet[:]); err != nil {
+				return
+			}
+			fms.mutex.Lock()
+			fms.tcpTypes = append(fms.tcpTypes, packet[2])
+			fms.mutex.Unlock()
+		}
+	}()
+}
+
+func (fms *fakeFms) lastStatus() []byte {
+	fms.mutex.Lock()
+	defer fms.mutex.Unlock()
+	if len(fms.statuses) == 0 {
+		return nil
+	}
+	return fms.statuses[len(fms.statuses)-1]
+}
+
+func (fms *fakeFms) clearStatuses() {
+	fms.mutex.Lock()
+	defer fms.mutex.Unlock()
+	fms.statuses = nil
+}
+
+func (fms *fakeFms) waitForStatus(t *testing.T, condition func(status []byte) bool) []byte {
+	for i := 0; i < 100; i++ {
+		if status := fms.lastStatus(); status != nil && condition(status) {
+			return status
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	assert.Fail(t, "timed out waiting for status packet")
+	return nil
+}
+
+func getFreeUdpPort(t *testing.T) int {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	assert.Nil(t, err)
+	defer conn.Close()
+	return conn.LocalAddr().(*net.UDPAddr).Port
+}
+
+func TestDriverStation(t *testing.T) {
+	fms := setupFakeFms(t)
+	controlPort := getFreeUdpPort(t)
+	ds := New(
+		Config{
+			TeamId:           254,
+			FmsAddress:       "127.0.0.1",
+			LocalAddress:     "127.0.0.1",
+			FmsTcpPort:       fms.tcpListener.Addr().(*net.TCPAddr).Port,
+			FmsUdpStatusPort: fms.udpListener.LocalAddr().(*net.UDPAddr).Port,
+			UdpControlPort:   controlPort,
+		},
+	)
+	accepted := make(chan struct{})
+	go func() {
+		fms.accept(t, 254, 4, 1)
+		close(accepted)
+	}()
+	assert.Nil(t, ds.Connect())
+	defer ds.Close()
+	<-accepted
+	station, wrongStation := ds.AllianceStation()
+	assert.Equal(t, "B2", station)
+	assert.True(t, wrongStation)
+
+	// Check that status packets report a healthy robot that isn't enabled yet.
+	status := fms.waitForStatus(t, func(status []byte) bool { return true })
+	assert.Equal(t, []byte{0, 254}, status[4:6])
+	assert.Equal(t, byte(0x38), status[3])
+	assert.Equal(t, []byte{12, 128}, status[6:8])
+	assert.Equal(t, byte(defaultTripTimeMs), status[14])
+
+	// Check that control packets and game data are received and reflected in the status.
+	_, ok := ds.LastControlPacket()
+	assert.False(t, ok)
+	udpConn, err := net.Dial("udp4", net.JoinHostPort("127.0.0.1", strconv.Itoa(controlPort)))
+	assert.Nil(t, err)
+	defer udpConn.Close()
+	controlPacket := []byte{0, 7, 0, 0x06, 0, 4, 2, 0, 5, 1, 0, 0, 0, 0, 0, 0, 12, 1, 1, 125, 0, 15}
+	udpConn.Write(controlPacket)
+	packet, err := ds.WaitForControlPacket(time.Second, func(packet ControlPacket) bool { return packet.Enabled })
+	assert.Nil(t, err)
+	assert.True(t, packet.Auto)
+	assert.Equal(t, 5, packet.MatchNumber)
+	assert.Equal(t, 1, ds.ControlPacketCount())
+	fms.waitForStatus(t, func(status []byte) bool { return status[3]&0x06 == 0x06 })
+	fms.tcpConn.Write([]byte{0, 5, 28, 3, 'R', 'G', 'B'})
+	time.Sleep(time.Millisecond * 50)
+	assert.Equal(t, "RGB", ds.GameData())
+
+	// Check the simulated robot conditions.
+	ds.SetBatteryVoltage(7.75)
+	ds.SetTripTimeMs(42)
+	status = fms.waitForStatus(t, func(status []byte) bool { return status[6] == 7 })
+	assert.Equal(t, byte(192), status[7])
+	assert.Equal(t, byte(42), status[14])
+
+	ds.SetEStop(true)
+	ds.SetAStop(true)
+	status = fms.waitForStatus(t, func(status []byte) bool { return status[3]&0xc0 == 0xc0 })
+	ds.SetEStop(false)
+	ds.SetAStop(false)
+
+	ds.SetRobotLinked(false)
+	status = fms.waitForStatus(t, func(status []byte) bool { return status[3]&0x20 == 0 })
+	assert.Equal(t, byte(0x10), status[3])
+	assert.Equal(t, []byte{0, 0}, status[6:8])
+	ds.SetRobotLinked(true)
+	fms.waitForStatus(t, func(status []byte) bool { return status[3]&0x20 != 0 })
+
+	ds.FlapRobotLink(time.Millisecond * 100)
+	fms.waitForStatus(t, func(status []byte) bool { return status[3]&0x20 == 0 })
+	fms.waitForStatus(t, func(status []byte) bool { return status[3]&0x20 != 0 })
+
+	ds.SetPacketLossRate(1)
+	time.Sleep(time.Millisecond * 50)
+	fms.clearStatuses()
+	time.Sleep(time.Millisecond * 100)
+	assert.Nil(t, fms.lastStatus())
+	ds.SetPacketLossRate(0)
+	status = fms.waitForStatus(t, func(status []byte) bool { return true })
+	assert.Greater(t, int(status[10])<<8+int(status[11]), 3)
+
+	// Check that robot log packets are sent over TCP once control packets are being received.
+	fms.mutex.Lock()
+	assert.Contains(t, fms.tcpTypes, byte(tcpRobotLogPacketType))
+	fms.mutex.Unlock()
+
+	// Check that the FMS dropping the connection is signalled.
+	fms.tcpConn.Close()
+	select {
+	case <-ds.Disconnected():
+	case <-time.After(time.Second):
+		assert.Fail(t, "timed out waiting for disconnection")
+	}
+	ds.Close()
+	ds.Close()
+}
+
+func TestDriverStationRejected(t *testing.T) {
+	fms := setupFakeFms(t)
+	ds := New(
+		Config{
+			TeamId:           1114,
+			FmsAddress:       "127.0.0.1",
+			FmsTcpPort:       fms.tcpListener.Addr().(*net.TCPAddr).Port,
+			FmsUdpStatusPort: fms.udpListener.LocalAddr().(*net.UDPAddr).Port,
+			UdpControlPort:   getFreeUdpPort(t),
+		},
+	)
+	go func() {
+		tcpConn, err := fms.tcpListener.Accept()
+		if assert.Nil(t, err) {
+			tcpConn.Close()
+		}
+	}()
+	err := ds.Connect()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "FMS did not accept connection for Team 1114")
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Encoding and decoding of the packets exchanged between the FMS and a driver station.
+
+package dssim
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"time"
+)
+
+const (
+	controlPacketBytes = 22
+
+	// Types of the tagged TCP packets.
+	tcpTeamNumberPacketType  = 24
+	tcpStationPacketType     = 25
+	tcpGameDataPacketType    = 28
+	tcpRobotLogPacketType    = 22
+	tcpKeepalivePacketType   = 29
+	tcpStationStatusMismatch = 1
+
+	// Bits of the robot status byte in status packets.
+	statusAuto        = 0x02
+	statusEnabled     = 0x04
+	statusRioLinked   = 0x08
+	statusRadioLinked = 0x10
+	statusRobotLinked = 0x20
+	statusAStop       = 0x40
+	statusEStop       = 0x80
+)
+
+var allianceStations = []string{"R1", "R2", "R3", "B1", "B2", "B3"}
+
+// Contents of a UDP control packet sent by the FMS to a driver station.
+type ControlPacket struct {
+	PacketNumber       int
+	Auto               bool
+	Enabled            bool
+	EStop              bool
+	AStop              bool
+	AllianceStation    string
+	MatchType          model.MatchType
+	MatchNumber        int
+	RepeatNumber       int
+	Time               time.Time
+	MatchSecsRemaining int
+}
+
+// State of a driver station and its robot, as reported to the FMS in a UDP status packet.
+type StatusPacket struct {
+	PacketNumber      int
+	TeamId            int
+	Auto              bool
+	Enabled           bool
+	EStop             bool
+	AStop             bool
+	RioLinked         bool
+	RadioLinked       bool
+	RobotLinked       bool
+	BatteryVoltage    float64
+	MissedPacketCount int
+	TripTimeMs        int
+}
+
+// Parses the given UDP control packet sent by the FMS.
+func DecodeControlPacket(data []byte) (ControlPacket, error) {
+	if len(data) < controlPacketBytes {
+		return ControlPacket{}, fmt.Errorf("control packet has insufficient length: %d", len(data))
+	}
+	if data[5] >= byte(len(allianceStations)) {
+		return ControlPacket{}, fmt.Errorf("control packet has invalid alliance station: %d", data[5])
+	}
+
+	microseconds := int(data[10])<<24 + int(data[11])<<16 + int(data[12])<<8 + int(data[13])
+	return ControlPacket{
+		PacketNumber:    int(data[0])<<8 + int(data[1]),
+		Auto:            data[3]&statusAuto != 0,
+		Enabled:         data[3]&statusEnabled != 0,
+		EStop:           data[3]&statusEStop != 0,
+		AStop:           data[3]&statusAStop != 0,
+		AllianceStation: allianceStations[data[5]],
+		MatchType:       model.MatchType(data[6]),
+		MatchNumber:     int(data[7])<<8 + int(data[8]),
+		RepeatNumber:    int(data[9]),
+		Time: time.Date(
+			int(data[19])+1900,
+			time.Month(data[18]),
+			int(data[17]),
+			int(data[16]),
+			int(data[15]),
+			int(data[14]),
+			microseconds*1000,
+			time.Local,
+		),
+		MatchSecsRemaining: int(data[20])<<8 + int(data[21]),
+	}, nil
+}
+
+// Serializes the given status into a UDP status packet to send to the FMS.
+func EncodeStatusPacket(status StatusPacket) []byte {
+	packet := make([]byte, 15)
+
+	// Packet number, stored big-endian in two bytes.
+	packet[0] = byte(status.PacketNumber >> 8 & 0xff)
+	packet[1] = byte(status.PacketNumber & 0xff)
+
+	// Protocol version.
+	packet[2] = 0
+
+	// Robot status byte.
+	if status.Auto {
+		packet[3] |= statusAuto
+	}
+	if status.Enabled {
+		packet[3] |= statusEnabled
+	}
+	if status.RioLinked {
+		packet[3] |= statusRioLinked
+	}
+	if status.RadioLinked {
+		packet[3] |= statusRadioLinked
+	}
+	if status.RobotLinked {
+		packet[3] |= statusRobotLinked
+	}
+	if status.AStop {
+		packet[3] |= statusAStop
+	}
+	if status.EStop {
+		packet[3] |= statusEStop
+	}
+
+	// Team number.
+	packet[4] = byte(status.TeamId >> 8 & 0xff)
+	packet[5] = byte(status.TeamId & 0xff)
+
+	// Robot battery voltage, stored as volts * 256.
+	voltage := max(status.BatteryVoltage, 0)
+	packet[6] = byte(int(voltage) & 0xff)
+	packet[7] = byte(int((voltage-float64(int(voltage)))*256) & 0xff)
+
+	// Tag 1: missed packet count and round-trip time to the robot.
+	packet[8] = 6
+	packet[9] = 1
+	packet[10] = byte(status.MissedPacketCount >> 8 & 0xff)
+	packet[11] = byte(status.MissedPacketCount & 0xff)
+	packet[14] = byte(min(max(status.TripTimeMs, 0), 255))
+
+	return packet
+}
+
+// Returns a tagged TCP packet of the given type containing the given data.
+func encodeTcpPacket(packetType byte, data []byte) []byte {
+	size := len(data) + 1
+	return append([]byte{byte(size >> 8 & 0xff), byte(size & 0xff), packetType}, data...)
+}
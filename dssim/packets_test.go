@@ -0,0 +1,58 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package dssim
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestDecodeControlPacket(t *testing.T) {
+	data := []byte{1, 2, 0, 0x86, 0, 5, 3, 0, 12, 2, 0, 1, 0, 0, 30, 45, 13, 18, 10, 125, 0, 135}
+	packet, err := DecodeControlPacket(data)
+	assert.Nil(t, err)
+	assert.Equal(t, 258, packet.PacketNumber)
+	assert.True(t, packet.Auto)
+	assert.True(t, packet.Enabled)
+	assert.True(t, packet.EStop)
+	assert.False(t, packet.AStop)
+	assert.Equal(t, "B3", packet.AllianceStation)
+	assert.Equal(t, model.Playoff, packet.MatchType)
+	assert.Equal(t, 12, packet.MatchNumber)
+	assert.Equal(t, 2, packet.RepeatNumber)
+	assert.Equal(t, time.Date(2025, 10, 18, 13, 45, 30, 65536000, time.Local), packet.Time)
+	assert.Equal(t, 135, packet.MatchSecsRemaining)
+
+	_, err = DecodeControlPacket(data[:21])
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "insufficient length")
+	}
+	data[5] = 6
+	_, err = DecodeControlPacket(data)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "invalid alliance station")
+	}
+}
+
+func TestEncodeStatusPacket(t *testing.T) {
+	data := EncodeStatusPacket(
+		StatusPacket{
+			PacketNumber:      513,
+			TeamId:            1114,
+			Enabled:           true,
+			RadioLinked:       true,
+			RioLinked:         true,
+			RobotLinked:       true,
+			BatteryVoltage:    11.5,
+			MissedPacketCount: 300,
+			TripTimeMs:        400,
+		},
+	)
+	assert.Equal(t, []byte{2, 1, 0, 0x3c, 4, 90, 11, 128, 6, 1, 1, 44, 0, 0, 255}, data)
+
+	data = EncodeStatusPacket(StatusPacket{TeamId: 254, EStop: true, AStop: true, BatteryVoltage: -1})
+	assert.Equal(t, []byte{0, 0, 0, 0xc0, 0, 254, 0, 0, 6, 1, 0, 0, 0, 0, 0}, data)
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/Team254/cheesy-arena/partner"
 	"github.com/Team254/cheesy-arena/playoff"
 	"github.com/Team254/cheesy-arena/plc"
+	"github.com/Team254/cheesy-arena/websocket"
 )
 
 const (
@@ -28,6 +29,7 @@ const (
 	dsPacketPeriodMs         = 500
 	dsPacketWarningMs        = 550
 	periodicTaskPeriodSec    = 30
+	tbaPublishPollPeriodSec  = 1
 	matchEndScoreDwellSec    = 3
 	postTimeoutSec           = 4
 	preLoadNextMatchDelaySec = 5
@@ -52,25 +54,39 @@ const (
 )
 
 type Arena struct {
-	Database         *model.Database
-	EventSettings    *model.EventSettings
-	accessPoint      network.AccessPoint
-	networkSwitch    *network.Switch
-	redSCC           *network.SCCSwitch
-	blueSCC          *network.SCCSwitch
-	Plc              plc.Plc
-	TbaClient        *partner.TbaClient
-	NexusClient      *partner.NexusClient
-	BlackmagicClient *partner.BlackmagicClient
-	CompanionClient  *partner.CompanionClient
-	AllianceStations map[string]*AllianceStation
-	Displays         map[string]*Display
-	TeamSigns        *TeamSigns
+	Database          *model.Database
+	EventSettings     *model.EventSettings
+	BackupScheduler   *model.BackupScheduler
+	Replication       *ReplicationClient
+	settingsReload    chan struct{}
+	NoFieldHardware   bool
+	accessPoint       network.AccessPoint
+	networkSwitch     *network.Switch
+	redSCC            *network.SCCSwitch
+	blueSCC           *network.SCCSwitch
+	Plc               plc.Plc
+	modbusPlc         *plc.ModbusPlc
+	simulatedPlc      *plc.SimulatedPlc
+	pendingPlc        plc.Plc
+	pendingPlcMutex   sync.Mutex
+	PlcChangeNotifier *websocket.Notifier
+	TbaClient         *partner.TbaClient
+	TbaPublisher      *partner.TbaPublisher
+	WebhookDispatcher *partner.WebhookDispatcher
+	NexusClient       *partner.NexusClient
+	NexusLocalServer  *partner.NexusLocalServer
+	BlackmagicClient  *partner.BlackmagicClient
+	CompanionClient   *partner.CompanionClient
+	AllianceStations  map[string]*AllianceStation
+	Displays          map[string]*Display
+	TeamSigns         *TeamSigns
 	ScoringPanelRegistry
 	ArenaNotifiers
 	MatchState
 	lastMatchState                    MatchState
 	CurrentMatch                      *model.Match
+	matchReplay                       *MatchReplay
+	matchReplayMutex                  sync.Mutex
 	MatchStartTime                    time.Time
 	LastMatchTimeSec                  float64
 	RedRealtimeScore                  *RealtimeScore
@@ -94,10 +110,20 @@ type Arena struct {
 	ShowLowerThird                    bool
 	MuteMatchSounds                   bool
 	matchAborted                      bool
+	scoreSnapshots                    []model.ScoreSnapshot
+	scoreSnapshotWrites               sync.WaitGroup
 	soundsPlayed                      map[*game.MatchSound]struct{}
 	breakDescription                  string
 	preloadedTeams                    *[6]*model.Team
+	practiceQueueMutex                sync.Mutex
+	practiceQueueActive               bool
+	matchLog                          matchLogState
+	matchLogMutex                     sync.Mutex
+	matchLogPrunes                    sync.WaitGroup
+	fieldHealth                       *fieldHealthState
 	NextFoulId                        int
+	NexusTeamStatuses                 []partner.NexusTeamStatus
+	NexusPartsRequests                []partner.NexusPartsRequest
 }
 
 type AllianceStation struct {
@@ -111,11 +137,17 @@ type AllianceStation struct {
 	aStopReset bool
 }
 
-// Creates the arena and sets it to its initial state.
-func NewArena(dbPath string) (*Arena, error) {
+// Creates the arena and sets it to its initial state. If noFieldHardware is true, the arena runs without connecting to
+// the PLC, access point, switches, or team signs, regardless of how they are configured in the event settings.
+func NewArena(dbPath string, noFieldHardware bool) (*Arena, error) {
 	arena := new(Arena)
+	arena.NoFieldHardware = noFieldHardware
 	arena.configureNotifiers()
-	arena.Plc = new(plc.ModbusPlc)
+	arena.modbusPlc = new(plc.ModbusPlc)
+	arena.simulatedPlc = plc.NewSimulatedPlc()
+	arena.PlcChangeNotifier = websocket.NewNotifier("reload", nil)
+	arena.settingsReload = make(chan struct{}, 1)
+	arena.fieldHealth = newFieldHealthState()
 
 	arena.AllianceStations = make(map[string]*AllianceStation)
 	arena.AllianceStations["R1"] = new(AllianceStation)
@@ -128,6 +160,10 @@ func NewArena(dbPath string) (*Arena, error) {
 	arena.Displays = make(map[string]*Display)
 
 	arena.TeamSigns = NewTeamSigns()
+	arena.BackupScheduler = model.NewBackupScheduler()
+	arena.TbaPublisher = partner.NewTbaPublisher()
+	arena.WebhookDispatcher = partner.NewWebhookDispatcher()
+	arena.NexusLocalServer = partner.NewNexusLocalServer()
 
 	var err error
 	arena.Database, err = model.OpenDatabase(dbPath)
@@ -164,15 +200,21 @@ func (arena *Arena) LoadSettings() error {
 	}
 	arena.EventSettings = settings
 
+	arena.BackupScheduler.IntervalMin = settings.BackupIntervalMin
+	arena.BackupScheduler.RetainCount = settings.BackupRetainCount
+	arena.BackupScheduler.RetainHourly = settings.BackupRetainHourly
+
 	// Initialize the components that depend on settings.
-	arena.TeamSigns.Red1.SetId(settings.TeamSignRed1Id)
-	arena.TeamSigns.Red2.SetId(settings.TeamSignRed2Id)
-	arena.TeamSigns.Red3.SetId(settings.TeamSignRed3Id)
-	arena.TeamSigns.RedTimer.SetId(settings.TeamSignRedTimerId)
-	arena.TeamSigns.Blue1.SetId(settings.TeamSignBlue1Id)
-	arena.TeamSigns.Blue2.SetId(settings.TeamSignBlue2Id)
-	arena.TeamSigns.Blue3.SetId(settings.TeamSignBlue3Id)
-	arena.TeamSigns.BlueTimer.SetId(settings.TeamSignBlueTimerId)
+	if !arena.NoFieldHardware {
+		arena.TeamSigns.Red1.SetId(settings.TeamSignRed1Id)
+		arena.TeamSigns.Red2.SetId(settings.TeamSignRed2Id)
+		arena.TeamSigns.Red3.SetId(settings.TeamSignRed3Id)
+		arena.TeamSigns.RedTimer.SetId(settings.TeamSignRedTimerId)
+		arena.TeamSigns.Blue1.SetId(settings.TeamSignBlue1Id)
+		arena.TeamSigns.Blue2.SetId(settings.TeamSignBlue2Id)
+		arena.TeamSigns.Blue3.SetId(settings.TeamSignBlue3Id)
+		arena.TeamSigns.BlueTimer.SetId(settings.TeamSignBlueTimerId)
+	}
 	accessPointWifiStatuses := [6]*network.TeamWifiStatus{
 		&arena.AllianceStations["R1"].WifiStatus,
 		&arena.AllianceStations["R2"].WifiStatus,
@@ -205,9 +247,46 @@ func (arena *Arena) LoadSettings() error {
 		sccUpCommands,
 		sccDownCommands,
 	)
-	arena.Plc.SetAddress(settings.PlcAddress)
+	if arena.NoFieldHardware || settings.PlcSimulated {
+		arena.modbusPlc.SetAddress("")
+	} else {
+		arena.modbusPlc.SetAddress(settings.PlcAddress)
+	}
+	var requestedPlc plc.Plc = arena.modbusPlc
+	if settings.PlcSimulated {
+		requestedPlc = arena.simulatedPlc
+	}
+	if arena.Plc == nil {
+		// The arena loop isn't running yet when the settings are first loaded.
+		arena.Plc = requestedPlc
+	} else {
+		// Leave the switch to the arena loop, which reads the PLC throughout each iteration.
+		arena.pendingPlcMutex.Lock()
+		arena.pendingPlc = requestedPlc
+		arena.pendingPlcMutex.Unlock()
+	}
 	arena.TbaClient = partner.NewTbaClient(settings.TbaEventCode, settings.TbaSecretId, settings.TbaSecret)
-	arena.NexusClient = partner.NewNexusClient(settings.TbaEventCode)
+	if settings.NexusLocalServerEnabled {
+		baseUrl, err := arena.NexusLocalServer.Start()
+		if err != nil {
+			return err
+		}
+		// The local stand-in serves the same data for any event, so allow it to be used without an event code.
+		eventCode := settings.TbaEventCode
+		if eventCode == "" {
+			eventCode = "local"
+		}
+		arena.NexusClient = partner.NewNexusClient(eventCode)
+		arena.NexusClient.BaseUrl = baseUrl
+		// Nor does it check keys, so allow the endpoints that require an event API key to be used without one.
+		arena.NexusClient.EventApiKey = settings.NexusEventApiKey
+		if arena.NexusClient.EventApiKey == "" {
+			arena.NexusClient.EventApiKey = "local"
+		}
+	} else {
+		arena.NexusClient = partner.NewNexusClient(settings.TbaEventCode)
+		arena.NexusClient.EventApiKey = settings.NexusEventApiKey
+	}
 	arena.BlackmagicClient = partner.NewBlackmagicClient(settings.BlackmagicAddresses)
 
 	// Initialize Companion client with event configurations
@@ -272,6 +351,9 @@ func (arena *Arena) LoadSettings() error {
 	game.UpdateMatchSounds()
 	arena.MatchTimingNotifier.Notify()
 
+	if err = game.SetCurrentGame(settings.GameName); err != nil {
+		return err
+	}
 	game.AutoBonusCoralThreshold = settings.AutoBonusCoralThreshold
 	game.CoralBonusPerLevelThreshold = settings.CoralBonusPerLevelThreshold
 	game.CoralBonusCoopEnabled = settings.CoralBonusCoopEnabled
@@ -322,6 +404,10 @@ func (arena *Arena) LoadMatch(match *model.Match) error {
 	}
 
 	arena.CurrentMatch = match
+	if match.Type != model.Test {
+		// Keep running from the walk-up queue through any test matches loaded while it was empty.
+		arena.practiceQueueActive = match.Type == model.Practice
+	}
 
 	loadedByNexus := false
 	if match.ShouldAllowNexusSubstitution() && arena.EventSettings.NexusEnabled {
@@ -389,6 +475,12 @@ func (arena *Arena) LoadMatch(match *model.Match) error {
 	arena.Plc.ResetMatch()
 	arena.NextFoulId = 1
 
+	if match.Type != model.Test && arena.EventSettings.NexusEnabled && arena.NexusClient.HasEventApiKey() &&
+		arena.Replication == nil {
+		// Let teams know through Nexus when to report to the field, without holding up loading the match.
+		go arena.publishNexusQueueStatuses(match)
+	}
+
 	// Notify any listeners about the new match.
 	arena.MatchLoadNotifier.Notify()
 	arena.RealtimeScoreNotifier.Notify()
@@ -404,9 +496,17 @@ func (arena *Arena) LoadTestMatch() error {
 	return arena.LoadMatch(&model.Match{Type: model.Test, ShortName: "T", LongName: "Test Match"})
 }
 
-// Loads the first unplayed match of the current match type.
+// Loads the first unplayed match of the current match type, or when practice matches are being run from the walk-up
+// queue, the next match built from it. The test match is loaded while the queue is short of teams, and the next call
+// after that goes back to the queue.
 func (arena *Arena) LoadNextMatch(startScheduledBreak bool) error {
-	nextMatch, err := arena.getNextMatch(false)
+	var nextMatch *model.Match
+	var err error
+	if arena.isRunningPracticeQueue() {
+		nextMatch, err = arena.getNextPracticeQueueMatch()
+	} else {
+		nextMatch, err = arena.getNextMatch(false)
+	}
 	if err != nil {
 		return err
 	}
@@ -490,7 +590,7 @@ func (arena *Arena) SubstituteTeams(red1, red2, red3, blue1, blue2, blue3 int) e
 
 // Starts the match if all conditions are met.
 func (arena *Arena) StartMatch() error {
-	err := arena.checkCanStartMatch()
+	err := arena.CheckCanStartMatch()
 	if err == nil {
 		// Save the match start time to the database for posterity.
 		arena.CurrentMatch.StartedAt = time.Now()
@@ -499,13 +599,12 @@ func (arena *Arena) StartMatch() error {
 		}
 		arena.updateCycleTime(arena.CurrentMatch.StartedAt)
 
+		arena.startMatchLog()
+
 		// Save the missed packet count to subtract it from the running count.
 		for _, allianceStation := range arena.AllianceStations {
 			if allianceStation.DsConn != nil {
-				err = allianceStation.DsConn.signalMatchStart(arena.CurrentMatch, &allianceStation.WifiStatus)
-				if err != nil {
-					log.Println(err)
-				}
+				allianceStation.DsConn.signalMatchStart()
 			}
 
 			// Save the teams that have successfully connected to the field.
@@ -630,6 +729,9 @@ func (arena *Arena) MatchTimeSec() float64 {
 // Performs a single iteration of checking inputs and timers and setting outputs accordingly to control the
 // flow of a match.
 func (arena *Arena) Update() {
+	arena.applyPendingPlc()
+	arena.reloadReplicatedSettings()
+
 	// Decide what state the robots need to be in, depending on where we are in the match.
 	auto := false
 	enabled := false
@@ -739,6 +841,8 @@ func (arena *Arena) Update() {
 	if int(matchTimeSec) != int(arena.LastMatchTimeSec) || arena.MatchState != arena.lastMatchState {
 		arena.MatchTimeNotifier.Notify()
 	}
+	arena.recordScoreSnapshot(matchTimeSec)
+	arena.updateMatchReplay()
 
 	// Send a packet if at a period transition point or if it's been long enough since the last one.
 	msSinceLastDsPacket := int(time.Since(arena.lastDsPacketTime).Seconds() * 1000)
@@ -761,6 +865,11 @@ func (arena *Arena) Update() {
 	// Handle the team number / timer displays.
 	arena.TeamSigns.Update(arena)
 
+	arena.updateMatchLog(matchTimeSec)
+
+	// Raise or clear alerts for the FTA based on the state of the field.
+	arena.updateFieldHealth()
+
 	arena.LastMatchTimeSec = matchTimeSec
 	arena.lastMatchState = arena.MatchState
 }
@@ -789,8 +898,15 @@ func (arena *Arena) Run() {
 	// Start other loops in goroutines.
 	go arena.listenForDriverStations()
 	go arena.listenForDsUdpPackets()
-	go arena.accessPoint.Run()
-	go arena.Plc.Run()
+	if !arena.NoFieldHardware {
+		go arena.accessPoint.Run()
+	}
+	go arena.modbusPlc.Run()
+	go arena.simulatedPlc.Run()
+	go arena.runTbaPublisher()
+	go arena.runWebhooks()
+	go arena.runNexusPoller()
+	arena.pruneMatchLogs(LogsDir, arena.EventSettings.MatchLogRetainDays)
 
 	for {
 		loopStartTime := time.Now()
@@ -874,6 +990,17 @@ func (arena *Arena) assignTeam(teamId int, station string) error {
 	return nil
 }
 
+// Replaces the copy of the given team held by any alliance station it is assigned to, so that changes saved elsewhere
+// (e.g. to its inspection status) take effect for the loaded match and aren't overwritten when it starts.
+func (arena *Arena) UpdateAssignedTeam(team *model.Team) {
+	for _, allianceStation := range arena.AllianceStations {
+		if allianceStation.Team != nil && allianceStation.Team.Id == team.Id {
+			teamCopy := *team
+			allianceStation.Team = &teamCopy
+		}
+	}
+}
+
 // Returns the next match of the same type that is currently loaded, or nil if there are no more matches.
 func (arena *Arena) getNextMatch(excludeCurrent bool) (*model.Match, error) {
 	if arena.CurrentMatch.Type == model.Test {
@@ -901,6 +1028,11 @@ func (arena *Arena) preLoadNextMatch() {
 		return
 	}
 
+	if arena.isRunningPracticeQueue() {
+		// The next match won't be built from the walk-up queue until it is loaded.
+		return
+	}
+
 	nextMatch, err := arena.getNextMatch(true)
 	if err != nil {
 		log.Printf("Failed to pre-load next match: %s", err.Error())
@@ -965,7 +1097,7 @@ func (arena *Arena) setupNetwork(teams [6]*model.Team, isPreload bool) {
 		}
 	}
 
-	if arena.EventSettings.NetworkSecurityEnabled {
+	if arena.EventSettings.NetworkSecurityEnabled && !arena.NoFieldHardware {
 		if err := arena.accessPoint.ConfigureTeamWifi(teams); err != nil {
 			log.Printf("Failed to configure team WiFi: %s", err.Error())
 		}
@@ -980,16 +1112,28 @@ func (arena *Arena) setupNetwork(teams [6]*model.Team, isPreload bool) {
 }
 
 // Returns nil if the match can be started, and an error otherwise.
-func (arena *Arena) checkCanStartMatch() error {
+func (arena *Arena) CheckCanStartMatch() error {
 	if arena.MatchState != PreMatch {
 		return fmt.Errorf("cannot start match while there is a match still in progress or with results pending")
 	}
+	if arena.MatchReplay() != nil {
+		return fmt.Errorf("cannot start match while a replay is in progress")
+	}
+	if arena.Replication != nil {
+		return fmt.Errorf("cannot start match while replicating from a primary; promote this instance first")
+	}
 
 	err := arena.checkAllianceStationsReady("R1", "R2", "R3", "B1", "B2", "B3")
 	if err != nil {
 		return err
 	}
 
+	if arena.EventSettings.InspectionEnforcement == model.InspectionRequired {
+		if err = arena.checkTeamsInspected(); err != nil {
+			return fmt.Errorf("cannot start match while %s", err.Error())
+		}
+	}
+
 	if arena.Plc.IsEnabled() {
 		if !arena.Plc.IsHealthy() {
 			return fmt.Errorf("cannot start match while PLC is not healthy")
@@ -1026,6 +1170,38 @@ func (arena *Arena) checkAllianceStationsReady(stations ...string) error {
 	return nil
 }
 
+// Returns an error if any team playing in the current match hasn't passed robot inspection. Bypassed teams and test
+// matches are exempt.
+func (arena *Arena) checkTeamsInspected() error {
+	if arena.CurrentMatch.Type == model.Test {
+		return nil
+	}
+	for _, station := range []string{"R1", "R2", "R3", "B1", "B2", "B3"} {
+		allianceStation := arena.AllianceStations[station]
+		if allianceStation.Team != nil && !allianceStation.Bypass &&
+			allianceStation.Team.InspectionStatus != model.InspectionPassed {
+			return fmt.Errorf(
+				"team %d has not passed robot inspection (status: %s)",
+				allianceStation.Team.Id,
+				allianceStation.Team.InspectionStatus.DisplayName(),
+			)
+		}
+	}
+	return nil
+}
+
+// Returns a message describing which team hasn't passed robot inspection if inspection is being enforced, or an empty
+// string otherwise.
+func (arena *Arena) getInspectionWarning() string {
+	if arena.EventSettings.InspectionEnforcement == model.InspectionNotEnforced {
+		return ""
+	}
+	if err := arena.checkTeamsInspected(); err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
 func (arena *Arena) sendDsPacket(auto bool, enabled bool) {
 	for _, allianceStation := range arena.AllianceStations {
 		dsConn := allianceStation.DsConn
@@ -1148,7 +1324,7 @@ func (arena *Arena) handlePlcInputOutput() {
 		if warningSequenceActive {
 			arena.Plc.SetTrussLights(lights, lights)
 		} else {
-			if !game.CoralBonusCoopEnabled || arena.CurrentMatch.Type == model.Playoff {
+			if !game.CurrentGame().CoopertitionEnabled() || arena.CurrentMatch.Type == model.Playoff {
 				// Just leave the lights on all match if co-op is not enabled for this match (or event).
 				arena.Plc.SetTrussLights([3]bool{true, true, true}, [3]bool{true, true, true})
 			} else {
@@ -1195,6 +1371,22 @@ func (arena *Arena) handleTeamStop(station string, eStopState, aStopState bool)
 	}
 }
 
+// Switches to the PLC requested by the most recently loaded settings, if it differs from the one in use. The switch is
+// deferred until no match is in progress so that the field I/O doesn't change from under a match.
+func (arena *Arena) applyPendingPlc() {
+	if arena.MatchState != PreMatch {
+		return
+	}
+	arena.pendingPlcMutex.Lock()
+	pendingPlc := arena.pendingPlc
+	arena.pendingPlc = nil
+	arena.pendingPlcMutex.Unlock()
+	if pendingPlc != nil && pendingPlc != arena.Plc {
+		arena.Plc = pendingPlc
+		arena.PlcChangeNotifier.Notify()
+	}
+}
+
 func (arena *Arena) handleSounds(matchTimeSec float64) {
 	if arena.MatchState == PreMatch || arena.MatchState == TimeoutActive || arena.MatchState == PostTimeout {
 		// Only apply this logic during a match.
@@ -1230,6 +1422,32 @@ func (arena *Arena) positionPostMatchScoreReady(position string) bool {
 func (arena *Arena) runPeriodicTasks() {
 	arena.updateEarlyLateMessage()
 	arena.purgeDisconnectedDisplays()
+	arena.runPeriodicBackup()
+}
+
+// Takes a periodic backup of the database if one is due, deferring it if a match is in progress.
+func (arena *Arena) runPeriodicBackup() {
+	if arena.MatchState != PreMatch && arena.MatchState != PostMatch {
+		return
+	}
+	if err := arena.BackupScheduler.BackupIfDue(arena.Database, arena.EventSettings.Name); err != nil {
+		log.Printf("Failed to take periodic database backup: %v", err)
+	}
+}
+
+// Loops indefinitely to publish data queued in the TBA outbox, retrying failed publishes once their backoff elapses.
+func (arena *Arena) runTbaPublisher() {
+	for {
+		select {
+		case <-arena.TbaPublisher.WakeChan():
+		case <-time.After(time.Second * tbaPublishPollPeriodSec):
+		}
+
+		// Leave publishing to the primary while this instance is a hot standby.
+		if arena.EventSettings.TbaPublishingEnabled && arena.Replication == nil {
+			arena.TbaPublisher.PublishDue(arena.Database, arena.TbaClient)
+		}
+	}
 }
 
 // trussLightWarningSequence generates the sequence of truss light states during the "sonar ping" warning sound. It
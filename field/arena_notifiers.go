@@ -20,11 +20,14 @@ type ArenaNotifiers struct {
 	AudienceDisplayModeNotifier        *websocket.Notifier
 	DisplayConfigurationNotifier       *websocket.Notifier
 	EventStatusNotifier                *websocket.Notifier
+	FieldAlertsNotifier                *websocket.Notifier
 	LowerThirdNotifier                 *websocket.Notifier
 	MatchLoadNotifier                  *websocket.Notifier
 	MatchTimeNotifier                  *websocket.Notifier
 	MatchTimingNotifier                *websocket.Notifier
 	PlaySoundNotifier                  *websocket.Notifier
+	PracticeQueueNotifier              *websocket.Notifier
+	RankingsNotifier                   *websocket.Notifier
 	RealtimeScoreNotifier              *websocket.Notifier
 	ReloadDisplaysNotifier             *websocket.Notifier
 	ScorePostedNotifier                *websocket.Notifier
@@ -55,11 +58,14 @@ func (arena *Arena) configureNotifiers() {
 		"displayConfiguration", arena.generateDisplayConfigurationMessage,
 	)
 	arena.EventStatusNotifier = websocket.NewNotifier("eventStatus", arena.generateEventStatusMessage)
+	arena.FieldAlertsNotifier = websocket.NewNotifier("fieldAlerts", arena.generateFieldAlertsMessage)
 	arena.LowerThirdNotifier = websocket.NewNotifier("lowerThird", arena.generateLowerThirdMessage)
 	arena.MatchLoadNotifier = websocket.NewNotifier("matchLoad", arena.GenerateMatchLoadMessage)
 	arena.MatchTimeNotifier = websocket.NewNotifier("matchTime", arena.generateMatchTimeMessage)
 	arena.MatchTimingNotifier = websocket.NewNotifier("matchTiming", arena.generateMatchTimingMessage)
 	arena.PlaySoundNotifier = websocket.NewNotifier("playSound", nil)
+	arena.PracticeQueueNotifier = websocket.NewNotifier("practiceQueue", arena.generatePracticeQueueMessage)
+	arena.RankingsNotifier = websocket.NewNotifier("rankings", arena.generateRankingsMessage)
 	arena.RealtimeScoreNotifier = websocket.NewNotifier("realtimeScore", arena.generateRealtimeScoreMessage)
 	arena.ReloadDisplaysNotifier = websocket.NewNotifier("reload", nil)
 	arena.ScorePostedNotifier = websocket.NewNotifier("scorePosted", arena.GenerateScorePostedMessage)
@@ -90,6 +96,7 @@ func (arena *Arena) generateArenaStatusMessage() any {
 		AllianceStations map[string]*AllianceStation
 		MatchState
 		CanStartMatch         bool
+		InspectionWarning     string
 		AccessPointStatus     string
 		SwitchStatus          string
 		RedSCCStatus          string
@@ -101,7 +108,8 @@ func (arena *Arena) generateArenaStatusMessage() any {
 		arena.CurrentMatch.Id,
 		arena.AllianceStations,
 		arena.MatchState,
-		arena.checkCanStartMatch() == nil,
+		arena.CheckCanStartMatch() == nil,
+		arena.getInspectionWarning(),
 		arena.accessPoint.Status,
 		arena.networkSwitch.Status,
 		arena.redSCC.Status,
@@ -131,6 +139,12 @@ func (arena *Arena) generateEventStatusMessage() any {
 	return arena.EventStatus
 }
 
+func (arena *Arena) generateFieldAlertsMessage() any {
+	return &struct {
+		Alerts []FieldAlert
+	}{arena.FieldAlerts()}
+}
+
 func (arena *Arena) generateLowerThirdMessage() any {
 	return &struct {
 		LowerThird     *model.LowerThird
@@ -139,25 +153,42 @@ func (arena *Arena) generateLowerThirdMessage() any {
 }
 
 func (arena *Arena) GenerateMatchLoadMessage() any {
+	match := arena.CurrentMatch
 	teams := make(map[string]*model.Team)
 	var allTeamIds []int
-	for station, allianceStation := range arena.AllianceStations {
-		teams[station] = allianceStation.Team
-		if allianceStation.Team != nil {
-			allTeamIds = append(allTeamIds, allianceStation.Team.Id)
+	breakDescription := arena.breakDescription
+	if replay := arena.MatchReplay(); replay != nil {
+		// Show the teams that played the replayed match rather than the ones in the currently loaded match.
+		match = replay.Match
+		breakDescription = ""
+		teamIds := map[string]int{
+			"R1": match.Red1, "R2": match.Red2, "R3": match.Red3, "B1": match.Blue1, "B2": match.Blue2, "B3": match.Blue3,
+		}
+		for station, teamId := range teamIds {
+			teams[station], _ = arena.Database.GetTeamById(teamId)
+			if teams[station] != nil {
+				allTeamIds = append(allTeamIds, teamId)
+			}
+		}
+	} else {
+		for station, allianceStation := range arena.AllianceStations {
+			teams[station] = allianceStation.Team
+			if allianceStation.Team != nil {
+				allTeamIds = append(allTeamIds, allianceStation.Team.Id)
+			}
 		}
 	}
 
-	matchResult, _ := arena.Database.GetMatchResultForMatch(arena.CurrentMatch.Id)
+	matchResult, _ := arena.Database.GetMatchResultForMatch(match.Id)
 	isReplay := matchResult != nil
 
 	var matchup *playoff.Matchup
 	redOffFieldTeams := []*model.Team{}
 	blueOffFieldTeams := []*model.Team{}
-	if arena.CurrentMatch.Type == model.Playoff {
-		matchGroup := arena.PlayoffTournament.MatchGroups()[arena.CurrentMatch.PlayoffMatchGroupId]
+	if match.Type == model.Playoff {
+		matchGroup := arena.PlayoffTournament.MatchGroups()[match.PlayoffMatchGroupId]
 		matchup, _ = matchGroup.(*playoff.Matchup)
-		redOffFieldTeamIds, blueOffFieldTeamIds, _ := arena.Database.GetOffFieldTeamIds(arena.CurrentMatch)
+		redOffFieldTeamIds, blueOffFieldTeamIds, _ := arena.Database.GetOffFieldTeamIds(match)
 		for _, teamId := range redOffFieldTeamIds {
 			team, _ := arena.Database.GetTeamById(teamId)
 			redOffFieldTeams = append(redOffFieldTeams, team)
@@ -189,19 +220,22 @@ func (arena *Arena) GenerateMatchLoadMessage() any {
 		BlueOffFieldTeams []*model.Team
 		BreakDescription  string
 	}{
-		arena.CurrentMatch,
-		arena.CurrentMatch.ShouldAllowSubstitution(),
+		match,
+		match.ShouldAllowSubstitution(),
 		isReplay,
 		teams,
 		rankings,
 		matchup,
 		redOffFieldTeams,
 		blueOffFieldTeams,
-		arena.breakDescription,
+		breakDescription,
 	}
 }
 
 func (arena *Arena) generateMatchTimeMessage() any {
+	if replay := arena.MatchReplay(); replay != nil {
+		return MatchTimeMessage{MatchState(replay.currentSnapshot().MatchState), int(replay.matchTimeSec())}
+	}
 	return MatchTimeMessage{arena.MatchState, int(arena.MatchTimeSec())}
 }
 
@@ -209,7 +243,29 @@ func (arena *Arena) generateMatchTimingMessage() any {
 	return &game.MatchTiming
 }
 
+func (arena *Arena) generatePracticeQueueMessage() any {
+	entries, _ := arena.Database.GetAllPracticeQueueEntries()
+	return &struct {
+		Enabled bool
+		Entries []model.PracticeQueueEntry
+	}{arena.EventSettings.PracticeWalkUpQueueEnabled, entries}
+}
+
+func (arena *Arena) generateRankingsMessage() any {
+	rankings, _ := arena.Database.GetAllRankings()
+	return rankings
+}
+
 func (arena *Arena) generateRealtimeScoreMessage() any {
+	redRealtimeScore, blueRealtimeScore := arena.RedRealtimeScore, arena.BlueRealtimeScore
+	matchState := arena.MatchState
+	if replay := arena.MatchReplay(); replay != nil {
+		redRealtimeScore, blueRealtimeScore = replay.realtimeScores()
+		matchState = MatchState(replay.currentSnapshot().MatchState)
+	}
+	redScoreSummary := redRealtimeScore.CurrentScore.Summarize(&blueRealtimeScore.CurrentScore)
+	blueScoreSummary := blueRealtimeScore.CurrentScore.Summarize(&redRealtimeScore.CurrentScore)
+
 	fields := struct {
 		Red       *audienceAllianceScoreFields
 		Blue      *audienceAllianceScoreFields
@@ -217,11 +273,11 @@ func (arena *Arena) generateRealtimeScoreMessage() any {
 		BlueCards map[string]string
 		MatchState
 	}{
-		getAudienceAllianceScoreFields(arena.RedRealtimeScore, arena.RedScoreSummary()),
-		getAudienceAllianceScoreFields(arena.BlueRealtimeScore, arena.BlueScoreSummary()),
-		arena.RedRealtimeScore.Cards,
-		arena.BlueRealtimeScore.Cards,
-		arena.MatchState,
+		getAudienceAllianceScoreFields(redRealtimeScore, redScoreSummary),
+		getAudienceAllianceScoreFields(blueRealtimeScore, blueScoreSummary),
+		redRealtimeScore.Cards,
+		blueRealtimeScore.Cards,
+		matchState,
 	}
 	return &fields
 }
@@ -315,7 +371,7 @@ func (arena *Arena) GenerateScorePostedMessage() any {
 		blueWins,
 		redDestination,
 		blueDestination,
-		game.CoralBonusCoopEnabled,
+		game.CurrentGame().CoopertitionEnabled(),
 	}
 }
 
@@ -8,6 +8,7 @@ import (
 	"github.com/Team254/cheesy-arena/model"
 	"github.com/Team254/cheesy-arena/partner"
 	"github.com/Team254/cheesy-arena/playoff"
+	"github.com/Team254/cheesy-arena/plc"
 	"github.com/Team254/cheesy-arena/tournament"
 	"github.com/Team254/cheesy-arena/websocket"
 	"github.com/stretchr/testify/assert"
@@ -64,7 +65,7 @@ func TestArenaCheckCanStartMatch(t *testing.T) {
 	arena := setupTestArena(t)
 
 	// Check robot state constraints.
-	err := arena.checkCanStartMatch()
+	err := arena.CheckCanStartMatch()
 	if assert.NotNil(t, err) {
 		assert.Contains(t, err.Error(), "cannot start match until all robots are connected or bypassed")
 	}
@@ -73,21 +74,76 @@ func TestArenaCheckCanStartMatch(t *testing.T) {
 	arena.AllianceStations["R3"].Bypass = true
 	arena.AllianceStations["B1"].Bypass = true
 	arena.AllianceStations["B2"].Bypass = true
-	err = arena.checkCanStartMatch()
+	err = arena.CheckCanStartMatch()
 	if assert.NotNil(t, err) {
 		assert.Contains(t, err.Error(), "cannot start match until all robots are connected or bypassed")
 	}
 	arena.AllianceStations["B3"].Bypass = true
-	assert.Nil(t, arena.checkCanStartMatch())
+	assert.Nil(t, arena.CheckCanStartMatch())
 
 	// Check PLC constraints.
 	arena.Plc.SetAddress("1.2.3.4")
-	err = arena.checkCanStartMatch()
+	err = arena.CheckCanStartMatch()
 	if assert.NotNil(t, err) {
 		assert.Contains(t, err.Error(), "cannot start match while PLC is not healthy")
 	}
 	arena.Plc.SetAddress("")
-	assert.Nil(t, arena.checkCanStartMatch())
+	assert.Nil(t, arena.CheckCanStartMatch())
+}
+
+func TestArenaNoFieldHardware(t *testing.T) {
+	arena := setupTestArena(t)
+	arena.EventSettings.PlcAddress = "1.2.3.4"
+	arena.EventSettings.TeamSignRed1Id = 12
+	assert.Nil(t, arena.Database.UpdateEventSettings(arena.EventSettings))
+	assert.Nil(t, arena.LoadSettings())
+	assert.True(t, arena.Plc.IsEnabled())
+
+	arena2, err := NewArena(arena.Database.Path+"2", true)
+	assert.Nil(t, err)
+	defer arena2.Database.Close()
+	arena2.EventSettings.PlcAddress = "1.2.3.4"
+	arena2.EventSettings.TeamSignRed1Id = 12
+	assert.Nil(t, arena2.Database.UpdateEventSettings(arena2.EventSettings))
+	assert.Nil(t, arena2.LoadSettings())
+	assert.False(t, arena2.Plc.IsEnabled())
+	assert.Nil(t, arena2.TeamSigns.Red1.udpConn)
+	arena2.AllianceStations["R1"].Bypass = true
+	arena2.AllianceStations["R2"].Bypass = true
+	arena2.AllianceStations["R3"].Bypass = true
+	arena2.AllianceStations["B1"].Bypass = true
+	arena2.AllianceStations["B2"].Bypass = true
+	arena2.AllianceStations["B3"].Bypass = true
+	assert.Nil(t, arena2.CheckCanStartMatch())
+}
+
+func TestArenaSimulatedPlc(t *testing.T) {
+	arena := setupTestArena(t)
+	assert.IsType(t, &plc.ModbusPlc{}, arena.Plc)
+
+	// Check that the switch is left to the arena loop.
+	arena.EventSettings.PlcAddress = "1.2.3.4"
+	arena.EventSettings.PlcSimulated = true
+	assert.Nil(t, arena.Database.UpdateEventSettings(arena.EventSettings))
+	assert.Nil(t, arena.LoadSettings())
+	assert.IsType(t, &plc.ModbusPlc{}, arena.Plc)
+	arena.Update()
+	assert.IsType(t, &plc.SimulatedPlc{}, arena.Plc)
+	assert.True(t, arena.Plc.IsEnabled())
+	assert.True(t, arena.Plc.IsHealthy())
+	assert.False(t, arena.modbusPlc.IsEnabled())
+
+	// Check that the switch waits until no match is in progress.
+	arena.EventSettings.PlcSimulated = false
+	assert.Nil(t, arena.Database.UpdateEventSettings(arena.EventSettings))
+	assert.Nil(t, arena.LoadSettings())
+	arena.MatchState = PostMatch
+	arena.applyPendingPlc()
+	assert.IsType(t, &plc.SimulatedPlc{}, arena.Plc)
+	arena.MatchState = PreMatch
+	arena.applyPendingPlc()
+	assert.IsType(t, &plc.ModbusPlc{}, arena.Plc)
+	assert.True(t, arena.Plc.IsEnabled())
 }
 
 func TestArenaMatchFlow(t *testing.T) {
@@ -402,6 +458,62 @@ func TestMatchStartRobotLinkEnforcement(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestMatchStartInspectionEnforcement(t *testing.T) {
+	arena := setupTestArena(t)
+
+	for teamId := 101; teamId <= 106; teamId++ {
+		arena.Database.CreateTeam(&model.Team{Id: teamId, InspectionStatus: model.InspectionPassed})
+	}
+	team, _ := arena.Database.GetTeamById(103)
+	team.InspectionStatus = model.InspectionNeedsReinspection
+	arena.Database.UpdateTeam(team)
+	match := model.Match{
+		Type: model.Qualification, Red1: 101, Red2: 102, Red3: 103, Blue1: 104, Blue2: 105, Blue3: 106,
+	}
+	arena.Database.CreateMatch(&match)
+	assert.Nil(t, arena.LoadMatch(&match))
+	for _, station := range arena.AllianceStations {
+		station.DsConn = &DriverStationConnection{TeamId: station.Team.Id, RobotLinked: true}
+	}
+
+	// Check that the match can be started regardless of inspection status if it isn't enforced.
+	assert.Nil(t, arena.CheckCanStartMatch())
+	assert.Equal(t, "", arena.getInspectionWarning())
+
+	// Check that only a warning is given in warning mode.
+	arena.EventSettings.InspectionEnforcement = model.InspectionWarning
+	assert.Nil(t, arena.CheckCanStartMatch())
+	assert.Equal(
+		t, "team 103 has not passed robot inspection (status: Needs Re-inspection)", arena.getInspectionWarning(),
+	)
+
+	// Check that the match is blocked in required mode, unless the team is bypassed.
+	arena.EventSettings.InspectionEnforcement = model.InspectionRequired
+	err := arena.CheckCanStartMatch()
+	if assert.NotNil(t, err) {
+		assert.Equal(
+			t, "cannot start match while team 103 has not passed robot inspection (status: Needs Re-inspection)",
+			err.Error(),
+		)
+	}
+	arena.AllianceStations["R3"].Bypass = true
+	assert.Nil(t, arena.CheckCanStartMatch())
+	arena.AllianceStations["R3"].Bypass = false
+
+	// Check that an updated inspection status takes effect for the loaded match.
+	team.InspectionStatus = model.InspectionPassed
+	arena.Database.UpdateTeam(team)
+	arena.UpdateAssignedTeam(team)
+	assert.Nil(t, arena.CheckCanStartMatch())
+	assert.Equal(t, "", arena.getInspectionWarning())
+
+	// Check that test matches are exempt.
+	arena.Database.CreateTeam(&model.Team{Id: 107})
+	assert.Nil(t, arena.LoadTestMatch())
+	assert.Nil(t, arena.SubstituteTeams(107, 0, 0, 0, 0, 0))
+	assert.Nil(t, arena.checkTeamsInspected())
+}
+
 func TestLoadNextMatch(t *testing.T) {
 	arena := setupTestArena(t)
 
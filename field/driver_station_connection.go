@@ -51,7 +51,6 @@ type DriverStationConnection struct {
 	packetCount               int
 	tcpConn                   net.Conn
 	udpConn                   net.Conn
-	log                       *TeamMatchLog
 
 	// WrongStation indicates if the team in the station is the incorrect team
 	// by being non-empty. If the team is in the correct station, or no team is
@@ -96,7 +95,8 @@ func (arena *Arena) listenForDsUdpPackets() {
 	udpAddress, _ := net.ResolveUDPAddr("udp4", fmt.Sprintf(":%d", driverStationUdpReceivePort))
 	listener, err := net.ListenUDP("udp4", udpAddress)
 	if err != nil {
-		log.Fatalf("Error opening driver station UDP socket: %v", err)
+		log.Printf("Error opening driver station UDP socket: %v", err)
+		return
 	}
 	log.Printf("Listening for driver stations on UDP port %d\n", driverStationUdpReceivePort)
 
@@ -177,9 +177,6 @@ func (dsConn *DriverStationConnection) update(arena *Arena) error {
 }
 
 func (dsConn *DriverStationConnection) close() {
-	if dsConn.log != nil {
-		dsConn.log.Close()
-	}
 	if dsConn.udpConn != nil {
 		dsConn.udpConn.Close()
 	}
@@ -189,12 +186,9 @@ func (dsConn *DriverStationConnection) close() {
 }
 
 // Called at the start of the match to allow for driver station initialization.
-func (dsConn *DriverStationConnection) signalMatchStart(match *model.Match, wifiStatus *network.TeamWifiStatus) error {
-	// Zero out missed packet count and begin logging.
+func (dsConn *DriverStationConnection) signalMatchStart() {
+	// Zero out missed packet count.
 	dsConn.MissedPacketCount = 0
-	var err error
-	dsConn.log, err = NewTeamMatchLog(dsConn.TeamId, match, wifiStatus)
-	return err
 }
 
 // Serializes the control information into a packet.
@@ -435,8 +429,8 @@ func (dsConn *DriverStationConnection) handleTcpConnection(arena *Arena) {
 			// Robot log packet. Just use to trigger fms log
 			// Create a log entry if the match is in progress.
 			matchTimeSec := arena.MatchTimeSec()
-			if matchTimeSec > 0 && dsConn.log != nil {
-				dsConn.log.LogDsPacket(matchTimeSec, packetType, dsConn)
+			if matchTimeSec > 0 {
+				arena.logDsStatus(matchTimeSec, dsConn)
 			}
 		default:
 			log.Printf("Received unknown packet type %d from Team %d", packetType, dsConn.TeamId)
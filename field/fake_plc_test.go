@@ -100,6 +100,10 @@ func (plc *FakePlc) GetCoilNames() []string {
 	return []string{}
 }
 
+func (plc *FakePlc) GetIoValues() ([]bool, []uint16, []bool) {
+	return []bool{}, []uint16{}, []bool{}
+}
+
 func (plc *FakePlc) GetProcessorCounts() (int, int) {
 	return plc.redProcessorCount, plc.blueProcessorCount
 }
@@ -0,0 +1,301 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Rules-based monitoring of the field hardware and robot connections, which raises alerts for the FTA when a problem
+// persists instead of relying on someone to notice it on the field monitor.
+
+package field
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	FieldAlertMaxSnoozeMin = 60
+	lowBatteryVoltage      = 7.0
+	highTripTimeMs         = 50
+)
+
+type FieldAlertSeverity string
+
+const (
+	FieldAlertWarning  FieldAlertSeverity = "warning"
+	FieldAlertCritical FieldAlertSeverity = "critical"
+)
+
+// A condition on the state of the arena that raises an alert once it has held continuously for the given duration.
+type fieldHealthRule struct {
+	id          string
+	severity    FieldAlertSeverity
+	durationSec float64
+	// Returns a description of the problem for each subject (e.g. an alliance station) that the condition currently
+	// holds for, using an empty subject for field-wide conditions.
+	check func(arena *Arena) map[string]string
+}
+
+// An alert raised by the field health monitor, which remains active until the condition that raised it clears.
+type FieldAlert struct {
+	Id           string
+	RuleId       string
+	Subject      string
+	Severity     FieldAlertSeverity
+	Message      string
+	RaisedAt     time.Time
+	Acknowledged bool
+	Snoozed      bool
+	SnoozedUntil time.Time
+}
+
+// State of the field health monitor between iterations of the arena loop.
+type fieldHealthState struct {
+	mutex sync.Mutex
+	// Time at which the condition of each alert ID, active or pending, started holding.
+	conditionSince map[string]time.Time
+	alerts         map[string]*FieldAlert
+	// Snoozes outlive the alerts they were applied to so that a flapping condition stays quiet.
+	snoozedUntil map[string]time.Time
+}
+
+var fieldHealthRules = []fieldHealthRule{
+	{id: "plcUnhealthy", severity: FieldAlertCritical, durationSec: 5, check: checkPlcHealth},
+	{id: "accessPointNotActive", severity: FieldAlertWarning, durationSec: 30, check: checkAccessPointStatus},
+	{id: "switchNotActive", severity: FieldAlertWarning, durationSec: 30, check: checkSwitchStatus},
+	{id: "sccError", severity: FieldAlertWarning, durationSec: 5, check: checkSccStatus},
+	{id: "robotLinkLost", severity: FieldAlertCritical, durationSec: 1, check: checkRobotLinks},
+	{id: "lowBattery", severity: FieldAlertWarning, durationSec: 2, check: checkBatteryVoltages},
+	{id: "highTripTime", severity: FieldAlertWarning, durationSec: 5, check: checkTripTimes},
+}
+
+func newFieldHealthState() *fieldHealthState {
+	return &fieldHealthState{
+		conditionSince: make(map[string]time.Time),
+		alerts:         make(map[string]*FieldAlert),
+		snoozedUntil:   make(map[string]time.Time),
+	}
+}
+
+// Evaluates all of the field health rules, raising alerts for conditions that have held for long enough and clearing
+// those whose conditions no longer hold. Called on every iteration of the arena loop.
+func (arena *Arena) updateFieldHealth() {
+	now := time.Now()
+	changed := false
+	state := arena.fieldHealth
+	state.mutex.Lock()
+
+	holding := make(map[string]struct{})
+	for _, rule := range fieldHealthRules {
+		for subject, message := range rule.check(arena) {
+			alertId := fieldAlertId(rule.id, subject)
+			holding[alertId] = struct{}{}
+			since, ok := state.conditionSince[alertId]
+			if !ok {
+				since = now
+				state.conditionSince[alertId] = now
+			}
+			if _, ok = state.alerts[alertId]; ok || now.Sub(since).Seconds() < rule.durationSec {
+				continue
+			}
+			state.alerts[alertId] = &FieldAlert{
+				Id:       alertId,
+				RuleId:   rule.id,
+				Subject:  subject,
+				Severity: rule.severity,
+				Message:  message,
+				RaisedAt: now,
+			}
+			log.Printf("Field alert raised: %s", message)
+			changed = true
+		}
+	}
+	for alertId := range state.conditionSince {
+		if _, ok := holding[alertId]; ok {
+			continue
+		}
+		delete(state.conditionSince, alertId)
+		if alert, ok := state.alerts[alertId]; ok {
+			log.Printf("Field alert cleared: %s", alert.Message)
+			delete(state.alerts, alertId)
+			changed = true
+		}
+	}
+
+	for alertId, snoozedUntil := range state.snoozedUntil {
+		if !now.Before(snoozedUntil) {
+			delete(state.snoozedUntil, alertId)
+		}
+	}
+	for alertId, alert := range state.alerts {
+		snoozedUntil, snoozed := state.snoozedUntil[alertId]
+		if snoozed != alert.Snoozed {
+			alert.Snoozed = snoozed
+			alert.SnoozedUntil = snoozedUntil
+			changed = true
+		}
+	}
+	state.mutex.Unlock()
+
+	if changed {
+		arena.FieldAlertsNotifier.Notify()
+	}
+}
+
+// Returns copies of the active field alerts, most severe and then oldest first.
+func (arena *Arena) FieldAlerts() []FieldAlert {
+	arena.fieldHealth.mutex.Lock()
+	defer arena.fieldHealth.mutex.Unlock()
+	alerts := make([]FieldAlert, 0, len(arena.fieldHealth.alerts))
+	for _, alert := range arena.fieldHealth.alerts {
+		alerts = append(alerts, *alert)
+	}
+	sort.Slice(
+		alerts,
+		func(i, j int) bool {
+			if alerts[i].Severity != alerts[j].Severity {
+				return alerts[i].Severity == FieldAlertCritical
+			}
+			if !alerts[i].RaisedAt.Equal(alerts[j].RaisedAt) {
+				return alerts[i].RaisedAt.Before(alerts[j].RaisedAt)
+			}
+			return alerts[i].Id < alerts[j].Id
+		},
+	)
+	return alerts
+}
+
+// Marks the given alert as having been seen, until its condition clears.
+func (arena *Arena) AcknowledgeFieldAlert(alertId string) error {
+	arena.fieldHealth.mutex.Lock()
+	alert, ok := arena.fieldHealth.alerts[alertId]
+	if !ok {
+		arena.fieldHealth.mutex.Unlock()
+		return fmt.Errorf("Field alert %q is not active.", alertId)
+	}
+	alert.Acknowledged = true
+	arena.fieldHealth.mutex.Unlock()
+
+	arena.FieldAlertsNotifier.Notify()
+	return nil
+}
+
+// Suppresses the given alert for the given number of minutes, including any recurrences of it during that time.
+func (arena *Arena) SnoozeFieldAlert(alertId string, durationMin int) error {
+	if durationMin < 1 || durationMin > FieldAlertMaxSnoozeMin {
+		return fmt.Errorf("Snooze duration must be between 1 and %d minutes.", FieldAlertMaxSnoozeMin)
+	}
+
+	arena.fieldHealth.mutex.Lock()
+	alert, ok := arena.fieldHealth.alerts[alertId]
+	if !ok {
+		arena.fieldHealth.mutex.Unlock()
+		return fmt.Errorf("Field alert %q is not active.", alertId)
+	}
+	snoozedUntil := time.Now().Add(time.Duration(durationMin) * time.Minute)
+	arena.fieldHealth.snoozedUntil[alertId] = snoozedUntil
+	alert.Snoozed = true
+	alert.SnoozedUntil = snoozedUntil
+	arena.fieldHealth.mutex.Unlock()
+
+	arena.FieldAlertsNotifier.Notify()
+	return nil
+}
+
+func fieldAlertId(ruleId, subject string) string {
+	if subject == "" {
+		return ruleId
+	}
+	return ruleId + "/" + subject
+}
+
+func checkPlcHealth(arena *Arena) map[string]string {
+	if arena.Plc.IsEnabled() && !arena.Plc.IsHealthy() {
+		return map[string]string{"": "The PLC is not responding."}
+	}
+	return nil
+}
+
+func checkAccessPointStatus(arena *Arena) map[string]string {
+	// The access point is only reconfigured when a match is loaded, so there is nothing to flag once play starts.
+	if arena.EventSettings.NetworkSecurityEnabled && !arena.NoFieldHardware && arena.MatchState == PreMatch &&
+		arena.accessPoint.Status != "ACTIVE" {
+		return map[string]string{"": fmt.Sprintf("The access point is %s.", strings.ToLower(arena.accessPoint.Status))}
+	}
+	return nil
+}
+
+func checkSwitchStatus(arena *Arena) map[string]string {
+	if arena.EventSettings.NetworkSecurityEnabled && !arena.NoFieldHardware && arena.MatchState == PreMatch &&
+		arena.networkSwitch.Status != "ACTIVE" {
+		return map[string]string{"": fmt.Sprintf("The switch is %s.", strings.ToLower(arena.networkSwitch.Status))}
+	}
+	return nil
+}
+
+func checkSccStatus(arena *Arena) map[string]string {
+	if !arena.EventSettings.SCCManagementEnabled || arena.NoFieldHardware {
+		return nil
+	}
+	problems := make(map[string]string)
+	if arena.redSCC.Status == "ERROR" {
+		problems["red"] = "The red SCC failed to apply its configuration."
+	}
+	if arena.blueSCC.Status == "ERROR" {
+		problems["blue"] = "The blue SCC failed to apply its configuration."
+	}
+	return problems
+}
+
+func checkRobotLinks(arena *Arena) map[string]string {
+	if arena.MatchState != AutoPeriod && arena.MatchState != PausePeriod && arena.MatchState != TeleopPeriod {
+		return nil
+	}
+	problems := make(map[string]string)
+	for station, allianceStation := range arena.AllianceStations {
+		if allianceStation.Team == nil || allianceStation.Bypass {
+			continue
+		}
+		if allianceStation.DsConn == nil || !allianceStation.DsConn.DsLinked {
+			problems[station] = fmt.Sprintf(
+				"Team %d's driver station in %s is not connected.", allianceStation.Team.Id, station,
+			)
+		} else if !allianceStation.DsConn.RobotLinked {
+			problems[station] = fmt.Sprintf(
+				"Team %d's robot in %s has lost its connection.", allianceStation.Team.Id, station,
+			)
+		}
+	}
+	return problems
+}
+
+func checkBatteryVoltages(arena *Arena) map[string]string {
+	if arena.MatchState != PreMatch {
+		return nil
+	}
+	problems := make(map[string]string)
+	for station, allianceStation := range arena.AllianceStations {
+		dsConn := allianceStation.DsConn
+		if dsConn != nil && dsConn.RobotLinked && dsConn.BatteryVoltage > 0 && dsConn.BatteryVoltage < lowBatteryVoltage {
+			problems[station] = fmt.Sprintf(
+				"Team %d's robot battery in %s is at %.1fV.", dsConn.TeamId, station, dsConn.BatteryVoltage,
+			)
+		}
+	}
+	return problems
+}
+
+func checkTripTimes(arena *Arena) map[string]string {
+	problems := make(map[string]string)
+	for station, allianceStation := range arena.AllianceStations {
+		dsConn := allianceStation.DsConn
+		if dsConn != nil && dsConn.RobotLinked && dsConn.DsRobotTripTimeMs > highTripTimeMs {
+			problems[station] = fmt.Sprintf(
+				"Team %d's trip time in %s is %dms.", dsConn.TeamId, station, dsConn.DsRobotTripTimeMs,
+			)
+		}
+	}
+	return problems
+}
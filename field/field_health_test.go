@@ -0,0 +1,145 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// Makes the condition of the given alert appear to have been holding for the given duration.
+func backdateFieldHealthCondition(arena *Arena, alertId string, duration time.Duration) {
+	arena.fieldHealth.mutex.Lock()
+	defer arena.fieldHealth.mutex.Unlock()
+	arena.fieldHealth.conditionSince[alertId] = arena.fieldHealth.conditionSince[alertId].Add(-duration)
+}
+
+func TestFieldHealthAlertLifecycle(t *testing.T) {
+	arena := setupTestArena(t)
+	arena.Database.CreateTeam(&model.Team{Id: 254})
+	assert.Nil(t, arena.assignTeam(254, "R1"))
+	dsConn := &DriverStationConnection{TeamId: 254, AllianceStation: "R1", RobotLinked: true, BatteryVoltage: 6.5}
+	arena.AllianceStations["R1"].DsConn = dsConn
+
+	// Check that the alert isn't raised until the condition has held for long enough.
+	arena.updateFieldHealth()
+	assert.Empty(t, arena.FieldAlerts())
+	backdateFieldHealthCondition(arena, "lowBattery/R1", 3*time.Second)
+	arena.updateFieldHealth()
+	alerts := arena.FieldAlerts()
+	if assert.Equal(t, 1, len(alerts)) {
+		assert.Equal(t, "lowBattery/R1", alerts[0].Id)
+		assert.Equal(t, "lowBattery", alerts[0].RuleId)
+		assert.Equal(t, "R1", alerts[0].Subject)
+		assert.Equal(t, FieldAlertWarning, alerts[0].Severity)
+		assert.Equal(t, "Team 254's robot battery in R1 is at 6.5V.", alerts[0].Message)
+		assert.False(t, alerts[0].Acknowledged)
+		assert.False(t, alerts[0].Snoozed)
+	}
+
+	// Check acknowledging and snoozing the alert.
+	assert.Nil(t, arena.AcknowledgeFieldAlert("lowBattery/R1"))
+	assert.True(t, arena.FieldAlerts()[0].Acknowledged)
+	assert.EqualError(t, arena.AcknowledgeFieldAlert("lowBattery/R2"), "Field alert \"lowBattery/R2\" is not active.")
+	assert.EqualError(
+		t, arena.SnoozeFieldAlert("lowBattery/R1", 0), "Snooze duration must be between 1 and 60 minutes.",
+	)
+	assert.EqualError(
+		t, arena.SnoozeFieldAlert("lowBattery/R1", 61), "Snooze duration must be between 1 and 60 minutes.",
+	)
+	assert.NotNil(t, arena.SnoozeFieldAlert("plcUnhealthy", 5))
+	assert.Nil(t, arena.SnoozeFieldAlert("lowBattery/R1", 5))
+	alerts = arena.FieldAlerts()
+	assert.True(t, alerts[0].Snoozed)
+	assert.True(t, alerts[0].SnoozedUntil.After(time.Now().Add(4*time.Minute)))
+
+	// Check that the alert clears along with its condition, and that a recurrence is new but still snoozed.
+	dsConn.BatteryVoltage = 12.5
+	arena.updateFieldHealth()
+	assert.Empty(t, arena.FieldAlerts())
+	dsConn.BatteryVoltage = 6.8
+	arena.updateFieldHealth()
+	backdateFieldHealthCondition(arena, "lowBattery/R1", 3*time.Second)
+	arena.updateFieldHealth()
+	alerts = arena.FieldAlerts()
+	if assert.Equal(t, 1, len(alerts)) {
+		assert.Equal(t, "Team 254's robot battery in R1 is at 6.8V.", alerts[0].Message)
+		assert.False(t, alerts[0].Acknowledged)
+		assert.True(t, alerts[0].Snoozed)
+	}
+
+	// Check that the alert reappears once the snooze expires.
+	arena.fieldHealth.snoozedUntil["lowBattery/R1"] = time.Now().Add(-time.Second)
+	arena.updateFieldHealth()
+	alerts = arena.FieldAlerts()
+	if assert.Equal(t, 1, len(alerts)) {
+		assert.False(t, alerts[0].Snoozed)
+	}
+
+	// Check that the battery is only monitored before the match.
+	arena.MatchState = TeleopPeriod
+	arena.updateFieldHealth()
+	assert.Empty(t, arena.FieldAlerts())
+}
+
+func TestFieldHealthRules(t *testing.T) {
+	arena := setupTestArena(t)
+	arena.Database.CreateTeam(&model.Team{Id: 254})
+	arena.Database.CreateTeam(&model.Team{Id: 1114})
+	assert.Nil(t, arena.assignTeam(254, "R1"))
+	assert.Nil(t, arena.assignTeam(1114, "B2"))
+	arena.AllianceStations["R1"].DsConn = &DriverStationConnection{
+		TeamId: 254, AllianceStation: "R1", DsLinked: true, RobotLinked: true, DsRobotTripTimeMs: 80,
+	}
+	arena.Plc.SetAddress("1.2.3.4")
+	arena.EventSettings.NetworkSecurityEnabled = true
+	arena.EventSettings.SCCManagementEnabled = true
+	arena.redSCC.Status = "ERROR"
+
+	assert.Equal(t, map[string]string{"": "The PLC is not responding."}, checkPlcHealth(arena))
+	assert.Equal(t, map[string]string{"": "The access point is unknown."}, checkAccessPointStatus(arena))
+	assert.Equal(t, map[string]string{"": "The switch is unknown."}, checkSwitchStatus(arena))
+	assert.Equal(t, map[string]string{"red": "The red SCC failed to apply its configuration."}, checkSccStatus(arena))
+	assert.Equal(t, map[string]string{"R1": "Team 254's trip time in R1 is 80ms."}, checkTripTimes(arena))
+	assert.Empty(t, checkRobotLinks(arena))
+
+	arena.accessPoint.Status = "ACTIVE"
+	arena.networkSwitch.Status = "ACTIVE"
+	assert.Empty(t, checkAccessPointStatus(arena))
+	assert.Empty(t, checkSwitchStatus(arena))
+
+	// Check that robot connections are only monitored during the match, and not for bypassed stations.
+	arena.MatchState = AutoPeriod
+	assert.Equal(
+		t,
+		map[string]string{"B2": "Team 1114's driver station in B2 is not connected."},
+		checkRobotLinks(arena),
+	)
+	arena.AllianceStations["R1"].DsConn.RobotLinked = false
+	assert.Equal(
+		t,
+		map[string]string{
+			"R1": "Team 254's robot in R1 has lost its connection.",
+			"B2": "Team 1114's driver station in B2 is not connected.",
+		},
+		checkRobotLinks(arena),
+	)
+	arena.AllianceStations["B2"].Bypass = true
+	assert.Equal(t, map[string]string{"R1": "Team 254's robot in R1 has lost its connection."}, checkRobotLinks(arena))
+
+	// Check that critical alerts are listed first.
+	arena.updateFieldHealth()
+	for _, alertId := range []string{"plcUnhealthy", "sccError/red", "robotLinkLost/R1"} {
+		backdateFieldHealthCondition(arena, alertId, 10*time.Second)
+	}
+	arena.updateFieldHealth()
+	alerts := arena.FieldAlerts()
+	if assert.Equal(t, 3, len(alerts)) {
+		assert.Equal(t, FieldAlertCritical, alerts[0].Severity)
+		assert.Equal(t, FieldAlertCritical, alerts[1].Severity)
+		assert.Equal(t, "sccError/red", alerts[2].Id)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Recording of scoring and foul changes made during a match to the per-match event log.
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"maps"
+	"slices"
+	"time"
+)
+
+// Point-in-time copy of both alliances' realtime scores, used to compute what changed as a result of a command.
+type RealtimeScoreSnapshot struct {
+	Red  RealtimeScore
+	Blue RealtimeScore
+}
+
+// SnapshotRealtimeScores returns a deep copy of the current realtime scores for later comparison.
+func (arena *Arena) SnapshotRealtimeScores() RealtimeScoreSnapshot {
+	return RealtimeScoreSnapshot{
+		Red:  copyRealtimeScore(arena.RedRealtimeScore),
+		Blue: copyRealtimeScore(arena.BlueRealtimeScore),
+	}
+}
+
+// NewRealtimeScoreSnapshot returns a snapshot representing the scores stored in the given match result.
+func NewRealtimeScoreSnapshot(matchResult *model.MatchResult) RealtimeScoreSnapshot {
+	return RealtimeScoreSnapshot{
+		Red:  copyRealtimeScore(&RealtimeScore{CurrentScore: *matchResult.RedScore, Cards: matchResult.RedCards}),
+		Blue: copyRealtimeScore(&RealtimeScore{CurrentScore: *matchResult.BlueScore, Cards: matchResult.BlueCards}),
+	}
+}
+
+// RecordMatchEvent compares the current realtime scores to the given snapshot taken before the command was processed
+// and, if anything changed, appends an entry to the current match's event log.
+func (arena *Arena) RecordMatchEvent(source, username, command string, before RealtimeScoreSnapshot) error {
+	if arena.CurrentMatch.Type == model.Test {
+		// Test matches aren't persisted, so there is nothing to associate the events with.
+		return nil
+	}
+	return RecordMatchEventForMatch(
+		arena.Database,
+		arena.CurrentMatch.Id,
+		arena.MatchTimeSec(),
+		source,
+		username,
+		command,
+		before,
+		arena.SnapshotRealtimeScores(),
+	)
+}
+
+// RecordMatchEventForMatch appends an entry to the given match's event log describing the differences between the two
+// snapshots, if there are any.
+func RecordMatchEventForMatch(
+	database *model.Database,
+	matchId int,
+	matchTimeSec float64,
+	source, username, command string,
+	before, after RealtimeScoreSnapshot,
+) error {
+	changes, err := model.DiffFields(before, after)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	matchEvent := model.MatchEvent{
+		MatchId:      matchId,
+		Timestamp:    time.Now(),
+		MatchTimeSec: matchTimeSec,
+		Source:       source,
+		Username:     username,
+		Command:      command,
+		Changes:      changes,
+	}
+	return database.CreateMatchEvent(&matchEvent)
+}
+
+// Returns a deep copy of the given realtime score so that later in-place modifications don't affect it.
+func copyRealtimeScore(realtimeScore *RealtimeScore) RealtimeScore {
+	scoreCopy := *realtimeScore
+	scoreCopy.CurrentScore.Fouls = slices.Clone(realtimeScore.CurrentScore.Fouls)
+	scoreCopy.CurrentScore.Counters = maps.Clone(realtimeScore.CurrentScore.Counters)
+	scoreCopy.Cards = maps.Clone(realtimeScore.Cards)
+	return scoreCopy
+}
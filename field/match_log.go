@@ -0,0 +1,153 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Recording of driver station telemetry and field state during a match to a per-match log bundle.
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/matchlog"
+	"log"
+	"slices"
+)
+
+// Directory in which the match logs are written; mutable for running several isolated instances and for testing.
+var LogsDir = "static/logs"
+
+// State of the arena as of the last record written to the match log, for detecting changes.
+type matchLogState struct {
+	writer            *matchlog.Writer
+	plcInputs         []bool
+	plcRegisters      []uint16
+	plcCoils          []bool
+	accessPointStatus string
+}
+
+// Begins a new match log bundle for the current match, closing any previous one.
+func (arena *Arena) startMatchLog() {
+	header := matchlog.Header{
+		MatchId:        arena.CurrentMatch.Id,
+		MatchType:      arena.CurrentMatch.Type.String(),
+		MatchShortName: arena.CurrentMatch.ShortName,
+		StartedAt:      arena.CurrentMatch.StartedAt,
+		TeamIds:        make(map[string]int),
+	}
+	for station, allianceStation := range arena.AllianceStations {
+		if allianceStation.Team != nil {
+			header.TeamIds[station] = allianceStation.Team.Id
+		}
+	}
+	writer, err := matchlog.Create(LogsDir, header)
+	if err != nil {
+		log.Printf("Failed to create match log: %v", err)
+	}
+
+	arena.matchLogMutex.Lock()
+	defer arena.matchLogMutex.Unlock()
+	if arena.matchLog.writer != nil {
+		arena.matchLog.writer.Close()
+	}
+	arena.matchLog = matchLogState{writer: writer}
+}
+
+// Records any changes to the arena state, PLC I/O and access point status to the match log, and closes the log once the
+// match is over. Called on every iteration of the arena loop.
+func (arena *Arena) updateMatchLog(matchTimeSec float64) {
+	arena.matchLogMutex.Lock()
+	defer arena.matchLogMutex.Unlock()
+	if arena.matchLog.writer == nil {
+		return
+	}
+
+	if arena.MatchState != arena.lastMatchState {
+		arena.writeMatchLogRecord(
+			matchlog.Record{
+				Type: matchlog.ArenaStateRecord, MatchTimeSec: matchTimeSec, MatchState: int(arena.MatchState),
+			},
+		)
+	}
+
+	inputs, registers, coils := arena.Plc.GetIoValues()
+	if !slices.Equal(inputs, arena.matchLog.plcInputs) || !slices.Equal(registers, arena.matchLog.plcRegisters) ||
+		!slices.Equal(coils, arena.matchLog.plcCoils) {
+		arena.writeMatchLogRecord(
+			matchlog.Record{
+				Type:         matchlog.PlcIoRecord,
+				MatchTimeSec: matchTimeSec,
+				PlcIo:        &matchlog.PlcIo{Inputs: inputs, Registers: registers, Coils: coils},
+			},
+		)
+		arena.matchLog.plcInputs, arena.matchLog.plcRegisters, arena.matchLog.plcCoils = inputs, registers, coils
+	}
+
+	if arena.accessPoint.Status != arena.matchLog.accessPointStatus {
+		arena.writeMatchLogRecord(
+			matchlog.Record{
+				Type: matchlog.AccessPointRecord, MatchTimeSec: matchTimeSec, AccessPoint: arena.accessPoint.Status,
+			},
+		)
+		arena.matchLog.accessPointStatus = arena.accessPoint.Status
+	}
+
+	if arena.MatchState == PostMatch {
+		arena.matchLog.writer.Close()
+		arena.matchLog = matchLogState{}
+		arena.pruneMatchLogs(LogsDir, arena.EventSettings.MatchLogRetainDays)
+	}
+}
+
+// Records the current state of the given driver station and its wifi connection to the match log.
+func (arena *Arena) logDsStatus(matchTimeSec float64, dsConn *DriverStationConnection) {
+	arena.matchLogMutex.Lock()
+	defer arena.matchLogMutex.Unlock()
+	if arena.matchLog.writer == nil {
+		return
+	}
+
+	wifiStatus := &arena.AllianceStations[dsConn.AllianceStation].WifiStatus
+	arena.writeMatchLogRecord(
+		matchlog.Record{
+			Type:         matchlog.DsStatusRecord,
+			MatchTimeSec: matchTimeSec,
+			Station:      dsConn.AllianceStation,
+			DsStatus: &matchlog.DsStatus{
+				TeamId:            dsConn.TeamId,
+				DsLinked:          dsConn.DsLinked,
+				RadioLinked:       dsConn.RadioLinked,
+				RioLinked:         dsConn.RioLinked,
+				RobotLinked:       dsConn.RobotLinked,
+				Auto:              dsConn.Auto,
+				Enabled:           dsConn.Enabled,
+				EStop:             dsConn.EStop,
+				AStop:             dsConn.AStop,
+				BatteryVoltage:    dsConn.BatteryVoltage,
+				MissedPacketCount: dsConn.MissedPacketCount,
+				TripTimeMs:        dsConn.DsRobotTripTimeMs,
+				RxRate:            wifiStatus.RxRate,
+				TxRate:            wifiStatus.TxRate,
+				SignalNoiseRatio:  wifiStatus.SignalNoiseRatio,
+			},
+		},
+	)
+}
+
+// Writes the given record to the current match log; the caller must hold the match log mutex.
+func (arena *Arena) writeMatchLogRecord(record matchlog.Record) {
+	if err := arena.matchLog.writer.Write(record); err != nil {
+		log.Printf("Failed to write to match log: %v", err)
+	}
+}
+
+// Deletes the match logs in the given directory that fall outside the retention policy, in a separate goroutine.
+func (arena *Arena) pruneMatchLogs(logsDir string, retainDays int) {
+	arena.matchLogPrunes.Add(1)
+	go func() {
+		defer arena.matchLogPrunes.Done()
+		numDeleted, err := matchlog.Prune(logsDir, retainDays)
+		if err != nil {
+			log.Printf("Failed to prune match logs: %v", err)
+		} else if numDeleted > 0 {
+			log.Printf("Deleted %d match logs older than %d days.", numDeleted, retainDays)
+		}
+	}()
+}
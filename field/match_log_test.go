@@ -0,0 +1,113 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/matchlog"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/plc"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestArenaMatchLog(t *testing.T) {
+	arena := setupTestArena(t)
+	simulatedPlc := plc.NewSimulatedPlc()
+	arena.Plc = simulatedPlc
+	arena.Database.CreateTeam(&model.Team{Id: 254})
+	assert.Nil(t, arena.assignTeam(254, "R1"))
+	dsConn := &DriverStationConnection{TeamId: 254, AllianceStation: "R1"}
+	arena.AllianceStations["R1"].DsConn = dsConn
+	for _, station := range []string{"R2", "R3", "B1", "B2", "B3"} {
+		arena.AllianceStations[station].Bypass = true
+	}
+
+	// Check that nothing is logged outside of a match.
+	arena.Update()
+	arena.logDsStatus(0, dsConn)
+	filenames, err := matchlog.List(LogsDir)
+	assert.Nil(t, err)
+	assert.Empty(t, filenames)
+
+	dsConn.RobotLinked = true
+	dsConn.BatteryVoltage = 12.4
+	assert.Nil(t, arena.StartMatch())
+	arena.Update()
+	arena.logDsStatus(0.5, dsConn)
+	assert.Nil(t, simulatedPlc.SetRegister(1, 3))
+	arena.Update()
+	arena.accessPoint.Status = "ERROR"
+	arena.Update()
+	arena.MatchStartTime = time.Now().Add(-time.Duration(game.MatchTiming.TeleopDurationSec*10) * time.Second)
+	for i := 0; i < 5 && arena.MatchState != PostMatch; i++ {
+		arena.Update()
+	}
+	assert.Equal(t, PostMatch, arena.MatchState)
+	assert.Nil(t, arena.matchLog.writer)
+
+	// Check that records written after the match are ignored.
+	arena.logDsStatus(200, dsConn)
+
+	filenames, err = matchlog.List(LogsDir)
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(filenames)) {
+		bundle, err := matchlog.Read(filenames[0])
+		assert.Nil(t, err)
+		assert.Equal(t, "Test", bundle.Header.MatchType)
+		assert.Equal(t, map[string]int{"R1": 254}, bundle.Header.TeamIds)
+
+		stateRecords := bundle.Query(matchlog.Query{Types: []matchlog.RecordType{matchlog.ArenaStateRecord}})
+		if assert.Equal(t, 5, len(stateRecords)) {
+			assert.Equal(t, int(WarmupPeriod), stateRecords[0].MatchState)
+			assert.Equal(t, int(PostMatch), stateRecords[4].MatchState)
+		}
+
+		plcRecords := bundle.Query(matchlog.Query{Types: []matchlog.RecordType{matchlog.PlcIoRecord}})
+		if assert.GreaterOrEqual(t, len(plcRecords), 2) {
+			assert.Equal(t, uint16(0), plcRecords[0].PlcIo.Registers[1])
+			assert.Equal(t, uint16(3), plcRecords[len(plcRecords)-1].PlcIo.Registers[1])
+		}
+
+		accessPointRecords := bundle.Query(matchlog.Query{Types: []matchlog.RecordType{matchlog.AccessPointRecord}})
+		if assert.Equal(t, 2, len(accessPointRecords)) {
+			assert.Equal(t, "UNKNOWN", accessPointRecords[0].AccessPoint)
+			assert.Equal(t, "ERROR", accessPointRecords[1].AccessPoint)
+		}
+
+		dsRecords := bundle.Query(matchlog.Query{Types: []matchlog.RecordType{matchlog.DsStatusRecord}})
+		if assert.Equal(t, 1, len(dsRecords)) {
+			assert.Equal(t, "R1", dsRecords[0].Station)
+			assert.Equal(t, 0.5, dsRecords[0].MatchTimeSec)
+			assert.Equal(t, 254, dsRecords[0].DsStatus.TeamId)
+			assert.True(t, dsRecords[0].DsStatus.RobotLinked)
+			assert.Equal(t, 12.4, dsRecords[0].DsStatus.BatteryVoltage)
+		}
+	}
+}
+
+func TestArenaPruneMatchLogs(t *testing.T) {
+	arena := setupTestArena(t)
+	dir := t.TempDir()
+	createBundle := func(startedAt time.Time) string {
+		writer, err := matchlog.Create(
+			dir, matchlog.Header{MatchId: 1, MatchType: "Test", MatchShortName: "T1", StartedAt: startedAt},
+		)
+		assert.Nil(t, err)
+		assert.Nil(t, writer.Close())
+		return writer.Filename
+	}
+	createBundle(time.Now().AddDate(0, 0, -10))
+	recent := createBundle(time.Now().Add(-time.Hour))
+
+	// Check that the prune uses the given directory and retention rather than the current global state.
+	arena.pruneMatchLogs(dir, 5)
+	LogsDir = t.TempDir()
+	arena.EventSettings.MatchLogRetainDays = 0
+	arena.matchLogPrunes.Wait()
+	filenames, err := matchlog.List(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{recent}, filenames)
+}
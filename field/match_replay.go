@@ -0,0 +1,210 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Recording of periodic realtime score snapshots during a match and playback of them to the displays afterwards.
+
+package field
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"log"
+	"time"
+)
+
+// Represents an in-progress replay of a previously played match's score progression.
+type MatchReplay struct {
+	Match     *model.Match
+	Speed     float64
+	StartTime time.Time
+	Finished  bool
+	snapshots []model.ScoreSnapshot
+	index     int
+	lastTime  float64
+}
+
+// Buffers a snapshot of the realtime score once per second while the match is in play, and once more at the end, at
+// which point the buffered snapshots are written to the database in the background to keep the arena loop responsive.
+func (arena *Arena) recordScoreSnapshot(matchTimeSec float64) {
+	if arena.CurrentMatch.Type == model.Test {
+		return
+	}
+	switch arena.MatchState {
+	case WarmupPeriod, AutoPeriod, PausePeriod, TeleopPeriod:
+		if int(matchTimeSec) == int(arena.LastMatchTimeSec) && arena.MatchState == arena.lastMatchState {
+			return
+		}
+	case PostMatch:
+		if arena.lastMatchState == PostMatch {
+			return
+		}
+		if !arena.matchAborted {
+			arena.appendScoreSnapshot(matchTimeSec)
+		}
+		arena.saveScoreSnapshots()
+		return
+	default:
+		return
+	}
+	arena.appendScoreSnapshot(matchTimeSec)
+}
+
+// Adds a snapshot of the current realtime scores to the buffer for the match in progress.
+func (arena *Arena) appendScoreSnapshot(matchTimeSec float64) {
+	redScore := copyRealtimeScore(arena.RedRealtimeScore)
+	blueScore := copyRealtimeScore(arena.BlueRealtimeScore)
+	arena.scoreSnapshots = append(
+		arena.scoreSnapshots,
+		model.ScoreSnapshot{
+			MatchId:      arena.CurrentMatch.Id,
+			MatchTimeSec: matchTimeSec,
+			MatchState:   int(arena.MatchState),
+			RedScore:     redScore.CurrentScore,
+			BlueScore:    blueScore.CurrentScore,
+			RedCards:     redScore.Cards,
+			BlueCards:    blueScore.Cards,
+		},
+	)
+}
+
+// Replaces any snapshots stored from a previous play of the match with the buffered ones, in a separate goroutine.
+func (arena *Arena) saveScoreSnapshots() {
+	matchId := arena.CurrentMatch.Id
+	scoreSnapshots := arena.scoreSnapshots
+	arena.scoreSnapshots = nil
+
+	arena.scoreSnapshotWrites.Add(1)
+	go func() {
+		defer arena.scoreSnapshotWrites.Done()
+		if err := arena.Database.DeleteScoreSnapshotsForMatch(matchId); err != nil {
+			log.Printf("Failed to delete previous score snapshots: %v", err)
+			return
+		}
+		for i := range scoreSnapshots {
+			if err := arena.Database.CreateScoreSnapshot(&scoreSnapshots[i]); err != nil {
+				log.Printf("Failed to record score snapshot: %v", err)
+				return
+			}
+		}
+	}()
+}
+
+// MatchReplay returns a copy of the replay in progress, or nil if there is none.
+func (arena *Arena) MatchReplay() *MatchReplay {
+	arena.matchReplayMutex.Lock()
+	defer arena.matchReplayMutex.Unlock()
+	if arena.matchReplay == nil {
+		return nil
+	}
+	replay := *arena.matchReplay
+	return &replay
+}
+
+// StartMatchReplay begins playing back the recorded score progression of the given match to the displays at the given
+// speed multiple. The live match state is left untouched, but no match may be started until the replay is stopped.
+func (arena *Arena) StartMatchReplay(matchId int, speed float64) error {
+	if arena.MatchState != PreMatch {
+		return fmt.Errorf("cannot start replay while there is a match still in progress or with results pending")
+	}
+	if speed <= 0 {
+		return fmt.Errorf("replay speed must be positive; got %v", speed)
+	}
+	match, err := arena.Database.GetMatchById(matchId)
+	if err != nil {
+		return err
+	}
+	if match == nil {
+		return fmt.Errorf("invalid match ID %d", matchId)
+	}
+	snapshots, err := arena.Database.GetScoreSnapshotsForMatch(matchId)
+	if err != nil {
+		return err
+	}
+	if len(snapshots) == 0 {
+		return fmt.Errorf("no score snapshots have been recorded for match %s", match.ShortName)
+	}
+
+	arena.matchReplayMutex.Lock()
+	arena.matchReplay = &MatchReplay{
+		Match: match, Speed: speed, StartTime: time.Now(), snapshots: snapshots, index: -1, lastTime: -1,
+	}
+	arena.matchReplayMutex.Unlock()
+	arena.MatchLoadNotifier.Notify()
+	arena.AudienceDisplayMode = "match"
+	arena.AudienceDisplayModeNotifier.Notify()
+	arena.updateMatchReplay()
+	return nil
+}
+
+// StopMatchReplay ends any replay in progress and restores the displays to the live match state.
+func (arena *Arena) StopMatchReplay() {
+	arena.matchReplayMutex.Lock()
+	if arena.matchReplay == nil {
+		arena.matchReplayMutex.Unlock()
+		return
+	}
+	arena.matchReplay = nil
+	arena.matchReplayMutex.Unlock()
+	arena.MatchLoadNotifier.Notify()
+	arena.AudienceDisplayMode = "blank"
+	arena.AudienceDisplayModeNotifier.Notify()
+	arena.MatchTimeNotifier.Notify()
+	arena.RealtimeScoreNotifier.Notify()
+}
+
+// Advances the replay in progress, if any, and notifies listeners when the replayed time or score changes.
+func (arena *Arena) updateMatchReplay() {
+	arena.matchReplayMutex.Lock()
+	replay := arena.matchReplay
+	if replay == nil || replay.Finished {
+		arena.matchReplayMutex.Unlock()
+		return
+	}
+
+	matchTimeSec := replay.matchTimeSec()
+	index := replay.index
+	for index+1 < len(replay.snapshots) && replay.snapshots[index+1].MatchTimeSec <= matchTimeSec {
+		index++
+	}
+	if index < 0 {
+		// Always show the first snapshot even if it was taken slightly after the start of the match.
+		index = 0
+	}
+
+	previousIndex := replay.index
+	previousTimeSec := replay.lastTime
+	replay.index = index
+	replay.lastTime = matchTimeSec
+	if index == len(replay.snapshots)-1 && matchTimeSec >= replay.snapshots[index].MatchTimeSec {
+		replay.Finished = true
+	}
+	// Release the lock before notifying, since generating the messages requires it.
+	arena.matchReplayMutex.Unlock()
+
+	// Send a match tick notification if passing an integer second threshold or if the snapshot (and therefore possibly
+	// the match state) changed.
+	if index != previousIndex {
+		arena.MatchTimeNotifier.Notify()
+		arena.RealtimeScoreNotifier.Notify()
+	} else if int(matchTimeSec) != int(previousTimeSec) {
+		arena.MatchTimeNotifier.Notify()
+	}
+}
+
+// Returns the replayed match time, capped at the time of the last snapshot.
+func (replay *MatchReplay) matchTimeSec() float64 {
+	matchTimeSec := time.Since(replay.StartTime).Seconds() * replay.Speed
+	return min(matchTimeSec, replay.snapshots[len(replay.snapshots)-1].MatchTimeSec)
+}
+
+// Returns the snapshot that should currently be shown.
+func (replay *MatchReplay) currentSnapshot() *model.ScoreSnapshot {
+	return &replay.snapshots[max(replay.index, 0)]
+}
+
+// Returns the realtime scores represented by the current snapshot.
+func (replay *MatchReplay) realtimeScores() (*RealtimeScore, *RealtimeScore) {
+	snapshot := replay.currentSnapshot()
+	return &RealtimeScore{CurrentScore: snapshot.RedScore, Cards: snapshot.RedCards},
+		&RealtimeScore{CurrentScore: snapshot.BlueScore, Cards: snapshot.BlueCards}
+}
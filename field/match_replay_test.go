@@ -0,0 +1,171 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package field
+
+import (
+	"encoding/json"
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestArenaRecordsScoreSnapshots(t *testing.T) {
+	arena := setupTestArena(t)
+	match := model.Match{Type: model.Qualification, ShortName: "Q1"}
+	assert.Nil(t, arena.Database.CreateMatch(&match))
+	assert.Nil(t, arena.LoadMatch(&match))
+	for _, allianceStation := range arena.AllianceStations {
+		allianceStation.Bypass = true
+	}
+
+	assert.Nil(t, arena.StartMatch())
+	arena.Update()
+	arena.Update()
+	if assert.Equal(t, 1, len(arena.scoreSnapshots)) {
+		assert.Equal(t, int(WarmupPeriod), arena.scoreSnapshots[0].MatchState)
+	}
+
+	arena.RedRealtimeScore.CurrentScore.BargeAlgae = 2
+	arena.MatchStartTime = time.Now().Add(-time.Duration(game.MatchTiming.WarmupDurationSec) * time.Second)
+	arena.Update()
+	if assert.Equal(t, 2, len(arena.scoreSnapshots)) {
+		assert.Equal(t, int(AutoPeriod), arena.scoreSnapshots[1].MatchState)
+		assert.Equal(t, 2, arena.scoreSnapshots[1].RedScore.BargeAlgae)
+	}
+
+	// Check that nothing is written to the database until the end of the match.
+	snapshots, _ := arena.Database.GetScoreSnapshotsForMatch(match.Id)
+	assert.Empty(t, snapshots)
+
+	arena.BlueRealtimeScore.CurrentScore.ProcessorAlgae = 3
+	arena.MatchStartTime = time.Now().Add(-time.Duration(game.MatchTiming.WarmupDurationSec+
+		game.MatchTiming.AutoDurationSec+game.MatchTiming.PauseDurationSec+game.MatchTiming.TeleopDurationSec) *
+		time.Second)
+	for arena.MatchState != PostMatch {
+		arena.Update()
+	}
+	arena.scoreSnapshotWrites.Wait()
+	assert.Empty(t, arena.scoreSnapshots)
+	snapshots, _ = arena.Database.GetScoreSnapshotsForMatch(match.Id)
+	numSnapshots := len(snapshots)
+	if assert.Greater(t, numSnapshots, 2) {
+		assert.Equal(t, int(PostMatch), snapshots[numSnapshots-1].MatchState)
+		assert.Equal(t, 3, snapshots[numSnapshots-1].BlueScore.ProcessorAlgae)
+	}
+	arena.Update()
+	snapshots, _ = arena.Database.GetScoreSnapshotsForMatch(match.Id)
+	assert.Equal(t, numSnapshots, len(snapshots))
+
+	// Replaying the match should replace the snapshots from the previous play.
+	assert.Nil(t, arena.ResetMatch())
+	for _, allianceStation := range arena.AllianceStations {
+		allianceStation.Bypass = true
+	}
+	assert.Nil(t, arena.StartMatch())
+	arena.Update()
+	arena.AbortMatch()
+	arena.Update()
+	arena.scoreSnapshotWrites.Wait()
+	snapshots, _ = arena.Database.GetScoreSnapshotsForMatch(match.Id)
+	if assert.Equal(t, 1, len(snapshots)) {
+		assert.Equal(t, int(WarmupPeriod), snapshots[0].MatchState)
+	}
+}
+
+func TestArenaMatchReplay(t *testing.T) {
+	arena := setupTestArena(t)
+	assert.Nil(t, arena.Database.CreateTeam(&model.Team{Id: 254}))
+	assert.Nil(t, arena.Database.CreateTeam(&model.Team{Id: 1114}))
+	match := model.Match{Type: model.Qualification, ShortName: "Q1", Red1: 254, Blue3: 1114}
+	assert.Nil(t, arena.Database.CreateMatch(&match))
+
+	err := arena.StartMatchReplay(match.Id, 1)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "no score snapshots have been recorded")
+	}
+	err = arena.StartMatchReplay(match.Id+1, 1)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "invalid match ID")
+	}
+
+	snapshots := []model.ScoreSnapshot{
+		{MatchId: match.Id, MatchTimeSec: 0, MatchState: int(WarmupPeriod)},
+		{MatchId: match.Id, MatchTimeSec: 10, MatchState: int(AutoPeriod), RedScore: game.Score{BargeAlgae: 1}},
+		{MatchId: match.Id, MatchTimeSec: 20, MatchState: int(TeleopPeriod), RedScore: game.Score{BargeAlgae: 4}},
+		{
+			MatchId:      match.Id,
+			MatchTimeSec: 20.5,
+			MatchState:   int(PostMatch),
+			RedScore:     game.Score{BargeAlgae: 5},
+			BlueCards:    map[string]string{"254": "yellow"},
+		},
+	}
+	for i := range snapshots {
+		assert.Nil(t, arena.Database.CreateScoreSnapshot(&snapshots[i]))
+	}
+
+	err = arena.StartMatchReplay(match.Id, 0)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "replay speed must be positive")
+	}
+	assert.Nil(t, arena.StartMatchReplay(match.Id, 2))
+	assert.Equal(t, "match", arena.AudienceDisplayMode)
+	assert.Equal(t, MatchTimeMessage{WarmupPeriod, 0}, arena.generateMatchTimeMessage())
+	matchLoad := getMatchLoadMessage(t, arena)
+	assert.Equal(t, "Q1", matchLoad.Match.ShortName)
+	if assert.NotNil(t, matchLoad.Teams["R1"]) && assert.NotNil(t, matchLoad.Teams["B3"]) {
+		assert.Equal(t, 254, matchLoad.Teams["R1"].Id)
+		assert.Equal(t, 1114, matchLoad.Teams["B3"].Id)
+	}
+	assert.Nil(t, matchLoad.Teams["R2"])
+	err = arena.StartMatch()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "cannot start match while a replay is in progress")
+	}
+
+	// Advance the replay to the middle of autonomous at double speed.
+	arena.matchReplay.StartTime = time.Now().Add(-6 * time.Second)
+	arena.Update()
+	assert.Equal(t, MatchTimeMessage{AutoPeriod, 12}, arena.generateMatchTimeMessage())
+	red, _ := arena.matchReplay.realtimeScores()
+	assert.Equal(t, 1, red.CurrentScore.BargeAlgae)
+	assert.False(t, arena.matchReplay.Finished)
+
+	arena.matchReplay.StartTime = time.Now().Add(-30 * time.Second)
+	arena.Update()
+	assert.True(t, arena.matchReplay.Finished)
+	assert.Equal(t, MatchTimeMessage{PostMatch, 20}, arena.generateMatchTimeMessage())
+	red, blue := arena.matchReplay.realtimeScores()
+	assert.Equal(t, 5, red.CurrentScore.BargeAlgae)
+	assert.Equal(t, "yellow", blue.Cards["254"])
+	assert.Equal(t, PreMatch, arena.MatchState)
+
+	arena.StopMatchReplay()
+	assert.Nil(t, arena.MatchReplay())
+	assert.Equal(t, "blank", arena.AudienceDisplayMode)
+	matchLoad = getMatchLoadMessage(t, arena)
+	assert.Equal(t, model.Test, matchLoad.Match.Type)
+	assert.Nil(t, matchLoad.Teams["R1"])
+	for _, allianceStation := range arena.AllianceStations {
+		allianceStation.Bypass = true
+	}
+	assert.Nil(t, arena.StartMatch())
+}
+
+// Subset of the match load message fields that the replay tests check.
+type testMatchLoadMessage struct {
+	Match *model.Match
+	Teams map[string]*model.Team
+}
+
+// Round-trips the match load message through JSON to get at the fields of its anonymous struct.
+func getMatchLoadMessage(t *testing.T, arena *Arena) testMatchLoadMessage {
+	var matchLoad testMatchLoadMessage
+	data, err := json.Marshal(arena.GenerateMatchLoadMessage())
+	assert.Nil(t, err)
+	assert.Nil(t, json.Unmarshal(data, &matchLoad))
+	return matchLoad
+}
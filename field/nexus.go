@@ -0,0 +1,97 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Exchange of queueing status, match results, and team-reported information with Nexus for FRC.
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
+	"log"
+	"time"
+)
+
+const nexusPollPeriodSec = 10
+
+// Queueing statuses to set in Nexus for the loaded match and the ones following it, in order.
+var nexusQueueStatuses = []partner.NexusMatchStatus{partner.NexusOnField, partner.NexusOnDeck, partner.NexusNowQueuing}
+
+// Loops indefinitely, pulling the statuses and parts requests that teams have reported through Nexus.
+func (arena *Arena) runNexusPoller() {
+	for {
+		// Leave polling to the primary while this instance is a hot standby.
+		if arena.EventSettings.NexusEnabled && arena.NexusClient.HasEventApiKey() && arena.Replication == nil {
+			arena.PollNexus()
+		}
+		time.Sleep(time.Second * nexusPollPeriodSec)
+	}
+}
+
+// Refreshes the team statuses and parts requests from Nexus, keeping the previous ones if they can't be retrieved.
+func (arena *Arena) PollNexus() {
+	teamStatuses, err := arena.NexusClient.GetTeamStatuses()
+	if err != nil {
+		log.Printf("Failed to get team statuses from Nexus: %s", err.Error())
+	} else {
+		arena.NexusTeamStatuses = teamStatuses
+	}
+
+	partsRequests, err := arena.NexusClient.GetPartsRequests()
+	if err != nil {
+		log.Printf("Failed to get parts requests from Nexus: %s", err.Error())
+	} else {
+		arena.NexusPartsRequests = partsRequests
+	}
+}
+
+// Returns the status most recently reported through Nexus by the given team, or nil if there is none.
+func (arena *Arena) GetNexusTeamStatus(teamId int) *partner.NexusTeamStatus {
+	for _, teamStatus := range arena.NexusTeamStatuses {
+		if teamStatus.Team == teamId {
+			return &teamStatus
+		}
+	}
+	return nil
+}
+
+// Marks the given match as on the field in Nexus and the incomplete matches following it as on deck and queuing.
+func (arena *Arena) publishNexusQueueStatuses(match *model.Match) {
+	matches, err := arena.Database.GetMatchesByType(match.Type, false)
+	if err != nil {
+		log.Printf("Failed to publish queueing status to Nexus: %s", err.Error())
+		return
+	}
+
+	queueMatches := []model.Match{*match}
+	foundMatch := false
+	for _, otherMatch := range matches {
+		if len(queueMatches) == len(nexusQueueStatuses) {
+			break
+		}
+		if otherMatch.Id == match.Id {
+			foundMatch = true
+		} else if foundMatch && !otherMatch.IsComplete() {
+			queueMatches = append(queueMatches, otherMatch)
+		}
+	}
+
+	for i, queueMatch := range queueMatches {
+		if err = arena.NexusClient.SetMatchStatus(queueMatch.TbaMatchKey, nexusQueueStatuses[i]); err != nil {
+			log.Printf(
+				"Failed to set status of match %s to %q in Nexus: %s",
+				queueMatch.TbaMatchKey.String(),
+				nexusQueueStatuses[i],
+				err.Error(),
+			)
+		}
+	}
+}
+
+// Publishes the final score of the given match to Nexus.
+func (arena *Arena) PublishNexusMatchResult(match *model.Match, redScore, blueScore int) {
+	result := partner.NexusMatchResult{RedScore: redScore, BlueScore: blueScore}
+	if err := arena.NexusClient.PublishMatchResult(match.TbaMatchKey, result); err != nil {
+		log.Printf("Failed to publish result of match %s to Nexus: %s", match.TbaMatchKey.String(), err.Error())
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNexusQueueStatusesAndResults(t *testing.T) {
+	arena := setupTestArena(t)
+	arena.EventSettings.NexusEnabled = true
+	arena.EventSettings.NexusLocalServerEnabled = true
+	assert.Nil(t, arena.Database.UpdateEventSettings(arena.EventSettings))
+	assert.Nil(t, arena.LoadSettings())
+
+	var matches []model.Match
+	for i := 1; i <= 5; i++ {
+		match := model.Match{
+			Type:        model.Qualification,
+			TypeOrder:   i,
+			TbaMatchKey: model.TbaMatchKey{CompLevel: "qm", MatchNumber: i},
+		}
+		if i == 2 {
+			match.Status = game.RedWonMatch
+		}
+		assert.Nil(t, arena.Database.CreateMatch(&match))
+		matches = append(matches, match)
+	}
+
+	arena.publishNexusQueueStatuses(&matches[0])
+	assert.Equal(
+		t,
+		map[string]partner.NexusMatchStatus{
+			"qm1": partner.NexusOnField, "qm3": partner.NexusOnDeck, "qm4": partner.NexusNowQueuing,
+		},
+		arena.NexusLocalServer.State().MatchStatuses,
+	)
+
+	arena.publishNexusQueueStatuses(&matches[4])
+	assert.Equal(t, partner.NexusOnField, arena.NexusLocalServer.State().MatchStatuses["qm5"])
+
+	arena.PublishNexusMatchResult(&matches[0], 120, 95)
+	assert.Equal(
+		t, partner.NexusMatchResult{RedScore: 120, BlueScore: 95}, arena.NexusLocalServer.State().MatchResults["qm1"],
+	)
+}
+
+func TestPollNexus(t *testing.T) {
+	arena := setupTestArena(t)
+	arena.EventSettings.NexusEnabled = true
+	arena.EventSettings.NexusLocalServerEnabled = true
+	assert.Nil(t, arena.Database.UpdateEventSettings(arena.EventSettings))
+	assert.Nil(t, arena.LoadSettings())
+
+	arena.NexusLocalServer.SetTeamStatus(254, "Repairing", "Swapping a battery")
+	arena.NexusLocalServer.AddPartsRequest(1114, "Bearing")
+	arena.PollNexus()
+	if assert.Equal(t, 1, len(arena.NexusTeamStatuses)) {
+		assert.Equal(t, "Repairing", arena.NexusTeamStatuses[0].Status)
+	}
+	if assert.NotNil(t, arena.GetNexusTeamStatus(254)) {
+		assert.Equal(t, "Swapping a battery", arena.GetNexusTeamStatus(254).Notes)
+	}
+	assert.Nil(t, arena.GetNexusTeamStatus(1114))
+	if assert.Equal(t, 1, len(arena.NexusPartsRequests)) {
+		assert.Equal(t, "Bearing", arena.NexusPartsRequests[0].Parts)
+	}
+
+	// Check that the previous data is retained if Nexus can't be reached.
+	arena.NexusClient.BaseUrl = "http://127.0.0.1:0"
+	arena.PollNexus()
+	assert.Equal(t, 1, len(arena.NexusTeamStatuses))
+	assert.Equal(t, 1, len(arena.NexusPartsRequests))
+	assert.NotEmpty(t, arena.NexusClient.Status().LastError)
+}
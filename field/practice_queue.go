@@ -0,0 +1,154 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Walk-up queue for practice matches, in which teams check in when they are ready to play and each match is built from
+// the teams that have been waiting the longest instead of following a pre-generated schedule.
+
+package field
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"time"
+)
+
+// Number of checked-in teams needed to build a practice match from the walk-up queue.
+const practiceQueueTeamsPerMatch = 6
+
+// Adds the given team to the end of the practice match walk-up queue.
+func (arena *Arena) CheckInPracticeTeam(teamId int) error {
+	if !arena.EventSettings.PracticeWalkUpQueueEnabled {
+		return fmt.Errorf("The practice match walk-up queue is not enabled.")
+	}
+
+	arena.practiceQueueMutex.Lock()
+	defer arena.practiceQueueMutex.Unlock()
+	team, err := arena.Database.GetTeamById(teamId)
+	if err != nil {
+		return err
+	}
+	if team == nil {
+		return fmt.Errorf("Team %d is not in the team list.", teamId)
+	}
+	entry, err := arena.Database.GetPracticeQueueEntryByTeam(teamId)
+	if err != nil {
+		return err
+	}
+	if entry != nil {
+		return fmt.Errorf("Team %d is already checked in.", teamId)
+	}
+	if err = arena.Database.CreatePracticeQueueEntry(
+		&model.PracticeQueueEntry{TeamId: teamId, CheckedInAt: time.Now()},
+	); err != nil {
+		return err
+	}
+	arena.PracticeQueueNotifier.Notify()
+	return nil
+}
+
+// Removes the given team from the practice match walk-up queue.
+func (arena *Arena) CheckOutPracticeTeam(teamId int) error {
+	arena.practiceQueueMutex.Lock()
+	defer arena.practiceQueueMutex.Unlock()
+	entry, err := arena.Database.GetPracticeQueueEntryByTeam(teamId)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		return fmt.Errorf("Team %d is not checked in.", teamId)
+	}
+	if err = arena.Database.DeletePracticeQueueEntry(entry.Id); err != nil {
+		return err
+	}
+	arena.PracticeQueueNotifier.Notify()
+	return nil
+}
+
+// Creates a new practice match from the six longest-waiting teams in the walk-up queue and removes them from it.
+// Returns nil if there aren't yet enough teams checked in.
+func (arena *Arena) buildPracticeMatchFromQueue() (*model.Match, error) {
+	arena.practiceQueueMutex.Lock()
+	defer arena.practiceQueueMutex.Unlock()
+	entries, err := arena.Database.GetAllPracticeQueueEntries()
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) < practiceQueueTeamsPerMatch {
+		return nil, nil
+	}
+	entries = entries[:practiceQueueTeamsPerMatch]
+
+	matches, err := arena.Database.GetMatchesByType(model.Practice, true)
+	if err != nil {
+		return nil, err
+	}
+	typeOrder := 1
+	if len(matches) > 0 {
+		typeOrder = matches[len(matches)-1].TypeOrder + 1
+	}
+
+	// Alternate the teams between the alliances so that teams that checked in together end up on opposite sides.
+	match := model.Match{
+		Type:        model.Practice,
+		TypeOrder:   typeOrder,
+		Time:        time.Now(),
+		LongName:    fmt.Sprintf("Practice %d", typeOrder),
+		ShortName:   fmt.Sprintf("P%d", typeOrder),
+		Red1:        entries[0].TeamId,
+		Blue1:       entries[1].TeamId,
+		Red2:        entries[2].TeamId,
+		Blue2:       entries[3].TeamId,
+		Red3:        entries[4].TeamId,
+		Blue3:       entries[5].TeamId,
+		TbaMatchKey: model.TbaMatchKey{CompLevel: "p", MatchNumber: typeOrder},
+	}
+	if err = arena.Database.CreateMatch(&match); err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if err = arena.Database.DeletePracticeQueueEntry(entry.Id); err != nil {
+			return nil, err
+		}
+	}
+	arena.PracticeQueueNotifier.Notify()
+	return &match, nil
+}
+
+// Returns true if practice matches are being run from the walk-up queue, including while the test match is loaded
+// because the queue ran short of teams.
+func (arena *Arena) isRunningPracticeQueue() bool {
+	if !arena.EventSettings.PracticeWalkUpQueueEnabled {
+		return false
+	}
+	return arena.CurrentMatch.Type == model.Practice ||
+		arena.CurrentMatch.Type == model.Test && arena.practiceQueueActive
+}
+
+// Returns the next match to play when running practice matches from the walk-up queue. A practice match that was loaded
+// but never completed (e.g. because its results were discarded) is played again before a new one is built.
+func (arena *Arena) getNextPracticeQueueMatch() (*model.Match, error) {
+	if arena.CurrentMatch.Type == model.Practice && arena.CurrentMatch.Id != 0 && !arena.CurrentMatch.IsComplete() {
+		return arena.Database.GetMatchById(arena.CurrentMatch.Id)
+	}
+	return arena.buildPracticeMatchFromQueue()
+}
+
+// Builds a practice match from the walk-up queue and loads it, returning an error if there aren't enough teams checked
+// in to fill it.
+func (arena *Arena) LoadNextPracticeQueueMatch() error {
+	if !arena.EventSettings.PracticeWalkUpQueueEnabled {
+		return fmt.Errorf("The practice match walk-up queue is not enabled.")
+	}
+	if arena.MatchState != PreMatch && arena.MatchState != TimeoutActive {
+		// Check this before building the match so that the teams don't lose their place in the queue.
+		return fmt.Errorf("cannot load match while there is a match still in progress or with results pending")
+	}
+	match, err := arena.buildPracticeMatchFromQueue()
+	if err != nil {
+		return err
+	}
+	if match == nil {
+		return fmt.Errorf("At least %d teams must be checked in to build a practice match.", practiceQueueTeamsPerMatch)
+	}
+	return arena.LoadMatch(match)
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPracticeQueueCheckIn(t *testing.T) {
+	arena := setupTestArena(t)
+	arena.Database.CreateTeam(&model.Team{Id: 254})
+
+	err := arena.CheckInPracticeTeam(254)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "The practice match walk-up queue is not enabled.", err.Error())
+	}
+
+	arena.EventSettings.PracticeWalkUpQueueEnabled = true
+	assert.Nil(t, arena.CheckInPracticeTeam(254))
+	err = arena.CheckInPracticeTeam(254)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "Team 254 is already checked in.", err.Error())
+	}
+	err = arena.CheckInPracticeTeam(1114)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "Team 1114 is not in the team list.", err.Error())
+	}
+	entries, _ := arena.Database.GetAllPracticeQueueEntries()
+	if assert.Equal(t, 1, len(entries)) {
+		assert.Equal(t, 254, entries[0].TeamId)
+	}
+
+	assert.Nil(t, arena.CheckOutPracticeTeam(254))
+	err = arena.CheckOutPracticeTeam(254)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "Team 254 is not checked in.", err.Error())
+	}
+	entries, _ = arena.Database.GetAllPracticeQueueEntries()
+	assert.Empty(t, entries)
+}
+
+func TestPracticeQueueLoadMatch(t *testing.T) {
+	arena := setupTestArena(t)
+	arena.EventSettings.PracticeWalkUpQueueEnabled = true
+	teamIds := []int{101, 102, 103, 104, 105, 106, 107}
+	for _, teamId := range teamIds {
+		arena.Database.CreateTeam(&model.Team{Id: teamId})
+	}
+	for _, teamId := range teamIds[:5] {
+		assert.Nil(t, arena.CheckInPracticeTeam(teamId))
+	}
+
+	err := arena.LoadNextPracticeQueueMatch()
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "At least 6 teams must be checked in to build a practice match.", err.Error())
+	}
+	assert.Equal(t, model.Test, arena.CurrentMatch.Type)
+
+	// Check that the longest-waiting teams are alternated between the alliances.
+	assert.Nil(t, arena.CheckInPracticeTeam(106))
+	assert.Nil(t, arena.CheckInPracticeTeam(107))
+	assert.Nil(t, arena.LoadNextPracticeQueueMatch())
+	assert.Equal(t, model.Practice, arena.CurrentMatch.Type)
+	assert.Equal(t, 1, arena.CurrentMatch.TypeOrder)
+	assert.Equal(t, "P1", arena.CurrentMatch.ShortName)
+	assert.Equal(t, 101, arena.CurrentMatch.Red1)
+	assert.Equal(t, 102, arena.CurrentMatch.Blue1)
+	assert.Equal(t, 103, arena.CurrentMatch.Red2)
+	assert.Equal(t, 104, arena.CurrentMatch.Blue2)
+	assert.Equal(t, 105, arena.CurrentMatch.Red3)
+	assert.Equal(t, 106, arena.CurrentMatch.Blue3)
+	entries, _ := arena.Database.GetAllPracticeQueueEntries()
+	if assert.Equal(t, 1, len(entries)) {
+		assert.Equal(t, 107, entries[0].TeamId)
+	}
+
+	// Check that a match that wasn't completed is reloaded instead of building a new one.
+	for _, teamId := range teamIds[:5] {
+		assert.Nil(t, arena.CheckInPracticeTeam(teamId))
+	}
+	assert.Nil(t, arena.LoadNextMatch(false))
+	assert.Equal(t, 1, arena.CurrentMatch.TypeOrder)
+	entries, _ = arena.Database.GetAllPracticeQueueEntries()
+	assert.Equal(t, 6, len(entries))
+
+	// Check that the next match is built from the queue once the current one is complete.
+	match, _ := arena.Database.GetMatchById(arena.CurrentMatch.Id)
+	match.Status = game.RedWonMatch
+	arena.Database.UpdateMatch(match)
+	arena.CurrentMatch.Status = game.RedWonMatch
+	assert.Nil(t, arena.LoadNextMatch(false))
+	assert.Equal(t, 2, arena.CurrentMatch.TypeOrder)
+	assert.Equal(t, 107, arena.CurrentMatch.Red1)
+	assert.Equal(t, 101, arena.CurrentMatch.Blue1)
+	entries, _ = arena.Database.GetAllPracticeQueueEntries()
+	assert.Empty(t, entries)
+
+	// Check that the test match is loaded when there aren't enough teams in the queue.
+	arena.CurrentMatch.Status = game.TieMatch
+	assert.Nil(t, arena.LoadNextMatch(false))
+	assert.Equal(t, model.Test, arena.CurrentMatch.Type)
+
+	// Check that the queue is picked up again from the test match once enough teams have checked in.
+	for _, teamId := range teamIds[:6] {
+		assert.Nil(t, arena.CheckInPracticeTeam(teamId))
+	}
+	assert.Nil(t, arena.LoadNextMatch(false))
+	assert.Equal(t, model.Practice, arena.CurrentMatch.Type)
+	assert.Equal(t, 3, arena.CurrentMatch.TypeOrder)
+
+	// Check that the queue is no longer used once a match of another type has been loaded.
+	assert.Nil(t, arena.LoadMatch(&model.Match{Type: model.Qualification}))
+	assert.Nil(t, arena.LoadTestMatch())
+	for _, teamId := range teamIds[:6] {
+		assert.Nil(t, arena.CheckInPracticeTeam(teamId))
+	}
+	assert.Nil(t, arena.LoadNextMatch(false))
+	assert.Equal(t, model.Test, arena.CurrentMatch.Type)
+}
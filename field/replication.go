@@ -0,0 +1,291 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Client for running this instance as a hot standby that replicates the database of a primary instance.
+
+package field
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/gorilla/websocket"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const replicationRetryDelaySec = 5
+
+// Tracks the state of the connection to the primary instance while this instance is acting as a secondary.
+type ReplicationClient struct {
+	PrimaryAddress   string
+	connected        bool
+	sequence         int
+	lastSyncTime     time.Time
+	lastError        string
+	username         string
+	password         string
+	arena            *Arena
+	conn             *websocket.Conn
+	stopped          bool
+	mutex            sync.Mutex
+	replicationMutex sync.Mutex
+}
+
+// Puts this instance into secondary mode, continuously replicating the database of the primary instance at the given
+// address (e.g. "10.0.100.6:8080") until promoted. The given credentials are used to log in to the primary if it has
+// authentication enabled, and must belong to an admin. The local database is read-only until promotion.
+func (arena *Arena) StartReplication(primaryAddress, username, password string) error {
+	if arena.Replication != nil {
+		return fmt.Errorf("already replicating from %s", arena.Replication.PrimaryAddress)
+	}
+	if arena.MatchState != PreMatch {
+		return fmt.Errorf("cannot start replicating while a match is in progress")
+	}
+	primaryAddress = strings.TrimSuffix(strings.TrimPrefix(primaryAddress, "http://"), "/")
+	if primaryAddress == "" {
+		return fmt.Errorf("primary address cannot be blank")
+	}
+
+	if password != "" && username == "" {
+		return fmt.Errorf("username cannot be blank when a password is given")
+	}
+
+	arena.Database.SetReadOnly(true)
+	arena.Replication = &ReplicationClient{
+		PrimaryAddress: primaryAddress, username: username, password: password, arena: arena,
+	}
+	go arena.Replication.run()
+	return nil
+}
+
+// Stops replicating from the primary instance and makes this instance the primary, using the most recently replicated
+// copy of the database.
+func (arena *Arena) PromoteToPrimary() error {
+	client := arena.Replication
+	if client == nil {
+		return fmt.Errorf("this instance is not replicating from a primary")
+	}
+	client.stop()
+	arena.Replication = nil
+	arena.Database.SetReadOnly(false)
+	log.Printf(
+		"Promoted to primary after replicating through sequence %d from %s.", client.Sequence(), client.PrimaryAddress,
+	)
+
+	if err := arena.LoadSettings(); err != nil {
+		return err
+	}
+	return arena.LoadTestMatch()
+}
+
+// Loops indefinitely, reconnecting to the primary whenever the connection drops, until stopped.
+func (client *ReplicationClient) run() {
+	for {
+		err := client.replicate()
+		if client.isStopped() {
+			return
+		}
+		client.mutex.Lock()
+		client.connected = false
+		if err != nil {
+			client.lastError = err.Error()
+		}
+		client.mutex.Unlock()
+		if err != nil {
+			log.Printf("Replication from %s failed: %v", client.PrimaryAddress, err)
+		}
+		time.Sleep(time.Second * replicationRetryDelaySec)
+		if client.isStopped() {
+			return
+		}
+	}
+}
+
+// Connects to the primary, loads its database snapshot, and then applies mutations as they arrive until the
+// connection is lost.
+func (client *ReplicationClient) replicate() error {
+	header := http.Header{}
+	if client.password != "" {
+		cookies, err := client.login()
+		if err != nil {
+			return err
+		}
+		for _, cookie := range cookies {
+			header.Add("Cookie", cookie.String())
+		}
+	}
+
+	wsUrl := fmt.Sprintf("ws://%s/setup/replication/websocket", client.PrimaryAddress)
+	conn, _, err := websocket.DefaultDialer.Dial(wsUrl, header)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client.mutex.Lock()
+	if client.stopped {
+		client.mutex.Unlock()
+		return nil
+	}
+	client.conn = conn
+	client.mutex.Unlock()
+
+	for {
+		var message struct {
+			Type string
+			Data json.RawMessage
+		}
+		if err = conn.ReadJSON(&message); err != nil {
+			return err
+		}
+		if err = client.handleMessage(message.Type, message.Data); err != nil {
+			return err
+		}
+	}
+}
+
+// Applies a single message received from the primary.
+func (client *ReplicationClient) handleMessage(messageType string, data json.RawMessage) error {
+	// Prevent a promotion from taking effect partway through applying a message.
+	client.replicationMutex.Lock()
+	defer client.replicationMutex.Unlock()
+	if client.stopped {
+		return nil
+	}
+
+	switch messageType {
+	case "snapshot":
+		var snapshot model.DatabaseSnapshot
+		if err := json.Unmarshal(data, &snapshot); err != nil {
+			return err
+		}
+		if err := client.arena.Database.LoadSnapshot(snapshot.Data); err != nil {
+			return err
+		}
+		client.arena.requestSettingsReload()
+		client.mutex.Lock()
+		client.sequence = snapshot.Sequence
+		client.connected = true
+		client.lastError = ""
+		client.lastSyncTime = time.Now()
+		client.mutex.Unlock()
+		log.Printf("Loaded database snapshot at sequence %d from %s.", snapshot.Sequence, client.PrimaryAddress)
+	case "mutation":
+		var mutation model.TableMutation
+		if err := json.Unmarshal(data, &mutation); err != nil {
+			return err
+		}
+		if sequence := client.Sequence(); mutation.Sequence != sequence+1 {
+			return fmt.Errorf("expected mutation %d but got %d", sequence+1, mutation.Sequence)
+		}
+		if err := client.arena.Database.ApplyMutation(mutation); err != nil {
+			return err
+		}
+		if mutation.Table == "EventSettings" {
+			client.arena.requestSettingsReload()
+		}
+		client.mutex.Lock()
+		client.sequence = mutation.Sequence
+		client.lastSyncTime = time.Now()
+		client.mutex.Unlock()
+	default:
+		return fmt.Errorf("invalid replication message type %q", messageType)
+	}
+	return nil
+}
+
+// Logs in to the primary as the configured user and returns the resulting session cookies.
+func (client *ReplicationClient) login() ([]*http.Cookie, error) {
+	httpClient := http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+		Timeout: time.Second * replicationRetryDelaySec,
+	}
+	resp, err := httpClient.PostForm(
+		fmt.Sprintf("http://%s/login", client.PrimaryAddress),
+		url.Values{"username": {client.username}, "password": {client.password}},
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// A successful login redirects and sets the session cookie, whereas a failed one re-renders the login page.
+	if resp.StatusCode != http.StatusSeeOther || len(resp.Cookies()) == 0 {
+		return nil, fmt.Errorf("failed to log in to primary as %q; check the username and password", client.username)
+	}
+	return resp.Cookies(), nil
+}
+
+// Stops the replication loop and disconnects from the primary, waiting for any in-progress message to be applied.
+func (client *ReplicationClient) stop() {
+	client.replicationMutex.Lock()
+	defer client.replicationMutex.Unlock()
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.stopped = true
+	client.connected = false
+	if client.conn != nil {
+		client.conn.Close()
+	}
+}
+
+func (client *ReplicationClient) isStopped() bool {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	return client.stopped
+}
+
+// Connected returns whether the client currently has an up-to-date copy of the primary's database.
+func (client *ReplicationClient) Connected() bool {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	return client.connected
+}
+
+// Sequence returns the sequence number of the last mutation applied from the primary.
+func (client *ReplicationClient) Sequence() int {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	return client.sequence
+}
+
+// LastSyncTime returns the time at which the last snapshot or mutation was applied from the primary.
+func (client *ReplicationClient) LastSyncTime() time.Time {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	return client.lastSyncTime
+}
+
+// LastError returns the error that most recently broke the connection to the primary, if any.
+func (client *ReplicationClient) LastError() string {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	return client.lastError
+}
+
+// Asks the arena loop to reload the event settings from the database once a replicated change has been applied to
+// them, so that they aren't swapped out from under it.
+func (arena *Arena) requestSettingsReload() {
+	select {
+	case arena.settingsReload <- struct{}{}:
+	default:
+		// A reload is already pending and will pick up this change too.
+	}
+}
+
+// Reloads the event settings if a replicated change to them is pending.
+func (arena *Arena) reloadReplicatedSettings() {
+	select {
+	case <-arena.settingsReload:
+		if err := arena.LoadSettings(); err != nil {
+			log.Printf("Failed to reload replicated event settings: %v", err)
+		}
+	default:
+	}
+}
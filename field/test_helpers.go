@@ -18,11 +18,14 @@ func SetupTestArena(t *testing.T) *Arena {
 	rand.Seed(0)
 	model.BaseDir = ".."
 	dbDir := t.TempDir()
+	model.DataDir = dbDir
+	LogsDir = filepath.Join(dbDir, "logs")
 	dbPath := filepath.Join(dbDir, "test.db")
-	arena, err := NewArena(dbPath)
+	arena, err := NewArena(dbPath, false)
 	assert.Nil(t, err)
 	t.Cleanup(
 		func() {
+			arena.matchLogPrunes.Wait()
 			arena.Database.Close()
 		},
 	)
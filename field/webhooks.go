@@ -0,0 +1,211 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Forwarding of arena notifier messages to the outbound webhooks that external systems have subscribed to them.
+
+package field
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/Team254/cheesy-arena/websocket"
+	"log"
+	"reflect"
+	"time"
+)
+
+const webhookPollPeriodSec = 1
+
+// The body of each webhook request, wrapping the message sent by the arena notifier of the same name.
+type WebhookPayload struct {
+	Event     string
+	Timestamp time.Time
+	Data      json.RawMessage
+}
+
+// The public fields of a team, sent to webhooks in place of the full model so that secrets such as the team's WPA key
+// and internal notes don't leave the arena.
+type webhookTeam struct {
+	Id              int
+	Name            string
+	Nickname        string
+	City            string
+	StateProv       string
+	Country         string
+	SchoolName      string
+	RookieYear      int
+	RobotName       string
+	Accomplishments string
+	YellowCard      bool
+}
+
+// Returns all the arena's notifiers, each of whose messages can be subscribed to via webhooks.
+func (arena *Arena) webhookNotifiers() []*websocket.Notifier {
+	var notifiers []*websocket.Notifier
+	notifiersValue := reflect.ValueOf(arena.ArenaNotifiers)
+	for i := 0; i < notifiersValue.NumField(); i++ {
+		if notifier, ok := notifiersValue.Field(i).Interface().(*websocket.Notifier); ok {
+			notifiers = append(notifiers, notifier)
+		}
+	}
+	return notifiers
+}
+
+// Returns the names of the events that webhooks can subscribe to, which are the message types of the arena's notifiers.
+func (arena *Arena) WebhookEvents() []string {
+	var events []string
+	for _, notifier := range arena.webhookNotifiers() {
+		events = append(events, notifier.MessageType())
+	}
+	return events
+}
+
+// Loops indefinitely, queueing a webhook delivery for each significant message sent by the arena's notifiers and
+// delivering queued ones, retrying failed deliveries once their backoff elapses.
+func (arena *Arena) runWebhooks() {
+	// The arena runs for the lifetime of the server, so the subscription is never ended.
+	lastSignificantFields := make(map[string][]byte)
+	go websocket.Subscribe(
+		nil,
+		func(messageType string, messageBody any) {
+			arena.handleWebhookNotification(lastSignificantFields, messageType, messageBody)
+		},
+		arena.webhookNotifiers()...,
+	)
+
+	for {
+		select {
+		case <-arena.WebhookDispatcher.WakeChan():
+		case <-time.After(time.Second * webhookPollPeriodSec):
+		}
+
+		// Leave delivery to the primary while this instance is a hot standby.
+		if arena.Replication == nil {
+			arena.WebhookDispatcher.DeliverDue(arena.Database)
+		}
+	}
+}
+
+// Queues the given notifier message for delivery to the webhooks subscribed to it, unless nothing significant has
+// changed since the previous message of the same type.
+func (arena *Arena) handleWebhookNotification(
+	lastSignificantFields map[string][]byte, messageType string, messageBody any,
+) {
+	if arena.Replication != nil {
+		// The primary queues its own deliveries, which are replicated to this instance.
+		return
+	}
+	if arena.MatchReplay() != nil && (messageType == "matchTime" || messageType == "realtimeScore") {
+		// Replays are only for the audience, and shouldn't be reported as if the match were being played live.
+		return
+	}
+
+	data, err := json.Marshal(messageBody)
+	if err != nil {
+		log.Printf("Failed to serialize '%s' message for webhooks: %v", messageType, err)
+		return
+	}
+	if dataFields := webhookDataFields(messageType); dataFields != nil {
+		if err = json.Unmarshal(data, dataFields); err != nil {
+			log.Printf("Failed to parse '%s' message for webhooks: %v", messageType, err)
+			return
+		}
+		if data, err = json.Marshal(dataFields); err != nil {
+			log.Printf("Failed to serialize '%s' message for webhooks: %v", messageType, err)
+			return
+		}
+	}
+	if significantFields := webhookSignificantFields(messageType); significantFields != nil {
+		if err = json.Unmarshal(data, significantFields); err != nil {
+			log.Printf("Failed to parse '%s' message for webhooks: %v", messageType, err)
+			return
+		}
+		significantData, _ := json.Marshal(significantFields)
+		if lastData, ok := lastSignificantFields[messageType]; ok && bytes.Equal(lastData, significantData) {
+			return
+		}
+		lastSignificantFields[messageType] = significantData
+	}
+
+	payload, err := json.Marshal(WebhookPayload{Event: messageType, Timestamp: time.Now(), Data: data})
+	if err != nil {
+		log.Printf("Failed to serialize '%s' webhook payload: %v", messageType, err)
+		return
+	}
+	if err = arena.WebhookDispatcher.Enqueue(arena.Database, messageType, payload); err != nil {
+		log.Printf("Failed to queue '%s' webhook deliveries: %v", messageType, err)
+	}
+}
+
+// Returns a struct to parse the given type of notifier message into that holds only the fields that may be sent to
+// webhooks, or nil if the whole message may be sent. Messages that carry teams are sent with only their public fields.
+func webhookDataFields(messageType string) any {
+	switch messageType {
+	case "arenaStatus":
+		return &struct {
+			MatchId          int
+			AllianceStations map[string]*struct {
+				DsConn     json.RawMessage
+				Ethernet   bool
+				AStop      bool
+				EStop      bool
+				Bypass     bool
+				Team       *webhookTeam
+				WifiStatus json.RawMessage
+			}
+			MatchState            int
+			CanStartMatch         bool
+			InspectionWarning     string
+			AccessPointStatus     string
+			SwitchStatus          string
+			RedSCCStatus          string
+			BlueSCCStatus         string
+			PlcIsHealthy          bool
+			FieldEStop            bool
+			PlcArmorBlockStatuses json.RawMessage
+		}{}
+	case "matchLoad":
+		return &struct {
+			Match             json.RawMessage
+			AllowSubstitution bool
+			IsReplay          bool
+			Teams             map[string]*webhookTeam
+			Rankings          json.RawMessage
+			Matchup           json.RawMessage
+			RedOffFieldTeams  []*webhookTeam
+			BlueOffFieldTeams []*webhookTeam
+			BreakDescription  string
+		}{}
+	default:
+		return nil
+	}
+}
+
+// Returns a struct to parse the fields of the given type of notifier message into that determine whether it is worth
+// delivering to webhooks, or nil if every message of the type should be delivered. This keeps notifiers that are sent
+// periodically (e.g. the match time every second) from flooding webhooks with messages in which nothing significant
+// has changed.
+func webhookSignificantFields(messageType string) any {
+	switch messageType {
+	case "allianceSelection":
+		return &struct {
+			Alliances json.RawMessage
+			ShowTimer bool
+		}{}
+	case "arenaStatus":
+		return &struct {
+			AllianceStations map[string]struct {
+				EStop  bool
+				AStop  bool
+				Bypass bool
+			}
+			FieldEStop bool
+		}{}
+	case "matchTime":
+		return &struct {
+			MatchState int
+		}{}
+	default:
+		return nil
+	}
+}
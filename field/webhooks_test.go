@@ -0,0 +1,83 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package field
+
+import (
+	"encoding/json"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestWebhookEvents(t *testing.T) {
+	arena := setupTestArena(t)
+
+	events := arena.WebhookEvents()
+	assert.Contains(t, events, "matchLoad")
+	assert.Contains(t, events, "rankings")
+	assert.Contains(t, events, "scorePosted")
+	assert.Equal(t, len(arena.webhookNotifiers()), len(events))
+}
+
+func TestHandleWebhookNotification(t *testing.T) {
+	arena := setupTestArena(t)
+	arena.Database.CreateWebhook(&model.Webhook{Url: "http://localhost", Enabled: true})
+	lastSignificantFields := make(map[string][]byte)
+	getDeliveries := func() []model.WebhookDelivery {
+		deliveries, _ := arena.Database.GetPendingWebhookDeliveries()
+		return deliveries
+	}
+
+	// Check that the message is wrapped in the payload.
+	arena.handleWebhookNotification(lastSignificantFields, "rankings", "rankings")
+	if deliveries := getDeliveries(); assert.Equal(t, 1, len(deliveries)) {
+		var payload WebhookPayload
+		assert.Nil(t, json.Unmarshal([]byte(deliveries[0].Payload), &payload))
+		assert.Equal(t, "rankings", payload.Event)
+		assert.Equal(t, `"rankings"`, string(payload.Data))
+		assert.False(t, payload.Timestamp.IsZero())
+	}
+
+	// Check that the match time is only delivered when the match state changes.
+	arena.handleWebhookNotification(lastSignificantFields, "matchTime", MatchTimeMessage{PreMatch, 0})
+	arena.handleWebhookNotification(lastSignificantFields, "matchTime", MatchTimeMessage{PreMatch, 1})
+	arena.handleWebhookNotification(lastSignificantFields, "matchTime", MatchTimeMessage{AutoPeriod, 2})
+	arena.handleWebhookNotification(lastSignificantFields, "matchTime", MatchTimeMessage{AutoPeriod, 3})
+	assert.Equal(t, 3, len(getDeliveries()))
+
+	// Check that the arena status is only delivered when a stop state changes.
+	arena.handleWebhookNotification(lastSignificantFields, "arenaStatus", arena.generateArenaStatusMessage())
+	arena.handleWebhookNotification(lastSignificantFields, "arenaStatus", arena.generateArenaStatusMessage())
+	assert.Equal(t, 4, len(getDeliveries()))
+	arena.AllianceStations["B2"].EStop = true
+	arena.handleWebhookNotification(lastSignificantFields, "arenaStatus", arena.generateArenaStatusMessage())
+	assert.Equal(t, 5, len(getDeliveries()))
+
+	// Check that the teams in the arena status and match load messages are stripped of their secrets.
+	team := &model.Team{Id: 254, Nickname: "The Cheesy Poofs", WpaKey: "12345678", FtaNotes: "Loose battery"}
+	arena.Database.CreateTeam(team)
+	assert.Nil(t, arena.assignTeam(254, "R1"))
+	arena.handleWebhookNotification(lastSignificantFields, "matchLoad", arena.GenerateMatchLoadMessage())
+	arena.AllianceStations["B2"].EStop = false
+	arena.handleWebhookNotification(lastSignificantFields, "arenaStatus", arena.generateArenaStatusMessage())
+	deliveries := getDeliveries()
+	if assert.Equal(t, 7, len(deliveries)) {
+		for _, delivery := range deliveries[5:] {
+			assert.Contains(t, delivery.Payload, "The Cheesy Poofs")
+			assert.NotContains(t, delivery.Payload, "WpaKey")
+			assert.NotContains(t, delivery.Payload, "12345678")
+			assert.NotContains(t, delivery.Payload, "Loose battery")
+		}
+	}
+
+	// Check that other messages are always delivered.
+	arena.handleWebhookNotification(lastSignificantFields, "reload", nil)
+	arena.handleWebhookNotification(lastSignificantFields, "reload", nil)
+	assert.Equal(t, 9, len(getDeliveries()))
+
+	// Check that nothing is queued while replicating from a primary.
+	arena.Replication = &ReplicationClient{}
+	arena.handleWebhookNotification(lastSignificantFields, "reload", nil)
+	assert.Equal(t, 9, len(getDeliveries()))
+}
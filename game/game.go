@@ -0,0 +1,101 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Pluggable definition of the season-specific scoring, ranking and rules logic.
+
+package game
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Game encapsulates everything that changes from one season to the next, so that an event can select which game (or
+// custom variant of one) is in use without modifying the rest of the code.
+type Game interface {
+	// Name returns the unique name under which the game is registered and stored in the event settings.
+	Name() string
+
+	// Summarize calculates the summary fields used for ranking and display for the given alliance's score.
+	Summarize(score, opponentScore *Score) *ScoreSummary
+
+	// AccumulateRankingFields adds the game-specific ranking and tiebreaker points for a single non-disqualified
+	// match to the given ranking fields.
+	AccumulateRankingFields(fields *RankingFields, ownScore, opponentScore *ScoreSummary)
+
+	// RankingLess returns true if the first team should be ranked ahead of the second.
+	RankingLess(a, b *RankingFields) bool
+
+	// BreakPlayoffTie determines the winner of a playoff match whose scores are equal, returning TieMatch if the
+	// tiebreakers are also equal.
+	BreakPlayoffTie(redScoreSummary, blueScoreSummary *ScoreSummary) MatchStatus
+
+	// Rules returns all rules from the game that carry point penalties.
+	Rules() []*Rule
+
+	// FoulPointValue returns the number of points that the given foul adds to the opposing alliance's score.
+	FoulPointValue(foul *Foul) int
+
+	// TbaScoreBreakdown returns the alliance's score breakdown in the format expected by The Blue Alliance.
+	TbaScoreBreakdown(score *Score, scoreSummary, opponentScoreSummary *ScoreSummary, includeRp bool) map[string]any
+
+	// ApplyScoringCommand applies a command received from a scoring panel to the given score, returning true if the
+	// score changed. Commands that the game doesn't recognize are ignored.
+	ApplyScoringCommand(score *Score, command string, data any) (bool, error)
+
+	// CoopertitionEnabled returns true if the game currently awards a bonus for both alliances cooperating.
+	CoopertitionEnabled() bool
+}
+
+// Guards the registered games and the current game, which are read from the arena loop and HTTP handlers alike.
+var gameMutex sync.RWMutex
+var games = make(map[string]Game)
+var currentGame Game
+
+func init() {
+	RegisterGame(Reefscape{})
+	currentGame = Reefscape{}
+}
+
+// RegisterGame makes the given game available for selection by name, replacing any existing game of the same name.
+func RegisterGame(game Game) {
+	gameMutex.Lock()
+	defer gameMutex.Unlock()
+	games[game.Name()] = game
+}
+
+// GetGameNames returns the names of all registered games in alphabetical order.
+func GetGameNames() []string {
+	gameMutex.RLock()
+	defer gameMutex.RUnlock()
+	names := make([]string, 0, len(games))
+	for name := range games {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CurrentGame returns the game that is currently in use.
+func CurrentGame() Game {
+	gameMutex.RLock()
+	defer gameMutex.RUnlock()
+	return currentGame
+}
+
+// SetCurrentGame switches to the registered game having the given name. An empty name selects the default game.
+func SetCurrentGame(name string) error {
+	if name == "" {
+		name = DefaultGameName
+	}
+	gameMutex.Lock()
+	defer gameMutex.Unlock()
+	game, ok := games[name]
+	if !ok {
+		return fmt.Errorf("no game registered with name %q", name)
+	}
+	currentGame = game
+	ruleMap = nil
+	return nil
+}
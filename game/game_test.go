@@ -0,0 +1,107 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package game
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"testing"
+)
+
+// Variant of the 2025 game that awards no ranking points for ties and is ranked purely on match points.
+type noTieRpVariant struct {
+	Reefscape
+}
+
+func (noTieRpVariant) Name() string {
+	return "No Tie RP"
+}
+
+func (variant noTieRpVariant) AccumulateRankingFields(fields *RankingFields, ownScore, opponentScore *ScoreSummary) {
+	variant.Reefscape.AccumulateRankingFields(fields, ownScore, opponentScore)
+	if ownScore.Score == opponentScore.Score {
+		fields.RankingPoints -= 1
+	}
+}
+
+func (noTieRpVariant) RankingLess(a, b *RankingFields) bool {
+	return a.MatchPoints > b.MatchPoints
+}
+
+func TestSetCurrentGame(t *testing.T) {
+	assert.Equal(t, DefaultGameName, CurrentGame().Name())
+	assert.Contains(t, GetGameNames(), DefaultGameName)
+
+	err := SetCurrentGame("Nonexistent")
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "no game registered with name \"Nonexistent\"", err.Error())
+	}
+	assert.Equal(t, DefaultGameName, CurrentGame().Name())
+
+	RegisterGame(noTieRpVariant{})
+	defer delete(games, "No Tie RP")
+	assert.Equal(t, []string{DefaultGameName, "No Tie RP"}, GetGameNames())
+	assert.Nil(t, SetCurrentGame("No Tie RP"))
+	assert.Equal(t, "No Tie RP", CurrentGame().Name())
+
+	// Check that the variant's overrides are used for ranking.
+	summary := &ScoreSummary{Score: 50, MatchPoints: 50, BonusRankingPoints: 1}
+	var fields RankingFields
+	fields.AddScoreSummary(summary, summary, false)
+	assert.Equal(t, 1, fields.RankingPoints)
+	assert.Equal(t, 1, fields.Ties)
+	rankings := Rankings{
+		{TeamId: 1, RankingFields: RankingFields{RankingPoints: 10, MatchPoints: 40, Played: 1}},
+		{TeamId: 2, RankingFields: RankingFields{RankingPoints: 1, MatchPoints: 90, Played: 1}},
+	}
+	sort.Sort(rankings)
+	assert.Equal(t, 2, rankings[0].TeamId)
+
+	// Check that the embedded game's logic is used for everything not overridden.
+	assert.Equal(t, TestScore1().Summarize(TestScore2()), Reefscape{}.Summarize(TestScore1(), TestScore2()))
+
+	// Check that an empty name reverts to the default game.
+	assert.Nil(t, SetCurrentGame(""))
+	assert.Equal(t, DefaultGameName, CurrentGame().Name())
+}
+
+// Variant of the 2025 game that adds a scoring element of its own.
+type extraElementVariant struct {
+	Reefscape
+}
+
+func (variant extraElementVariant) ApplyScoringCommand(score *Score, command string, data any) (bool, error) {
+	if command == "extra" {
+		if score.Counters == nil {
+			score.Counters = make(map[string]int)
+		}
+		score.Counters["extra"]++
+		return true, nil
+	}
+	return variant.Reefscape.ApplyScoringCommand(score, command, data)
+}
+
+func TestGameScoringCommands(t *testing.T) {
+	score := new(Score)
+	changed, err := Reefscape{}.ApplyScoringCommand(score, "leave", map[string]any{"TeamPosition": 2})
+	assert.Nil(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, [3]bool{false, true, false}, score.LeaveStatuses)
+	changed, err = Reefscape{}.ApplyScoringCommand(score, "leave", map[string]any{"TeamPosition": 4})
+	assert.Nil(t, err)
+	assert.False(t, changed)
+	_, err = Reefscape{}.ApplyScoringCommand(score, "barge", map[string]any{"Adjustment": "one"})
+	assert.NotNil(t, err)
+	changed, err = Reefscape{}.ApplyScoringCommand(score, "extra", nil)
+	assert.Nil(t, err)
+	assert.False(t, changed)
+
+	// Check that a variant can keep its own elements in the score's counters.
+	before := *score
+	changed, err = extraElementVariant{}.ApplyScoringCommand(score, "extra", nil)
+	assert.Nil(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, map[string]int{"extra": 1}, score.Counters)
+	assert.False(t, score.Equals(&before))
+}
@@ -42,25 +42,7 @@ func (fields *RankingFields) AddScoreSummary(ownScore *ScoreSummary, opponentSco
 		return
 	}
 
-	// Assign ranking points and wins/losses/ties.
-	if ownScore.Score > opponentScore.Score {
-		fields.RankingPoints += 3
-		fields.Wins += 1
-	} else if ownScore.Score == opponentScore.Score {
-		fields.RankingPoints += 1
-		fields.Ties += 1
-	} else {
-		fields.Losses += 1
-	}
-	fields.RankingPoints += ownScore.BonusRankingPoints
-
-	// Assign tiebreaker points.
-	if ownScore.CoopertitionBonus {
-		fields.CoopertitionPoints++
-	}
-	fields.MatchPoints += ownScore.MatchPoints
-	fields.AutoPoints += ownScore.AutoPoints
-	fields.BargePoints += ownScore.BargePoints
+	CurrentGame().AccumulateRankingFields(fields, ownScore, opponentScore)
 }
 
 // Helper function to implement the required interface for Sort.
@@ -70,26 +52,7 @@ func (rankings Rankings) Len() int {
 
 // Helper function to implement the required interface for Sort.
 func (rankings Rankings) Less(i, j int) bool {
-	a := rankings[i]
-	b := rankings[j]
-
-	// Use cross-multiplication to keep it in integer math.
-	if a.RankingPoints*b.Played == b.RankingPoints*a.Played {
-		if a.CoopertitionPoints*b.Played == b.CoopertitionPoints*a.Played {
-			if a.MatchPoints*b.Played == b.MatchPoints*a.Played {
-				if a.AutoPoints*b.Played == b.AutoPoints*a.Played {
-					if a.BargePoints*b.Played == b.BargePoints*a.Played {
-						return a.Random > b.Random
-					}
-					return a.BargePoints*b.Played > b.BargePoints*a.Played
-				}
-				return a.AutoPoints*b.Played > b.AutoPoints*a.Played
-			}
-			return a.MatchPoints*b.Played > b.MatchPoints*a.Played
-		}
-		return a.CoopertitionPoints*b.Played > b.CoopertitionPoints*a.Played
-	}
-	return a.RankingPoints*b.Played > b.RankingPoints*a.Played
+	return CurrentGame().RankingLess(&rankings[i].RankingFields, &rankings[j].RankingFields)
 }
 
 // Helper function to implement the required interface for Sort.
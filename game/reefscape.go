@@ -0,0 +1,230 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Game definition for the 2025 game, REEFSCAPE.
+
+package game
+
+// Name under which the default game is registered.
+const DefaultGameName = "2025 Reefscape"
+
+// Game-specific settings that can be changed via the settings.
+var AutoBonusCoralThreshold = 1
+var CoralBonusPerLevelThreshold = 7
+var CoralBonusCoopEnabled = true
+var BargeBonusPointThreshold = 16
+var IncludeAlgaeInBargeBonus = false
+
+// Reefscape implements the scoring, ranking and rules logic for the 2025 game. Custom variants can embed it and
+// override only the methods that differ.
+type Reefscape struct{}
+
+// Name returns the name under which the game is registered.
+func (Reefscape) Name() string {
+	return DefaultGameName
+}
+
+// CoopertitionEnabled returns true if the coral bonus can be earned with fewer levels when both alliances score enough
+// algae in their processors.
+func (Reefscape) CoopertitionEnabled() bool {
+	return CoralBonusCoopEnabled
+}
+
+// Summarize calculates and returns the summary fields used for ranking and display.
+func (Reefscape) Summarize(score, opponentScore *Score) *ScoreSummary {
+	summary := new(ScoreSummary)
+
+	// Leave the score at zero if the alliance was disqualified.
+	if score.PlayoffDq {
+		return summary
+	}
+
+	// Calculate autonomous period points.
+	for _, status := range score.LeaveStatuses {
+		if status {
+			summary.LeavePoints += 3
+		}
+	}
+	autoCoralPoints := score.Reef.AutoCoralPoints()
+	summary.AutoPoints = summary.LeavePoints + autoCoralPoints
+
+	summary.NumCoral = score.Reef.AutoCoralCount() + score.Reef.TeleopCoralCount()
+	summary.CoralPoints = autoCoralPoints + score.Reef.TeleopCoralPoints()
+	summary.NumAlgae = score.BargeAlgae + score.ProcessorAlgae
+	summary.AlgaePoints = 4*score.BargeAlgae + 6*score.ProcessorAlgae
+
+	// Calculate endgame points.
+	for _, status := range score.EndgameStatuses {
+		switch status {
+		case EndgameParked:
+			summary.BargePoints += 2
+		case EndgameShallowCage:
+			summary.BargePoints += 6
+		case EndgameDeepCage:
+			summary.BargePoints += 12
+		default:
+		}
+	}
+
+	summary.MatchPoints = summary.LeavePoints + summary.CoralPoints + summary.AlgaePoints + summary.BargePoints
+
+	// Calculate penalty points.
+	for _, foul := range opponentScore.Fouls {
+		summary.FoulPoints += foul.PointValue()
+		// Store the number of major fouls since it is used to break ties in playoffs.
+		if foul.IsMajor {
+			summary.NumOpponentMajorFouls++
+		}
+
+		rule := foul.Rule()
+		if rule != nil {
+			// Check for the opponent fouls that automatically trigger a ranking point.
+			if rule.IsRankingPoint {
+				switch rule.RuleNumber {
+				case "G410":
+					summary.CoralBonusRankingPoint = true
+				case "G418":
+					summary.BargeBonusRankingPoint = true
+				case "G428":
+					summary.BargeBonusRankingPoint = true
+				}
+			}
+		}
+	}
+
+	summary.Score = summary.MatchPoints + summary.FoulPoints
+
+	// Calculate bonus ranking points.
+	// Autonomous bonus ranking point.
+	allRobotsLeft := true
+	for i, left := range score.LeaveStatuses {
+		if !left && !score.RobotsBypassed[i] {
+			allRobotsLeft = false
+			break
+		}
+	}
+	if allRobotsLeft && score.Reef.isAutoBonusCoralThresholdMet() {
+		summary.AutoBonusRankingPoint = true
+	}
+
+	// Coral bonus ranking point.
+	summary.NumCoralLevels = score.Reef.countCoralBonusSatisfiedLevels()
+	summary.NumCoralLevelsGoal = 4
+	if CoralBonusCoopEnabled {
+		summary.CoopertitionCriteriaMet = score.ProcessorAlgae >= 2
+		summary.CoopertitionBonus = summary.CoopertitionCriteriaMet && opponentScore.ProcessorAlgae >= 2
+		if summary.CoopertitionBonus {
+			summary.NumCoralLevelsGoal = 3
+		}
+	}
+	if summary.NumCoralLevels >= summary.NumCoralLevelsGoal {
+		summary.CoralBonusRankingPoint = true
+	}
+
+	// Barge bonus ranking point.
+	bargePointsForBonus := summary.BargePoints
+	if IncludeAlgaeInBargeBonus {
+		bargePointsForBonus += summary.AlgaePoints
+	}
+	if bargePointsForBonus >= BargeBonusPointThreshold {
+		summary.BargeBonusRankingPoint = true
+	}
+
+	// Check for G206 violation.
+	for _, foul := range score.Fouls {
+		if foul.Rule() != nil && foul.Rule().RuleNumber == "G206" {
+			summary.CoralBonusRankingPoint = false
+			summary.BargeBonusRankingPoint = false
+			break
+		}
+	}
+
+	// Add up the bonus ranking points.
+	if summary.AutoBonusRankingPoint {
+		summary.BonusRankingPoints++
+	}
+	if summary.CoralBonusRankingPoint {
+		summary.BonusRankingPoints++
+	}
+	if summary.BargeBonusRankingPoint {
+		summary.BonusRankingPoints++
+	}
+
+	return summary
+}
+
+// AccumulateRankingFields assigns ranking points, wins/losses/ties and tiebreaker points for a single match.
+func (Reefscape) AccumulateRankingFields(fields *RankingFields, ownScore, opponentScore *ScoreSummary) {
+	// Assign ranking points and wins/losses/ties.
+	if ownScore.Score > opponentScore.Score {
+		fields.RankingPoints += 3
+		fields.Wins += 1
+	} else if ownScore.Score == opponentScore.Score {
+		fields.RankingPoints += 1
+		fields.Ties += 1
+	} else {
+		fields.Losses += 1
+	}
+	fields.RankingPoints += ownScore.BonusRankingPoints
+
+	// Assign tiebreaker points.
+	if ownScore.CoopertitionBonus {
+		fields.CoopertitionPoints++
+	}
+	fields.MatchPoints += ownScore.MatchPoints
+	fields.AutoPoints += ownScore.AutoPoints
+	fields.BargePoints += ownScore.BargePoints
+}
+
+// RankingLess orders teams by average ranking points, then by the average of each of the tiebreakers in turn.
+func (Reefscape) RankingLess(a, b *RankingFields) bool {
+	// Use cross-multiplication to keep it in integer math.
+	if a.RankingPoints*b.Played == b.RankingPoints*a.Played {
+		if a.CoopertitionPoints*b.Played == b.CoopertitionPoints*a.Played {
+			if a.MatchPoints*b.Played == b.MatchPoints*a.Played {
+				if a.AutoPoints*b.Played == b.AutoPoints*a.Played {
+					if a.BargePoints*b.Played == b.BargePoints*a.Played {
+						return a.Random > b.Random
+					}
+					return a.BargePoints*b.Played > b.BargePoints*a.Played
+				}
+				return a.AutoPoints*b.Played > b.AutoPoints*a.Played
+			}
+			return a.MatchPoints*b.Played > b.MatchPoints*a.Played
+		}
+		return a.CoopertitionPoints*b.Played > b.CoopertitionPoints*a.Played
+	}
+	return a.RankingPoints*b.Played > b.RankingPoints*a.Played
+}
+
+// BreakPlayoffTie checks the scoring breakdowns to resolve playoff ties.
+func (Reefscape) BreakPlayoffTie(redScoreSummary, blueScoreSummary *ScoreSummary) MatchStatus {
+	if status := comparePoints(
+		redScoreSummary.NumOpponentMajorFouls, blueScoreSummary.NumOpponentMajorFouls,
+	); status != TieMatch {
+		return status
+	}
+	if status := comparePoints(redScoreSummary.AutoPoints, blueScoreSummary.AutoPoints); status != TieMatch {
+		return status
+	}
+	return comparePoints(redScoreSummary.BargePoints, blueScoreSummary.BargePoints)
+}
+
+// Rules returns all rules from the 2025 game that carry point penalties.
+func (Reefscape) Rules() []*Rule {
+	return rules
+}
+
+// FoulPointValue returns the number of points that the foul adds to the opposing alliance's score.
+func (Reefscape) FoulPointValue(foul *Foul) int {
+	if foul.IsMajor {
+		return 6
+	} else {
+		if foul.Rule() != nil && foul.Rule().RuleNumber == "G206" {
+			// Special case in 2025 for G206, which is not actually a foul but does make the alliance ineligible for
+			// some bonus RPs.
+			return 0
+		}
+		return 2
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Handling of the REEFSCAPE-specific commands sent by the scoring panels.
+
+package game
+
+import "github.com/mitchellh/mapstructure"
+
+// ApplyScoringCommand applies a command received from a scoring panel to the given score, returning true if the score
+// changed.
+func (Reefscape) ApplyScoringCommand(score *Score, command string, data any) (bool, error) {
+	switch command {
+	case "reef":
+		args := struct {
+			ReefPosition int
+			ReefLevel    int
+			Current      bool
+			Autonomous   bool
+		}{}
+		if err := mapstructure.Decode(data, &args); err != nil {
+			return false, err
+		}
+
+		if args.ReefPosition >= 1 && args.ReefPosition <= 12 && args.ReefLevel >= 2 && args.ReefLevel <= 4 {
+			level := Level(args.ReefLevel - 2)
+			reefIndex := args.ReefPosition - 1
+			if args.Current {
+				score.Reef.Branches[level][reefIndex] = !score.Reef.Branches[level][reefIndex]
+			}
+			if args.Autonomous {
+				score.Reef.AutoBranches[level][reefIndex] = !score.Reef.AutoBranches[level][reefIndex]
+			}
+			return true, nil
+		}
+	case "endgame":
+		args := struct {
+			TeamPosition  int
+			EndgameStatus int
+		}{}
+		if err := mapstructure.Decode(data, &args); err != nil {
+			return false, err
+		}
+
+		if args.TeamPosition >= 1 && args.TeamPosition <= 3 && args.EndgameStatus >= 0 && args.EndgameStatus <= 3 {
+			score.EndgameStatuses[args.TeamPosition-1] = EndgameStatus(args.EndgameStatus)
+			return true, nil
+		}
+	case "leave":
+		args := struct {
+			TeamPosition int
+		}{}
+		if err := mapstructure.Decode(data, &args); err != nil {
+			return false, err
+		}
+
+		if args.TeamPosition >= 1 && args.TeamPosition <= 3 {
+			score.LeaveStatuses[args.TeamPosition-1] = !score.LeaveStatuses[args.TeamPosition-1]
+			return true, nil
+		}
+	case "barge", "processor", "trough":
+		args := struct {
+			Adjustment int
+			Current    bool
+			Autonomous bool
+			NearSide   bool
+		}{}
+		if err := mapstructure.Decode(data, &args); err != nil {
+			return false, err
+		}
+
+		switch command {
+		case "barge":
+			score.BargeAlgae = max(0, score.BargeAlgae+args.Adjustment)
+			return true, nil
+		case "processor":
+			score.ProcessorAlgae = max(0, score.ProcessorAlgae+args.Adjustment)
+			return true, nil
+		case "trough":
+			if args.Current {
+				if args.NearSide {
+					score.Reef.TroughNear = max(0, score.Reef.TroughNear+args.Adjustment)
+				} else {
+					score.Reef.TroughFar = max(0, score.Reef.TroughFar+args.Adjustment)
+				}
+			}
+			if args.Autonomous {
+				if args.NearSide {
+					score.Reef.AutoTroughNear = max(0, score.Reef.AutoTroughNear+args.Adjustment)
+				} else {
+					score.Reef.AutoTroughFar = max(0, score.Reef.AutoTroughFar+args.Adjustment)
+				}
+			}
+			return args.Current || args.Autonomous, nil
+		}
+	}
+	return false, nil
+}
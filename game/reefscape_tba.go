@@ -0,0 +1,155 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Mapping of the 2025 game score to the breakdown format used by The Blue Alliance.
+
+package game
+
+import "github.com/mitchellh/mapstructure"
+
+type reefscapeTbaScoreBreakdown struct {
+	AutoLineRobot1          string           `mapstructure:"autoLineRobot1"`
+	AutoLineRobot2          string           `mapstructure:"autoLineRobot2"`
+	AutoLineRobot3          string           `mapstructure:"autoLineRobot3"`
+	AutoMobilityPoints      int              `mapstructure:"autoMobilityPoints"`
+	AutoReef                reefscapeTbaReef `mapstructure:"autoReef"`
+	AutoCoralCount          int              `mapstructure:"autoCoralCount"`
+	AutoCoralPoints         int              `mapstructure:"autoCoralPoints"`
+	AutoPoints              int              `mapstructure:"autoPoints"`
+	TeleopReef              reefscapeTbaReef `mapstructure:"teleopReef"`
+	TeleopCoralCount        int              `mapstructure:"teleopCoralCount"`
+	TeleopCoralPoints       int              `mapstructure:"teleopCoralPoints"`
+	NetAlgaeCount           int              `mapstructure:"netAlgaeCount"`
+	WallAlgaeCount          int              `mapstructure:"wallAlgaeCount"`
+	AlgaePoints             int              `mapstructure:"algaePoints"`
+	EndGameRobot1           string           `mapstructure:"endGameRobot1"`
+	EndGameRobot2           string           `mapstructure:"endGameRobot2"`
+	EndGameRobot3           string           `mapstructure:"endGameRobot3"`
+	EndGameBargePoints      int              `mapstructure:"endGameBargePoints"`
+	TeleopPoints            int              `mapstructure:"teleopPoints"`
+	CoopertitionCriteriaMet bool             `mapstructure:"coopertitionCriteriaMet"`
+	AutoBonusAchieved       bool             `mapstructure:"autoBonusAchieved"`
+	CoralBonusAchieved      bool             `mapstructure:"coralBonusAchieved"`
+	BargeBonusAchieved      bool             `mapstructure:"bargeBonusAchieved"`
+	FoulCount               int              `mapstructure:"foulCount"`
+	TechFoulCount           int              `mapstructure:"techFoulCount"`
+	G206Penalty             bool             `mapstructure:"g206Penalty"`
+	G410Penalty             bool             `mapstructure:"g410Penalty"`
+	G418Penalty             bool             `mapstructure:"g418Penalty"`
+	G428Penalty             bool             `mapstructure:"g428Penalty"`
+	FoulPoints              int              `mapstructure:"foulPoints"`
+	TotalPoints             int              `mapstructure:"totalPoints"`
+	RP                      int              `mapstructure:"rp"`
+}
+
+type reefscapeTbaReef struct {
+	BotRow         map[string]bool `mapstructure:"botRow"`
+	MidRow         map[string]bool `mapstructure:"midRow"`
+	TopRow         map[string]bool `mapstructure:"topRow"`
+	TbaBotRowCount int             `mapstructure:"tba_botRowCount"`
+	TbaMidRowCount int             `mapstructure:"tba_midRowCount"`
+	TbaTopRowCount int             `mapstructure:"tba_topRowCount"`
+	Trough         int             `mapstructure:"trough"`
+}
+
+var reefscapeTbaLeaveMapping = map[bool]string{false: "No", true: "Yes"}
+var reefscapeTbaEndgameMapping = map[EndgameStatus]string{
+	EndgameNone:        "None",
+	EndgameParked:      "Parked",
+	EndgameShallowCage: "ShallowCage",
+	EndgameDeepCage:    "DeepCage",
+}
+
+// TbaScoreBreakdown returns the alliance's score breakdown in the format expected by The Blue Alliance.
+func (Reefscape) TbaScoreBreakdown(
+	score *Score, scoreSummary, opponentScoreSummary *ScoreSummary, includeRp bool,
+) map[string]any {
+	var breakdown reefscapeTbaScoreBreakdown
+	breakdown.AutoLineRobot1 = reefscapeTbaLeaveMapping[score.LeaveStatuses[0]]
+	breakdown.AutoLineRobot2 = reefscapeTbaLeaveMapping[score.LeaveStatuses[1]]
+	breakdown.AutoLineRobot3 = reefscapeTbaLeaveMapping[score.LeaveStatuses[2]]
+	breakdown.AutoMobilityPoints = scoreSummary.LeavePoints
+	breakdown.AutoReef.BotRow = make(map[string]bool)
+	breakdown.AutoReef.MidRow = make(map[string]bool)
+	breakdown.AutoReef.TopRow = make(map[string]bool)
+	for i := 0; i < 12; i++ {
+		breakdown.AutoReef.BotRow["node"+string(rune('A'+i))] = score.Reef.AutoBranches[Level2][i]
+		breakdown.AutoReef.MidRow["node"+string(rune('A'+i))] = score.Reef.AutoBranches[Level3][i]
+		breakdown.AutoReef.TopRow["node"+string(rune('A'+i))] = score.Reef.AutoBranches[Level4][i]
+	}
+	breakdown.AutoReef.TbaBotRowCount = score.Reef.CountCoralByLevelAndPeriod(Level2, true)
+	breakdown.AutoReef.TbaMidRowCount = score.Reef.CountCoralByLevelAndPeriod(Level3, true)
+	breakdown.AutoReef.TbaTopRowCount = score.Reef.CountCoralByLevelAndPeriod(Level4, true)
+	breakdown.AutoReef.Trough = score.Reef.CountCoralByLevelAndPeriod(Level1, true)
+	breakdown.AutoCoralCount = score.Reef.AutoCoralCount()
+	breakdown.AutoCoralPoints = score.Reef.AutoCoralPoints()
+	breakdown.AutoPoints = scoreSummary.AutoPoints
+	breakdown.TeleopReef.BotRow = make(map[string]bool)
+	breakdown.TeleopReef.MidRow = make(map[string]bool)
+	breakdown.TeleopReef.TopRow = make(map[string]bool)
+	for i := 0; i < 12; i++ {
+		breakdown.TeleopReef.BotRow["node"+string(rune('A'+i))] = score.Reef.Branches[Level2][i]
+		breakdown.TeleopReef.MidRow["node"+string(rune('A'+i))] = score.Reef.Branches[Level3][i]
+		breakdown.TeleopReef.TopRow["node"+string(rune('A'+i))] = score.Reef.Branches[Level4][i]
+	}
+	breakdown.TeleopReef.TbaBotRowCount = breakdown.AutoReef.TbaBotRowCount +
+		score.Reef.CountCoralByLevelAndPeriod(Level2, false)
+	breakdown.TeleopReef.TbaMidRowCount = breakdown.AutoReef.TbaMidRowCount +
+		score.Reef.CountCoralByLevelAndPeriod(Level3, false)
+	breakdown.TeleopReef.TbaTopRowCount = breakdown.AutoReef.TbaTopRowCount +
+		score.Reef.CountCoralByLevelAndPeriod(Level4, false)
+	breakdown.TeleopReef.Trough = score.Reef.CountCoralByLevelAndPeriod(Level1, false)
+	breakdown.TeleopCoralCount = score.Reef.TeleopCoralCount()
+	teleopCoralPoints := score.Reef.TeleopCoralPoints()
+	breakdown.TeleopCoralPoints = teleopCoralPoints
+	breakdown.NetAlgaeCount = score.BargeAlgae
+	breakdown.WallAlgaeCount = score.ProcessorAlgae
+	breakdown.AlgaePoints = scoreSummary.AlgaePoints
+	breakdown.EndGameRobot1 = reefscapeTbaEndgameMapping[score.EndgameStatuses[0]]
+	breakdown.EndGameRobot2 = reefscapeTbaEndgameMapping[score.EndgameStatuses[1]]
+	breakdown.EndGameRobot3 = reefscapeTbaEndgameMapping[score.EndgameStatuses[2]]
+	breakdown.EndGameBargePoints = scoreSummary.BargePoints
+	breakdown.TeleopPoints = teleopCoralPoints + scoreSummary.AlgaePoints + scoreSummary.BargePoints
+	breakdown.CoopertitionCriteriaMet = scoreSummary.CoopertitionCriteriaMet
+	breakdown.AutoBonusAchieved = scoreSummary.AutoBonusRankingPoint
+	breakdown.CoralBonusAchieved = scoreSummary.CoralBonusRankingPoint
+	breakdown.BargeBonusAchieved = scoreSummary.BargeBonusRankingPoint
+	for _, foul := range score.Fouls {
+		if foul.IsMajor {
+			breakdown.TechFoulCount++
+		} else if foul.PointValue() > 0 {
+			breakdown.FoulCount++
+		}
+		if foul.Rule() != nil && foul.Rule().IsRankingPoint {
+			switch foul.Rule().RuleNumber {
+			case "G206":
+				breakdown.G206Penalty = true
+			case "G410":
+				breakdown.G410Penalty = true
+			case "G418":
+				breakdown.G418Penalty = true
+			case "G428":
+				breakdown.G428Penalty = true
+			}
+		}
+	}
+	breakdown.FoulPoints = scoreSummary.FoulPoints
+	breakdown.TotalPoints = scoreSummary.Score
+
+	if includeRp {
+		// Calculate and set the ranking points for the match.
+		var rankingFields RankingFields
+		rankingFields.AddScoreSummary(scoreSummary, opponentScoreSummary, false)
+		breakdown.RP = rankingFields.RankingPoints
+	}
+
+	// Turn the breakdown struct into a map in order to be able to remove any fields that are disabled based on the
+	// event settings.
+	breakdownMap := make(map[string]any)
+	_ = mapstructure.Decode(breakdown, &breakdownMap)
+	if !CoralBonusCoopEnabled {
+		delete(breakdownMap, "coopertitionCriteriaMet")
+	}
+
+	return breakdownMap
+}
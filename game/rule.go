@@ -13,7 +13,7 @@ type Rule struct {
 	Description    string
 }
 
-// All rules from the 2022 game that carry point penalties.
+// All rules from the 2025 game that carry point penalties.
 // @formatter:off
 var rules = []*Rule{
 	{1, "G206", false, true, "A team or ALLIANCE may not collude with another team to each purposefully violate a rule in an attempt to influence Ranking Points."},
@@ -59,6 +59,7 @@ var rules = []*Rule{
 }
 
 // @formatter:on
+// Lazily built from the current game's rules; guarded by gameMutex.
 var ruleMap map[int]*Rule
 
 // Returns the rule having the given ID, or nil if no such rule exists.
@@ -68,9 +69,12 @@ func GetRuleById(id int) *Rule {
 
 // Returns a slice of all defined rules that carry point penalties.
 func GetAllRules() map[int]*Rule {
+	gameMutex.Lock()
+	defer gameMutex.Unlock()
 	if ruleMap == nil {
-		ruleMap = make(map[int]*Rule, len(rules))
-		for _, rule := range rules {
+		gameRules := currentGame.Rules()
+		ruleMap = make(map[int]*Rule, len(gameRules))
+		for _, rule := range gameRules {
 			ruleMap[rule.Id] = rule
 		}
 	}
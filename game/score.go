@@ -5,6 +5,10 @@
 
 package game
 
+import "maps"
+
+// Score holds the scoring elements of the default game as dedicated fields; other games keep their tallies in
+// Counters instead, keyed by names of their choosing.
 type Score struct {
 	RobotsBypassed  [3]bool
 	LeaveStatuses   [3]bool
@@ -14,15 +18,9 @@ type Score struct {
 	EndgameStatuses [3]EndgameStatus
 	Fouls           []Foul
 	PlayoffDq       bool
+	Counters        map[string]int
 }
 
-// Game-specific settings that can be changed via the settings.
-var AutoBonusCoralThreshold = 1
-var CoralBonusPerLevelThreshold = 7
-var CoralBonusCoopEnabled = true
-var BargeBonusPointThreshold = 16
-var IncludeAlgaeInBargeBonus = false
-
 // Represents the state of a robot at the end of the match.
 type EndgameStatus int
 
@@ -35,125 +33,7 @@ const (
 
 // Summarize calculates and returns the summary fields used for ranking and display.
 func (score *Score) Summarize(opponentScore *Score) *ScoreSummary {
-	summary := new(ScoreSummary)
-
-	// Leave the score at zero if the alliance was disqualified.
-	if score.PlayoffDq {
-		return summary
-	}
-
-	// Calculate autonomous period points.
-	for _, status := range score.LeaveStatuses {
-		if status {
-			summary.LeavePoints += 3
-		}
-	}
-	autoCoralPoints := score.Reef.AutoCoralPoints()
-	summary.AutoPoints = summary.LeavePoints + autoCoralPoints
-
-	summary.NumCoral = score.Reef.AutoCoralCount() + score.Reef.TeleopCoralCount()
-	summary.CoralPoints = autoCoralPoints + score.Reef.TeleopCoralPoints()
-	summary.NumAlgae = score.BargeAlgae + score.ProcessorAlgae
-	summary.AlgaePoints = 4*score.BargeAlgae + 6*score.ProcessorAlgae
-
-	// Calculate endgame points.
-	for _, status := range score.EndgameStatuses {
-		switch status {
-		case EndgameParked:
-			summary.BargePoints += 2
-		case EndgameShallowCage:
-			summary.BargePoints += 6
-		case EndgameDeepCage:
-			summary.BargePoints += 12
-		default:
-		}
-	}
-
-	summary.MatchPoints = summary.LeavePoints + summary.CoralPoints + summary.AlgaePoints + summary.BargePoints
-
-	// Calculate penalty points.
-	for _, foul := range opponentScore.Fouls {
-		summary.FoulPoints += foul.PointValue()
-		// Store the number of major fouls since it is used to break ties in playoffs.
-		if foul.IsMajor {
-			summary.NumOpponentMajorFouls++
-		}
-
-		rule := foul.Rule()
-		if rule != nil {
-			// Check for the opponent fouls that automatically trigger a ranking point.
-			if rule.IsRankingPoint {
-				switch rule.RuleNumber {
-				case "G410":
-					summary.CoralBonusRankingPoint = true
-				case "G418":
-					summary.BargeBonusRankingPoint = true
-				case "G428":
-					summary.BargeBonusRankingPoint = true
-				}
-			}
-		}
-	}
-
-	summary.Score = summary.MatchPoints + summary.FoulPoints
-
-	// Calculate bonus ranking points.
-	// Autonomous bonus ranking point.
-	allRobotsLeft := true
-	for i, left := range score.LeaveStatuses {
-		if !left && !score.RobotsBypassed[i] {
-			allRobotsLeft = false
-			break
-		}
-	}
-	if allRobotsLeft && score.Reef.isAutoBonusCoralThresholdMet() {
-		summary.AutoBonusRankingPoint = true
-	}
-
-	// Coral bonus ranking point.
-	summary.NumCoralLevels = score.Reef.countCoralBonusSatisfiedLevels()
-	summary.NumCoralLevelsGoal = 4
-	if CoralBonusCoopEnabled {
-		summary.CoopertitionCriteriaMet = score.ProcessorAlgae >= 2
-		summary.CoopertitionBonus = summary.CoopertitionCriteriaMet && opponentScore.ProcessorAlgae >= 2
-		if summary.CoopertitionBonus {
-			summary.NumCoralLevelsGoal = 3
-		}
-	}
-	if summary.NumCoralLevels >= summary.NumCoralLevelsGoal {
-		summary.CoralBonusRankingPoint = true
-	}
-
-	// Barge bonus ranking point.
-	bargePointsForBonus := summary.BargePoints
-	if IncludeAlgaeInBargeBonus {
-		bargePointsForBonus += summary.AlgaePoints
-	}
-	if bargePointsForBonus >= BargeBonusPointThreshold {
-		summary.BargeBonusRankingPoint = true
-	}
-
-	// Check for G206 violation.
-	for _, foul := range score.Fouls {
-		if foul.Rule() != nil && foul.Rule().RuleNumber == "G206" {
-			summary.CoralBonusRankingPoint = false
-			summary.BargeBonusRankingPoint = false
-			break
-		}
-	}
-
-	// Add up the bonus ranking points.
-	if summary.AutoBonusRankingPoint {
-		summary.BonusRankingPoints++
-	}
-	if summary.CoralBonusRankingPoint {
-		summary.BonusRankingPoints++
-	}
-	if summary.BargeBonusRankingPoint {
-		summary.BonusRankingPoints++
-	}
-
-	return summary
+	return CurrentGame().Summarize(score, opponentScore)
 }
 
 // Equals returns true if and only if all fields of the two scores are equal.
@@ -165,6 +45,7 @@ func (score *Score) Equals(other *Score) bool {
 		score.ProcessorAlgae != other.ProcessorAlgae ||
 		score.EndgameStatuses != other.EndgameStatuses ||
 		score.PlayoffDq != other.PlayoffDq ||
+		!maps.Equal(score.Counters, other.Counters) ||
 		len(score.Fouls) != len(other.Fouls) {
 		return false
 	}
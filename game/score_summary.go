@@ -48,18 +48,8 @@ func DetermineMatchStatus(redScoreSummary, blueScoreSummary *ScoreSummary, apply
 	}
 
 	if applyPlayoffTiebreakers {
-		// Check scoring breakdowns to resolve playoff ties.
-		if status := comparePoints(
-			redScoreSummary.NumOpponentMajorFouls, blueScoreSummary.NumOpponentMajorFouls,
-		); status != TieMatch {
-			return status
-		}
-		if status := comparePoints(redScoreSummary.AutoPoints, blueScoreSummary.AutoPoints); status != TieMatch {
-			return status
-		}
-		if status := comparePoints(redScoreSummary.BargePoints, blueScoreSummary.BargePoints); status != TieMatch {
-			return status
-		}
+		// Check the game-specific scoring breakdowns to resolve playoff ties.
+		return CurrentGame().BreakPlayoffTie(redScoreSummary, blueScoreSummary)
 	}
 
 	return TieMatch
@@ -7,24 +7,43 @@
 package main
 
 import (
+	"flag"
+	"github.com/Team254/cheesy-arena/config"
 	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/model"
 	"github.com/Team254/cheesy-arena/web"
 	"log"
+	"os"
 )
 
-const eventDbPath = "./event.db"
-const httpPort = 8080
-
 // Main entry point for the application.
 func main() {
-	arena, err := field.NewArena(eventDbPath)
+	config, err := config.Parse(os.Args[1:], os.Stderr)
+	if err == flag.ErrHelp {
+		os.Exit(0)
+	} else if err != nil {
+		log.Fatalln("Error parsing configuration: ", err)
+	}
+
+	// Keep all the data belonging to this instance separate so that several instances can be run on one machine.
+	model.DataDir = config.DataDir
+	field.LogsDir = config.LogDir
+	model.BaseDir = config.AssetDir
+	if err = os.MkdirAll(config.DataDir, 0755); err != nil {
+		log.Fatalln("Error creating data directory: ", err)
+	}
+	if config.NoFieldHardware {
+		log.Println("Running without field hardware.")
+	}
+
+	arena, err := field.NewArena(config.DbPath, config.NoFieldHardware)
 	if err != nil {
 		log.Fatalln("Error during startup: ", err)
 	}
 
 	// Start the web server in a separate goroutine.
 	web := web.NewWeb(arena)
-	go web.ServeWebInterface(httpPort)
+	go web.ServeWebInterface(config.ListenAddress(), config.TlsCertFile, config.TlsKeyFile)
 
 	// Run the arena state machine in the main thread.
 	arena.Run()
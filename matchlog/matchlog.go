@@ -0,0 +1,312 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Compact per-match log bundles recording driver station and wifi telemetry, arena state transitions, PLC I/O changes
+// and access point status.
+
+package matchlog
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	formatVersion   = 1
+	bundleExtension = ".matchlog"
+	timestampFormat = "20060102150405"
+)
+
+// Matches the bundle filenames, e.g. "20250321142533_Qualification_Match_Q12.matchlog".
+var bundleFilenameRe = regexp.MustCompile(`^(\d{14})_([A-Za-z]+)_Match_(.+)\.matchlog$`)
+
+type RecordType int
+
+const (
+	DsStatusRecord RecordType = iota
+	ArenaStateRecord
+	PlcIoRecord
+	AccessPointRecord
+)
+
+var recordTypeNames = map[RecordType]string{
+	DsStatusRecord:    "dsStatus",
+	ArenaStateRecord:  "arenaState",
+	PlcIoRecord:       "plcIo",
+	AccessPointRecord: "accessPoint",
+}
+
+func (recordType RecordType) String() string {
+	return recordTypeNames[recordType]
+}
+
+// Returns the record type having the given name, or false if there is none.
+func ParseRecordType(name string) (RecordType, bool) {
+	for recordType, recordTypeName := range recordTypeNames {
+		if recordTypeName == name {
+			return recordType, true
+		}
+	}
+	return 0, false
+}
+
+// Identifies the match that a bundle was recorded for; written once at the start of each bundle.
+type Header struct {
+	Version        int
+	MatchId        int
+	MatchType      string
+	MatchShortName string
+	StartedAt      time.Time
+	TeamIds        map[string]int // Keyed by alliance station (e.g. "R1").
+}
+
+// A single entry in a bundle. Only the field corresponding to the record type is populated, and since gob omits
+// zero-valued fields, the others take up no space.
+type Record struct {
+	Type         RecordType
+	MatchTimeSec float64
+	Station      string // Alliance station that a DsStatusRecord pertains to; empty for field-wide records.
+	DsStatus     *DsStatus
+	MatchState   int // Value of field.MatchState that the arena transitioned to.
+	PlcIo        *PlcIo
+	AccessPoint  string
+}
+
+// State of a team's driver station and robot, and of its wifi connection, as of a received driver station packet.
+type DsStatus struct {
+	TeamId            int
+	DsLinked          bool
+	RadioLinked       bool
+	RioLinked         bool
+	RobotLinked       bool
+	Auto              bool
+	Enabled           bool
+	EStop             bool
+	AStop             bool
+	BatteryVoltage    float64
+	MissedPacketCount int
+	TripTimeMs        int
+	RxRate            float64
+	TxRate            float64
+	SignalNoiseRatio  int
+}
+
+// Values of all of the PLC's I/O after a change.
+type PlcIo struct {
+	Inputs    []bool
+	Registers []uint16
+	Coils     []bool
+}
+
+// A bundle and all of its records, in the order that they were written.
+type Bundle struct {
+	Filename string
+	Header   Header
+	Records  []Record
+}
+
+// Restricts the records returned from a bundle.
+type Query struct {
+	// If set, excludes the driver station records of all other stations; field-wide records are still included.
+	Station string
+	// If non-empty, excludes records of all other types.
+	Types []RecordType
+	// Range of match times in seconds to include; a ToSec of zero means there is no upper bound.
+	FromSec float64
+	ToSec   float64
+}
+
+// Appends records to a bundle file as they happen, so that the bundle can be read while the match is still in play.
+type Writer struct {
+	Filename string
+	mutex    sync.Mutex
+	file     *os.File
+	encoder  *gob.Encoder
+}
+
+// Creates a bundle in the given directory for the match described by the given header.
+func Create(dir string, header Header) (*Writer, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	header.Version = formatVersion
+	filename := filepath.Join(
+		dir,
+		fmt.Sprintf(
+			"%s_%s_Match_%s%s",
+			header.StartedAt.Format(timestampFormat),
+			header.MatchType,
+			header.MatchShortName,
+			bundleExtension,
+		),
+	)
+	file, err := os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	writer := &Writer{Filename: filename, file: file, encoder: gob.NewEncoder(file)}
+	if err = writer.encoder.Encode(header); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return writer, nil
+}
+
+// Appends the given record to the bundle. Safe to call from multiple goroutines.
+func (writer *Writer) Write(record Record) error {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+	if writer.file == nil {
+		return fmt.Errorf("match log %s is closed", writer.Filename)
+	}
+	return writer.encoder.Encode(record)
+}
+
+// Closes the bundle file; subsequent writes will fail.
+func (writer *Writer) Close() error {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+	if writer.file == nil {
+		return nil
+	}
+	err := writer.file.Close()
+	writer.file = nil
+	return err
+}
+
+// Reads the given bundle in its entirety. A bundle whose last record is truncated (because it is still being written,
+// or because the arena exited mid-match) is returned without that record rather than as an error.
+func Read(filename string) (*Bundle, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	decoder := gob.NewDecoder(file)
+	bundle := Bundle{Filename: filename}
+	if err = decoder.Decode(&bundle.Header); err != nil {
+		return nil, fmt.Errorf("invalid match log %s: %v", filename, err)
+	}
+	if bundle.Header.Version != formatVersion {
+		return nil, fmt.Errorf("match log %s has unsupported version %d", filename, bundle.Header.Version)
+	}
+	for {
+		var record Record
+		if err = decoder.Decode(&record); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				break
+			}
+			return nil, fmt.Errorf("invalid match log %s: %v", filename, err)
+		}
+		bundle.Records = append(bundle.Records, record)
+	}
+	return &bundle, nil
+}
+
+// Reads only the header of the given bundle.
+func ReadHeader(filename string) (Header, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return Header{}, err
+	}
+	defer file.Close()
+
+	var header Header
+	if err = gob.NewDecoder(file).Decode(&header); err != nil {
+		return Header{}, fmt.Errorf("invalid match log %s: %v", filename, err)
+	}
+	return header, nil
+}
+
+// Returns the filenames of all bundles in the given directory, oldest first.
+func List(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+
+	filenames := []string{}
+	for _, entry := range entries {
+		if !entry.IsDir() && bundleFilenameRe.MatchString(entry.Name()) {
+			filenames = append(filenames, filepath.Join(dir, entry.Name()))
+		}
+	}
+	// The timestamp prefix makes lexical order chronological.
+	sort.Strings(filenames)
+	return filenames, nil
+}
+
+// Returns the filenames of all bundles in the given directory recorded for the given match, oldest first.
+func ListForMatch(dir string, matchId int) ([]string, error) {
+	filenames, err := List(dir)
+	if err != nil {
+		return nil, err
+	}
+	matchFilenames := []string{}
+	for _, filename := range filenames {
+		header, err := ReadHeader(filename)
+		if err == nil && header.MatchId == matchId {
+			matchFilenames = append(matchFilenames, filename)
+		}
+	}
+	return matchFilenames, nil
+}
+
+// Deletes the bundles in the given directory that were started more than the given number of days ago, returning
+// how many were deleted. Does nothing if the number of days is not positive.
+func Prune(dir string, retainDays int) (int, error) {
+	if retainDays <= 0 {
+		return 0, nil
+	}
+	filenames, err := List(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -retainDays)
+	numDeleted := 0
+	for _, filename := range filenames {
+		matches := bundleFilenameRe.FindStringSubmatch(filepath.Base(filename))
+		startedAt, err := time.ParseInLocation(timestampFormat, matches[1], time.Local)
+		if err != nil || !startedAt.Before(cutoff) {
+			continue
+		}
+		if err = os.Remove(filename); err != nil && !os.IsNotExist(err) {
+			return numDeleted, err
+		}
+		numDeleted++
+	}
+	return numDeleted, nil
+}
+
+// Returns the records of the bundle that satisfy the given query, in the order that they were written.
+func (bundle *Bundle) Query(query Query) []Record {
+	records := []Record{}
+	for _, record := range bundle.Records {
+		if query.Station != "" && record.Station != "" && record.Station != query.Station {
+			continue
+		}
+		if len(query.Types) > 0 && !slices.Contains(query.Types, record.Type) {
+			continue
+		}
+		if record.MatchTimeSec < query.FromSec || query.ToSec > 0 && record.MatchTimeSec > query.ToSec {
+			continue
+		}
+		records = append(records, record)
+	}
+	return records
+}
@@ -0,0 +1,174 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package matchlog
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWriteAndRead(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "logs")
+	startedAt := time.Date(2025, 3, 21, 14, 25, 33, 0, time.Local)
+	writer, err := Create(
+		dir,
+		Header{
+			MatchId:        12,
+			MatchType:      "Qualification",
+			MatchShortName: "Q12",
+			StartedAt:      startedAt,
+			TeamIds:        map[string]int{"R1": 254, "B3": 1114},
+		},
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(dir, "20250321142533_Qualification_Match_Q12.matchlog"), writer.Filename)
+
+	records := []Record{
+		{Type: ArenaStateRecord, MatchState: 3},
+		{
+			Type:         DsStatusRecord,
+			MatchTimeSec: 0.25,
+			Station:      "R1",
+			DsStatus:     &DsStatus{TeamId: 254, DsLinked: true, RobotLinked: true, BatteryVoltage: 12.5, TripTimeMs: 3},
+		},
+		{Type: PlcIoRecord, MatchTimeSec: 1.5, PlcIo: &PlcIo{Inputs: []bool{true, false}, Registers: []uint16{7}}},
+		{Type: AccessPointRecord, MatchTimeSec: 2, AccessPoint: "ACTIVE"},
+		{
+			Type:         DsStatusRecord,
+			MatchTimeSec: 2.5,
+			Station:      "B3",
+			DsStatus:     &DsStatus{TeamId: 1114, DsLinked: true, MissedPacketCount: 4, SignalNoiseRatio: 38},
+		},
+	}
+	for _, record := range records {
+		assert.Nil(t, writer.Write(record))
+	}
+
+	// The bundle should be readable while it is still being written.
+	bundle, err := Read(writer.Filename)
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(bundle.Records))
+
+	assert.Nil(t, writer.Close())
+	assert.Nil(t, writer.Close())
+	assert.NotNil(t, writer.Write(records[0]))
+
+	bundle, err = Read(writer.Filename)
+	assert.Nil(t, err)
+	assert.Equal(t, formatVersion, bundle.Header.Version)
+	assert.Equal(t, 12, bundle.Header.MatchId)
+	assert.True(t, startedAt.Equal(bundle.Header.StartedAt))
+	assert.Equal(t, map[string]int{"R1": 254, "B3": 1114}, bundle.Header.TeamIds)
+	assert.Equal(t, records, bundle.Records)
+
+	header, err := ReadHeader(writer.Filename)
+	assert.Nil(t, err)
+	assert.Equal(t, "Q12", header.MatchShortName)
+}
+
+func TestReadTruncated(t *testing.T) {
+	dir := t.TempDir()
+	writer, err := Create(dir, Header{MatchType: "Test", StartedAt: time.Now()})
+	assert.Nil(t, err)
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, writer.Write(Record{Type: ArenaStateRecord, MatchTimeSec: float64(i), MatchState: 3}))
+	}
+	assert.Nil(t, writer.Close())
+
+	info, err := os.Stat(writer.Filename)
+	assert.Nil(t, err)
+	assert.Nil(t, os.Truncate(writer.Filename, info.Size()-3))
+	bundle, err := Read(writer.Filename)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(bundle.Records))
+
+	assert.Nil(t, os.WriteFile(writer.Filename, []byte("not a match log"), 0644))
+	_, err = Read(writer.Filename)
+	assert.NotNil(t, err)
+}
+
+func TestQuery(t *testing.T) {
+	bundle := Bundle{
+		Records: []Record{
+			{Type: ArenaStateRecord, MatchTimeSec: 0, MatchState: 3},
+			{Type: DsStatusRecord, MatchTimeSec: 1, Station: "R1", DsStatus: &DsStatus{TeamId: 254}},
+			{Type: DsStatusRecord, MatchTimeSec: 1, Station: "B1", DsStatus: &DsStatus{TeamId: 1114}},
+			{Type: PlcIoRecord, MatchTimeSec: 5, PlcIo: &PlcIo{}},
+			{Type: DsStatusRecord, MatchTimeSec: 10, Station: "R1", DsStatus: &DsStatus{TeamId: 254}},
+		},
+	}
+
+	assert.Equal(t, bundle.Records, bundle.Query(Query{}))
+	assert.Equal(
+		t,
+		[]Record{bundle.Records[0], bundle.Records[1], bundle.Records[3], bundle.Records[4]},
+		bundle.Query(Query{Station: "R1"}),
+	)
+	assert.Equal(
+		t,
+		[]Record{bundle.Records[1], bundle.Records[4]},
+		bundle.Query(Query{Station: "R1", Types: []RecordType{DsStatusRecord}}),
+	)
+	assert.Equal(
+		t,
+		[]Record{bundle.Records[1], bundle.Records[2], bundle.Records[3]},
+		bundle.Query(Query{FromSec: 1, ToSec: 5}),
+	)
+	assert.Equal(t, []Record{}, bundle.Query(Query{FromSec: 20}))
+}
+
+func TestListAndPrune(t *testing.T) {
+	dir := t.TempDir()
+	filenames, err := List(filepath.Join(dir, "nonexistent"))
+	assert.Nil(t, err)
+	assert.Empty(t, filenames)
+
+	now := time.Now()
+	createBundle := func(matchId int, startedAt time.Time) string {
+		writer, err := Create(
+			dir, Header{MatchId: matchId, MatchType: "Qualification", MatchShortName: "Q1", StartedAt: startedAt},
+		)
+		assert.Nil(t, err)
+		assert.Nil(t, writer.Close())
+		return writer.Filename
+	}
+	oldest := createBundle(1, now.AddDate(0, 0, -10))
+	old := createBundle(2, now.AddDate(0, 0, -3))
+	recent := createBundle(1, now.Add(-time.Hour))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte{}, 0644))
+
+	filenames, err = List(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{oldest, old, recent}, filenames)
+	filenames, err = ListForMatch(dir, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{oldest, recent}, filenames)
+
+	numDeleted, err := Prune(dir, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, numDeleted)
+	numDeleted, err = Prune(dir, 5)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, numDeleted)
+	filenames, _ = List(dir)
+	assert.Equal(t, []string{old, recent}, filenames)
+	numDeleted, err = Prune(dir, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, numDeleted)
+	filenames, _ = List(dir)
+	assert.Equal(t, []string{recent}, filenames)
+}
+
+func TestParseRecordType(t *testing.T) {
+	for _, recordType := range []RecordType{DsStatusRecord, ArenaStateRecord, PlcIoRecord, AccessPointRecord} {
+		parsedType, ok := ParseRecordType(recordType.String())
+		assert.True(t, ok)
+		assert.Equal(t, recordType, parsedType)
+	}
+	_, ok := ParseRecordType("bogus")
+	assert.False(t, ok)
+}
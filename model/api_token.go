@@ -0,0 +1,83 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Model and datastore CRUD methods for a bearer token granting an external system access to the REST API with the
+// permissions of a given role.
+
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+)
+
+type ApiToken struct {
+	Id        int `db:"id"`
+	Name      string
+	Role      UserRole
+	TokenHash string // Only a hash of the token is stored; the token itself is shown once when it is created.
+	CreatedAt time.Time
+}
+
+// Returns the hash under which the given token is stored.
+func HashApiToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+func (database *Database) CreateApiToken(apiToken *ApiToken) error {
+	if err := validateApiToken(apiToken); err != nil {
+		return err
+	}
+	return database.apiTokenTable.create(apiToken)
+}
+
+func (database *Database) GetApiTokenById(id int) (*ApiToken, error) {
+	return database.apiTokenTable.getById(id)
+}
+
+// Returns the API token matching the given plaintext token, or nil if there is none.
+func (database *Database) GetApiTokenByToken(token string) (*ApiToken, error) {
+	apiTokens, err := database.apiTokenTable.getByIndex("TokenHash", HashApiToken(token))
+	if err != nil || len(apiTokens) == 0 {
+		return nil, err
+	}
+	return &apiTokens[0], nil
+}
+
+func (database *Database) DeleteApiToken(id int) error {
+	return database.apiTokenTable.delete(id)
+}
+
+func (database *Database) TruncateApiTokens() error {
+	return database.apiTokenTable.truncate()
+}
+
+// Returns all API tokens, sorted by name.
+func (database *Database) GetAllApiTokens() ([]ApiToken, error) {
+	apiTokens, err := database.apiTokenTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(apiTokens, func(i, j int) bool {
+		return apiTokens[i].Name < apiTokens[j].Name
+	})
+	return apiTokens, nil
+}
+
+// Checks that the given API token has the required fields.
+func validateApiToken(apiToken *ApiToken) error {
+	if apiToken.Name == "" {
+		return fmt.Errorf("name cannot be blank")
+	}
+	if !apiToken.Role.IsValid() {
+		return fmt.Errorf("invalid role %q", apiToken.Role)
+	}
+	if apiToken.TokenHash == "" {
+		return fmt.Errorf("token cannot be blank")
+	}
+	return nil
+}
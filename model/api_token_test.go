@@ -0,0 +1,59 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestApiTokenCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	apiToken := ApiToken{
+		Name:      "Scoreboard",
+		Role:      ReadOnlyRole,
+		TokenHash: HashApiToken("abc123"),
+		CreatedAt: time.Unix(1000, 0).UTC(),
+	}
+	assert.Nil(t, db.CreateApiToken(&apiToken))
+	apiToken2, err := db.GetApiTokenById(1)
+	assert.Nil(t, err)
+	assert.Equal(t, apiToken, *apiToken2)
+
+	apiToken2, err = db.GetApiTokenByToken("abc123")
+	assert.Nil(t, err)
+	assert.Equal(t, apiToken, *apiToken2)
+	apiToken2, err = db.GetApiTokenByToken(apiToken.TokenHash)
+	assert.Nil(t, err)
+	assert.Nil(t, apiToken2)
+
+	apiToken3 := ApiToken{Name: "Automation", Role: ScorekeeperRole, TokenHash: HashApiToken("def456")}
+	assert.Nil(t, db.CreateApiToken(&apiToken3))
+	apiTokens, err := db.GetAllApiTokens()
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(apiTokens)) {
+		assert.Equal(t, "Automation", apiTokens[0].Name)
+		assert.Equal(t, "Scoreboard", apiTokens[1].Name)
+	}
+
+	assert.Nil(t, db.DeleteApiToken(apiToken.Id))
+	apiToken2, err = db.GetApiTokenById(1)
+	assert.Nil(t, err)
+	assert.Nil(t, apiToken2)
+}
+
+func TestApiTokenValidation(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	err := db.CreateApiToken(&ApiToken{Role: AdminRole, TokenHash: "x"})
+	assert.EqualError(t, err, "name cannot be blank")
+	err = db.CreateApiToken(&ApiToken{Name: "A", Role: "blorpy", TokenHash: "x"})
+	assert.EqualError(t, err, "invalid role \"blorpy\"")
+	err = db.CreateApiToken(&ApiToken{Name: "A", Role: AdminRole})
+	assert.EqualError(t, err, "token cannot be blank")
+}
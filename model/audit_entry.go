@@ -0,0 +1,43 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Model and datastore CRUD methods for the audit trail of administrative actions.
+
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+type AuditEntry struct {
+	Id        int `db:"id"`
+	Timestamp time.Time
+	Username  string
+	Action    string
+	TargetId  string
+	Changes   []FieldChange
+}
+
+func (database *Database) CreateAuditEntry(auditEntry *AuditEntry) error {
+	return database.auditEntryTable.create(auditEntry)
+}
+
+// Returns all audit entries, most recent first.
+func (database *Database) GetAllAuditEntries() ([]AuditEntry, error) {
+	auditEntries, err := database.auditEntryTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(
+		auditEntries,
+		func(i, j int) bool {
+			return auditEntries[i].Id > auditEntries[j].Id
+		},
+	)
+	return auditEntries, nil
+}
+
+func (database *Database) TruncateAuditEntries() error {
+	return database.auditEntryTable.truncate()
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestAuditEntryCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	auditEntries, err := db.GetAllAuditEntries()
+	assert.Nil(t, err)
+	assert.Empty(t, auditEntries)
+
+	auditEntry1 := AuditEntry{
+		Timestamp: time.Unix(100, 0).UTC(),
+		Username:  "admin",
+		Action:    "team.edit",
+		TargetId:  "254",
+		Changes:   []FieldChange{{Field: "Nickname", Before: "Poofs", After: "The Cheesy Poofs"}},
+	}
+	assert.Nil(t, db.CreateAuditEntry(&auditEntry1))
+	auditEntry2 := AuditEntry{Timestamp: time.Unix(200, 0).UTC(), Username: "sk", Action: "db.clear", TargetId: "all"}
+	assert.Nil(t, db.CreateAuditEntry(&auditEntry2))
+
+	auditEntries, err = db.GetAllAuditEntries()
+	assert.Nil(t, err)
+	assert.Equal(t, []AuditEntry{auditEntry2, auditEntry1}, auditEntries)
+
+	assert.Nil(t, db.TruncateAuditEntries())
+	auditEntries, err = db.GetAllAuditEntries()
+	assert.Nil(t, err)
+	assert.Empty(t, auditEntries)
+}
@@ -0,0 +1,169 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Scheduler for taking periodic and event-triggered database backups and pruning old ones.
+
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	backupTimestampFormat = "20060102150405"
+	periodicBackupReason  = "periodic"
+)
+
+// Matches backup filenames of the form "<event name>_<timestamp>_<reason>.db".
+var backupFilenameRe = regexp.MustCompile(`^(.*)_(\d{14})_(.*)\.db$`)
+
+// Represents a database backup file on disk.
+type BackupFile struct {
+	Filename  string
+	Path      string
+	Timestamp time.Time
+	Reason    string
+	SizeBytes int64
+}
+
+type BackupScheduler struct {
+	// Interval at which to take periodic backups, or zero to disable them.
+	IntervalMin int
+	// Number of most recent backups to retain, or zero to retain all of them.
+	RetainCount int
+	// Whether to additionally retain the most recent backup from each hour when pruning.
+	RetainHourly   bool
+	lastBackupTime time.Time
+	mutex          sync.Mutex
+}
+
+func NewBackupScheduler() *BackupScheduler {
+	return &BackupScheduler{lastBackupTime: time.Now()}
+}
+
+// Takes a periodic backup of the given database if the configured interval has elapsed since the last backup.
+func (scheduler *BackupScheduler) BackupIfDue(database *Database, eventName string) error {
+	if scheduler.IntervalMin <= 0 ||
+		time.Since(scheduler.lastBackupTime) < time.Duration(scheduler.IntervalMin)*time.Minute {
+		return nil
+	}
+	return scheduler.Backup(database, eventName, periodicBackupReason)
+}
+
+// Takes a backup of the given database for the given reason and then prunes old backups according to the retention
+// policy.
+func (scheduler *BackupScheduler) Backup(database *Database, eventName, reason string) error {
+	scheduler.mutex.Lock()
+	defer scheduler.mutex.Unlock()
+
+	if err := database.Backup(eventName, reason); err != nil {
+		return err
+	}
+	scheduler.lastBackupTime = time.Now()
+	return scheduler.prune()
+}
+
+// Deletes the backups that fall outside the retention policy.
+func (scheduler *BackupScheduler) prune() error {
+	backups, err := ListBackups()
+	if err != nil {
+		return err
+	}
+	for _, backup := range selectBackupsToPrune(backups, scheduler.RetainCount, scheduler.RetainHourly) {
+		if err = os.Remove(backup.Path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns all backups in the backups directory, most recent first.
+func ListBackups() ([]BackupFile, error) {
+	backupsPath := filepath.Join(DataDir, backupsDir)
+	entries, err := os.ReadDir(backupsPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []BackupFile{}, nil
+		}
+		return nil, err
+	}
+
+	backups := []BackupFile{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		matches := backupFilenameRe.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			continue
+		}
+		timestamp, err := time.ParseInLocation(backupTimestampFormat, matches[2], time.Local)
+		if err != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		backups = append(
+			backups,
+			BackupFile{
+				Filename:  entry.Name(),
+				Path:      filepath.Join(backupsPath, entry.Name()),
+				Timestamp: timestamp,
+				Reason:    matches[3],
+				SizeBytes: info.Size(),
+			},
+		)
+	}
+	sort.SliceStable(backups, func(i, j int) bool {
+		return backups[i].Timestamp.After(backups[j].Timestamp)
+	})
+	return backups, nil
+}
+
+// Returns the backup having the given filename, or nil if it doesn't exist in the backups directory.
+func GetBackup(filename string) (*BackupFile, error) {
+	backups, err := ListBackups()
+	if err != nil {
+		return nil, err
+	}
+	for _, backup := range backups {
+		if backup.Filename == filename {
+			return &backup, nil
+		}
+	}
+	return nil, nil
+}
+
+// Returns the backups from the given list (which must be sorted most recent first) that fall outside the retention
+// policy. Backups taken before a destructive operation are never pruned so that the operation can always be undone.
+func selectBackupsToPrune(backups []BackupFile, retainCount int, retainHourly bool) []BackupFile {
+	if retainCount <= 0 {
+		return nil
+	}
+
+	var backupsToPrune []BackupFile
+	numRetained := 0
+	retainedHours := make(map[time.Time]struct{})
+	for _, backup := range backups {
+		if strings.HasPrefix(backup.Reason, "pre_") {
+			continue
+		}
+		hour := backup.Timestamp.Truncate(time.Hour)
+		_, hourRetained := retainedHours[hour]
+		if numRetained < retainCount || retainHourly && !hourRetained {
+			numRetained++
+			retainedHours[hour] = struct{}{}
+		} else {
+			backupsToPrune = append(backupsToPrune, backup)
+		}
+	}
+	return backupsToPrune
+}
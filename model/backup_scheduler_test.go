@@ -0,0 +1,90 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBackupSchedulerBackupAndList(t *testing.T) {
+	database := SetupTestDb(t)
+
+	backups, err := ListBackups()
+	assert.Nil(t, err)
+	assert.Empty(t, backups)
+
+	scheduler := NewBackupScheduler()
+	scheduler.IntervalMin = 10
+	assert.Nil(t, scheduler.BackupIfDue(database, "Chezy Champs"))
+	backups, _ = ListBackups()
+	assert.Empty(t, backups)
+
+	scheduler.lastBackupTime = time.Now().Add(-11 * time.Minute)
+	assert.Nil(t, scheduler.BackupIfDue(database, "Chezy Champs"))
+	backups, _ = ListBackups()
+	if assert.Equal(t, 1, len(backups)) {
+		assert.Equal(t, "periodic", backups[0].Reason)
+		assert.Regexp(t, `^Chezy_Champs_\d{14}_periodic\.db$`, backups[0].Filename)
+		assert.Greater(t, backups[0].SizeBytes, int64(0))
+		assert.WithinDuration(t, time.Now(), backups[0].Timestamp, 2*time.Second)
+	}
+	assert.Nil(t, scheduler.BackupIfDue(database, "Chezy Champs"))
+	backups, _ = ListBackups()
+	assert.Equal(t, 1, len(backups))
+
+	backup, err := GetBackup(backups[0].Filename)
+	assert.Nil(t, err)
+	assert.Equal(t, backups[0], *backup)
+	backup, err = GetBackup("../test.db")
+	assert.Nil(t, err)
+	assert.Nil(t, backup)
+
+	// Check that files not matching the backup naming scheme are ignored.
+	assert.Nil(t, os.WriteFile(filepath.Join(DataDir, backupsDir, "notes.txt"), []byte("hi"), 0644))
+	backups, _ = ListBackups()
+	assert.Equal(t, 1, len(backups))
+
+	scheduler.IntervalMin = 0
+	scheduler.lastBackupTime = time.Now().Add(-time.Hour)
+	assert.Nil(t, scheduler.BackupIfDue(database, "Chezy Champs"))
+	backups, _ = ListBackups()
+	assert.Equal(t, 1, len(backups))
+}
+
+func TestSelectBackupsToPrune(t *testing.T) {
+	baseTime := time.Date(2025, 10, 18, 9, 0, 0, 0, time.Local)
+	var backups []BackupFile
+	for i := 11; i >= 0; i-- {
+		// Two backups per hour, with a pre-clear backup in the oldest hour.
+		reason := "periodic"
+		if i == 0 {
+			reason = "pre_clear"
+		}
+		backups = append(
+			backups,
+			BackupFile{
+				Filename:  string(rune('a' + i)),
+				Timestamp: baseTime.Add(time.Duration(i) * 30 * time.Minute),
+				Reason:    reason,
+			},
+		)
+	}
+
+	assert.Empty(t, selectBackupsToPrune(backups, 0, true))
+
+	filenames := func(backups []BackupFile) string {
+		var filenames string
+		for _, backup := range backups {
+			filenames += backup.Filename
+		}
+		return filenames
+	}
+	assert.Equal(t, "hgfedcb", filenames(selectBackupsToPrune(backups, 4, false)))
+	assert.Equal(t, "gec", filenames(selectBackupsToPrune(backups, 4, true)))
+	assert.Equal(t, "", filenames(selectBackupsToPrune(backups, 20, false)))
+}
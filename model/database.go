@@ -13,44 +13,89 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 )
 
 const backupsDir = "db/backups"
 
-var BaseDir = "." // Mutable for testing
+var (
+	// Directory containing the templates, static assets, and schedules; mutable for testing.
+	BaseDir = "."
+	// Directory in which database backups are stored; mutable for running several isolated instances and for testing.
+	DataDir = "."
+)
 
 type Database struct {
-	Path                string
-	bolt                *bbolt.DB
-	allianceTable       *table[Alliance]
-	awardTable          *table[Award]
-	eventSettingsTable  *table[EventSettings]
-	judgingSlotTable    *table[JudgingSlot]
-	lowerThirdTable     *table[LowerThird]
-	matchTable          *table[Match]
-	matchResultTable    *table[MatchResult]
-	rankingTable        *table[game.Ranking]
-	scheduleBlockTable  *table[ScheduleBlock]
-	scheduledBreakTable *table[ScheduledBreak]
-	sponsorSlideTable   *table[SponsorSlide]
-	teamTable           *table[Team]
-	userSessionTable    *table[UserSession]
+	Path                    string
+	bolt                    *bbolt.DB
+	allianceTable           *table[Alliance]
+	apiTokenTable           *table[ApiToken]
+	auditEntryTable         *table[AuditEntry]
+	awardTable              *table[Award]
+	eventSettingsTable      *table[EventSettings]
+	judgingSlotTable        *table[JudgingSlot]
+	lowerThirdTable         *table[LowerThird]
+	matchTable              *table[Match]
+	matchEventTable         *table[MatchEvent]
+	matchResultTable        *table[MatchResult]
+	practiceQueueEntryTable *table[PracticeQueueEntry]
+	rankingTable            *table[game.Ranking]
+	scheduleBlockTable      *table[ScheduleBlock]
+	scoreSnapshotTable      *table[ScoreSnapshot]
+	scheduledBreakTable     *table[ScheduledBreak]
+	sponsorSlideTable       *table[SponsorSlide]
+	tbaPublishItemTable     *table[TbaPublishItem]
+	teamTable               *table[Team]
+	teamUnavailabilityTable *table[TeamUnavailability]
+	userTable               *table[User]
+	userSessionTable        *table[UserSession]
+	webhookTable            *table[Webhook]
+	webhookDeliveryTable    *table[WebhookDelivery]
+	tables                  map[string]indexedTable
+	mutationMutex           sync.Mutex
+	mutationSequence        int
+	mutationSubscribers     map[chan TableMutation]struct{}
+	readOnly                bool
 }
 
 // Opens the Bolt database at the given path, creating it if it doesn't exist.
 func OpenDatabase(filename string) (*Database, error) {
-	database := Database{Path: filename}
+	database := Database{
+		Path:                filename,
+		tables:              make(map[string]indexedTable),
+		mutationSubscribers: make(map[chan TableMutation]struct{}),
+	}
 	var err error
 	database.bolt, err = bbolt.Open(database.Path, 0644, &bbolt.Options{NoSync: true, Timeout: time.Second})
 	if err != nil {
 		return nil, err
 	}
 
+	// Determine whether the database was just created, before registering the tables creates their buckets.
+	isNewDatabase, err := database.isEmpty()
+	if err != nil {
+		database.bolt.Close()
+		return nil, err
+	}
+	if !isNewDatabase {
+		// Refuse to touch a database written by a newer version of the software.
+		if _, err = database.checkSchemaVersion(); err != nil {
+			database.bolt.Close()
+			return nil, err
+		}
+	}
+
 	// Register tables.
 	if database.allianceTable, err = newTable[Alliance](&database); err != nil {
 		return nil, err
 	}
+	if database.apiTokenTable, err = newTable[ApiToken](&database, "TokenHash"); err != nil {
+		return nil, err
+	}
+	if database.auditEntryTable, err = newTable[AuditEntry](&database); err != nil {
+		return nil, err
+	}
 	if database.awardTable, err = newTable[Award](&database); err != nil {
 		return nil, err
 	}
@@ -63,10 +108,16 @@ func OpenDatabase(filename string) (*Database, error) {
 	if database.lowerThirdTable, err = newTable[LowerThird](&database); err != nil {
 		return nil, err
 	}
-	if database.matchTable, err = newTable[Match](&database); err != nil {
+	if database.matchTable, err = newTable[Match](&database, "Type,TypeOrder"); err != nil {
+		return nil, err
+	}
+	if database.matchEventTable, err = newTable[MatchEvent](&database, "MatchId"); err != nil {
+		return nil, err
+	}
+	if database.matchResultTable, err = newTable[MatchResult](&database, "MatchId,PlayNumber"); err != nil {
 		return nil, err
 	}
-	if database.matchResultTable, err = newTable[MatchResult](&database); err != nil {
+	if database.practiceQueueEntryTable, err = newTable[PracticeQueueEntry](&database, "TeamId"); err != nil {
 		return nil, err
 	}
 	if database.rankingTable, err = newTable[game.Ranking](&database); err != nil {
@@ -75,16 +126,39 @@ func OpenDatabase(filename string) (*Database, error) {
 	if database.scheduleBlockTable, err = newTable[ScheduleBlock](&database); err != nil {
 		return nil, err
 	}
-	if database.scheduledBreakTable, err = newTable[ScheduledBreak](&database); err != nil {
+	if database.scoreSnapshotTable, err = newTable[ScoreSnapshot](&database, "MatchId"); err != nil {
+		return nil, err
+	}
+	if database.scheduledBreakTable, err = newTable[ScheduledBreak](&database, "MatchType,TypeOrderBefore"); err != nil {
 		return nil, err
 	}
 	if database.sponsorSlideTable, err = newTable[SponsorSlide](&database); err != nil {
 		return nil, err
 	}
+	if database.tbaPublishItemTable, err = newTable[TbaPublishItem](&database, "Type"); err != nil {
+		return nil, err
+	}
 	if database.teamTable, err = newTable[Team](&database); err != nil {
 		return nil, err
 	}
-	if database.userSessionTable, err = newTable[UserSession](&database); err != nil {
+	if database.teamUnavailabilityTable, err = newTable[TeamUnavailability](&database, "TeamId"); err != nil {
+		return nil, err
+	}
+	if database.userTable, err = newTable[User](&database, "Username"); err != nil {
+		return nil, err
+	}
+	if database.userSessionTable, err = newTable[UserSession](&database, "Token", "Username"); err != nil {
+		return nil, err
+	}
+	if database.webhookTable, err = newTable[Webhook](&database); err != nil {
+		return nil, err
+	}
+	if database.webhookDeliveryTable, err = newTable[WebhookDelivery](&database, "Pending", "WebhookId"); err != nil {
+		return nil, err
+	}
+
+	if err = database.migrate(isNewDatabase); err != nil {
+		database.bolt.Close()
 		return nil, err
 	}
 
@@ -92,12 +166,19 @@ func OpenDatabase(filename string) (*Database, error) {
 }
 
 func (database *Database) Close() error {
+	// Disconnect any replication subscribers so that they resynchronize from whichever database replaces this one.
+	database.mutationMutex.Lock()
+	for subscriber := range database.mutationSubscribers {
+		database.removeSubscriber(subscriber)
+	}
+	database.mutationMutex.Unlock()
+
 	return database.bolt.Close()
 }
 
 // Creates a copy of the current database and saves it to the backups directory.
 func (database *Database) Backup(eventName, reason string) error {
-	backupsPath := filepath.Join(BaseDir, backupsDir)
+	backupsPath := filepath.Join(DataDir, backupsDir)
 	err := os.MkdirAll(backupsPath, 0755)
 	if err != nil {
 		return err
@@ -106,7 +187,7 @@ func (database *Database) Backup(eventName, reason string) error {
 		"%s/%s_%s_%s.db",
 		backupsPath,
 		strings.Replace(eventName, " ", "_", -1),
-		time.Now().Format("20060102150405"),
+		time.Now().Format(backupTimestampFormat),
 		reason,
 	)
 
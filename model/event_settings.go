@@ -18,6 +18,15 @@ const (
 	SingleEliminationPlayoff
 )
 
+// Determines what happens when a match is started with a team that hasn't passed robot inspection.
+type InspectionEnforcement int
+
+const (
+	InspectionNotEnforced InspectionEnforcement = iota
+	InspectionWarning
+	InspectionRequired
+)
+
 // Configured here to avoid circular import dependencies.
 var (
 	sccDefaultUpCommands = []string{
@@ -46,12 +55,16 @@ type EventSettings struct {
 	SelectionRound2Order             string
 	SelectionRound3Order             string
 	SelectionShowUnpickedTeams       bool
+	PracticeWalkUpQueueEnabled       bool
+	InspectionEnforcement            InspectionEnforcement
 	TbaDownloadEnabled               bool
 	TbaPublishingEnabled             bool
 	TbaEventCode                     string
 	TbaSecretId                      string
 	TbaSecret                        string
 	NexusEnabled                     bool
+	NexusLocalServerEnabled          bool
+	NexusEventApiKey                 string
 	NetworkSecurityEnabled           bool
 	ApAddress                        string
 	ApPassword                       string
@@ -66,7 +79,12 @@ type EventSettings struct {
 	SCCUpCommands                    string
 	SCCDownCommands                  string
 	PlcAddress                       string
+	PlcSimulated                     bool
 	AdminPassword                    string
+	BackupIntervalMin                int
+	BackupRetainCount                int
+	BackupRetainHourly               bool
+	MatchLogRetainDays               int
 	TeamSignRed1Id                   int
 	TeamSignRed2Id                   int
 	TeamSignRed3Id                   int
@@ -111,6 +129,7 @@ type EventSettings struct {
 	PauseDurationSec                 int
 	TeleopDurationSec                int
 	WarningRemainingDurationSec      int
+	GameName                         string
 	AutoBonusCoralThreshold          int
 	CoralBonusPerLevelThreshold      int
 	CoralBonusCoopEnabled            bool
@@ -136,6 +155,9 @@ func (database *Database) GetEventSettings() (*EventSettings, error) {
 		SelectionRound3Order:        "",
 		SelectionShowUnpickedTeams:  true,
 		TbaDownloadEnabled:          true,
+		BackupIntervalMin:           10,
+		BackupRetainCount:           50,
+		BackupRetainHourly:          true,
 		ApChannel:                   36,
 		SCCUpCommands:               strings.Join(sccDefaultUpCommands, "\n"),
 		SCCDownCommands:             strings.Join(sccDefaultDownCommands, "\n"),
@@ -145,6 +167,7 @@ func (database *Database) GetEventSettings() (*EventSettings, error) {
 		PauseDurationSec:            game.MatchTiming.PauseDurationSec,
 		TeleopDurationSec:           game.MatchTiming.TeleopDurationSec,
 		WarningRemainingDurationSec: game.MatchTiming.WarningRemainingDurationSec,
+		GameName:                    game.DefaultGameName,
 		AutoBonusCoralThreshold:     game.AutoBonusCoralThreshold,
 		CoralBonusPerLevelThreshold: game.CoralBonusPerLevelThreshold,
 		CoralBonusCoopEnabled:       game.CoralBonusCoopEnabled,
@@ -25,6 +25,9 @@ func TestEventSettingsReadWrite(t *testing.T) {
 			SelectionRound3Order:        "",
 			SelectionShowUnpickedTeams:  true,
 			TbaDownloadEnabled:          true,
+			BackupIntervalMin:           10,
+			BackupRetainCount:           50,
+			BackupRetainHourly:          true,
 			ApChannel:                   36,
 			SCCUpCommands:               "configure terminal\ninterface range gigabitEthernet 1/2-4\nno shutdown\nexit\nexit\nexit",
 			SCCDownCommands:             "configure terminal\ninterface range gigabitEthernet 1/2-4\nshutdown\nexit\nexit\nexit",
@@ -33,6 +36,7 @@ func TestEventSettingsReadWrite(t *testing.T) {
 			PauseDurationSec:            3,
 			TeleopDurationSec:           135,
 			WarningRemainingDurationSec: 20,
+			GameName:                    "2025 Reefscape",
 			AutoBonusCoralThreshold:     1,
 			CoralBonusPerLevelThreshold: 7,
 			CoralBonusCoopEnabled:       true,
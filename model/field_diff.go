@@ -0,0 +1,91 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Generic field-by-field comparison of two values, used to record what changed in the match event and audit logs.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Represents a single field whose value was changed, identified by its dot-separated path.
+type FieldChange struct {
+	Field  string
+	Before string
+	After  string
+}
+
+// DiffFields compares the JSON representations of the given before and after values and returns a list of the
+// leaf fields that differ between them, ordered by field path.
+func DiffFields(before, after any) ([]FieldChange, error) {
+	beforeFields, err := flattenToFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := flattenToFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []FieldChange
+	for field, beforeValue := range beforeFields {
+		if afterValue := afterFields[field]; afterValue != beforeValue {
+			changes = append(changes, FieldChange{Field: field, Before: beforeValue, After: afterValue})
+		}
+	}
+	for field, afterValue := range afterFields {
+		if _, ok := beforeFields[field]; !ok {
+			changes = append(changes, FieldChange{Field: field, Before: "", After: afterValue})
+		}
+	}
+	sort.Slice(
+		changes,
+		func(i, j int) bool {
+			return changes[i].Field < changes[j].Field
+		},
+	)
+	return changes, nil
+}
+
+// Converts the given value to a map of dot-separated field paths to the string representation of each leaf value.
+func flattenToFields(value any) (map[string]string, error) {
+	valueJson, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var generic any
+	if err = json.Unmarshal(valueJson, &generic); err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]string)
+	var flatten func(prefix string, node any)
+	flatten = func(prefix string, node any) {
+		switch typedNode := node.(type) {
+		case map[string]any:
+			for key, child := range typedNode {
+				flatten(joinFieldPath(prefix, key), child)
+			}
+		case []any:
+			for i, child := range typedNode {
+				flatten(joinFieldPath(prefix, fmt.Sprint(i)), child)
+			}
+		case nil:
+			// Treat nil the same as a missing value so that a nil slice and an empty one compare as equal.
+		default:
+			fields[prefix] = fmt.Sprint(typedNode)
+		}
+	}
+	flatten("", generic)
+	return fields, nil
+}
+
+func joinFieldPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
@@ -0,0 +1,37 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package model
+
+import (
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDiffFields(t *testing.T) {
+	before := game.Score{BargeAlgae: 2, Fouls: []game.Foul{{FoulId: 1, IsMajor: true, TeamId: 254, RuleId: 3}}}
+	after := before
+	after.BargeAlgae = 3
+	after.Reef.Branches[game.Level4][5] = true
+	after.Fouls = nil
+
+	changes, err := DiffFields(before, after)
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		[]FieldChange{
+			{"BargeAlgae", "2", "3"},
+			{"Fouls.0.FoulId", "1", ""},
+			{"Fouls.0.IsMajor", "true", ""},
+			{"Fouls.0.RuleId", "3", ""},
+			{"Fouls.0.TeamId", "254", ""},
+			{"Reef.Branches.2.5", "false", "true"},
+		},
+		changes,
+	)
+
+	changes, err = DiffFields(before, before)
+	assert.Nil(t, err)
+	assert.Empty(t, changes)
+}
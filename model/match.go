@@ -8,7 +8,6 @@ package model
 import (
 	"fmt"
 	"github.com/Team254/cheesy-arena/game"
-	"sort"
 	"strings"
 	"time"
 )
@@ -85,38 +84,25 @@ func (database *Database) TruncateMatches() error {
 }
 
 func (database *Database) GetMatchByTypeOrder(matchType MatchType, typeOrder int) (*Match, error) {
-	matches, err := database.GetMatchesByType(matchType, true)
-	if err != nil {
+	matches, err := database.matchTable.getByIndex("Type,TypeOrder", matchType, typeOrder)
+	if err != nil || len(matches) == 0 {
 		return nil, err
 	}
-
-	for _, match := range matches {
-		if match.TypeOrder == typeOrder {
-			return &match, nil
-		}
-	}
-	return nil, nil
+	return &matches[0], nil
 }
 
 func (database *Database) GetMatchesByType(matchType MatchType, includeHidden bool) ([]Match, error) {
-	matches, err := database.matchTable.getAll()
+	matches, err := database.matchTable.getByIndex("Type,TypeOrder", matchType)
 	if err != nil {
 		return nil, err
 	}
 
 	var matchingMatches []Match
 	for _, match := range matches {
-		if match.Type == matchType && (includeHidden || match.Status != game.MatchHidden) {
+		if includeHidden || match.Status != game.MatchHidden {
 			matchingMatches = append(matchingMatches, match)
 		}
 	}
-
-	sort.Slice(
-		matchingMatches,
-		func(i, j int) bool {
-			return matchingMatches[i].TypeOrder < matchingMatches[j].TypeOrder
-		},
-	)
 	return matchingMatches, nil
 }
 
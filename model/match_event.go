@@ -0,0 +1,48 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Model and datastore CRUD methods for the append-only log of scoring and foul changes made to a match.
+
+package model
+
+import (
+	"time"
+)
+
+type MatchEvent struct {
+	Id           int `db:"id"`
+	MatchId      int
+	Timestamp    time.Time
+	MatchTimeSec float64
+	Source       string
+	Username     string
+	Command      string
+	Changes      []FieldChange
+}
+
+func (database *Database) CreateMatchEvent(matchEvent *MatchEvent) error {
+	return database.matchEventTable.create(matchEvent)
+}
+
+// Returns all events recorded for the given match, in the order in which they occurred.
+func (database *Database) GetMatchEventsForMatch(matchId int) ([]MatchEvent, error) {
+	return database.matchEventTable.getByIndex("MatchId", matchId)
+}
+
+// Deletes all events recorded for the given match.
+func (database *Database) DeleteMatchEventsForMatch(matchId int) error {
+	matchEvents, err := database.GetMatchEventsForMatch(matchId)
+	if err != nil {
+		return err
+	}
+	for _, matchEvent := range matchEvents {
+		if err = database.matchEventTable.delete(matchEvent.Id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (database *Database) TruncateMatchEvents() error {
+	return database.matchEventTable.truncate()
+}
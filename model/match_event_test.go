@@ -0,0 +1,50 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestMatchEventCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	matchEvent1 := MatchEvent{
+		MatchId:      254,
+		Timestamp:    time.Unix(100, 0).UTC(),
+		MatchTimeSec: 12.5,
+		Source:       "scoring/red_near",
+		Username:     "scorer",
+		Command:      "barge",
+		Changes:      []FieldChange{{"Red.BargeAlgae", "0", "1"}},
+	}
+	assert.Nil(t, db.CreateMatchEvent(&matchEvent1))
+	matchEvent2 := MatchEvent{MatchId: 1114, Timestamp: time.Unix(200, 0).UTC(), Command: "addFoul"}
+	assert.Nil(t, db.CreateMatchEvent(&matchEvent2))
+	matchEvent3 := MatchEvent{MatchId: 254, Timestamp: time.Unix(300, 0).UTC(), Command: "deleteFoul"}
+	assert.Nil(t, db.CreateMatchEvent(&matchEvent3))
+
+	matchEvents, err := db.GetMatchEventsForMatch(254)
+	assert.Nil(t, err)
+	assert.Equal(t, []MatchEvent{matchEvent1, matchEvent3}, matchEvents)
+	matchEvents, err = db.GetMatchEventsForMatch(1)
+	assert.Nil(t, err)
+	assert.Empty(t, matchEvents)
+
+	assert.Nil(t, db.DeleteMatchEventsForMatch(254))
+	matchEvents, err = db.GetMatchEventsForMatch(254)
+	assert.Nil(t, err)
+	assert.Empty(t, matchEvents)
+	matchEvents, err = db.GetMatchEventsForMatch(1114)
+	assert.Nil(t, err)
+	assert.Equal(t, []MatchEvent{matchEvent2}, matchEvents)
+
+	assert.Nil(t, db.TruncateMatchEvents())
+	matchEvents, err = db.GetMatchEventsForMatch(1114)
+	assert.Nil(t, err)
+	assert.Empty(t, matchEvents)
+}
@@ -35,19 +35,11 @@ func (database *Database) CreateMatchResult(matchResult *MatchResult) error {
 }
 
 func (database *Database) GetMatchResultForMatch(matchId int) (*MatchResult, error) {
-	matchResults, err := database.matchResultTable.getAll()
-	if err != nil {
+	matchResults, err := database.matchResultTable.getByIndex("MatchId,PlayNumber", matchId)
+	if err != nil || len(matchResults) == 0 {
 		return nil, err
 	}
-
-	var mostRecentMatchResult *MatchResult
-	for i, matchResult := range matchResults {
-		if matchResult.MatchId == matchId &&
-			(mostRecentMatchResult == nil || matchResult.PlayNumber > mostRecentMatchResult.PlayNumber) {
-			mostRecentMatchResult = &matchResults[i]
-		}
-	}
-	return mostRecentMatchResult, nil
+	return &matchResults[len(matchResults)-1], nil
 }
 
 func (database *Database) UpdateMatchResult(matchResult *MatchResult) error {
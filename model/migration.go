@@ -0,0 +1,252 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Versioned migrations for upgrading the schema of the records stored in an existing database.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"go.etcd.io/bbolt"
+	"log"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var (
+	schemaBucketKey  = []byte("_Schema")
+	schemaVersionKey = []byte("Version")
+)
+
+// Represents a single step in upgrading the database schema.
+type migration struct {
+	description string
+	migrate     func(tx *bbolt.Tx) error
+}
+
+// The ordered list of schema migrations. The schema version of a database is the number of migrations that have been
+// applied to it; append new migrations to the end of the list and never reorder or remove existing ones.
+var migrations = []migration{
+	{"Enable automatic backups for events created before they existed", migrateBackupSettings},
+}
+
+// Returns the schema version that this version of the software reads and writes.
+func LatestSchemaVersion() int {
+	return len(migrations)
+}
+
+// Returns the schema version recorded in the database.
+func (database *Database) SchemaVersion() (int, error) {
+	var version int
+	err := database.bolt.View(
+		func(tx *bbolt.Tx) error {
+			var err error
+			version, err = getSchemaVersion(tx)
+			return err
+		},
+	)
+	return version, err
+}
+
+// Applies any migrations that have not yet been applied to the database, each in its own transaction. A newly created
+// database is stamped with the latest version instead since it has no old records to migrate.
+func (database *Database) migrate(isNewDatabase bool) error {
+	if isNewDatabase {
+		return database.bolt.Update(
+			func(tx *bbolt.Tx) error {
+				return setSchemaVersion(tx, LatestSchemaVersion())
+			},
+		)
+	}
+
+	version, err := database.checkSchemaVersion()
+	if err != nil {
+		return err
+	}
+	if version == LatestSchemaVersion() {
+		return nil
+	}
+
+	// Keep a copy of the database as it was before migrating in case something goes wrong.
+	eventName, err := database.getEventNameForBackup()
+	if err != nil {
+		return err
+	}
+	if err = database.Backup(eventName, fmt.Sprintf("pre_migration_v%d", version)); err != nil {
+		return err
+	}
+	for ; version < LatestSchemaVersion(); version++ {
+		migration := migrations[version]
+		log.Printf("Migrating database schema to version %d: %s", version+1, migration.description)
+		err = database.bolt.Update(
+			func(tx *bbolt.Tx) error {
+				if err := migration.migrate(tx); err != nil {
+					return err
+				}
+				return setSchemaVersion(tx, version+1)
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to migrate database schema to version %d: %v", version+1, err)
+		}
+	}
+
+	// Migrations rewrite records directly rather than through the table layer, so the indexes must be rebuilt.
+	return database.rebuildIndexes()
+}
+
+// Returns the schema version recorded in the database, or an error if it is newer than this version of the software
+// can read. Must be called before the tables are registered, since doing so creates buckets and rebuilds indexes.
+func (database *Database) checkSchemaVersion() (int, error) {
+	version, err := database.SchemaVersion()
+	if err != nil {
+		return 0, err
+	}
+	if version > LatestSchemaVersion() {
+		return 0, fmt.Errorf(
+			"database schema version %d is newer than the latest version supported by this version of Cheesy Arena "+
+				"(%d); please upgrade Cheesy Arena to open it",
+			version,
+			LatestSchemaVersion(),
+		)
+	}
+	return version, nil
+}
+
+// Returns the event name to label a pre-migration backup with, read directly from the stored event settings since
+// they may not match the current struct definition yet. Falls back to the name of the database file.
+func (database *Database) getEventNameForBackup() (string, error) {
+	eventName := strings.TrimSuffix(filepath.Base(database.Path), filepath.Ext(database.Path))
+	err := database.bolt.View(
+		func(tx *bbolt.Tx) error {
+			bucket := tx.Bucket([]byte("EventSettings"))
+			if bucket == nil {
+				return nil
+			}
+			_, value := bucket.Cursor().First()
+			if value == nil {
+				return nil
+			}
+			var record struct {
+				Name string
+			}
+			if err := json.Unmarshal(value, &record); err != nil {
+				return err
+			}
+			if record.Name != "" {
+				eventName = record.Name
+			}
+			return nil
+		},
+	)
+	return eventName, err
+}
+
+// Returns true if the database contains no buckets, i.e. it has just been created.
+func (database *Database) isEmpty() (bool, error) {
+	isEmpty := true
+	err := database.bolt.View(
+		func(tx *bbolt.Tx) error {
+			return tx.ForEach(
+				func(name []byte, bucket *bbolt.Bucket) error {
+					isEmpty = false
+					return nil
+				},
+			)
+		},
+	)
+	return isEmpty, err
+}
+
+func getSchemaVersion(tx *bbolt.Tx) (int, error) {
+	bucket := tx.Bucket(schemaBucketKey)
+	if bucket == nil {
+		// The database predates schema versioning.
+		return 0, nil
+	}
+	versionBytes := bucket.Get(schemaVersionKey)
+	if versionBytes == nil {
+		return 0, nil
+	}
+	return strconv.Atoi(string(versionBytes))
+}
+
+func setSchemaVersion(tx *bbolt.Tx, version int) error {
+	bucket, err := tx.CreateBucketIfNotExists(schemaBucketKey)
+	if err != nil {
+		return err
+	}
+	return bucket.Put(schemaVersionKey, []byte(strconv.Itoa(version)))
+}
+
+// Rewrites every record in the given table using the given function, which operates on the raw JSON fields of the
+// record so that it is independent of the current definition of the corresponding struct.
+func updateRecords(tx *bbolt.Tx, tableName string, updateFunc func(record map[string]json.RawMessage) error) error {
+	bucket := tx.Bucket([]byte(tableName))
+	if bucket == nil {
+		// The table didn't exist at the time; there is nothing to migrate.
+		return nil
+	}
+
+	updatedRecords := make(map[string][]byte)
+	err := bucket.ForEach(
+		func(key, value []byte) error {
+			var record map[string]json.RawMessage
+			if err := json.Unmarshal(value, &record); err != nil {
+				return err
+			}
+			if err := updateFunc(record); err != nil {
+				return err
+			}
+			updatedValue, err := json.Marshal(record)
+			if err != nil {
+				return err
+			}
+			updatedRecords[string(key)] = updatedValue
+			return nil
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Write the updated records after iterating, since modifying a bucket during iteration is unsafe.
+	for key, value := range updatedRecords {
+		if err = bucket.Put([]byte(key), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Sets the given field to the given value in every record in the given table that doesn't already have it.
+func setFieldDefault(tx *bbolt.Tx, tableName, fieldName string, value any) error {
+	valueJson, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return updateRecords(
+		tx,
+		tableName,
+		func(record map[string]json.RawMessage) error {
+			if _, ok := record[fieldName]; !ok {
+				record[fieldName] = valueJson
+			}
+			return nil
+		},
+	)
+}
+
+// Version 1: Databases created before automatic backups existed have them disabled, since the settings default to
+// zero; turn them on with the same defaults as a new event.
+func migrateBackupSettings(tx *bbolt.Tx) error {
+	if err := setFieldDefault(tx, "EventSettings", "BackupIntervalMin", 10); err != nil {
+		return err
+	}
+	if err := setFieldDefault(tx, "EventSettings", "BackupRetainCount", 50); err != nil {
+		return err
+	}
+	return setFieldDefault(tx, "EventSettings", "BackupRetainHourly", true)
+}
@@ -0,0 +1,189 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/bbolt"
+	"path/filepath"
+	"testing"
+)
+
+func TestMigrateNewDatabase(t *testing.T) {
+	database := setupTestDb(t)
+	version, err := database.SchemaVersion()
+	assert.Nil(t, err)
+	assert.Equal(t, LatestSchemaVersion(), version)
+}
+
+func TestMigrateLegacyDatabase(t *testing.T) {
+	DataDir = t.TempDir()
+	t.Cleanup(func() { DataDir = "." })
+	dbPath := filepath.Join(t.TempDir(), "legacy.db")
+	writeRawRecords(t, dbPath, "EventSettings", map[string]string{"1": `{"Id":1,"Name":"Chezy Champs"}`})
+
+	database, err := OpenDatabase(dbPath)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer database.Close()
+	version, _ := database.SchemaVersion()
+	assert.Equal(t, LatestSchemaVersion(), version)
+	eventSettings, _ := database.GetEventSettings()
+	assert.Equal(t, "Chezy Champs", eventSettings.Name)
+	assert.Equal(t, 10, eventSettings.BackupIntervalMin)
+	assert.Equal(t, 50, eventSettings.BackupRetainCount)
+	assert.True(t, eventSettings.BackupRetainHourly)
+
+	// Check that the database was backed up before migrating.
+	backups, _ := ListBackups()
+	if assert.Equal(t, 1, len(backups)) {
+		assert.Equal(t, "pre_migration_v0", backups[0].Reason)
+		assert.Contains(t, backups[0].Filename, "Chezy_Champs_")
+	}
+
+	// Check that reopening the database doesn't migrate it again.
+	eventSettings.BackupIntervalMin = 0
+	assert.Nil(t, database.UpdateEventSettings(eventSettings))
+	database.Close()
+	database, err = OpenDatabase(dbPath)
+	assert.Nil(t, err)
+	eventSettings, _ = database.GetEventSettings()
+	assert.Equal(t, 0, eventSettings.BackupIntervalMin)
+	backups, _ = ListBackups()
+	assert.Equal(t, 1, len(backups))
+}
+
+func TestMigrateNewerDatabase(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "newer.db")
+	writeRawRecords(t, dbPath, "EventSettings", map[string]string{"1": `{"Id":1,"Name":"Chezy Champs"}`})
+	db, err := bbolt.Open(dbPath, 0644, nil)
+	assert.Nil(t, err)
+	assert.Nil(
+		t,
+		db.Update(
+			func(tx *bbolt.Tx) error {
+				return setSchemaVersion(tx, LatestSchemaVersion()+1)
+			},
+		),
+	)
+	db.Close()
+
+	_, err = OpenDatabase(dbPath)
+	if assert.NotNil(t, err) {
+		assert.Contains(
+			t, err.Error(), fmt.Sprintf("database schema version %d is newer", LatestSchemaVersion()+1),
+		)
+	}
+
+	// Check that the database was left untouched.
+	db, err = bbolt.Open(dbPath, 0644, nil)
+	assert.Nil(t, err)
+	defer db.Close()
+	assert.Nil(
+		t,
+		db.View(
+			func(tx *bbolt.Tx) error {
+				assert.Nil(t, tx.Bucket([]byte("Team")))
+				assert.Nil(t, tx.Bucket([]byte("EventSettings:Name")))
+				return nil
+			},
+		),
+	)
+}
+
+func TestMigrateCustomMigrations(t *testing.T) {
+	DataDir = t.TempDir()
+	originalMigrations := migrations
+	t.Cleanup(
+		func() {
+			DataDir = "."
+			migrations = originalMigrations
+		},
+	)
+	dbPath := filepath.Join(t.TempDir(), "legacy.db")
+	writeRawRecords(
+		t,
+		dbPath,
+		"Team",
+		map[string]string{"254": `{"Id":254,"Nick":"The Cheesy Poofs"}`, "1114": `{"Id":1114,"Nick":"Simbotics"}`},
+	)
+
+	var appliedMigrations []string
+	migrations = []migration{
+		{
+			"Rename nickname",
+			func(tx *bbolt.Tx) error {
+				appliedMigrations = append(appliedMigrations, "rename")
+				return updateRecords(
+					tx,
+					"Team",
+					func(record map[string]json.RawMessage) error {
+						record["Nickname"] = record["Nick"]
+						delete(record, "Nick")
+						return nil
+					},
+				)
+			},
+		},
+		{
+			"Fail",
+			func(tx *bbolt.Tx) error {
+				appliedMigrations = append(appliedMigrations, "fail")
+				if err := setFieldDefault(tx, "Team", "RookieYear", 2009); err != nil {
+					return err
+				}
+				return fmt.Errorf("something went wrong")
+			},
+		},
+	}
+	_, err := OpenDatabase(dbPath)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "failed to migrate database schema to version 2: something went wrong", err.Error())
+	}
+	assert.Equal(t, []string{"rename", "fail"}, appliedMigrations)
+
+	// Check that the successful migration was committed and the failed one rolled back.
+	migrations = migrations[:1]
+	appliedMigrations = nil
+	database, err := OpenDatabase(dbPath)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer database.Close()
+	assert.Empty(t, appliedMigrations)
+	version, _ := database.SchemaVersion()
+	assert.Equal(t, 1, version)
+	teams, _ := database.GetAllTeams()
+	assert.Equal(t, []Team{{Id: 254, Nickname: "The Cheesy Poofs"}, {Id: 1114, Nickname: "Simbotics"}}, teams)
+}
+
+// Creates a database file at the given path containing only the given raw records, mimicking one created by an older
+// version of the software.
+func writeRawRecords(t *testing.T, dbPath, tableName string, records map[string]string) {
+	db, err := bbolt.Open(dbPath, 0644, nil)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+	assert.Nil(
+		t,
+		db.Update(
+			func(tx *bbolt.Tx) error {
+				bucket, err := tx.CreateBucket([]byte(tableName))
+				if err != nil {
+					return err
+				}
+				for key, value := range records {
+					if err = bucket.Put([]byte(key), []byte(value)); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		),
+	)
+}
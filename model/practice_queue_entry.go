@@ -0,0 +1,56 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Model and datastore CRUD methods for a team that is checked in to the practice match walk-up queue.
+
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+type PracticeQueueEntry struct {
+	Id          int `db:"id"`
+	TeamId      int
+	CheckedInAt time.Time
+}
+
+func (database *Database) CreatePracticeQueueEntry(entry *PracticeQueueEntry) error {
+	return database.practiceQueueEntryTable.create(entry)
+}
+
+func (database *Database) DeletePracticeQueueEntry(id int) error {
+	return database.practiceQueueEntryTable.delete(id)
+}
+
+func (database *Database) TruncatePracticeQueueEntries() error {
+	return database.practiceQueueEntryTable.truncate()
+}
+
+// Returns the team's entry in the queue, or nil if it isn't checked in.
+func (database *Database) GetPracticeQueueEntryByTeam(teamId int) (*PracticeQueueEntry, error) {
+	entries, err := database.practiceQueueEntryTable.getByIndex("TeamId", teamId)
+	if err != nil || len(entries) == 0 {
+		return nil, err
+	}
+	return &entries[0], nil
+}
+
+// Returns all the entries in the queue, with the longest-waiting team first.
+func (database *Database) GetAllPracticeQueueEntries() ([]PracticeQueueEntry, error) {
+	entries, err := database.practiceQueueEntryTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(
+		entries,
+		func(i, j int) bool {
+			if entries[i].CheckedInAt.Equal(entries[j].CheckedInAt) {
+				return entries[i].Id < entries[j].Id
+			}
+			return entries[i].CheckedInAt.Before(entries[j].CheckedInAt)
+		},
+	)
+	return entries, nil
+}
@@ -0,0 +1,41 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestPracticeQueueEntryCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	entry1 := PracticeQueueEntry{0, 254, time.Unix(500, 0).UTC()}
+	assert.Nil(t, db.CreatePracticeQueueEntry(&entry1))
+	entry2 := PracticeQueueEntry{0, 1114, time.Unix(100, 0).UTC()}
+	assert.Nil(t, db.CreatePracticeQueueEntry(&entry2))
+	entry3 := PracticeQueueEntry{0, 2056, time.Unix(500, 0).UTC()}
+	assert.Nil(t, db.CreatePracticeQueueEntry(&entry3))
+
+	entries, err := db.GetAllPracticeQueueEntries()
+	assert.Nil(t, err)
+	assert.Equal(t, []PracticeQueueEntry{entry2, entry1, entry3}, entries)
+
+	entry, err := db.GetPracticeQueueEntryByTeam(254)
+	assert.Nil(t, err)
+	assert.Equal(t, entry1, *entry)
+	entry, err = db.GetPracticeQueueEntryByTeam(148)
+	assert.Nil(t, err)
+	assert.Nil(t, entry)
+
+	assert.Nil(t, db.DeletePracticeQueueEntry(entry2.Id))
+	entries, _ = db.GetAllPracticeQueueEntries()
+	assert.Equal(t, []PracticeQueueEntry{entry1, entry3}, entries)
+
+	assert.Nil(t, db.TruncatePracticeQueueEntries())
+	entries, _ = db.GetAllPracticeQueueEntries()
+	assert.Empty(t, entries)
+}
@@ -0,0 +1,281 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Publishing of committed table mutations so that they can be replicated to a hot-standby instance.
+
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go.etcd.io/bbolt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Number of mutations that a subscriber may fall behind by before it is disconnected and must resynchronize.
+const mutationBufferSize = 1000
+
+// Tables whose records belong to the instance they were written on; they are neither published to nor overwritten on a
+// standby, and may still be written to while replicating from a primary so that the standby can be administered and
+// promoted with its own login sessions.
+var localTables = map[string]struct{}{"UserSession": {}}
+
+// ErrReadOnly is returned when writing to a database that is replicating from a primary.
+var ErrReadOnly = errors.New("the database is read-only while this instance is replicating from a primary")
+
+type MutationOperation string
+
+const (
+	PutMutation      MutationOperation = "put"
+	DeleteMutation   MutationOperation = "delete"
+	TruncateMutation MutationOperation = "truncate"
+)
+
+// Represents a single committed change to a table, in a form that can be applied verbatim to another database.
+type TableMutation struct {
+	Sequence  int
+	Table     string
+	Operation MutationOperation
+	Key       string
+	Value     json.RawMessage
+	// The table's ID sequence after the mutation, so that the next autogenerated ID is the same on every instance.
+	IdSequence uint64
+}
+
+// Represents the full state of the database at a given mutation sequence number, from which a subscriber can begin
+// applying subsequent mutations.
+type DatabaseSnapshot struct {
+	Sequence int
+	Data     []byte
+}
+
+// Runs the given function within a read-write transaction on the given table and, once the transaction has been
+// committed, publishes the mutation it returns (if any) to all subscribers.
+func (database *Database) updateAndPublish(
+	tableName string, mutationFunc func(tx *bbolt.Tx) (*TableMutation, error),
+) error {
+	// Hold the lock across the commit so that mutations are published in the same order they were applied.
+	database.mutationMutex.Lock()
+	defer database.mutationMutex.Unlock()
+
+	_, isLocal := localTables[tableName]
+	if database.readOnly && !isLocal {
+		return ErrReadOnly
+	}
+
+	var mutation *TableMutation
+	err := database.bolt.Update(
+		func(tx *bbolt.Tx) error {
+			var err error
+			mutation, err = mutationFunc(tx)
+			if err == nil && mutation != nil {
+				mutation.IdSequence = tx.Bucket([]byte(tableName)).Sequence()
+			}
+			return err
+		},
+	)
+	if err != nil || mutation == nil || isLocal {
+		return err
+	}
+
+	database.mutationSequence++
+	mutation.Sequence = database.mutationSequence
+	for subscriber := range database.mutationSubscribers {
+		select {
+		case subscriber <- *mutation:
+		default:
+			// The subscriber has fallen too far behind; drop it so that it reconnects and takes a fresh snapshot.
+			database.removeSubscriber(subscriber)
+		}
+	}
+	return nil
+}
+
+// Takes a snapshot of the database and registers a channel that will receive every mutation committed after it. The
+// channel is closed if the subscriber falls too far behind or the database is closed.
+func (database *Database) SubscribeToMutations() (*DatabaseSnapshot, chan TableMutation, error) {
+	database.mutationMutex.Lock()
+	defer database.mutationMutex.Unlock()
+
+	var buffer bytes.Buffer
+	if err := database.WriteBackup(&buffer); err != nil {
+		return nil, nil, err
+	}
+	subscriber := make(chan TableMutation, mutationBufferSize)
+	database.mutationSubscribers[subscriber] = struct{}{}
+	return &DatabaseSnapshot{Sequence: database.mutationSequence, Data: buffer.Bytes()}, subscriber, nil
+}
+
+// SetReadOnly sets whether writes to the database, other than those of replicated mutations and snapshots, should be
+// rejected with ErrReadOnly.
+func (database *Database) SetReadOnly(readOnly bool) {
+	database.mutationMutex.Lock()
+	defer database.mutationMutex.Unlock()
+	database.readOnly = readOnly
+}
+
+// Deregisters and closes the given mutation subscriber channel, if it is still registered.
+func (database *Database) UnsubscribeFromMutations(subscriber chan TableMutation) {
+	database.mutationMutex.Lock()
+	defer database.mutationMutex.Unlock()
+	database.removeSubscriber(subscriber)
+}
+
+// Applies a mutation received from another database verbatim, keeping the table's indexes up to date, without
+// publishing it. Mutations to local tables are ignored.
+func (database *Database) ApplyMutation(mutation TableMutation) error {
+	if _, ok := localTables[mutation.Table]; ok {
+		return nil
+	}
+	return database.bolt.Update(
+		func(tx *bbolt.Tx) error {
+			bucketKey := []byte(mutation.Table)
+			bucket := tx.Bucket(bucketKey)
+			if bucket == nil {
+				return fmt.Errorf("unknown table %s", mutation.Table)
+			}
+
+			table, ok := database.tables[mutation.Table]
+			if !ok {
+				return fmt.Errorf("unknown table %s", mutation.Table)
+			}
+			key := []byte(mutation.Key)
+			oldValue := bytes.Clone(bucket.Get(key))
+
+			switch mutation.Operation {
+			case PutMutation:
+				if err := table.updateIndexesFromJson(tx, key, oldValue, mutation.Value); err != nil {
+					return err
+				}
+				if err := bucket.Put(key, mutation.Value); err != nil {
+					return err
+				}
+			case DeleteMutation:
+				if oldValue != nil {
+					if err := table.updateIndexesFromJson(tx, key, oldValue, nil); err != nil {
+						return err
+					}
+				}
+				if err := bucket.Delete(key); err != nil {
+					return err
+				}
+			case TruncateMutation:
+				if err := tx.DeleteBucket(bucketKey); err != nil {
+					return err
+				}
+				var err error
+				if bucket, err = tx.CreateBucket(bucketKey); err != nil {
+					return err
+				}
+				if err = table.clearIndexes(tx); err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("invalid mutation operation %q", mutation.Operation)
+			}
+
+			// Mirror the primary's ID sequence so that records created after a promotion don't collide.
+			return bucket.SetSequence(mutation.IdSequence)
+		},
+	)
+}
+
+// LoadSnapshot replaces the entire contents of the database, other than the local tables, with those of the given Bolt
+// database file, in a single transaction so that concurrent readers and writers see either the old or the new contents
+// and the database never needs to be closed.
+func (database *Database) LoadSnapshot(data []byte) error {
+	tempFile, err := os.CreateTemp(filepath.Dir(database.Path), "replicated-db-")
+	if err != nil {
+		return err
+	}
+	tempFilePath := tempFile.Name()
+	defer os.Remove(tempFilePath)
+	_, err = tempFile.Write(data)
+	tempFile.Close()
+	if err != nil {
+		return err
+	}
+	snapshot, err := bbolt.Open(tempFilePath, 0644, &bbolt.Options{ReadOnly: true, Timeout: time.Second})
+	if err != nil {
+		return err
+	}
+	defer snapshot.Close()
+
+	return snapshot.View(
+		func(snapshotTx *bbolt.Tx) error {
+			return database.bolt.Update(
+				func(tx *bbolt.Tx) error {
+					var bucketKeys [][]byte
+					err := tx.ForEach(
+						func(name []byte, _ *bbolt.Bucket) error {
+							if !isLocalBucket(name) {
+								bucketKeys = append(bucketKeys, bytes.Clone(name))
+							}
+							return nil
+						},
+					)
+					if err != nil {
+						return err
+					}
+					for _, bucketKey := range bucketKeys {
+						if err = tx.DeleteBucket(bucketKey); err != nil {
+							return err
+						}
+					}
+
+					return snapshotTx.ForEach(
+						func(name []byte, snapshotBucket *bbolt.Bucket) error {
+							if isLocalBucket(name) {
+								return nil
+							}
+							bucket, err := tx.CreateBucket(name)
+							if err != nil {
+								return err
+							}
+							return copyBucket(snapshotBucket, bucket)
+						},
+					)
+				},
+			)
+		},
+	)
+}
+
+// Recursively copies all keys, nested buckets, and the ID sequence of the source bucket into the destination bucket.
+func copyBucket(source, destination *bbolt.Bucket) error {
+	err := source.ForEach(
+		func(key, value []byte) error {
+			if value == nil {
+				nestedBucket, err := destination.CreateBucket(key)
+				if err != nil {
+					return err
+				}
+				return copyBucket(source.Bucket(key), nestedBucket)
+			}
+			return destination.Put(key, value)
+		},
+	)
+	if err != nil {
+		return err
+	}
+	return destination.SetSequence(source.Sequence())
+}
+
+// Returns whether the given Bolt bucket holds the records or indexes of one of the local tables.
+func isLocalBucket(name []byte) bool {
+	tableName, _, _ := strings.Cut(string(name), ":")
+	_, ok := localTables[tableName]
+	return ok
+}
+
+func (database *Database) removeSubscriber(subscriber chan TableMutation) {
+	if _, ok := database.mutationSubscribers[subscriber]; ok {
+		delete(database.mutationSubscribers, subscriber)
+		close(subscriber)
+	}
+}
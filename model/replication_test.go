@@ -0,0 +1,171 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReplicateMutations(t *testing.T) {
+	primary := setupTestDb(t)
+	assert.Nil(t, primary.CreateTeam(&Team{Id: 254, Nickname: "The Cheesy Poofs"}))
+	assert.Nil(t, primary.CreateAward(&Award{Type: WinnerAward, AwardName: "Winner"}))
+
+	snapshot, mutations, err := primary.SubscribeToMutations()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, snapshot.Sequence)
+
+	// Load the snapshot into a new database as a secondary would.
+	secondaryPath := filepath.Join(t.TempDir(), "secondary.db")
+	assert.Nil(t, os.WriteFile(secondaryPath, snapshot.Data, 0644))
+	secondary, err := OpenDatabase(secondaryPath)
+	assert.Nil(t, err)
+	defer secondary.Close()
+	team, _ := secondary.GetTeamById(254)
+	assert.Equal(t, "The Cheesy Poofs", team.Nickname)
+
+	assert.Nil(t, primary.UpdateTeam(&Team{Id: 254, Nickname: "Teh Chezy Pofs"}))
+	assert.Nil(t, primary.CreateTeam(&Team{Id: 1114}))
+	assert.Nil(t, primary.DeleteTeam(1114))
+	assert.Nil(t, primary.CreateAward(&Award{Type: FinalistAward, AwardName: "Finalist"}))
+	assert.Nil(t, primary.TruncateAlliances())
+	assert.NotNil(t, primary.DeleteTeam(1114))
+	for i := 3; i <= 7; i++ {
+		mutation := <-mutations
+		assert.Equal(t, i, mutation.Sequence)
+		assert.Nil(t, secondary.ApplyMutation(mutation))
+	}
+	assert.Empty(t, mutations)
+
+	teams, _ := secondary.GetAllTeams()
+	assert.Equal(t, []Team{{Id: 254, Nickname: "Teh Chezy Pofs"}}, teams)
+	awards, _ := secondary.GetAllAwards()
+	assert.Equal(t, 2, len(awards))
+
+	// Check that the secondary's indexes are kept up to date.
+	sam := User{Username: "sam", Role: ScorerRole, PasswordHash: "hash"}
+	assert.Nil(t, primary.CreateUser(&sam))
+	assert.Nil(t, primary.CreateUser(&User{Username: "alex", Role: AdminRole, PasswordHash: "hash"}))
+	assert.Nil(t, primary.DeleteUser(sam.Id))
+	for i := 8; i <= 10; i++ {
+		assert.Nil(t, secondary.ApplyMutation(<-mutations))
+	}
+	user, _ := secondary.GetUserByUsername("sam")
+	assert.Nil(t, user)
+	user, _ = secondary.GetUserByUsername("alex")
+	if assert.NotNil(t, user) {
+		assert.Equal(t, AdminRole, user.Role)
+	}
+	assert.Nil(t, primary.TruncateUsers())
+	assert.Nil(t, secondary.ApplyMutation(<-mutations))
+	user, _ = secondary.GetUserByUsername("alex")
+	assert.Nil(t, user)
+
+	// Check that the local tables are neither published nor applied.
+	assert.Nil(t, primary.CreateUserSession(&UserSession{Token: "token1", Username: "admin"}))
+	assert.Empty(t, mutations)
+	sessionMutation := TableMutation{
+		Table:      "UserSession",
+		Operation:  PutMutation,
+		Key:        "1",
+		Value:      []byte("{\"Id\":1,\"Token\":\"token1\",\"Username\":\"admin\"}"),
+		IdSequence: 1,
+	}
+	assert.Nil(t, secondary.ApplyMutation(sessionMutation))
+	userSession, _ := secondary.GetUserSessionByToken("token1")
+	assert.Nil(t, userSession)
+
+	// Check that the secondary mirrors the primary's ID sequences, including across truncations.
+	assert.Nil(t, primary.TruncateAwards())
+	assert.Nil(t, secondary.ApplyMutation(<-mutations))
+	assert.Nil(t, primary.CreateAward(&Award{Type: JudgedAward, AwardName: "Spirit"}))
+	assert.Nil(t, secondary.ApplyMutation(<-mutations))
+	award := Award{Type: JudgedAward, AwardName: "Imagery"}
+	assert.Nil(t, primary.CreateAward(&award))
+	<-mutations
+	secondaryAward := Award{Type: JudgedAward, AwardName: "Imagery"}
+	assert.Nil(t, secondary.CreateAward(&secondaryAward))
+	assert.Equal(t, award.Id, secondaryAward.Id)
+
+	assert.NotNil(t, secondary.ApplyMutation(TableMutation{Table: "Bogus", Operation: PutMutation, Key: "1"}))
+	assert.NotNil(t, secondary.ApplyMutation(TableMutation{Table: "Team", Operation: "bogus", Key: "1"}))
+
+	// Check that unsubscribing and closing the database close the channel.
+	primary.UnsubscribeFromMutations(mutations)
+	_, ok := <-mutations
+	assert.False(t, ok)
+	_, mutations, _ = primary.SubscribeToMutations()
+	primary.Close()
+	_, ok = <-mutations
+	assert.False(t, ok)
+}
+
+func TestReadOnlyDatabase(t *testing.T) {
+	database := setupTestDb(t)
+	database.SetReadOnly(true)
+	assert.Equal(t, ErrReadOnly, database.CreateTeam(&Team{Id: 254}))
+	assert.Nil(t, database.CreateUserSession(&UserSession{Token: "token1", Username: "admin"}))
+	assert.Nil(
+		t,
+		database.ApplyMutation(
+			TableMutation{Table: "Team", Operation: PutMutation, Key: "254", Value: []byte("{\"Id\":254}")},
+		),
+	)
+	team, _ := database.GetTeamById(254)
+	assert.NotNil(t, team)
+
+	database.SetReadOnly(false)
+	assert.Nil(t, database.CreateTeam(&Team{Id: 1114}))
+}
+
+func TestLoadSnapshot(t *testing.T) {
+	primary := setupTestDb(t)
+	assert.Nil(t, primary.CreateTeam(&Team{Id: 254}))
+	assert.Nil(t, primary.CreateUserSession(&UserSession{Token: "token1", Username: "admin"}))
+	assert.Nil(t, primary.CreateAward(&Award{Type: WinnerAward, AwardName: "Winner"}))
+	snapshot, _, err := primary.SubscribeToMutations()
+	assert.Nil(t, err)
+
+	secondary := setupTestDb(t)
+	assert.Nil(t, secondary.CreateTeam(&Team{Id: 1114}))
+	assert.Nil(t, secondary.CreateUserSession(&UserSession{Token: "token2", Username: "admin"}))
+	assert.Nil(t, secondary.CreateUserSession(&UserSession{Token: "token3", Username: "admin"}))
+	assert.Nil(t, secondary.LoadSnapshot(snapshot.Data))
+	teams, _ := secondary.GetAllTeams()
+	assert.Equal(t, []Team{{Id: 254}}, teams)
+
+	// Check that the secondary keeps its own login sessions and their ID sequence.
+	userSession, _ := secondary.GetUserSessionByToken("token1")
+	assert.Nil(t, userSession)
+	userSession, _ = secondary.GetUserSessionByToken("token2")
+	assert.NotNil(t, userSession)
+	userSession = &UserSession{Token: "token4", Username: "admin"}
+	assert.Nil(t, secondary.CreateUserSession(userSession))
+	assert.Equal(t, 3, userSession.Id)
+	award := Award{Type: FinalistAward, AwardName: "Finalist"}
+	assert.Nil(t, secondary.CreateAward(&award))
+	assert.Equal(t, 2, award.Id)
+
+	assert.NotNil(t, secondary.LoadSnapshot([]byte("bogus")))
+}
+
+func TestSlowMutationSubscriberIsDropped(t *testing.T) {
+	database := setupTestDb(t)
+	_, mutations, err := database.SubscribeToMutations()
+	assert.Nil(t, err)
+
+	for i := 1; i <= mutationBufferSize+1; i++ {
+		assert.Nil(t, database.CreateTeam(&Team{Id: i}))
+	}
+	for i := 1; i <= mutationBufferSize; i++ {
+		mutation, ok := <-mutations
+		assert.True(t, ok)
+		assert.Equal(t, i, mutation.Sequence)
+	}
+	_, ok := <-mutations
+	assert.False(t, ok)
+}
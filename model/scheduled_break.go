@@ -6,7 +6,6 @@
 package model
 
 import (
-	"sort"
 	"time"
 )
 
@@ -32,25 +31,7 @@ func (database *Database) UpdateScheduledBreak(scheduledBreak *ScheduledBreak) e
 }
 
 func (database *Database) GetScheduledBreaksByMatchType(matchType MatchType) ([]ScheduledBreak, error) {
-	scheduledBreaks, err := database.scheduledBreakTable.getAll()
-	if err != nil {
-		return nil, err
-	}
-
-	var matchingScheduledBreaks []ScheduledBreak
-	for _, scheduledBreak := range scheduledBreaks {
-		if scheduledBreak.MatchType == matchType {
-			matchingScheduledBreaks = append(matchingScheduledBreaks, scheduledBreak)
-		}
-	}
-
-	sort.Slice(
-		matchingScheduledBreaks,
-		func(i, j int) bool {
-			return matchingScheduledBreaks[i].TypeOrderBefore < matchingScheduledBreaks[j].TypeOrderBefore
-		},
-	)
-	return matchingScheduledBreaks, nil
+	return database.scheduledBreakTable.getByIndex("MatchType,TypeOrderBefore", matchType)
 }
 
 func (database *Database) GetScheduledBreakByMatchTypeOrder(
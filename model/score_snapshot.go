@@ -0,0 +1,48 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Model and datastore CRUD methods for the periodic snapshots of the realtime score taken while a match is in play.
+
+package model
+
+import (
+	"github.com/Team254/cheesy-arena/game"
+)
+
+type ScoreSnapshot struct {
+	Id           int `db:"id"`
+	MatchId      int
+	MatchTimeSec float64
+	MatchState   int
+	RedScore     game.Score
+	BlueScore    game.Score
+	RedCards     map[string]string
+	BlueCards    map[string]string
+}
+
+func (database *Database) CreateScoreSnapshot(scoreSnapshot *ScoreSnapshot) error {
+	return database.scoreSnapshotTable.create(scoreSnapshot)
+}
+
+// Returns all snapshots recorded for the given match, in chronological order.
+func (database *Database) GetScoreSnapshotsForMatch(matchId int) ([]ScoreSnapshot, error) {
+	return database.scoreSnapshotTable.getByIndex("MatchId", matchId)
+}
+
+// Deletes all snapshots recorded for the given match.
+func (database *Database) DeleteScoreSnapshotsForMatch(matchId int) error {
+	scoreSnapshots, err := database.GetScoreSnapshotsForMatch(matchId)
+	if err != nil {
+		return err
+	}
+	for _, scoreSnapshot := range scoreSnapshots {
+		if err = database.scoreSnapshotTable.delete(scoreSnapshot.Id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (database *Database) TruncateScoreSnapshots() error {
+	return database.scoreSnapshotTable.truncate()
+}
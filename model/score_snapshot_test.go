@@ -0,0 +1,47 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package model
+
+import (
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestScoreSnapshotCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	scoreSnapshot1 := ScoreSnapshot{
+		MatchId:      254,
+		MatchTimeSec: 1,
+		MatchState:   3,
+		RedScore:     *game.TestScore1(),
+		BlueScore:    *game.TestScore2(),
+		RedCards:     map[string]string{"1868": "yellow"},
+		BlueCards:    map[string]string{},
+	}
+	assert.Nil(t, db.CreateScoreSnapshot(&scoreSnapshot1))
+	scoreSnapshot2 := ScoreSnapshot{MatchId: 1114, MatchTimeSec: 1}
+	assert.Nil(t, db.CreateScoreSnapshot(&scoreSnapshot2))
+	scoreSnapshot3 := ScoreSnapshot{MatchId: 254, MatchTimeSec: 2, MatchState: 3}
+	assert.Nil(t, db.CreateScoreSnapshot(&scoreSnapshot3))
+
+	scoreSnapshots, err := db.GetScoreSnapshotsForMatch(254)
+	assert.Nil(t, err)
+	assert.Equal(t, []ScoreSnapshot{scoreSnapshot1, scoreSnapshot3}, scoreSnapshots)
+
+	assert.Nil(t, db.DeleteScoreSnapshotsForMatch(254))
+	scoreSnapshots, err = db.GetScoreSnapshotsForMatch(254)
+	assert.Nil(t, err)
+	assert.Empty(t, scoreSnapshots)
+	scoreSnapshots, err = db.GetScoreSnapshotsForMatch(1114)
+	assert.Nil(t, err)
+	assert.Equal(t, []ScoreSnapshot{scoreSnapshot2}, scoreSnapshots)
+
+	assert.Nil(t, db.TruncateScoreSnapshots())
+	scoreSnapshots, err = db.GetScoreSnapshotsForMatch(1114)
+	assert.Nil(t, err)
+	assert.Empty(t, scoreSnapshots)
+}
@@ -16,16 +16,19 @@ import (
 
 // Encapsulates all persistence operations for a particular data type represented by a struct.
 type table[R any] struct {
+	database     *Database
 	bolt         *bbolt.DB
 	recordType   reflect.Type
 	name         string
 	bucketKey    []byte
 	idFieldIndex *int
 	manualId     bool
+	indexes      map[string]*tableIndex
 }
 
-// Registers a new table for a struct.
-func newTable[R any](database *Database) (*table[R], error) {
+// Registers a new table for a struct, along with a secondary index for each of the given comma-separated lists of
+// field names (e.g. "Type,TypeOrder").
+func newTable[R any](database *Database, indexSpecs ...string) (*table[R], error) {
 	var recordType R
 	recordTypeValue := reflect.ValueOf(recordType)
 	if recordTypeValue.Kind() != reflect.Struct {
@@ -33,6 +36,7 @@ func newTable[R any](database *Database) (*table[R], error) {
 	}
 
 	var table table[R]
+	table.database = database
 	table.bolt = database.bolt
 	table.recordType = reflect.TypeOf(recordType)
 	table.name = table.recordType.Name()
@@ -64,17 +68,35 @@ func newTable[R any](database *Database) (*table[R], error) {
 		return nil, fmt.Errorf("struct %s has no field tagged as the id", table.name)
 	}
 
-	// Create the Bolt bucket corresponding to the struct.
+	table.indexes = make(map[string]*tableIndex)
+	for _, indexSpec := range indexSpecs {
+		index, err := table.newIndex(indexSpec)
+		if err != nil {
+			return nil, err
+		}
+		table.indexes[indexSpec] = index
+	}
+
+	// Create the Bolt bucket corresponding to the struct, and build its indexes if any of them don't exist yet (e.g.
+	// because they were added in a newer version of the software). Migrations rebuild all indexes separately.
 	err := table.bolt.Update(
 		func(tx *bbolt.Tx) error {
-			_, err := tx.CreateBucketIfNotExists(table.bucketKey)
-			return err
+			if _, err := tx.CreateBucketIfNotExists(table.bucketKey); err != nil {
+				return err
+			}
+			for _, index := range table.indexes {
+				if tx.Bucket(index.bucketKey) == nil {
+					return table.rebuildIndexes(tx)
+				}
+			}
+			return nil
 		},
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	database.tables[table.name] = &table
 	return &table, nil
 }
 
@@ -140,18 +162,19 @@ func (table *table[R]) create(record *R) error {
 		)
 	}
 
-	return table.bolt.Update(
-		func(tx *bbolt.Tx) error {
+	return table.database.updateAndPublish(
+		table.name,
+		func(tx *bbolt.Tx) (*TableMutation, error) {
 			bucket, err := table.getBucket(tx)
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			if !table.manualId {
 				// Generate a new ID for the record.
 				newSequence, err := bucket.NextSequence()
 				if err != nil {
-					return err
+					return nil, err
 				}
 				id = int(newSequence)
 				value.Field(*table.idFieldIndex).SetInt(int64(id))
@@ -161,14 +184,13 @@ func (table *table[R]) create(record *R) error {
 			key := idToKey(id)
 			oldRecord := bucket.Get(key)
 			if oldRecord != nil {
-				return fmt.Errorf("%s with ID %d already exists: %s", table.name, id, string(oldRecord))
+				return nil, fmt.Errorf("%s with ID %d already exists: %s", table.name, id, string(oldRecord))
 			}
 
-			recordJson, err := json.Marshal(record)
-			if err != nil {
-				return err
+			if err = table.updateIndexes(tx, id, nil, record); err != nil {
+				return nil, err
 			}
-			return bucket.Put(key, recordJson)
+			return table.put(bucket, key, record)
 		},
 	)
 }
@@ -183,70 +205,95 @@ func (table *table[R]) update(record *R) error {
 		return fmt.Errorf("can't update %s with zero ID", table.name)
 	}
 
-	return table.bolt.Update(
-		func(tx *bbolt.Tx) error {
+	return table.database.updateAndPublish(
+		table.name,
+		func(tx *bbolt.Tx) (*TableMutation, error) {
 			bucket, err := table.getBucket(tx)
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			// Ensure that a record having the same ID exists in the table.
 			key := idToKey(id)
 			oldRecord := bucket.Get(key)
 			if oldRecord == nil {
-				return fmt.Errorf("can't update non-existent %s with ID %d", table.name, id)
+				return nil, fmt.Errorf("can't update non-existent %s with ID %d", table.name, id)
 			}
 
-			recordJson, err := json.Marshal(record)
-			if err != nil {
-				return err
+			if err = table.updateIndexes(tx, id, oldRecord, record); err != nil {
+				return nil, err
 			}
-			return bucket.Put(key, recordJson)
+			return table.put(bucket, key, record)
 		},
 	)
 }
 
 // Deletes the record having the given ID from the table. Returns an error if the record does not exist.
 func (table *table[R]) delete(id int) error {
-	return table.bolt.Update(
-		func(tx *bbolt.Tx) error {
+	return table.database.updateAndPublish(
+		table.name,
+		func(tx *bbolt.Tx) (*TableMutation, error) {
 			bucket, err := table.getBucket(tx)
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			// Ensure that a record having the same ID exists in the table.
 			key := idToKey(id)
 			oldRecord := bucket.Get(key)
 			if oldRecord == nil {
-				return fmt.Errorf("can't delete non-existent %s with ID %d", table.name, id)
+				return nil, fmt.Errorf("can't delete non-existent %s with ID %d", table.name, id)
 			}
 
-			return bucket.Delete(key)
+			if err = table.updateIndexes(tx, id, oldRecord, nil); err != nil {
+				return nil, err
+			}
+			if err = bucket.Delete(key); err != nil {
+				return nil, err
+			}
+			return &TableMutation{Table: table.name, Operation: DeleteMutation, Key: string(key)}, nil
 		},
 	)
 }
 
 // Deletes all records from the table.
 func (table *table[R]) truncate() error {
-	return table.bolt.Update(
-		func(tx *bbolt.Tx) error {
+	return table.database.updateAndPublish(
+		table.name,
+		func(tx *bbolt.Tx) (*TableMutation, error) {
 			_, err := table.getBucket(tx)
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			// Carry out the truncation by way of deleting the whole bucket and then recreate it.
 			err = tx.DeleteBucket(table.bucketKey)
 			if err != nil {
-				return err
+				return nil, err
+			}
+			if _, err = tx.CreateBucket(table.bucketKey); err != nil {
+				return nil, err
 			}
-			_, err = tx.CreateBucket(table.bucketKey)
-			return err
+			if err = table.clearIndexes(tx); err != nil {
+				return nil, err
+			}
+			return &TableMutation{Table: table.name, Operation: TruncateMutation}, nil
 		},
 	)
 }
 
+// Serializes and writes the given record to the given key in the bucket, returning the corresponding mutation.
+func (table *table[R]) put(bucket *bbolt.Bucket, key []byte, record *R) (*TableMutation, error) {
+	recordJson, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	if err = bucket.Put(key, recordJson); err != nil {
+		return nil, err
+	}
+	return &TableMutation{Table: table.name, Operation: PutMutation, Key: string(key), Value: recordJson}, nil
+}
+
 // Obtains the Bolt bucket belonging to the table.
 func (table *table[R]) getBucket(tx *bbolt.Tx) (*bbolt.Bucket, error) {
 	bucket := tx.Bucket(table.bucketKey)
@@ -0,0 +1,259 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Secondary indexes on a table, maintained in separate Bolt buckets alongside the records they index.
+
+package model
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"go.etcd.io/bbolt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Represents a secondary index on one or more fields of a table's struct. Each index entry is a key formed from the
+// encoded field values followed by the record ID, so that a cursor scan returns records ordered by the indexed fields
+// and then by ID.
+type tableIndex struct {
+	name         string
+	bucketKey    []byte
+	fieldIndices []int
+	fieldTypes   []reflect.Type
+}
+
+// Common interface for tables of any record type, used for maintaining indexes on changes that are applied to the
+// database outside of the table's own methods.
+type indexedTable interface {
+	updateIndexesFromJson(tx *bbolt.Tx, key, oldRecordJson, newRecordJson []byte) error
+	clearIndexes(tx *bbolt.Tx) error
+	rebuildIndexes(tx *bbolt.Tx) error
+}
+
+// Parses the given comma-separated list of field names (e.g. "Type,TypeOrder") into an index on the table.
+func (table *table[R]) newIndex(indexSpec string) (*tableIndex, error) {
+	index := tableIndex{name: indexSpec, bucketKey: []byte(fmt.Sprintf("%s:%s", table.name, indexSpec))}
+	for _, fieldName := range strings.Split(indexSpec, ",") {
+		field, ok := table.recordType.FieldByName(fieldName)
+		if !ok || len(field.Index) != 1 {
+			return nil, fmt.Errorf("struct %s has no field %q to index", table.name, fieldName)
+		}
+		if indexKindClass(field.Type.Kind()) == "" {
+			return nil, fmt.Errorf(
+				"field %s in struct %s has unindexable type %v", fieldName, table.name, field.Type.Kind(),
+			)
+		}
+		index.fieldIndices = append(index.fieldIndices, field.Index[0])
+		index.fieldTypes = append(index.fieldTypes, field.Type)
+	}
+	return &index, nil
+}
+
+// Returns the records whose leading indexed fields equal the given values, ordered by the remaining indexed fields and
+// then by ID. Fewer values than there are fields in the index may be given in order to match on a prefix.
+func (table *table[R]) getByIndex(indexName string, values ...any) ([]R, error) {
+	index, ok := table.indexes[indexName]
+	if !ok {
+		return nil, fmt.Errorf("table %s has no index %q", table.name, indexName)
+	}
+	if len(values) > len(index.fieldTypes) {
+		return nil, fmt.Errorf(
+			"index %q on table %s has %d fields; got %d values", indexName, table.name, len(index.fieldTypes),
+			len(values),
+		)
+	}
+	var prefix []byte
+	for i, value := range values {
+		reflectValue := reflect.ValueOf(value)
+		fieldType := index.fieldTypes[i]
+		if !reflectValue.IsValid() || indexKindClass(reflectValue.Kind()) != indexKindClass(fieldType.Kind()) {
+			return nil, fmt.Errorf(
+				"value %v for index %q on table %s must be of type %v", value, indexName, table.name, fieldType,
+			)
+		}
+		prefix = appendIndexValue(prefix, reflectValue.Convert(fieldType))
+	}
+
+	records := []R{}
+	err := table.bolt.View(
+		func(tx *bbolt.Tx) error {
+			bucket, err := table.getBucket(tx)
+			if err != nil {
+				return err
+			}
+			indexBucket := tx.Bucket(index.bucketKey)
+			if indexBucket == nil {
+				return fmt.Errorf("unknown index %s", index.bucketKey)
+			}
+
+			cursor := indexBucket.Cursor()
+			for indexKey, _ := cursor.Seek(prefix); indexKey != nil && bytes.HasPrefix(indexKey, prefix); indexKey, _ =
+				cursor.Next() {
+				id := int(binary.BigEndian.Uint64(indexKey[len(indexKey)-8:]))
+				recordJson := bucket.Get(idToKey(id))
+				if recordJson == nil {
+					return fmt.Errorf("index %s refers to non-existent %s with ID %d", index.bucketKey, table.name, id)
+				}
+				var record R
+				if err = json.Unmarshal(recordJson, &record); err != nil {
+					return err
+				}
+				records = append(records, record)
+			}
+			return nil
+		},
+	)
+	return records, err
+}
+
+// Updates the index entries for the record having the given ID to reflect its change from the given old serialized
+// value (nil if it is being created) to the given new value (nil if it is being deleted).
+func (table *table[R]) updateIndexes(tx *bbolt.Tx, id int, oldRecordJson []byte, newRecord *R) error {
+	if len(table.indexes) == 0 {
+		return nil
+	}
+
+	var oldRecord *R
+	if oldRecordJson != nil {
+		oldRecord = new(R)
+		if err := json.Unmarshal(oldRecordJson, oldRecord); err != nil {
+			return err
+		}
+	}
+	for _, index := range table.indexes {
+		indexBucket := tx.Bucket(index.bucketKey)
+		if indexBucket == nil {
+			return fmt.Errorf("unknown index %s", index.bucketKey)
+		}
+		if oldRecord != nil {
+			if err := indexBucket.Delete(index.key(reflect.ValueOf(oldRecord).Elem(), id)); err != nil {
+				return err
+			}
+		}
+		if newRecord != nil {
+			if err := indexBucket.Put(index.key(reflect.ValueOf(newRecord).Elem(), id), []byte{}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Updates the index entries for a record that was changed by writing serialized JSON directly to the table's bucket.
+func (table *table[R]) updateIndexesFromJson(tx *bbolt.Tx, key, oldRecordJson, newRecordJson []byte) error {
+	if len(table.indexes) == 0 {
+		return nil
+	}
+
+	id, err := strconv.Atoi(string(key))
+	if err != nil {
+		return err
+	}
+	var newRecord *R
+	if newRecordJson != nil {
+		newRecord = new(R)
+		if err = json.Unmarshal(newRecordJson, newRecord); err != nil {
+			return err
+		}
+	}
+	return table.updateIndexes(tx, id, oldRecordJson, newRecord)
+}
+
+// Deletes all entries from the table's indexes.
+func (table *table[R]) clearIndexes(tx *bbolt.Tx) error {
+	for _, index := range table.indexes {
+		if tx.Bucket(index.bucketKey) != nil {
+			if err := tx.DeleteBucket(index.bucketKey); err != nil {
+				return err
+			}
+		}
+		if _, err := tx.CreateBucket(index.bucketKey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Recreates the table's indexes from scratch using the records currently in the table.
+func (table *table[R]) rebuildIndexes(tx *bbolt.Tx) error {
+	if len(table.indexes) == 0 {
+		return nil
+	}
+
+	if err := table.clearIndexes(tx); err != nil {
+		return err
+	}
+	bucket, err := table.getBucket(tx)
+	if err != nil {
+		return err
+	}
+	return bucket.ForEach(
+		func(key, value []byte) error {
+			return table.updateIndexesFromJson(tx, key, nil, value)
+		},
+	)
+}
+
+// Recreates the indexes of every table, for use after records have been modified in bulk outside of the table layer.
+func (database *Database) rebuildIndexes() error {
+	return database.bolt.Update(
+		func(tx *bbolt.Tx) error {
+			for _, table := range database.tables {
+				if err := table.rebuildIndexes(tx); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	)
+}
+
+// Returns the index entry key for the given record.
+func (index *tableIndex) key(record reflect.Value, id int) []byte {
+	var key []byte
+	for _, fieldIndex := range index.fieldIndices {
+		key = appendIndexValue(key, record.Field(fieldIndex))
+	}
+	return binary.BigEndian.AppendUint64(key, uint64(id))
+}
+
+// Appends the given field value to the given index key, encoded such that keys sort in the same order as the values.
+func appendIndexValue(key []byte, value reflect.Value) []byte {
+	switch indexKindClass(value.Kind()) {
+	case "int":
+		// Flip the sign bit so that negative values sort before positive ones.
+		return binary.BigEndian.AppendUint64(key, uint64(value.Int())^(1<<63))
+	case "uint":
+		return binary.BigEndian.AppendUint64(key, value.Uint())
+	case "bool":
+		if value.Bool() {
+			return append(key, 1)
+		}
+		return append(key, 0)
+	default:
+		// Escape any zero bytes and terminate the string with a zero byte so that no string value is a prefix of
+		// another.
+		key = append(key, bytes.ReplaceAll([]byte(value.String()), []byte{0}, []byte{0, 0xff})...)
+		return append(key, 0, 0)
+	}
+}
+
+// Returns the class of values of the given kind that can be indexed, or a blank string if the kind is not indexable.
+func indexKindClass(kind reflect.Kind) string {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "int"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "uint"
+	case reflect.Bool:
+		return "bool"
+	case reflect.String:
+		return "string"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/bbolt"
+	"testing"
+)
+
+type indexedRecord struct {
+	Id         int `db:"id"`
+	IntData    int
+	StringData string
+	BoolData   bool
+	FloatData  float64
+}
+
+func TestTableIndexMaintenance(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	table, err := newTable[indexedRecord](db, "StringData,IntData", "BoolData")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	record1 := indexedRecord{IntData: 3, StringData: "a"}
+	record2 := indexedRecord{IntData: -5, StringData: "a", BoolData: true}
+	record3 := indexedRecord{IntData: 1, StringData: "a\x00"}
+	record4 := indexedRecord{IntData: 2, StringData: "b", BoolData: true}
+	for _, record := range []*indexedRecord{&record1, &record2, &record3, &record4} {
+		assert.Nil(t, table.create(record))
+	}
+
+	// Check matching on a prefix of the index fields, ordered by the remaining fields.
+	records, err := table.getByIndex("StringData,IntData", "a")
+	assert.Nil(t, err)
+	assert.Equal(t, []indexedRecord{record2, record1}, records)
+	records, _ = table.getByIndex("StringData,IntData", "a", 3)
+	assert.Equal(t, []indexedRecord{record1}, records)
+	records, _ = table.getByIndex("StringData,IntData", "a\x00")
+	assert.Equal(t, []indexedRecord{record3}, records)
+	records, _ = table.getByIndex("StringData,IntData")
+	assert.Equal(t, []indexedRecord{record2, record1, record3, record4}, records)
+	records, _ = table.getByIndex("BoolData", true)
+	assert.Equal(t, []indexedRecord{record2, record4}, records)
+	records, _ = table.getByIndex("StringData,IntData", "c")
+	assert.Equal(t, []indexedRecord{}, records)
+
+	// Check that updates and deletes are reflected in the index.
+	record1.StringData = "b"
+	assert.Nil(t, table.update(&record1))
+	assert.Nil(t, table.delete(record2.Id))
+	records, _ = table.getByIndex("StringData,IntData", "a")
+	assert.Equal(t, []indexedRecord{}, records)
+	records, _ = table.getByIndex("StringData,IntData", "b")
+	assert.Equal(t, []indexedRecord{record4, record1}, records)
+	records, _ = table.getByIndex("BoolData", true)
+	assert.Equal(t, []indexedRecord{record4}, records)
+
+	assert.Nil(t, table.truncate())
+	records, _ = table.getByIndex("StringData,IntData")
+	assert.Equal(t, []indexedRecord{}, records)
+	records, _ = table.getByIndex("BoolData", true)
+	assert.Equal(t, []indexedRecord{}, records)
+}
+
+func TestTableIndexErrors(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	_, err := newTable[indexedRecord](db, "Bogus")
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "struct indexedRecord has no field \"Bogus\" to index", err.Error())
+	}
+	_, err = newTable[indexedRecord](db, "FloatData")
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "field FloatData in struct indexedRecord has unindexable type float64", err.Error())
+	}
+
+	table, err := newTable[indexedRecord](db, "IntData")
+	assert.Nil(t, err)
+	_, err = table.getByIndex("StringData", "a")
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "table indexedRecord has no index \"StringData\"", err.Error())
+	}
+	_, err = table.getByIndex("IntData", 1, 2)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "index \"IntData\" on table indexedRecord has 1 fields; got 2 values", err.Error())
+	}
+	_, err = table.getByIndex("IntData", "1")
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "value 1 for index \"IntData\" on table indexedRecord must be of type int", err.Error())
+	}
+}
+
+func TestTableIndexRebuiltOnRegistration(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	// Populate the table before it has any indexes, as an older version of the software would have.
+	table, _ := newTable[indexedRecord](db)
+	record1 := indexedRecord{IntData: 2}
+	record2 := indexedRecord{IntData: 1}
+	assert.Nil(t, table.create(&record1))
+	assert.Nil(t, table.create(&record2))
+
+	table, err := newTable[indexedRecord](db, "IntData")
+	assert.Nil(t, err)
+	records, _ := table.getByIndex("IntData")
+	assert.Equal(t, []indexedRecord{record2, record1}, records)
+
+	// Corrupt the index and check that re-registering the table leaves an existing index alone, but that a full
+	// rebuild (as done after migrations) repairs it.
+	hasBogusEntry := func() bool {
+		var found bool
+		_ = db.bolt.View(
+			func(tx *bbolt.Tx) error {
+				found = tx.Bucket([]byte("indexedRecord:IntData")).Get([]byte("bogus")) != nil
+				return nil
+			},
+		)
+		return found
+	}
+	assert.Nil(
+		t,
+		db.bolt.Update(
+			func(tx *bbolt.Tx) error {
+				return tx.Bucket([]byte("indexedRecord:IntData")).Put([]byte("bogus"), []byte{})
+			},
+		),
+	)
+	table, err = newTable[indexedRecord](db, "IntData")
+	assert.Nil(t, err)
+	assert.True(t, hasBogusEntry())
+	assert.Nil(t, db.rebuildIndexes())
+	assert.False(t, hasBogusEntry())
+	records, _ = table.getByIndex("IntData")
+	assert.Equal(t, []indexedRecord{record2, record1}, records)
+}
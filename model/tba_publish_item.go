@@ -0,0 +1,48 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Model and datastore CRUD methods for the outbox of data waiting to be published to The Blue Alliance.
+
+package model
+
+import "time"
+
+// Represents one kind of data (e.g. "rankings") published to The Blue Alliance. There is at most one item per kind,
+// since each publish sends the complete current data and so supersedes any earlier one that hasn't gone out yet.
+type TbaPublishItem struct {
+	Id            int `db:"id"`
+	Type          string
+	Pending       bool
+	ClearExisting bool
+	QueuedAt      time.Time
+	Attempts      int
+	NextAttemptAt time.Time
+	LastAttemptAt time.Time
+	LastError     string
+	LastSuccessAt time.Time
+}
+
+func (database *Database) CreateTbaPublishItem(item *TbaPublishItem) error {
+	return database.tbaPublishItemTable.create(item)
+}
+
+// Returns the item for the given type of data, or nil if it has never been published.
+func (database *Database) GetTbaPublishItemByType(publishType string) (*TbaPublishItem, error) {
+	items, err := database.tbaPublishItemTable.getByIndex("Type", publishType)
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+	return &items[0], nil
+}
+
+func (database *Database) GetAllTbaPublishItems() ([]TbaPublishItem, error) {
+	return database.tbaPublishItemTable.getAll()
+}
+
+func (database *Database) UpdateTbaPublishItem(item *TbaPublishItem) error {
+	return database.tbaPublishItemTable.update(item)
+}
+
+func (database *Database) TruncateTbaPublishItems() error {
+	return database.tbaPublishItemTable.truncate()
+}
@@ -0,0 +1,37 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTbaPublishItemCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	item, err := db.GetTbaPublishItemByType("rankings")
+	assert.Nil(t, err)
+	assert.Nil(t, item)
+
+	item1 := TbaPublishItem{Type: "rankings", Pending: true}
+	item2 := TbaPublishItem{Type: "matches", Pending: true, ClearExisting: true}
+	assert.Nil(t, db.CreateTbaPublishItem(&item1))
+	assert.Nil(t, db.CreateTbaPublishItem(&item2))
+	item, err = db.GetTbaPublishItemByType("matches")
+	assert.Nil(t, err)
+	assert.Equal(t, item2, *item)
+
+	item2.Pending = false
+	item2.LastError = "oops"
+	assert.Nil(t, db.UpdateTbaPublishItem(&item2))
+	items, err := db.GetAllTbaPublishItems()
+	assert.Nil(t, err)
+	assert.Equal(t, []TbaPublishItem{item1, item2}, items)
+
+	assert.Nil(t, db.TruncateTbaPublishItems())
+	items, _ = db.GetAllTbaPublishItems()
+	assert.Empty(t, items)
+}
@@ -5,23 +5,65 @@
 
 package model
 
-import "sort"
+import (
+	"fmt"
+	"sort"
+)
+
+type InspectionStatus int
+
+const (
+	InspectionNotStarted InspectionStatus = iota
+	InspectionInProgress
+	InspectionPassed
+	InspectionNeedsReinspection
+)
+
+// InspectionStatuses lists all valid inspection statuses, in the order in which a robot progresses through them.
+var InspectionStatuses = []InspectionStatus{
+	InspectionNotStarted, InspectionInProgress, InspectionPassed, InspectionNeedsReinspection,
+}
+
+var inspectionStatusNames = map[InspectionStatus]string{
+	InspectionNotStarted:        "Not Started",
+	InspectionInProgress:        "In Progress",
+	InspectionPassed:            "Passed",
+	InspectionNeedsReinspection: "Needs Re-inspection",
+}
 
 type Team struct {
-	Id              int `db:"id,manual"`
-	Name            string
-	Nickname        string
-	City            string
-	StateProv       string
-	Country         string
-	SchoolName      string
-	RookieYear      int
-	RobotName       string
-	Accomplishments string
-	WpaKey          string
-	YellowCard      bool
-	HasConnected    bool
-	FtaNotes        string
+	Id                  int `db:"id,manual"`
+	Name                string
+	Nickname            string
+	City                string
+	StateProv           string
+	Country             string
+	SchoolName          string
+	RookieYear          int
+	RobotName           string
+	Accomplishments     string
+	WpaKey              string
+	YellowCard          bool
+	HasConnected        bool
+	FtaNotes            string
+	InspectionStatus    InspectionStatus
+	InspectionWeightLbs float64
+	InspectionNotes     string
+	InspectionInspector string
+}
+
+// Returns the human-readable name of the inspection status.
+func (status InspectionStatus) DisplayName() string {
+	if name, ok := inspectionStatusNames[status]; ok {
+		return name
+	}
+	return fmt.Sprintf("Unknown (%d)", status)
+}
+
+// Returns true if the inspection status is one of the known statuses.
+func (status InspectionStatus) IsValid() bool {
+	_, ok := inspectionStatusNames[status]
+	return ok
 }
 
 func (database *Database) CreateTeam(team *Team) error {
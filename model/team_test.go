@@ -88,3 +88,11 @@ func TestGetAllTeams(t *testing.T) {
 		assert.Equal(t, i+1, teams[i].Id)
 	}
 }
+
+func TestInspectionStatusDisplayName(t *testing.T) {
+	assert.Equal(t, "Not Started", InspectionNotStarted.DisplayName())
+	assert.Equal(t, "Needs Re-inspection", InspectionNeedsReinspection.DisplayName())
+	assert.Equal(t, "Unknown (7)", InspectionStatus(7).DisplayName())
+	assert.True(t, InspectionPassed.IsValid())
+	assert.False(t, InspectionStatus(-1).IsValid())
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Model and datastore CRUD methods for a window of time during which a team is not available to play or be judged,
+// e.g. because it is arriving late or leaving early.
+
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+type TeamUnavailability struct {
+	Id        int `db:"id"`
+	TeamId    int
+	StartTime time.Time
+	EndTime   time.Time
+	Reason    string
+}
+
+func (database *Database) CreateTeamUnavailability(unavailability *TeamUnavailability) error {
+	return database.teamUnavailabilityTable.create(unavailability)
+}
+
+func (database *Database) GetTeamUnavailabilityById(id int) (*TeamUnavailability, error) {
+	return database.teamUnavailabilityTable.getById(id)
+}
+
+func (database *Database) DeleteTeamUnavailability(id int) error {
+	return database.teamUnavailabilityTable.delete(id)
+}
+
+func (database *Database) TruncateTeamUnavailabilities() error {
+	return database.teamUnavailabilityTable.truncate()
+}
+
+// Returns all unavailability windows for all teams, ordered by team and then by start time.
+func (database *Database) GetAllTeamUnavailabilities() ([]TeamUnavailability, error) {
+	unavailabilities, err := database.teamUnavailabilityTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+	sortTeamUnavailabilities(unavailabilities)
+	return unavailabilities, nil
+}
+
+// Returns the unavailability windows for the given team, ordered by start time.
+func (database *Database) GetTeamUnavailabilitiesByTeam(teamId int) ([]TeamUnavailability, error) {
+	unavailabilities, err := database.teamUnavailabilityTable.getByIndex("TeamId", teamId)
+	if err != nil {
+		return nil, err
+	}
+	sortTeamUnavailabilities(unavailabilities)
+	return unavailabilities, nil
+}
+
+func (database *Database) DeleteTeamUnavailabilitiesByTeam(teamId int) error {
+	unavailabilities, err := database.GetTeamUnavailabilitiesByTeam(teamId)
+	if err != nil {
+		return err
+	}
+
+	for _, unavailability := range unavailabilities {
+		if err = database.teamUnavailabilityTable.delete(unavailability.Id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns true if the given instant falls within the window.
+func (unavailability *TeamUnavailability) Contains(instant time.Time) bool {
+	return !instant.Before(unavailability.StartTime) && instant.Before(unavailability.EndTime)
+}
+
+// Returns true if the window overlaps the given interval of time.
+func (unavailability *TeamUnavailability) Overlaps(startTime, endTime time.Time) bool {
+	return startTime.Before(unavailability.EndTime) && endTime.After(unavailability.StartTime)
+}
+
+func sortTeamUnavailabilities(unavailabilities []TeamUnavailability) {
+	sort.Slice(
+		unavailabilities,
+		func(i, j int) bool {
+			if unavailabilities[i].TeamId != unavailabilities[j].TeamId {
+				return unavailabilities[i].TeamId < unavailabilities[j].TeamId
+			}
+			return unavailabilities[i].StartTime.Before(unavailabilities[j].StartTime)
+		},
+	)
+}
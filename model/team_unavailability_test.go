@@ -0,0 +1,63 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestTeamUnavailabilityCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	unavailability1 := TeamUnavailability{0, 254, time.Unix(500, 0).UTC(), time.Unix(600, 0).UTC(), "Leaving early"}
+	assert.Nil(t, db.CreateTeamUnavailability(&unavailability1))
+	unavailability2 := TeamUnavailability{0, 1114, time.Unix(0, 0).UTC(), time.Unix(100, 0).UTC(), "Arriving late"}
+	assert.Nil(t, db.CreateTeamUnavailability(&unavailability2))
+	unavailability3 := TeamUnavailability{0, 254, time.Unix(100, 0).UTC(), time.Unix(200, 0).UTC(), "Inspection"}
+	assert.Nil(t, db.CreateTeamUnavailability(&unavailability3))
+
+	unavailability, err := db.GetTeamUnavailabilityById(2)
+	assert.Nil(t, err)
+	assert.Equal(t, unavailability2, *unavailability)
+
+	unavailabilities, err := db.GetAllTeamUnavailabilities()
+	assert.Nil(t, err)
+	assert.Equal(t, []TeamUnavailability{unavailability3, unavailability1, unavailability2}, unavailabilities)
+	unavailabilities, err = db.GetTeamUnavailabilitiesByTeam(254)
+	assert.Nil(t, err)
+	assert.Equal(t, []TeamUnavailability{unavailability3, unavailability1}, unavailabilities)
+	unavailabilities, err = db.GetTeamUnavailabilitiesByTeam(148)
+	assert.Nil(t, err)
+	assert.Empty(t, unavailabilities)
+
+	assert.Nil(t, db.DeleteTeamUnavailability(unavailability3.Id))
+	unavailabilities, _ = db.GetTeamUnavailabilitiesByTeam(254)
+	assert.Equal(t, []TeamUnavailability{unavailability1}, unavailabilities)
+
+	assert.Nil(t, db.DeleteTeamUnavailabilitiesByTeam(254))
+	unavailabilities, _ = db.GetAllTeamUnavailabilities()
+	assert.Equal(t, []TeamUnavailability{unavailability2}, unavailabilities)
+
+	assert.Nil(t, db.TruncateTeamUnavailabilities())
+	unavailabilities, _ = db.GetAllTeamUnavailabilities()
+	assert.Empty(t, unavailabilities)
+}
+
+func TestTeamUnavailabilityOverlapsAndContains(t *testing.T) {
+	unavailability := TeamUnavailability{StartTime: time.Unix(100, 0), EndTime: time.Unix(200, 0)}
+	assert.True(t, unavailability.Overlaps(time.Unix(50, 0), time.Unix(150, 0)))
+	assert.True(t, unavailability.Overlaps(time.Unix(150, 0), time.Unix(250, 0)))
+	assert.True(t, unavailability.Overlaps(time.Unix(120, 0), time.Unix(130, 0)))
+	assert.True(t, unavailability.Overlaps(time.Unix(0, 0), time.Unix(300, 0)))
+	assert.False(t, unavailability.Overlaps(time.Unix(0, 0), time.Unix(100, 0)))
+	assert.False(t, unavailability.Overlaps(time.Unix(200, 0), time.Unix(300, 0)))
+
+	assert.True(t, unavailability.Contains(time.Unix(100, 0)))
+	assert.True(t, unavailability.Contains(time.Unix(199, 0)))
+	assert.False(t, unavailability.Contains(time.Unix(99, 0)))
+	assert.False(t, unavailability.Contains(time.Unix(200, 0)))
+}
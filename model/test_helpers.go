@@ -15,6 +15,7 @@ import (
 func SetupTestDb(t *testing.T) *Database {
 	BaseDir = ".."
 	dbDir := t.TempDir()
+	DataDir = dbDir
 	dbPath := filepath.Join(dbDir, "test.db")
 	database, err := OpenDatabase(dbPath)
 	assert.Nil(t, err)
@@ -0,0 +1,148 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Model and datastore CRUD methods for a named user account and the role it is granted.
+
+package model
+
+import (
+	"fmt"
+	"golang.org/x/crypto/bcrypt"
+	"sort"
+)
+
+// Username of the built-in admin account, which logs in with the admin password from the event settings.
+const AdminUsername = "admin"
+
+type UserRole string
+
+const (
+	AdminRole       UserRole = "admin"
+	ScorekeeperRole UserRole = "scorekeeper"
+	HeadRefereeRole UserRole = "head_referee"
+	ScorerRole      UserRole = "scorer"
+	QueuerRole      UserRole = "queuer"
+	ReadOnlyRole    UserRole = "read_only"
+)
+
+// UserRoles lists all valid roles, in descending order of privilege.
+var UserRoles = []UserRole{AdminRole, ScorekeeperRole, HeadRefereeRole, ScorerRole, QueuerRole, ReadOnlyRole}
+
+var userRoleNames = map[UserRole]string{
+	AdminRole:       "Admin",
+	ScorekeeperRole: "Scorekeeper",
+	HeadRefereeRole: "Head Referee",
+	ScorerRole:      "Scorer",
+	QueuerRole:      "Queuer",
+	ReadOnlyRole:    "Read-Only",
+}
+
+type User struct {
+	Id           int `db:"id"`
+	Username     string
+	Role         UserRole
+	PasswordHash string
+}
+
+// Returns the human-readable name of the role.
+func (role UserRole) DisplayName() string {
+	if name, ok := userRoleNames[role]; ok {
+		return name
+	}
+	return string(role)
+}
+
+// Returns true if the role is one of the known roles.
+func (role UserRole) IsValid() bool {
+	_, ok := userRoleNames[role]
+	return ok
+}
+
+// SetPassword stores a salted hash of the given password on the user.
+func (user *User) SetPassword(password string) error {
+	if password == "" {
+		return fmt.Errorf("password cannot be blank")
+	}
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	user.PasswordHash = string(passwordHash)
+	return nil
+}
+
+// CheckPassword returns true if the given password matches the one stored for the user.
+func (user *User) CheckPassword(password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) == nil
+}
+
+func (database *Database) CreateUser(user *User) error {
+	if err := database.validateUser(user); err != nil {
+		return err
+	}
+	return database.userTable.create(user)
+}
+
+func (database *Database) GetUserById(id int) (*User, error) {
+	return database.userTable.getById(id)
+}
+
+// Returns the user with the given username, or nil if there is none.
+func (database *Database) GetUserByUsername(username string) (*User, error) {
+	users, err := database.userTable.getByIndex("Username", username)
+	if err != nil || len(users) == 0 {
+		return nil, err
+	}
+	return &users[0], nil
+}
+
+func (database *Database) UpdateUser(user *User) error {
+	if err := database.validateUser(user); err != nil {
+		return err
+	}
+	return database.userTable.update(user)
+}
+
+func (database *Database) DeleteUser(id int) error {
+	return database.userTable.delete(id)
+}
+
+func (database *Database) TruncateUsers() error {
+	return database.userTable.truncate()
+}
+
+// Returns all users, sorted by username.
+func (database *Database) GetAllUsers() ([]User, error) {
+	users, err := database.userTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].Username < users[j].Username
+	})
+	return users, nil
+}
+
+// Checks that the given user has the required fields and doesn't collide with an existing one.
+func (database *Database) validateUser(user *User) error {
+	if user.Username == "" {
+		return fmt.Errorf("username cannot be blank")
+	}
+	if user.Username == AdminUsername {
+		return fmt.Errorf("the username %q is reserved for the built-in admin account", AdminUsername)
+	}
+	if !user.Role.IsValid() {
+		return fmt.Errorf("invalid role %q", user.Role)
+	}
+	if user.PasswordHash == "" {
+		return fmt.Errorf("password cannot be blank")
+	}
+	existingUser, err := database.GetUserByUsername(user.Username)
+	if err != nil {
+		return err
+	}
+	if existingUser != nil && existingUser.Id != user.Id {
+		return fmt.Errorf("a user with the username %q already exists", user.Username)
+	}
+	return nil
+}
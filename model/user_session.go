@@ -19,23 +19,32 @@ func (database *Database) CreateUserSession(session *UserSession) error {
 }
 
 func (database *Database) GetUserSessionByToken(token string) (*UserSession, error) {
-	userSessions, err := database.userSessionTable.getAll()
-	if err != nil {
+	userSessions, err := database.userSessionTable.getByIndex("Token", token)
+	if err != nil || len(userSessions) == 0 {
 		return nil, err
 	}
-
-	for _, userSession := range userSessions {
-		if userSession.Token == token {
-			return &userSession, nil
-		}
-	}
-	return nil, nil
+	return &userSessions[0], nil
 }
 
 func (database *Database) DeleteUserSession(id int) error {
 	return database.userSessionTable.delete(id)
 }
 
+// Deletes all sessions belonging to the given user, forcing them to log in again.
+func (database *Database) DeleteUserSessionsForUsername(username string) error {
+	userSessions, err := database.userSessionTable.getByIndex("Username", username)
+	if err != nil {
+		return err
+	}
+
+	for _, userSession := range userSessions {
+		if err = database.userSessionTable.delete(userSession.Id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (database *Database) TruncateUserSessions() error {
 	return database.userSessionTable.truncate()
 }
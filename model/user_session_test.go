@@ -48,3 +48,23 @@ func TestTruncateUserSessions(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Nil(t, session2)
 }
+
+func TestDeleteUserSessionsForUsername(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	session1 := UserSession{0, "token1", "Bertha", time.Now()}
+	db.CreateUserSession(&session1)
+	session2 := UserSession{0, "token2", "Ernie", time.Now()}
+	db.CreateUserSession(&session2)
+	session3 := UserSession{0, "token3", "Bertha", time.Now()}
+	db.CreateUserSession(&session3)
+
+	assert.Nil(t, db.DeleteUserSessionsForUsername("Bertha"))
+	session, _ := db.GetUserSessionByToken("token1")
+	assert.Nil(t, session)
+	session, _ = db.GetUserSessionByToken("token3")
+	assert.Nil(t, session)
+	session, _ = db.GetUserSessionByToken("token2")
+	assert.NotNil(t, session)
+}
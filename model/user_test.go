@@ -0,0 +1,90 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetNonexistentUser(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	user, err := db.GetUserById(1114)
+	assert.Nil(t, err)
+	assert.Nil(t, user)
+	user, err = db.GetUserByUsername("blorpy")
+	assert.Nil(t, err)
+	assert.Nil(t, user)
+}
+
+func TestUserCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	user := User{Username: "sam", Role: ScorerRole}
+	assert.Nil(t, user.SetPassword("sekrit"))
+	assert.NotEqual(t, "sekrit", user.PasswordHash)
+	assert.Nil(t, db.CreateUser(&user))
+	user2, err := db.GetUserById(user.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, user, *user2)
+	user2, err = db.GetUserByUsername("sam")
+	assert.Nil(t, err)
+	assert.Equal(t, user, *user2)
+	assert.True(t, user2.CheckPassword("sekrit"))
+	assert.False(t, user2.CheckPassword("blorpy"))
+
+	user.Role = HeadRefereeRole
+	assert.Nil(t, db.UpdateUser(&user))
+	user2, _ = db.GetUserById(user.Id)
+	assert.Equal(t, HeadRefereeRole, user2.Role)
+
+	otherUser := User{Username: "alex", Role: AdminRole}
+	assert.Nil(t, otherUser.SetPassword("password"))
+	assert.Nil(t, db.CreateUser(&otherUser))
+	users, err := db.GetAllUsers()
+	assert.Nil(t, err)
+	assert.Equal(t, []User{otherUser, user}, users)
+
+	assert.Nil(t, db.DeleteUser(user.Id))
+	user2, err = db.GetUserById(user.Id)
+	assert.Nil(t, err)
+	assert.Nil(t, user2)
+
+	assert.Nil(t, db.TruncateUsers())
+	users, err = db.GetAllUsers()
+	assert.Nil(t, err)
+	assert.Empty(t, users)
+}
+
+func TestUserValidation(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	user := User{Username: "sam", Role: ScorerRole}
+	assert.EqualError(t, db.CreateUser(&user), "password cannot be blank")
+	assert.EqualError(t, user.SetPassword(""), "password cannot be blank")
+	assert.Nil(t, user.SetPassword("sekrit"))
+	user.Username = ""
+	assert.EqualError(t, db.CreateUser(&user), "username cannot be blank")
+	user.Username = AdminUsername
+	assert.EqualError(t, db.CreateUser(&user), "the username \"admin\" is reserved for the built-in admin account")
+	user.Username = "sam"
+	user.Role = "janitor"
+	assert.EqualError(t, db.CreateUser(&user), "invalid role \"janitor\"")
+	user.Role = QueuerRole
+	assert.Nil(t, db.CreateUser(&user))
+
+	duplicateUser := User{Username: "sam", Role: ReadOnlyRole, PasswordHash: user.PasswordHash}
+	assert.EqualError(t, db.CreateUser(&duplicateUser), "a user with the username \"sam\" already exists")
+	assert.Nil(t, db.UpdateUser(&user))
+}
+
+func TestUserRoleDisplayName(t *testing.T) {
+	assert.Equal(t, "Head Referee", HeadRefereeRole.DisplayName())
+	assert.Equal(t, "Read-Only", ReadOnlyRole.DisplayName())
+	assert.Equal(t, "blorpy", UserRole("blorpy").DisplayName())
+}
@@ -0,0 +1,47 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Model and datastore CRUD methods for an outbound webhook that external systems can register to be notified of arena
+// events.
+
+package model
+
+import "slices"
+
+type Webhook struct {
+	Id      int `db:"id"`
+	Url     string
+	Secret  string
+	Events  []string // Arena notifier message types to deliver; empty means all of them.
+	Enabled bool
+}
+
+func (database *Database) CreateWebhook(webhook *Webhook) error {
+	return database.webhookTable.create(webhook)
+}
+
+func (database *Database) GetWebhookById(id int) (*Webhook, error) {
+	return database.webhookTable.getById(id)
+}
+
+func (database *Database) UpdateWebhook(webhook *Webhook) error {
+	return database.webhookTable.update(webhook)
+}
+
+func (database *Database) DeleteWebhook(id int) error {
+	return database.webhookTable.delete(id)
+}
+
+func (database *Database) TruncateWebhooks() error {
+	return database.webhookTable.truncate()
+}
+
+// Returns all webhooks, ordered by ID.
+func (database *Database) GetAllWebhooks() ([]Webhook, error) {
+	return database.webhookTable.getAll()
+}
+
+// Returns true if the webhook is enabled and should be sent events of the given type.
+func (webhook *Webhook) IsSubscribedTo(event string) bool {
+	return webhook.Enabled && (len(webhook.Events) == 0 || slices.Contains(webhook.Events, event))
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Model and datastore CRUD methods for the log of events queued for delivery to webhooks, which doubles as the outbox
+// from which pending deliveries are retried.
+
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+type WebhookDelivery struct {
+	Id             int `db:"id"`
+	WebhookId      int
+	Event          string
+	Payload        string
+	CreatedAt      time.Time
+	Pending        bool
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastAttemptAt  time.Time
+	LastStatusCode int
+	LastError      string
+	DeliveredAt    time.Time
+}
+
+func (database *Database) CreateWebhookDelivery(delivery *WebhookDelivery) error {
+	return database.webhookDeliveryTable.create(delivery)
+}
+
+func (database *Database) GetWebhookDeliveryById(id int) (*WebhookDelivery, error) {
+	return database.webhookDeliveryTable.getById(id)
+}
+
+func (database *Database) UpdateWebhookDelivery(delivery *WebhookDelivery) error {
+	return database.webhookDeliveryTable.update(delivery)
+}
+
+func (database *Database) DeleteWebhookDelivery(id int) error {
+	return database.webhookDeliveryTable.delete(id)
+}
+
+func (database *Database) TruncateWebhookDeliveries() error {
+	return database.webhookDeliveryTable.truncate()
+}
+
+// Returns the deliveries that have yet to succeed or be given up on, oldest first.
+func (database *Database) GetPendingWebhookDeliveries() ([]WebhookDelivery, error) {
+	return database.webhookDeliveryTable.getByIndex("Pending", true)
+}
+
+// Returns all deliveries, most recent first.
+func (database *Database) GetAllWebhookDeliveries() ([]WebhookDelivery, error) {
+	deliveries, err := database.webhookDeliveryTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(
+		deliveries,
+		func(i, j int) bool {
+			return deliveries[i].Id > deliveries[j].Id
+		},
+	)
+	return deliveries, nil
+}
+
+// Deletes all deliveries to the given webhook, including any that are still pending.
+func (database *Database) DeleteWebhookDeliveriesByWebhook(webhookId int) error {
+	deliveries, err := database.webhookDeliveryTable.getByIndex("WebhookId", webhookId)
+	if err != nil {
+		return err
+	}
+	for _, delivery := range deliveries {
+		if err = database.webhookDeliveryTable.delete(delivery.Id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestWebhookDeliveryCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	delivery1 := WebhookDelivery{WebhookId: 1, Event: "matchLoad", Payload: "{}", Pending: true}
+	delivery2 := WebhookDelivery{WebhookId: 2, Event: "matchLoad", Payload: "{}", Pending: true}
+	delivery3 := WebhookDelivery{WebhookId: 1, Event: "scorePosted", Payload: "{}", LastStatusCode: 200}
+	assert.Nil(t, db.CreateWebhookDelivery(&delivery1))
+	assert.Nil(t, db.CreateWebhookDelivery(&delivery2))
+	assert.Nil(t, db.CreateWebhookDelivery(&delivery3))
+	delivery, err := db.GetWebhookDeliveryById(2)
+	assert.Nil(t, err)
+	assert.Equal(t, delivery2, *delivery)
+
+	deliveries, err := db.GetPendingWebhookDeliveries()
+	assert.Nil(t, err)
+	assert.Equal(t, []WebhookDelivery{delivery1, delivery2}, deliveries)
+	deliveries, err = db.GetAllWebhookDeliveries()
+	assert.Nil(t, err)
+	assert.Equal(t, []WebhookDelivery{delivery3, delivery2, delivery1}, deliveries)
+
+	delivery1.Pending = false
+	assert.Nil(t, db.UpdateWebhookDelivery(&delivery1))
+	deliveries, _ = db.GetPendingWebhookDeliveries()
+	assert.Equal(t, []WebhookDelivery{delivery2}, deliveries)
+
+	assert.Nil(t, db.DeleteWebhookDeliveriesByWebhook(1))
+	deliveries, _ = db.GetAllWebhookDeliveries()
+	assert.Equal(t, []WebhookDelivery{delivery2}, deliveries)
+
+	assert.Nil(t, db.TruncateWebhookDeliveries())
+	deliveries, _ = db.GetAllWebhookDeliveries()
+	assert.Empty(t, deliveries)
+}
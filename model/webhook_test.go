@@ -0,0 +1,43 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestWebhookCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	webhook := Webhook{Url: "http://localhost:8000/hook", Secret: "shh", Events: []string{"matchLoad"}, Enabled: true}
+	assert.Nil(t, db.CreateWebhook(&webhook))
+	webhook2, err := db.GetWebhookById(1)
+	assert.Nil(t, err)
+	assert.Equal(t, webhook, *webhook2)
+
+	webhook.Events = nil
+	assert.Nil(t, db.UpdateWebhook(&webhook))
+	webhooks, err := db.GetAllWebhooks()
+	assert.Nil(t, err)
+	assert.Equal(t, []Webhook{webhook}, webhooks)
+
+	assert.Nil(t, db.DeleteWebhook(webhook.Id))
+	webhook2, err = db.GetWebhookById(1)
+	assert.Nil(t, err)
+	assert.Nil(t, webhook2)
+}
+
+func TestWebhookIsSubscribedTo(t *testing.T) {
+	webhook := Webhook{Events: []string{"matchLoad", "scorePosted"}, Enabled: true}
+	assert.True(t, webhook.IsSubscribedTo("matchLoad"))
+	assert.False(t, webhook.IsSubscribedTo("matchTime"))
+
+	webhook.Events = nil
+	assert.True(t, webhook.IsSubscribedTo("matchTime"))
+
+	webhook.Enabled = false
+	assert.False(t, webhook.IsSubscribedTo("matchLoad"))
+}
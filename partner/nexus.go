@@ -1,26 +1,49 @@
 // Copyright 2023 Team 254. All Rights Reserved.
 // Author: pat@patfairbank.com (Patrick Fairbank)
 //
-// Methods for pulling match lineups from Nexus for FRC.
+// Methods for exchanging match lineups, queueing status, results, and team status with Nexus for FRC.
 
 package partner
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"github.com/Team254/cheesy-arena/model"
 	"io"
 	"net/http"
 	"strconv"
+	"sync"
+	"time"
 )
 
 const nexusBaseUrl = "https://frc.nexus"
 const nexusApiKey = "Vn6D9y80kQcNijDItKOJHg8yYEk"
 
+// Queueing status of a match, as shown to teams in the Nexus app.
+type NexusMatchStatus string
+
+const (
+	NexusNowQueuing NexusMatchStatus = "Now queuing"
+	NexusOnDeck     NexusMatchStatus = "On deck"
+	NexusOnField    NexusMatchStatus = "On field"
+)
+
 type NexusClient struct {
-	BaseUrl   string
-	apiKey    string
-	eventCode string
+	BaseUrl string
+	// Key issued by Nexus to the event for the endpoints beyond lineups, which are only used if it is set.
+	EventApiKey string
+	apiKey      string
+	eventCode   string
+	mutex       sync.Mutex
+	status      NexusConnectionStatus
+}
+
+// Outcome of the most recent requests made to Nexus, for surfacing to the scorekeeper.
+type NexusConnectionStatus struct {
+	LastSuccessAt time.Time
+	LastErrorAt   time.Time
+	LastError     string
 }
 
 type nexusLineup struct {
@@ -28,35 +51,47 @@ type nexusLineup struct {
 	Blue [3]string `json:"blue"`
 }
 
+type nexusMatchStatusRequest struct {
+	Status NexusMatchStatus `json:"status"`
+}
+
+type NexusMatchResult struct {
+	RedScore  int `json:"redScore"`
+	BlueScore int `json:"blueScore"`
+}
+
+// Status reported by a team through Nexus (e.g. whether their robot is ready or under repair).
+type NexusTeamStatus struct {
+	Team      int       `json:"team"`
+	Status    string    `json:"status"`
+	Notes     string    `json:"notes"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Request made by a team through Nexus for parts or tools from other teams or the event.
+type NexusPartsRequest struct {
+	Id          int       `json:"id"`
+	Team        int       `json:"team"`
+	Parts       string    `json:"parts"`
+	RequestedAt time.Time `json:"requestedAt"`
+	Fulfilled   bool      `json:"fulfilled"`
+}
+
 func NewNexusClient(eventCode string) *NexusClient {
 	return &NexusClient{BaseUrl: nexusBaseUrl, apiKey: nexusApiKey, eventCode: eventCode}
 }
 
+// Returns the outcome of the most recent requests made to Nexus.
+func (client *NexusClient) Status() NexusConnectionStatus {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	return client.status
+}
+
 // Gets the team lineup for a given match from the Nexus API. Returns nil and an error if the lineup is not available.
 func (client *NexusClient) GetLineup(tbaMatchKey model.TbaMatchKey) (*[6]int, error) {
-	path := fmt.Sprintf(
-		"/api/v1/event/%s/match/%s/lineups?key=%s",
-		client.eventCode,
-		tbaMatchKey.String(),
-		client.apiKey,
-	)
-	resp, err := client.getRequest(path)
-	if err != nil {
-		return nil, err
-	}
-
-	// Get the response and handle errors
-	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error getting lineup from Nexus: %d, %s", resp.StatusCode, string(body))
-	}
-
 	var nexusLineup nexusLineup
-	if err = json.Unmarshal(body, &nexusLineup); err != nil {
+	if err := client.getJson(client.eventPath("/match/%s/lineups", tbaMatchKey.String()), &nexusLineup); err != nil {
 		return nil, err
 	}
 
@@ -77,13 +112,127 @@ func (client *NexusClient) GetLineup(tbaMatchKey model.TbaMatchKey) (*[6]int, er
 	return nil, fmt.Errorf("Lineup not yet submitted")
 }
 
-// Sends a GET request to the Nexus API.
-func (client *NexusClient) getRequest(path string) (*http.Response, error) {
+// Returns true if an event API key has been set, without which only lineups can be retrieved from Nexus.
+func (client *NexusClient) HasEventApiKey() bool {
+	return client.EventApiKey != ""
+}
+
+// Sets the queueing status of the given match in Nexus so that teams are notified when to report to the field.
+func (client *NexusClient) SetMatchStatus(tbaMatchKey model.TbaMatchKey, status NexusMatchStatus) error {
+	path, err := client.eventApiKeyPath("/match/%s/status", tbaMatchKey.String())
+	if err != nil {
+		return err
+	}
+	return client.postJson(path, nexusMatchStatusRequest{Status: status})
+}
+
+// Publishes the final score of the given match to Nexus.
+func (client *NexusClient) PublishMatchResult(tbaMatchKey model.TbaMatchKey, result NexusMatchResult) error {
+	path, err := client.eventApiKeyPath("/match/%s/result", tbaMatchKey.String())
+	if err != nil {
+		return err
+	}
+	return client.postJson(path, result)
+}
+
+// Gets the statuses that teams have reported through Nexus.
+func (client *NexusClient) GetTeamStatuses() ([]NexusTeamStatus, error) {
+	path, err := client.eventApiKeyPath("/teams/status")
+	if err != nil {
+		return nil, err
+	}
+	var teamStatuses []NexusTeamStatus
+	if err = client.getJson(path, &teamStatuses); err != nil {
+		return nil, err
+	}
+	return teamStatuses, nil
+}
+
+// Gets the parts requests that teams have made through Nexus.
+func (client *NexusClient) GetPartsRequests() ([]NexusPartsRequest, error) {
+	path, err := client.eventApiKeyPath("/parts")
+	if err != nil {
+		return nil, err
+	}
+	var partsRequests []NexusPartsRequest
+	if err = client.getJson(path, &partsRequests); err != nil {
+		return nil, err
+	}
+	return partsRequests, nil
+}
+
+// Returns the full path of the given event-scoped API endpoint, including the API key.
+func (client *NexusClient) eventPath(format string, args ...any) string {
+	return fmt.Sprintf("/api/v1/event/%s%s?key=%s", client.eventCode, fmt.Sprintf(format, args...), client.apiKey)
+}
+
+// Returns the full path of the given event-scoped API endpoint, including the event API key, or an error if there is
+// none.
+func (client *NexusClient) eventApiKeyPath(format string, args ...any) (string, error) {
+	if !client.HasEventApiKey() {
+		return "", fmt.Errorf("No Nexus event API key is configured.")
+	}
+	return fmt.Sprintf(
+		"/api/v1/event/%s%s?key=%s", client.eventCode, fmt.Sprintf(format, args...), client.EventApiKey,
+	), nil
+}
+
+// Sends a GET request to the Nexus API and decodes the JSON response into the given value.
+func (client *NexusClient) getJson(path string, value any) error {
+	body, err := client.recordResult(client.sendRequest("GET", path, nil))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, value)
+}
+
+// Sends a POST request with the given value as its JSON body to the Nexus API.
+func (client *NexusClient) postJson(path string, value any) error {
+	requestBody, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	_, err = client.recordResult(client.sendRequest("POST", path, requestBody))
+	return err
+}
+
+// Sends a request to the Nexus API and returns the body of the response, or an error if it was unsuccessful.
+func (client *NexusClient) sendRequest(method, path string, requestBody []byte) ([]byte, error) {
 	url := client.BaseUrl + path
-	httpClient := &http.Client{}
-	req, err := http.NewRequest("GET", url, nil)
+	httpClient := &http.Client{Timeout: 5 * time.Second}
+	req, err := http.NewRequest(method, url, bytes.NewReader(requestBody))
+	if err != nil {
+		return nil, err
+	}
+	if requestBody != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the response and handle errors
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, err
 	}
-	return httpClient.Do(req)
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("Error from Nexus: %d, %s", resp.StatusCode, string(body))
+	}
+	return body, nil
+}
+
+// Records the outcome of a request in the connection status and passes it through.
+func (client *NexusClient) recordResult(body []byte, err error) ([]byte, error) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	if err != nil {
+		client.status.LastErrorAt = time.Now()
+		client.status.LastError = err.Error()
+	} else {
+		client.status.LastSuccessAt = time.Now()
+	}
+	return body, err
 }
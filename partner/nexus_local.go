@@ -0,0 +1,230 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// In-memory stand-in for the Nexus API, for exercising the Nexus integration without an internet connection.
+
+package partner
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Serves the subset of the Nexus API used by Cheesy Arena from memory, while also allowing the data that would
+// otherwise be entered by teams in the Nexus app to be set locally. Data is shared across all event codes.
+type NexusLocalServer struct {
+	mutex              sync.Mutex
+	mux                *http.ServeMux
+	baseUrl            string
+	lineups            map[string]nexusLineup
+	matchStatuses      map[string]NexusMatchStatus
+	matchResults       map[string]NexusMatchResult
+	teamStatuses       map[int]NexusTeamStatus
+	partsRequests      []NexusPartsRequest
+	nextPartsRequestId int
+}
+
+// Snapshot of the data held by the local server.
+type NexusLocalState struct {
+	Lineups       map[string][6]int
+	MatchStatuses map[string]NexusMatchStatus
+	MatchResults  map[string]NexusMatchResult
+	TeamStatuses  []NexusTeamStatus
+	PartsRequests []NexusPartsRequest
+}
+
+func NewNexusLocalServer() *NexusLocalServer {
+	server := &NexusLocalServer{
+		lineups:            make(map[string]nexusLineup),
+		matchStatuses:      make(map[string]NexusMatchStatus),
+		matchResults:       make(map[string]NexusMatchResult),
+		teamStatuses:       make(map[int]NexusTeamStatus),
+		nextPartsRequestId: 1,
+	}
+	server.mux = http.NewServeMux()
+	server.mux.HandleFunc("GET /api/v1/event/{eventCode}/match/{matchKey}/lineups", server.lineupGetHandler)
+	server.mux.HandleFunc("POST /api/v1/event/{eventCode}/match/{matchKey}/status", server.matchStatusPostHandler)
+	server.mux.HandleFunc("POST /api/v1/event/{eventCode}/match/{matchKey}/result", server.matchResultPostHandler)
+	server.mux.HandleFunc("GET /api/v1/event/{eventCode}/teams/status", server.teamStatusesGetHandler)
+	server.mux.HandleFunc("GET /api/v1/event/{eventCode}/parts", server.partsRequestsGetHandler)
+	return server
+}
+
+// Starts listening for API requests on a local port, if not already doing so, and returns the base URL to use for them.
+func (server *NexusLocalServer) Start() (string, error) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	if server.baseUrl != "" {
+		return server.baseUrl, nil
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", fmt.Errorf("failed to start local Nexus server: %v", err)
+	}
+	server.baseUrl = "http://" + listener.Addr().String()
+	go func() {
+		log.Printf("Serving local Nexus stand-in at %s", server.baseUrl)
+		log.Println(http.Serve(listener, server))
+	}()
+	return server.baseUrl, nil
+}
+
+func (server *NexusLocalServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	server.mux.ServeHTTP(w, r)
+}
+
+// Sets the lineup that will be returned for the given match; zero denotes an empty position.
+func (server *NexusLocalServer) SetLineup(matchKey string, lineup [6]int) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	var nexusLineup nexusLineup
+	for i, team := range lineup {
+		teamString := ""
+		if team > 0 {
+			teamString = strconv.Itoa(team)
+		}
+		if i < 3 {
+			nexusLineup.Red[i] = teamString
+		} else {
+			nexusLineup.Blue[i-3] = teamString
+		}
+	}
+	server.lineups[matchKey] = nexusLineup
+}
+
+// Sets the status reported by the given team, or clears it if the status is blank.
+func (server *NexusLocalServer) SetTeamStatus(team int, status, notes string) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	if status == "" {
+		delete(server.teamStatuses, team)
+		return
+	}
+	server.teamStatuses[team] = NexusTeamStatus{Team: team, Status: status, Notes: notes, UpdatedAt: time.Now()}
+}
+
+// Adds a parts request from the given team.
+func (server *NexusLocalServer) AddPartsRequest(team int, parts string) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.partsRequests = append(
+		server.partsRequests,
+		NexusPartsRequest{Id: server.nextPartsRequestId, Team: team, Parts: parts, RequestedAt: time.Now()},
+	)
+	server.nextPartsRequestId++
+}
+
+// Marks the given parts request as fulfilled. Returns an error if it doesn't exist.
+func (server *NexusLocalServer) FulfillPartsRequest(id int) error {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	for i := range server.partsRequests {
+		if server.partsRequests[i].Id == id {
+			server.partsRequests[i].Fulfilled = true
+			return nil
+		}
+	}
+	return fmt.Errorf("parts request %d does not exist", id)
+}
+
+// Returns a copy of all the data held by the local server.
+func (server *NexusLocalServer) State() NexusLocalState {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	state := NexusLocalState{
+		Lineups:       make(map[string][6]int),
+		MatchStatuses: make(map[string]NexusMatchStatus),
+		MatchResults:  make(map[string]NexusMatchResult),
+		TeamStatuses:  server.sortedTeamStatuses(),
+		PartsRequests: append([]NexusPartsRequest{}, server.partsRequests...),
+	}
+	for matchKey, nexusLineup := range server.lineups {
+		var lineup [6]int
+		for i := 0; i < 3; i++ {
+			lineup[i], _ = strconv.Atoi(nexusLineup.Red[i])
+			lineup[i+3], _ = strconv.Atoi(nexusLineup.Blue[i])
+		}
+		state.Lineups[matchKey] = lineup
+	}
+	for matchKey, status := range server.matchStatuses {
+		state.MatchStatuses[matchKey] = status
+	}
+	for matchKey, result := range server.matchResults {
+		state.MatchResults[matchKey] = result
+	}
+	return state
+}
+
+func (server *NexusLocalServer) lineupGetHandler(w http.ResponseWriter, r *http.Request) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	lineup, ok := server.lineups[r.PathValue("matchKey")]
+	if !ok {
+		http.Error(w, "Match not found", 404)
+		return
+	}
+	writeNexusLocalJson(w, lineup)
+}
+
+func (server *NexusLocalServer) matchStatusPostHandler(w http.ResponseWriter, r *http.Request) {
+	var request nexusMatchStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), 400)
+		return
+	}
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.matchStatuses[r.PathValue("matchKey")] = request.Status
+	writeNexusLocalJson(w, request)
+}
+
+func (server *NexusLocalServer) matchResultPostHandler(w http.ResponseWriter, r *http.Request) {
+	var result NexusMatchResult
+	if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
+		http.Error(w, err.Error(), 400)
+		return
+	}
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.matchResults[r.PathValue("matchKey")] = result
+	writeNexusLocalJson(w, result)
+}
+
+func (server *NexusLocalServer) teamStatusesGetHandler(w http.ResponseWriter, r *http.Request) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	writeNexusLocalJson(w, server.sortedTeamStatuses())
+}
+
+func (server *NexusLocalServer) partsRequestsGetHandler(w http.ResponseWriter, r *http.Request) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	writeNexusLocalJson(w, append([]NexusPartsRequest{}, server.partsRequests...))
+}
+
+// Returns the team statuses ordered by team number. Must be called with the mutex held.
+func (server *NexusLocalServer) sortedTeamStatuses() []NexusTeamStatus {
+	teamStatuses := make([]NexusTeamStatus, 0, len(server.teamStatuses))
+	for _, teamStatus := range server.teamStatuses {
+		teamStatuses = append(teamStatuses, teamStatus)
+	}
+	sort.Slice(teamStatuses, func(i, j int) bool {
+		return teamStatuses[i].Team < teamStatuses[j].Team
+	})
+	return teamStatuses
+}
+
+func writeNexusLocalJson(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		http.Error(w, err.Error(), 500)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package partner
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNexusLocalServer(t *testing.T) {
+	server := NewNexusLocalServer()
+	baseUrl, err := server.Start()
+	assert.Nil(t, err)
+	sameBaseUrl, err := server.Start()
+	assert.Nil(t, err)
+	assert.Equal(t, baseUrl, sameBaseUrl)
+	client := NewNexusClient("my_event_code")
+	client.BaseUrl = baseUrl
+
+	// Check lineups.
+	tbaMatchKey := model.TbaMatchKey{CompLevel: "p", SetNumber: 0, MatchNumber: 1}
+	lineup, err := client.GetLineup(tbaMatchKey)
+	assert.Nil(t, lineup)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "Match not found")
+	}
+	status := client.Status()
+	assert.True(t, status.LastSuccessAt.IsZero())
+	assert.False(t, status.LastErrorAt.IsZero())
+	assert.Contains(t, status.LastError, "Match not found")
+
+	server.SetLineup("p1", [6]int{254, 0, 1114, 2056, 118, 0})
+	lineup, err = client.GetLineup(tbaMatchKey)
+	if assert.Nil(t, err) {
+		assert.Equal(t, [6]int{254, 0, 1114, 2056, 118, 0}, *lineup)
+	}
+	assert.False(t, client.Status().LastSuccessAt.IsZero())
+
+	// Check that the endpoints beyond lineups require an event API key.
+	assert.EqualError(t, client.SetMatchStatus(tbaMatchKey, NexusOnField), "No Nexus event API key is configured.")
+	_, err = client.GetTeamStatuses()
+	assert.EqualError(t, err, "No Nexus event API key is configured.")
+	client.EventApiKey = "my_event_api_key"
+
+	// Check queueing statuses and results.
+	assert.Nil(t, client.SetMatchStatus(tbaMatchKey, NexusOnField))
+	assert.Nil(t, client.SetMatchStatus(model.TbaMatchKey{CompLevel: "p", MatchNumber: 2}, NexusOnDeck))
+	assert.Nil(t, client.PublishMatchResult(tbaMatchKey, NexusMatchResult{RedScore: 100, BlueScore: 75}))
+	state := server.State()
+	assert.Equal(t, map[string][6]int{"p1": {254, 0, 1114, 2056, 118, 0}}, state.Lineups)
+	assert.Equal(t, map[string]NexusMatchStatus{"p1": NexusOnField, "p2": NexusOnDeck}, state.MatchStatuses)
+	assert.Equal(t, map[string]NexusMatchResult{"p1": {RedScore: 100, BlueScore: 75}}, state.MatchResults)
+
+	// Check team statuses.
+	teamStatuses, err := client.GetTeamStatuses()
+	assert.Nil(t, err)
+	assert.Empty(t, teamStatuses)
+	server.SetTeamStatus(1114, "Repairing", "Replacing intake")
+	server.SetTeamStatus(254, "Ready", "")
+	teamStatuses, err = client.GetTeamStatuses()
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(teamStatuses)) {
+		assert.Equal(t, 254, teamStatuses[0].Team)
+		assert.Equal(t, "Ready", teamStatuses[0].Status)
+		assert.Equal(t, 1114, teamStatuses[1].Team)
+		assert.Equal(t, "Repairing", teamStatuses[1].Status)
+		assert.Equal(t, "Replacing intake", teamStatuses[1].Notes)
+	}
+	server.SetTeamStatus(254, "", "")
+	teamStatuses, _ = client.GetTeamStatuses()
+	if assert.Equal(t, 1, len(teamStatuses)) {
+		assert.Equal(t, 1114, teamStatuses[0].Team)
+	}
+
+	// Check parts requests.
+	server.AddPartsRequest(2056, "Falcon motor")
+	server.AddPartsRequest(118, "Zip ties")
+	assert.Nil(t, server.FulfillPartsRequest(1))
+	assert.NotNil(t, server.FulfillPartsRequest(3))
+	partsRequests, err := client.GetPartsRequests()
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(partsRequests)) {
+		assert.Equal(t, 1, partsRequests[0].Id)
+		assert.Equal(t, 2056, partsRequests[0].Team)
+		assert.Equal(t, "Falcon motor", partsRequests[0].Parts)
+		assert.True(t, partsRequests[0].Fulfilled)
+		assert.Equal(t, 2, partsRequests[1].Id)
+		assert.False(t, partsRequests[1].Fulfilled)
+	}
+}
@@ -60,3 +60,35 @@ func TestGetLineup(t *testing.T) {
 		assert.Contains(t, err.Error(), "Lineup not yet submitted")
 	}
 }
+
+func TestNexusEventApiKey(t *testing.T) {
+	// Mock the Nexus server.
+	var requestUrls []string
+	nexusServer := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				requestUrls = append(requestUrls, r.URL.String())
+				w.Write([]byte("[]"))
+			},
+		),
+	)
+	defer nexusServer.Close()
+	client := NewNexusClient("my_event_code")
+	client.BaseUrl = nexusServer.URL
+	client.EventApiKey = "my_event_api_key"
+
+	tbaMatchKey := model.TbaMatchKey{CompLevel: "qm", MatchNumber: 3}
+	assert.Nil(t, client.SetMatchStatus(tbaMatchKey, NexusOnDeck))
+	assert.Nil(t, client.PublishMatchResult(tbaMatchKey, NexusMatchResult{RedScore: 1, BlueScore: 2}))
+	_, err := client.GetPartsRequests()
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		[]string{
+			"/api/v1/event/my_event_code/match/qm3/status?key=my_event_api_key",
+			"/api/v1/event/my_event_code/match/qm3/result?key=my_event_api_key",
+			"/api/v1/event/my_event_code/parts?key=my_event_api_key",
+		},
+		requestUrls,
+	)
+}
@@ -13,17 +13,17 @@ import (
 	"fmt"
 	"github.com/Team254/cheesy-arena/game"
 	"github.com/Team254/cheesy-arena/model"
-	"github.com/mitchellh/mapstructure"
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strconv"
 )
 
 const (
 	tbaBaseUrl = "https://www.thebluealliance.com"
 	tbaAuthKey = "MAApv9MCuKY9MSFkXLuzTSYBCdosboxDq8Q3ujUE2Mn8PD3Nmv64uczu5Lvy0NQ3"
-	AvatarsDir = "static/img/avatars"
+	AvatarsDir = "avatars"
 )
 
 type TbaClient struct {
@@ -52,51 +52,6 @@ type TbaAlliance struct {
 	Score      *int     `json:"score"`
 }
 
-type TbaScoreBreakdown struct {
-	AutoLineRobot1          string  `mapstructure:"autoLineRobot1"`
-	AutoLineRobot2          string  `mapstructure:"autoLineRobot2"`
-	AutoLineRobot3          string  `mapstructure:"autoLineRobot3"`
-	AutoMobilityPoints      int     `mapstructure:"autoMobilityPoints"`
-	AutoReef                tbaReef `mapstructure:"autoReef"`
-	AutoCoralCount          int     `mapstructure:"autoCoralCount"`
-	AutoCoralPoints         int     `mapstructure:"autoCoralPoints"`
-	AutoPoints              int     `mapstructure:"autoPoints"`
-	TeleopReef              tbaReef `mapstructure:"teleopReef"`
-	TeleopCoralCount        int     `mapstructure:"teleopCoralCount"`
-	TeleopCoralPoints       int     `mapstructure:"teleopCoralPoints"`
-	NetAlgaeCount           int     `mapstructure:"netAlgaeCount"`
-	WallAlgaeCount          int     `mapstructure:"wallAlgaeCount"`
-	AlgaePoints             int     `mapstructure:"algaePoints"`
-	EndGameRobot1           string  `mapstructure:"endGameRobot1"`
-	EndGameRobot2           string  `mapstructure:"endGameRobot2"`
-	EndGameRobot3           string  `mapstructure:"endGameRobot3"`
-	EndGameBargePoints      int     `mapstructure:"endGameBargePoints"`
-	TeleopPoints            int     `mapstructure:"teleopPoints"`
-	CoopertitionCriteriaMet bool    `mapstructure:"coopertitionCriteriaMet"`
-	AutoBonusAchieved       bool    `mapstructure:"autoBonusAchieved"`
-	CoralBonusAchieved      bool    `mapstructure:"coralBonusAchieved"`
-	BargeBonusAchieved      bool    `mapstructure:"bargeBonusAchieved"`
-	FoulCount               int     `mapstructure:"foulCount"`
-	TechFoulCount           int     `mapstructure:"techFoulCount"`
-	G206Penalty             bool    `mapstructure:"g206Penalty"`
-	G410Penalty             bool    `mapstructure:"g410Penalty"`
-	G418Penalty             bool    `mapstructure:"g418Penalty"`
-	G428Penalty             bool    `mapstructure:"g428Penalty"`
-	FoulPoints              int     `mapstructure:"foulPoints"`
-	TotalPoints             int     `mapstructure:"totalPoints"`
-	RP                      int     `mapstructure:"rp"`
-}
-
-type tbaReef struct {
-	BotRow         map[string]bool `mapstructure:"botRow"`
-	MidRow         map[string]bool `mapstructure:"midRow"`
-	TopRow         map[string]bool `mapstructure:"topRow"`
-	TbaBotRowCount int             `mapstructure:"tba_botRowCount"`
-	TbaMidRowCount int             `mapstructure:"tba_midRowCount"`
-	TbaTopRowCount int             `mapstructure:"tba_topRowCount"`
-	Trough         int             `mapstructure:"trough"`
-}
-
 type TbaRanking struct {
 	TeamKey string `json:"team_key"`
 	Rank    int    `json:"rank"`
@@ -139,6 +94,20 @@ type TbaAward struct {
 	EventName string
 }
 
+type TbaScheduledMatch struct {
+	Key         string                          `json:"key"`
+	CompLevel   string                          `json:"comp_level"`
+	SetNumber   int                             `json:"set_number"`
+	MatchNumber int                             `json:"match_number"`
+	Alliances   map[string]TbaScheduledAlliance `json:"alliances"`
+	Time        int64                           `json:"time"`
+}
+
+type TbaScheduledAlliance struct {
+	TeamKeys          []string `json:"team_keys"`
+	SurrogateTeamKeys []string `json:"surrogate_team_keys"`
+}
+
 type TbaEvent struct {
 	Name string `json:"name"`
 }
@@ -154,14 +123,6 @@ type TbaPublishedAward struct {
 	Awardee string `json:"awardee"`
 }
 
-var leaveMapping = map[bool]string{false: "No", true: "Yes"}
-var endGameStatusMapping = map[game.EndgameStatus]string{
-	game.EndgameNone:        "None",
-	game.EndgameParked:      "Parked",
-	game.EndgameShallowCage: "ShallowCage",
-	game.EndgameDeepCage:    "DeepCage",
-}
-
 func NewTbaClient(eventCode, secretId, secret string) *TbaClient {
 	return &TbaClient{
 		BaseUrl:         tbaBaseUrl,
@@ -219,6 +180,29 @@ func (client *TbaClient) GetRobotName(teamNumber int, year int) (string, error)
 	return "", nil
 }
 
+// Returns the list of matches published on TBA for the event, in no particular order.
+func (client *TbaClient) GetMatches() ([]TbaScheduledMatch, error) {
+	path := fmt.Sprintf("/api/v3/event/%s/matches", client.eventCode)
+	resp, err := client.getRequest(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the response and handle errors
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("Got status code %d from TBA: %s", resp.StatusCode, body)
+	}
+
+	var matches []TbaScheduledMatch
+	err = json.Unmarshal(body, &matches)
+	return matches, err
+}
+
 func (client *TbaClient) GetTeamAwards(teamNumber int) ([]*TbaAward, error) {
 	path := fmt.Sprintf("/api/v3/team/%s/awards", getTbaTeam(teamNumber))
 	resp, err := client.getRequest(path)
@@ -283,9 +267,12 @@ func (client *TbaClient) DownloadTeamAvatar(teamNumber, year int) error {
 				return err
 			}
 
-			// Store the avatar to disk as a PNG file.
-			avatarPath := fmt.Sprintf("%s/%d.png", AvatarsDir, teamNumber)
-			return os.WriteFile(avatarPath, avatarBytes, 0644)
+			// Store the avatar to disk as a PNG file alongside the rest of this instance's event data.
+			avatarsPath := filepath.Join(model.DataDir, AvatarsDir)
+			if err = os.MkdirAll(avatarsPath, 0755); err != nil {
+				return err
+			}
+			return os.WriteFile(filepath.Join(avatarsPath, fmt.Sprintf("%d.png", teamNumber)), avatarBytes, 0644)
 		}
 	}
 
@@ -331,10 +318,6 @@ func (client *TbaClient) PublishMatches(database *model.Database) error {
 	if err != nil {
 		return err
 	}
-	eventSettings, err := database.GetEventSettings()
-	if err != nil {
-		return err
-	}
 	matches := append(qualMatches, playoffMatches...)
 	tbaMatches := make([]TbaMatch, len(matches))
 
@@ -351,8 +334,8 @@ func (client *TbaClient) PublishMatches(database *model.Database) error {
 			}
 			if matchResult != nil {
 				scoreBreakdown = make(map[string]map[string]any)
-				scoreBreakdown["red"] = createTbaScoringBreakdown(eventSettings, &match, matchResult, "red")
-				scoreBreakdown["blue"] = createTbaScoringBreakdown(eventSettings, &match, matchResult, "blue")
+				scoreBreakdown["red"] = createTbaScoringBreakdown(&match, matchResult, "red")
+				scoreBreakdown["blue"] = createTbaScoringBreakdown(&match, matchResult, "blue")
 				redScoreValue := scoreBreakdown["red"]["totalPoints"].(int)
 				blueScoreValue, _ := scoreBreakdown["blue"]["totalPoints"].(int)
 				redScore = &redScoreValue
@@ -627,13 +610,7 @@ func createTbaAlliance(teamIds [3]int, surrogates [3]bool, score *int, cards map
 	return &alliance
 }
 
-func createTbaScoringBreakdown(
-	eventSettings *model.EventSettings,
-	match *model.Match,
-	matchResult *model.MatchResult,
-	alliance string,
-) map[string]any {
-	var breakdown TbaScoreBreakdown
+func createTbaScoringBreakdown(match *model.Match, matchResult *model.MatchResult, alliance string) map[string]any {
 	var score *game.Score
 	var scoreSummary, opponentScoreSummary *game.ScoreSummary
 	if alliance == "red" {
@@ -645,92 +622,5 @@ func createTbaScoringBreakdown(
 		scoreSummary = matchResult.BlueScoreSummary()
 		opponentScoreSummary = matchResult.RedScoreSummary()
 	}
-
-	breakdown.AutoLineRobot1 = leaveMapping[score.LeaveStatuses[0]]
-	breakdown.AutoLineRobot2 = leaveMapping[score.LeaveStatuses[1]]
-	breakdown.AutoLineRobot3 = leaveMapping[score.LeaveStatuses[2]]
-	breakdown.AutoMobilityPoints = scoreSummary.LeavePoints
-	breakdown.AutoReef.BotRow = make(map[string]bool)
-	breakdown.AutoReef.MidRow = make(map[string]bool)
-	breakdown.AutoReef.TopRow = make(map[string]bool)
-	for i := 0; i < 12; i++ {
-		breakdown.AutoReef.BotRow["node"+string(rune('A'+i))] = score.Reef.AutoBranches[game.Level2][i]
-		breakdown.AutoReef.MidRow["node"+string(rune('A'+i))] = score.Reef.AutoBranches[game.Level3][i]
-		breakdown.AutoReef.TopRow["node"+string(rune('A'+i))] = score.Reef.AutoBranches[game.Level4][i]
-	}
-	breakdown.AutoReef.TbaBotRowCount = score.Reef.CountCoralByLevelAndPeriod(game.Level2, true)
-	breakdown.AutoReef.TbaMidRowCount = score.Reef.CountCoralByLevelAndPeriod(game.Level3, true)
-	breakdown.AutoReef.TbaTopRowCount = score.Reef.CountCoralByLevelAndPeriod(game.Level4, true)
-	breakdown.AutoReef.Trough = score.Reef.CountCoralByLevelAndPeriod(game.Level1, true)
-	breakdown.AutoCoralCount = score.Reef.AutoCoralCount()
-	breakdown.AutoCoralPoints = score.Reef.AutoCoralPoints()
-	breakdown.AutoPoints = scoreSummary.AutoPoints
-	breakdown.TeleopReef.BotRow = make(map[string]bool)
-	breakdown.TeleopReef.MidRow = make(map[string]bool)
-	breakdown.TeleopReef.TopRow = make(map[string]bool)
-	for i := 0; i < 12; i++ {
-		breakdown.TeleopReef.BotRow["node"+string(rune('A'+i))] = score.Reef.Branches[game.Level2][i]
-		breakdown.TeleopReef.MidRow["node"+string(rune('A'+i))] = score.Reef.Branches[game.Level3][i]
-		breakdown.TeleopReef.TopRow["node"+string(rune('A'+i))] = score.Reef.Branches[game.Level4][i]
-	}
-	breakdown.TeleopReef.TbaBotRowCount = breakdown.AutoReef.TbaBotRowCount +
-		score.Reef.CountCoralByLevelAndPeriod(game.Level2, false)
-	breakdown.TeleopReef.TbaMidRowCount = breakdown.AutoReef.TbaMidRowCount +
-		score.Reef.CountCoralByLevelAndPeriod(game.Level3, false)
-	breakdown.TeleopReef.TbaTopRowCount = breakdown.AutoReef.TbaTopRowCount +
-		score.Reef.CountCoralByLevelAndPeriod(game.Level4, false)
-	breakdown.TeleopReef.Trough = score.Reef.CountCoralByLevelAndPeriod(game.Level1, false)
-	breakdown.TeleopCoralCount = score.Reef.TeleopCoralCount()
-	teleopCoralPoints := score.Reef.TeleopCoralPoints()
-	breakdown.TeleopCoralPoints = teleopCoralPoints
-	breakdown.NetAlgaeCount = score.BargeAlgae
-	breakdown.WallAlgaeCount = score.ProcessorAlgae
-	breakdown.AlgaePoints = scoreSummary.AlgaePoints
-	breakdown.EndGameRobot1 = endGameStatusMapping[score.EndgameStatuses[0]]
-	breakdown.EndGameRobot2 = endGameStatusMapping[score.EndgameStatuses[1]]
-	breakdown.EndGameRobot3 = endGameStatusMapping[score.EndgameStatuses[2]]
-	breakdown.EndGameBargePoints = scoreSummary.BargePoints
-	breakdown.TeleopPoints = teleopCoralPoints + scoreSummary.AlgaePoints + scoreSummary.BargePoints
-	breakdown.CoopertitionCriteriaMet = scoreSummary.CoopertitionCriteriaMet
-	breakdown.AutoBonusAchieved = scoreSummary.AutoBonusRankingPoint
-	breakdown.CoralBonusAchieved = scoreSummary.CoralBonusRankingPoint
-	breakdown.BargeBonusAchieved = scoreSummary.BargeBonusRankingPoint
-	for _, foul := range score.Fouls {
-		if foul.IsMajor {
-			breakdown.TechFoulCount++
-		} else if foul.PointValue() > 0 {
-			breakdown.FoulCount++
-		}
-		if foul.Rule() != nil && foul.Rule().IsRankingPoint {
-			switch foul.Rule().RuleNumber {
-			case "G206":
-				breakdown.G206Penalty = true
-			case "G410":
-				breakdown.G410Penalty = true
-			case "G418":
-				breakdown.G418Penalty = true
-			case "G428":
-				breakdown.G428Penalty = true
-			}
-		}
-	}
-	breakdown.FoulPoints = scoreSummary.FoulPoints
-	breakdown.TotalPoints = scoreSummary.Score
-
-	if match.ShouldUpdateRankings() {
-		// Calculate and set the ranking points for the match.
-		var ranking game.Ranking
-		ranking.AddScoreSummary(scoreSummary, opponentScoreSummary, false)
-		breakdown.RP = ranking.RankingPoints
-	}
-
-	// Turn the breakdown struct into a map in order to be able to remove any fields that are disabled based on the
-	// event settings.
-	breakdownMap := make(map[string]any)
-	_ = mapstructure.Decode(breakdown, &breakdownMap)
-	if !eventSettings.CoralBonusCoopEnabled {
-		delete(breakdownMap, "coopertitionCriteriaMet")
-	}
-
-	return breakdownMap
+	return game.CurrentGame().TbaScoreBreakdown(score, scoreSummary, opponentScoreSummary, match.ShouldUpdateRankings())
 }
@@ -0,0 +1,209 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Durable outbox for publishing data to The Blue Alliance, which retries with backoff until each publish succeeds.
+
+package partner
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	tbaPublishInitialBackoffSec = 5
+	tbaPublishMaxBackoffSec     = 300
+
+	// Number of consecutive failed attempts after which a publish is reported as failed rather than pending. It
+	// continues to be retried at the maximum backoff regardless.
+	TbaPublishFailureThreshold = 3
+)
+
+type TbaPublishType string
+
+const (
+	TbaPublishTeams     TbaPublishType = "teams"
+	TbaPublishMatches   TbaPublishType = "matches"
+	TbaPublishRankings  TbaPublishType = "rankings"
+	TbaPublishAlliances TbaPublishType = "alliances"
+	TbaPublishAwards    TbaPublishType = "awards"
+)
+
+// The types of data that can be published, in the order in which they are displayed.
+var TbaPublishTypes = []TbaPublishType{
+	TbaPublishTeams, TbaPublishMatches, TbaPublishRankings, TbaPublishAlliances, TbaPublishAwards,
+}
+
+type TbaPublisher struct {
+	mutex    sync.Mutex
+	wakeChan chan struct{}
+}
+
+func NewTbaPublisher() *TbaPublisher {
+	return &TbaPublisher{wakeChan: make(chan struct{}, 1)}
+}
+
+// Returns a channel that receives a value whenever there is a new publish to attempt right away.
+func (publisher *TbaPublisher) WakeChan() <-chan struct{} {
+	return publisher.wakeChan
+}
+
+// Adds a publish of the given type of data to the outbox, superseding any publish of the same type that is still
+// pending. If clearExisting is true, the previously published data is deleted from TBA before publishing (only
+// supported for matches).
+func (publisher *TbaPublisher) Enqueue(
+	database *model.Database, publishType TbaPublishType, clearExisting bool,
+) error {
+	if clearExisting && publishType != TbaPublishMatches {
+		return fmt.Errorf("clearing existing data is not supported for TBA %s", publishType)
+	}
+
+	publisher.mutex.Lock()
+	defer publisher.mutex.Unlock()
+	item, err := database.GetTbaPublishItemByType(string(publishType))
+	if err != nil {
+		return err
+	}
+	if item == nil {
+		item = &model.TbaPublishItem{Type: string(publishType)}
+	} else if !item.Pending {
+		item.Attempts = 0
+	}
+	now := time.Now()
+	item.Pending = true
+	item.ClearExisting = item.ClearExisting || clearExisting
+	item.QueuedAt = now
+	item.NextAttemptAt = now
+	if item.Id == 0 {
+		err = database.CreateTbaPublishItem(item)
+	} else {
+		err = database.UpdateTbaPublishItem(item)
+	}
+	if err != nil {
+		return err
+	}
+	publisher.wake()
+	return nil
+}
+
+// Makes every pending publish due immediately, rather than waiting for its backoff to elapse.
+func (publisher *TbaPublisher) RetryNow(database *model.Database) error {
+	publisher.mutex.Lock()
+	defer publisher.mutex.Unlock()
+	items, err := database.GetAllTbaPublishItems()
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if item.Pending {
+			item.NextAttemptAt = time.Now()
+			if err = database.UpdateTbaPublishItem(&item); err != nil {
+				return err
+			}
+		}
+	}
+	publisher.wake()
+	return nil
+}
+
+// Attempts each pending publish whose backoff has elapsed, oldest first, recording the outcome of each.
+func (publisher *TbaPublisher) PublishDue(database *model.Database, client *TbaClient) {
+	items, err := database.GetAllTbaPublishItems()
+	if err != nil {
+		log.Printf("Failed to load TBA publish outbox: %v", err)
+		return
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].QueuedAt.Before(items[j].QueuedAt)
+	})
+
+	for _, item := range items {
+		if !item.Pending || time.Now().Before(item.NextAttemptAt) {
+			continue
+		}
+		publishErr := publisher.publish(database, client, TbaPublishType(item.Type), item.ClearExisting)
+		if err = publisher.recordAttempt(database, item, publishErr); err != nil {
+			log.Printf("Failed to update TBA publish outbox: %v", err)
+		}
+	}
+}
+
+// Publishes the current data of the given type to TBA.
+func (publisher *TbaPublisher) publish(
+	database *model.Database, client *TbaClient, publishType TbaPublishType, clearExisting bool,
+) error {
+	switch publishType {
+	case TbaPublishTeams:
+		return client.PublishTeams(database)
+	case TbaPublishMatches:
+		if clearExisting {
+			if err := client.DeletePublishedMatches(); err != nil {
+				return fmt.Errorf("failed to delete published matches: %v", err)
+			}
+		}
+		return client.PublishMatches(database)
+	case TbaPublishRankings:
+		return client.PublishRankings(database)
+	case TbaPublishAlliances:
+		return client.PublishAlliances(database)
+	case TbaPublishAwards:
+		return client.PublishAwards(database)
+	default:
+		return fmt.Errorf("invalid TBA publish type %q", publishType)
+	}
+}
+
+// Updates the given item to reflect the outcome of an attempt to publish it. If the item was enqueued again while the
+// attempt was in flight, it is left pending since the attempt may not have included the latest data.
+func (publisher *TbaPublisher) recordAttempt(
+	database *model.Database, attemptedItem model.TbaPublishItem, publishErr error,
+) error {
+	publisher.mutex.Lock()
+	defer publisher.mutex.Unlock()
+	item, err := database.GetTbaPublishItemByType(attemptedItem.Type)
+	if err != nil || item == nil {
+		return err
+	}
+	supersededDuringAttempt := !item.QueuedAt.Equal(attemptedItem.QueuedAt)
+
+	now := time.Now()
+	item.LastAttemptAt = now
+	if publishErr == nil {
+		item.LastSuccessAt = now
+		item.LastError = ""
+		item.Attempts = 0
+		item.ClearExisting = false
+		if !supersededDuringAttempt {
+			item.Pending = false
+		}
+	} else {
+		log.Printf("Failed to publish %s to TBA: %v", item.Type, publishErr)
+		item.LastError = publishErr.Error()
+		item.Attempts++
+		if !supersededDuringAttempt {
+			item.NextAttemptAt = now.Add(tbaPublishBackoff(item.Attempts))
+		}
+	}
+	return database.UpdateTbaPublishItem(item)
+}
+
+// Signals the publishing loop without blocking, coalescing with any signal that hasn't yet been received.
+func (publisher *TbaPublisher) wake() {
+	select {
+	case publisher.wakeChan <- struct{}{}:
+	default:
+	}
+}
+
+// Returns the time to wait before retrying a publish that has failed the given number of consecutive times.
+func tbaPublishBackoff(attempts int) time.Duration {
+	backoffSec := tbaPublishInitialBackoffSec
+	for i := 1; i < attempts && backoffSec < tbaPublishMaxBackoffSec; i++ {
+		backoffSec *= 2
+	}
+	return time.Duration(min(backoffSec, tbaPublishMaxBackoffSec)) * time.Second
+}
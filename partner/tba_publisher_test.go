@@ -0,0 +1,140 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package partner
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTbaPublisherRetriesUntilSuccess(t *testing.T) {
+	database := setupTestDb(t)
+	var requestPaths []string
+	healthy := false
+	tbaServer := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				requestPaths = append(requestPaths, r.URL.Path)
+				if !healthy {
+					http.Error(w, "oops", 500)
+				}
+			},
+		),
+	)
+	defer tbaServer.Close()
+	client := NewTbaClient("my_event_code", "my_secret_id", "my_secret")
+	client.BaseUrl = tbaServer.URL
+	publisher := NewTbaPublisher()
+
+	assert.Nil(t, publisher.Enqueue(database, TbaPublishRankings, false))
+	assert.Nil(t, publisher.Enqueue(database, TbaPublishTeams, false))
+	assert.Nil(t, publisher.Enqueue(database, TbaPublishTeams, false))
+	items, _ := database.GetAllTbaPublishItems()
+	assert.Equal(t, 2, len(items))
+	select {
+	case <-publisher.WakeChan():
+	default:
+		assert.Fail(t, "expected publisher to be woken")
+	}
+
+	// Check that failed publishes are retried with backoff, in the order in which they were queued.
+	publisher.PublishDue(database, client)
+	assert.Equal(
+		t,
+		[]string{
+			"/api/trusted/v1/event/my_event_code/rankings/update",
+			"/api/trusted/v1/event/my_event_code/team_list/update",
+		},
+		requestPaths,
+	)
+	item, _ := database.GetTbaPublishItemByType("teams")
+	assert.True(t, item.Pending)
+	assert.Equal(t, 1, item.Attempts)
+	assert.Contains(t, item.LastError, "Got status code 500 from TBA")
+	assert.WithinDuration(t, time.Now().Add(5*time.Second), item.NextAttemptAt, time.Second)
+	publisher.PublishDue(database, client)
+	assert.Equal(t, 2, len(requestPaths))
+
+	// Check that retrying immediately ignores the backoff.
+	healthy = true
+	assert.Nil(t, publisher.RetryNow(database))
+	publisher.PublishDue(database, client)
+	assert.Equal(t, 4, len(requestPaths))
+	item, _ = database.GetTbaPublishItemByType("teams")
+	assert.False(t, item.Pending)
+	assert.Equal(t, 0, item.Attempts)
+	assert.Equal(t, "", item.LastError)
+	assert.WithinDuration(t, time.Now(), item.LastSuccessAt, time.Second)
+	publisher.PublishDue(database, client)
+	assert.Equal(t, 4, len(requestPaths))
+}
+
+func TestTbaPublisherClearExistingMatches(t *testing.T) {
+	database := setupTestDb(t)
+	var requestPaths []string
+	tbaServer := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				requestPaths = append(requestPaths, strings.TrimPrefix(r.URL.Path, "/api/trusted/v1/event/my_event_code"))
+			},
+		),
+	)
+	defer tbaServer.Close()
+	client := NewTbaClient("my_event_code", "my_secret_id", "my_secret")
+	client.BaseUrl = tbaServer.URL
+	publisher := NewTbaPublisher()
+
+	assert.NotNil(t, publisher.Enqueue(database, TbaPublishTeams, true))
+	assert.Nil(t, publisher.Enqueue(database, TbaPublishMatches, true))
+	assert.Nil(t, publisher.Enqueue(database, TbaPublishMatches, false))
+	publisher.PublishDue(database, client)
+	assert.Equal(t, []string{"/matches/delete_all", "/matches/update"}, requestPaths)
+	item, _ := database.GetTbaPublishItemByType("matches")
+	assert.False(t, item.ClearExisting)
+
+	assert.Nil(t, publisher.Enqueue(database, TbaPublishMatches, false))
+	publisher.PublishDue(database, client)
+	assert.Equal(t, []string{"/matches/delete_all", "/matches/update", "/matches/update"}, requestPaths)
+}
+
+func TestTbaPublisherSupersededDuringAttempt(t *testing.T) {
+	database := setupTestDb(t)
+	publisher := NewTbaPublisher()
+	assert.Nil(t, publisher.Enqueue(database, TbaPublishAwards, false))
+	attemptedItem, _ := database.GetTbaPublishItemByType("awards")
+
+	// Simulate the item being queued again while the publish is in flight.
+	time.Sleep(time.Millisecond)
+	assert.Nil(t, publisher.Enqueue(database, TbaPublishAwards, false))
+	assert.Nil(t, publisher.recordAttempt(database, *attemptedItem, nil))
+	item, _ := database.GetTbaPublishItemByType("awards")
+	assert.True(t, item.Pending)
+	assert.False(t, item.LastSuccessAt.IsZero())
+	assert.Nil(t, publisher.recordAttempt(database, *item, nil))
+	item, _ = database.GetTbaPublishItemByType("awards")
+	assert.False(t, item.Pending)
+}
+
+func TestTbaPublishBackoff(t *testing.T) {
+	assert.Equal(t, 5*time.Second, tbaPublishBackoff(1))
+	assert.Equal(t, 10*time.Second, tbaPublishBackoff(2))
+	assert.Equal(t, 80*time.Second, tbaPublishBackoff(5))
+	assert.Equal(t, 300*time.Second, tbaPublishBackoff(7))
+	assert.Equal(t, 300*time.Second, tbaPublishBackoff(100))
+}
+
+func TestTbaPublishItemUnknownType(t *testing.T) {
+	database := setupTestDb(t)
+	item := model.TbaPublishItem{Type: "bogus", Pending: true}
+	assert.Nil(t, database.CreateTbaPublishItem(&item))
+	publisher := NewTbaPublisher()
+	publisher.PublishDue(database, NewTbaClient("", "", ""))
+	updatedItem, _ := database.GetTbaPublishItemByType("bogus")
+	assert.Equal(t, "invalid TBA publish type \"bogus\"", updatedItem.LastError)
+}
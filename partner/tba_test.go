@@ -12,6 +12,8 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -200,3 +202,67 @@ func TestPublishAwards(t *testing.T) {
 func setupTestDb(t *testing.T) *model.Database {
 	return model.SetupTestDb(t)
 }
+
+func TestGetMatches(t *testing.T) {
+	// Mock the TBA server.
+	tbaServer := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/v3/event/my_event_code/matches", r.URL.Path)
+				w.Write(
+					[]byte(
+						`[{"key":"my_event_code_qm2","comp_level":"qm","set_number":1,"match_number":2,` +
+							`"alliances":{"red":{"team_keys":["frc254","frc1114","frc2056"],` +
+							`"surrogate_team_keys":["frc2056"]},"blue":{"team_keys":["frc1678","frc118","frc148"],` +
+							`"surrogate_team_keys":[]}},"time":1700000000}]`,
+					),
+				)
+			},
+		),
+	)
+	defer tbaServer.Close()
+	client := NewTbaClient("my_event_code", "my_secret_id", "my_secret")
+	client.BaseUrl = tbaServer.URL
+
+	matches, err := client.GetMatches()
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(matches)) {
+		assert.Equal(t, "qm", matches[0].CompLevel)
+		assert.Equal(t, 2, matches[0].MatchNumber)
+		assert.Equal(t, []string{"frc254", "frc1114", "frc2056"}, matches[0].Alliances["red"].TeamKeys)
+		assert.Equal(t, []string{"frc2056"}, matches[0].Alliances["red"].SurrogateTeamKeys)
+		assert.Equal(t, int64(1700000000), matches[0].Time)
+	}
+
+	// Check that an error status is surfaced rather than treated as an empty schedule.
+	tbaServer.Config.Handler = http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "Not found", 404)
+		},
+	)
+	_, err = client.GetMatches()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "status code 404")
+	}
+}
+
+func TestDownloadTeamAvatar(t *testing.T) {
+	// Setting up the test database points the data directory at a temporary one.
+	setupTestDb(t)
+	tbaServer := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/v3/team/frc254/media/2025", r.URL.Path)
+				w.Write([]byte(`[{"type":"avatar","details":{"base64Image":"cG9vZnM="}}]`))
+			},
+		),
+	)
+	defer tbaServer.Close()
+	client := NewTbaClient("my_event_code", "my_secret_id", "my_secret")
+	client.BaseUrl = tbaServer.URL
+
+	assert.Nil(t, client.DownloadTeamAvatar(254, 2025))
+	avatar, err := os.ReadFile(filepath.Join(model.DataDir, AvatarsDir, "254.png"))
+	assert.Nil(t, err)
+	assert.Equal(t, "poofs", string(avatar))
+}
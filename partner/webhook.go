@@ -0,0 +1,246 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Delivery of arena events to outbound webhooks, with signed payloads and retries with backoff from a durable outbox.
+
+package partner
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	webhookInitialBackoffSec = 5
+	webhookMaxBackoffSec     = 300
+	webhookTimeoutSec        = 5
+
+	// Number of failed attempts after which a delivery is given up on.
+	WebhookMaxAttempts = 8
+
+	// Number of completed deliveries to keep in the log; older ones are pruned.
+	webhookDeliveryRetainCount = 500
+
+	WebhookEventHeader     = "X-Cheesy-Arena-Event"
+	WebhookDeliveryHeader  = "X-Cheesy-Arena-Delivery"
+	WebhookSignatureHeader = "X-Cheesy-Arena-Signature"
+)
+
+type WebhookDispatcher struct {
+	client   *http.Client
+	wakeChan chan struct{}
+}
+
+func NewWebhookDispatcher() *WebhookDispatcher {
+	return &WebhookDispatcher{
+		client:   &http.Client{Timeout: webhookTimeoutSec * time.Second},
+		wakeChan: make(chan struct{}, 1),
+	}
+}
+
+// Returns a channel that receives a value whenever there is a new delivery to attempt right away.
+func (dispatcher *WebhookDispatcher) WakeChan() <-chan struct{} {
+	return dispatcher.wakeChan
+}
+
+// Queues a delivery of the given event payload to each enabled webhook that is subscribed to the event.
+func (dispatcher *WebhookDispatcher) Enqueue(database *model.Database, event string, payload []byte) error {
+	webhooks, err := database.GetAllWebhooks()
+	if err != nil {
+		return err
+	}
+	queued := false
+	now := time.Now()
+	for _, webhook := range webhooks {
+		if !webhook.IsSubscribedTo(event) {
+			continue
+		}
+		delivery := model.WebhookDelivery{
+			WebhookId:     webhook.Id,
+			Event:         event,
+			Payload:       string(payload),
+			CreatedAt:     now,
+			Pending:       true,
+			NextAttemptAt: now,
+		}
+		if err = database.CreateWebhookDelivery(&delivery); err != nil {
+			return err
+		}
+		queued = true
+	}
+	if queued {
+		dispatcher.wake()
+	}
+	return nil
+}
+
+// Queues the given delivery to be attempted again immediately, even if it was previously given up on.
+func (dispatcher *WebhookDispatcher) Redeliver(database *model.Database, deliveryId int) error {
+	delivery, err := database.GetWebhookDeliveryById(deliveryId)
+	if err != nil {
+		return err
+	}
+	if delivery == nil {
+		return fmt.Errorf("webhook delivery %d does not exist", deliveryId)
+	}
+	delivery.Pending = true
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now()
+	if err = database.UpdateWebhookDelivery(delivery); err != nil {
+		return err
+	}
+	dispatcher.wake()
+	return nil
+}
+
+// Attempts each pending delivery whose backoff has elapsed and records the outcome of each. Deliveries to the same
+// webhook are made in the order they were queued, so one that is waiting to be retried holds back any that follow it.
+func (dispatcher *WebhookDispatcher) DeliverDue(database *model.Database) {
+	deliveries, err := database.GetPendingWebhookDeliveries()
+	if err != nil {
+		log.Printf("Failed to load webhook deliveries: %v", err)
+		return
+	}
+	webhooks := make(map[int]*model.Webhook)
+	blockedWebhookIds := make(map[int]struct{})
+	for _, delivery := range deliveries {
+		if _, ok := blockedWebhookIds[delivery.WebhookId]; ok {
+			continue
+		}
+		if time.Now().Before(delivery.NextAttemptAt) {
+			blockedWebhookIds[delivery.WebhookId] = struct{}{}
+			continue
+		}
+
+		webhook, ok := webhooks[delivery.WebhookId]
+		if !ok {
+			if webhook, err = database.GetWebhookById(delivery.WebhookId); err != nil {
+				log.Printf("Failed to load webhook %d: %v", delivery.WebhookId, err)
+				return
+			}
+			webhooks[delivery.WebhookId] = webhook
+		}
+		var statusCode int
+		var deliverErr error
+		if webhook == nil {
+			deliverErr = fmt.Errorf("webhook %d no longer exists", delivery.WebhookId)
+			delivery.Attempts = WebhookMaxAttempts
+		} else {
+			statusCode, deliverErr = dispatcher.deliver(webhook, &delivery)
+		}
+		if deliverErr != nil {
+			blockedWebhookIds[delivery.WebhookId] = struct{}{}
+		}
+		if err = recordWebhookAttempt(database, &delivery, statusCode, deliverErr); err != nil {
+			log.Printf("Failed to update webhook delivery %d: %v", delivery.Id, err)
+		}
+	}
+
+	if err = pruneWebhookDeliveries(database); err != nil {
+		log.Printf("Failed to prune webhook deliveries: %v", err)
+	}
+}
+
+// Sends the given delivery to the given webhook, returning the HTTP status code and an error if the delivery failed.
+func (dispatcher *WebhookDispatcher) deliver(webhook *model.Webhook, delivery *model.WebhookDelivery) (int, error) {
+	request, err := http.NewRequest("POST", webhook.Url, bytes.NewBufferString(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(WebhookEventHeader, delivery.Event)
+	request.Header.Set(WebhookDeliveryHeader, strconv.Itoa(delivery.Id))
+	if webhook.Secret != "" {
+		request.Header.Set(WebhookSignatureHeader, SignWebhookPayload(webhook.Secret, []byte(delivery.Payload)))
+	}
+
+	response, err := dispatcher.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return response.StatusCode, fmt.Errorf("webhook returned status %s", response.Status)
+	}
+	return response.StatusCode, nil
+}
+
+// Updates the given delivery to reflect the outcome of an attempt to send it.
+func recordWebhookAttempt(
+	database *model.Database, delivery *model.WebhookDelivery, statusCode int, deliverErr error,
+) error {
+	now := time.Now()
+	delivery.LastAttemptAt = now
+	delivery.LastStatusCode = statusCode
+	delivery.Attempts++
+	if deliverErr == nil {
+		delivery.Pending = false
+		delivery.DeliveredAt = now
+		delivery.LastError = ""
+	} else {
+		delivery.LastError = deliverErr.Error()
+		if delivery.Attempts >= WebhookMaxAttempts {
+			log.Printf("Giving up on webhook delivery %d after %d attempts: %v", delivery.Id, delivery.Attempts, deliverErr)
+			delivery.Pending = false
+		} else {
+			delivery.NextAttemptAt = now.Add(webhookBackoff(delivery.Attempts))
+		}
+	}
+	return database.UpdateWebhookDelivery(delivery)
+}
+
+// Deletes the oldest completed deliveries beyond the number that are retained in the log.
+func pruneWebhookDeliveries(database *model.Database) error {
+	deliveries, err := database.GetAllWebhookDeliveries()
+	if err != nil {
+		return err
+	}
+	numRetained := 0
+	for _, delivery := range deliveries {
+		if delivery.Pending {
+			continue
+		}
+		numRetained++
+		if numRetained > webhookDeliveryRetainCount {
+			if err = database.DeleteWebhookDelivery(delivery.Id); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Returns the signature of the given payload that is sent along with it, so that the receiver can verify that it came
+// from this server using the secret they share with it.
+func SignWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Signals the delivery loop without blocking, coalescing with any signal that hasn't yet been received.
+func (dispatcher *WebhookDispatcher) wake() {
+	select {
+	case dispatcher.wakeChan <- struct{}{}:
+	default:
+	}
+}
+
+// Returns the time to wait before retrying a delivery that has failed the given number of consecutive times.
+func webhookBackoff(attempts int) time.Duration {
+	backoffSec := webhookInitialBackoffSec
+	for i := 1; i < attempts && backoffSec < webhookMaxBackoffSec; i++ {
+		backoffSec *= 2
+	}
+	return time.Duration(min(backoffSec, webhookMaxBackoffSec)) * time.Second
+}
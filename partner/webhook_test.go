@@ -0,0 +1,142 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package partner
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type receivedWebhook struct {
+	event     string
+	signature string
+	body      string
+}
+
+func TestWebhookDispatcherDelivers(t *testing.T) {
+	database := setupTestDb(t)
+	var received []receivedWebhook
+	healthy := true
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				received = append(
+					received,
+					receivedWebhook{r.Header.Get(WebhookEventHeader), r.Header.Get(WebhookSignatureHeader), string(body)},
+				)
+				if !healthy {
+					http.Error(w, "oops", 500)
+				}
+			},
+		),
+	)
+	defer server.Close()
+	dispatcher := NewWebhookDispatcher()
+
+	database.CreateWebhook(&model.Webhook{Url: server.URL, Secret: "shh", Enabled: true})
+	database.CreateWebhook(&model.Webhook{Url: server.URL, Events: []string{"scorePosted"}, Enabled: true})
+	database.CreateWebhook(&model.Webhook{Url: server.URL})
+
+	// Check that the event is only queued for the webhooks subscribed to it.
+	assert.Nil(t, dispatcher.Enqueue(database, "matchLoad", []byte(`{"a":1}`)))
+	deliveries, _ := database.GetPendingWebhookDeliveries()
+	if assert.Equal(t, 1, len(deliveries)) {
+		assert.Equal(t, 1, deliveries[0].WebhookId)
+	}
+	select {
+	case <-dispatcher.WakeChan():
+	default:
+		assert.Fail(t, "expected dispatcher to be woken")
+	}
+
+	// Check that the payload is signed with the secret when one is configured.
+	assert.Nil(t, dispatcher.Enqueue(database, "scorePosted", []byte(`{"b":2}`)))
+	dispatcher.DeliverDue(database)
+	if assert.Equal(t, 3, len(received)) {
+		assert.Equal(t, receivedWebhook{"matchLoad", SignWebhookPayload("shh", []byte(`{"a":1}`)), `{"a":1}`}, received[0])
+		assert.Equal(t, "scorePosted", received[1].event)
+		assert.Equal(t, "", received[2].signature)
+	}
+	assert.Equal(t, "sha256=", SignWebhookPayload("shh", []byte(`{"a":1}`))[:7])
+	deliveries, _ = database.GetPendingWebhookDeliveries()
+	assert.Empty(t, deliveries)
+	delivery, _ := database.GetWebhookDeliveryById(1)
+	assert.Equal(t, 200, delivery.LastStatusCode)
+	assert.False(t, delivery.DeliveredAt.IsZero())
+}
+
+func TestWebhookDispatcherRetries(t *testing.T) {
+	database := setupTestDb(t)
+	var received []string
+	healthy := false
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				received = append(received, string(body))
+				if !healthy {
+					http.Error(w, "oops", 503)
+				}
+			},
+		),
+	)
+	defer server.Close()
+	dispatcher := NewWebhookDispatcher()
+	database.CreateWebhook(&model.Webhook{Url: server.URL, Enabled: true})
+
+	// Check that a failed delivery holds back the ones after it until it is retried.
+	assert.Nil(t, dispatcher.Enqueue(database, "matchLoad", []byte("1")))
+	assert.Nil(t, dispatcher.Enqueue(database, "matchLoad", []byte("2")))
+	dispatcher.DeliverDue(database)
+	assert.Equal(t, []string{"1"}, received)
+	delivery, _ := database.GetWebhookDeliveryById(1)
+	assert.True(t, delivery.Pending)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, 503, delivery.LastStatusCode)
+	assert.Contains(t, delivery.LastError, "503")
+	assert.WithinDuration(t, time.Now().Add(5*time.Second), delivery.NextAttemptAt, time.Second)
+	dispatcher.DeliverDue(database)
+	assert.Equal(t, []string{"1"}, received)
+
+	// Check that the deliveries go out in order once the backoff elapses.
+	healthy = true
+	delivery.NextAttemptAt = time.Now()
+	database.UpdateWebhookDelivery(delivery)
+	dispatcher.DeliverDue(database)
+	assert.Equal(t, []string{"1", "1", "2"}, received)
+	deliveries, _ := database.GetPendingWebhookDeliveries()
+	assert.Empty(t, deliveries)
+
+	// Check that a delivery is given up on after the maximum number of attempts, and can be redelivered manually.
+	healthy = false
+	assert.Nil(t, dispatcher.Enqueue(database, "matchLoad", []byte("3")))
+	delivery, _ = database.GetWebhookDeliveryById(3)
+	delivery.Attempts = WebhookMaxAttempts - 1
+	database.UpdateWebhookDelivery(delivery)
+	dispatcher.DeliverDue(database)
+	delivery, _ = database.GetWebhookDeliveryById(3)
+	assert.False(t, delivery.Pending)
+	assert.Equal(t, WebhookMaxAttempts, delivery.Attempts)
+	healthy = true
+	assert.Nil(t, dispatcher.Redeliver(database, 3))
+	dispatcher.DeliverDue(database)
+	delivery, _ = database.GetWebhookDeliveryById(3)
+	assert.False(t, delivery.Pending)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, "", delivery.LastError)
+	assert.NotNil(t, dispatcher.Redeliver(database, 99))
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	assert.Equal(t, 5*time.Second, webhookBackoff(1))
+	assert.Equal(t, 10*time.Second, webhookBackoff(2))
+	assert.Equal(t, 40*time.Second, webhookBackoff(4))
+	assert.Equal(t, 300*time.Second, webhookBackoff(10))
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/Team254/cheesy-arena/websocket"
 	"github.com/goburrow/modbus"
 	"log"
+	"slices"
 	"strings"
 	"time"
 )
@@ -33,6 +34,7 @@ type Plc interface {
 	GetInputNames() []string
 	GetRegisterNames() []string
 	GetCoilNames() []string
+	GetIoValues() ([]bool, []uint16, []bool)
 	GetProcessorCounts() (int, int)
 	SetTrussLights(redLights, blueLights [3]bool)
 }
@@ -295,6 +297,11 @@ func (plc *ModbusPlc) GetCoilNames() []string {
 	return coilNames
 }
 
+// Returns copies of the current input, register and coil values, in the same order as their names.
+func (plc *ModbusPlc) GetIoValues() ([]bool, []uint16, []bool) {
+	return slices.Clone(plc.inputs[:]), slices.Clone(plc.registers[:]), slices.Clone(plc.coils[:])
+}
+
 // Returns the red and blue processor counts, respectively.
 func (plc *ModbusPlc) GetProcessorCounts() (int, int) {
 	return int(plc.registers[redProcessor]), int(plc.registers[blueProcessor])
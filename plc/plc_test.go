@@ -461,6 +461,24 @@ func TestPlcCoilsGameSpecific(t *testing.T) {
 	assert.Equal(t, []bool{true, true, true, true, true, true}, client.coils[8:14])
 }
 
+func TestPlcGetIoValues(t *testing.T) {
+	var plc ModbusPlc
+	plc.inputs[red2AStop] = true
+	plc.registers[blueProcessor] = 5
+	plc.coils[stackLightGreen] = true
+
+	inputs, registers, coils := plc.GetIoValues()
+	assert.Equal(t, int(inputCount), len(inputs))
+	assert.True(t, inputs[red2AStop])
+	assert.Equal(t, []uint16{0, 0, 5}, registers)
+	assert.Equal(t, int(coilCount), len(coils))
+	assert.True(t, coils[stackLightGreen])
+
+	// The returned values should be copies that don't change along with the PLC.
+	plc.inputs[red2AStop] = false
+	assert.True(t, inputs[red2AStop])
+}
+
 func TestPlcIsHealthy(t *testing.T) {
 	var client FakeModbusClient
 	var plc ModbusPlc
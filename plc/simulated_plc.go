@@ -0,0 +1,84 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Software stand-in for the field PLC whose inputs and registers are set by hand, for exercising the field logic
+// without the physical PLC.
+
+package plc
+
+import (
+	"fmt"
+	"time"
+)
+
+// Simulated PLC that behaves like a healthy, connected ModbusPlc whose inputs and registers come from calls to
+// SetInput() and SetRegister() instead of from the hardware.
+type SimulatedPlc struct {
+	ModbusPlc
+}
+
+// Creates a simulated PLC with all the stops released, all the team Ethernet ports connected, and all the ArmorBlock
+// I/O modules connected.
+func NewSimulatedPlc() *SimulatedPlc {
+	plc := new(SimulatedPlc)
+	plc.SetAddress("")
+	for i := range plc.inputs {
+		// The stop inputs are active-low, so setting them all means that none of the stops are pressed.
+		plc.inputs[i] = true
+	}
+	plc.registers[fieldIoConnection] = 1<<armorBlockCount - 1
+	return plc
+}
+
+// Ignores the address since the simulated PLC doesn't connect to anything.
+func (plc *SimulatedPlc) SetAddress(address string) {
+	plc.ModbusPlc.SetAddress("")
+}
+
+// Always returns true since the simulated PLC is always in use once selected.
+func (plc *SimulatedPlc) IsEnabled() bool {
+	return true
+}
+
+// Always returns true since the simulated PLC can't lose its connection.
+func (plc *SimulatedPlc) IsHealthy() bool {
+	return true
+}
+
+// Loops indefinitely to apply the outputs as the real PLC would and to notify listeners of any I/O changes.
+func (plc *SimulatedPlc) Run() {
+	for {
+		startTime := time.Now()
+		plc.update()
+		time.Sleep(time.Until(startTime.Add(time.Millisecond * plcLoopPeriodMs)))
+	}
+}
+
+// Performs a single iteration of the simulated PLC cycle.
+func (plc *SimulatedPlc) update() {
+	plc.coils[heartbeat] = true
+	if plc.matchResetCycles > 5 {
+		plc.coils[matchReset] = false
+	} else {
+		plc.matchResetCycles++
+	}
+	plc.ModbusPlc.update()
+}
+
+// Sets the raw value of the discrete input at the given index (as listed by GetInputNames()).
+func (plc *SimulatedPlc) SetInput(index int, value bool) error {
+	if index < 0 || index >= int(inputCount) {
+		return fmt.Errorf("invalid PLC input index %d", index)
+	}
+	plc.inputs[index] = value
+	return nil
+}
+
+// Sets the raw value of the register at the given index (as listed by GetRegisterNames()).
+func (plc *SimulatedPlc) SetRegister(index int, value uint16) error {
+	if index < 0 || index >= int(registerCount) {
+		return fmt.Errorf("invalid PLC register index %d", index)
+	}
+	plc.registers[index] = value
+	return nil
+}
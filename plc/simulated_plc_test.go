@@ -0,0 +1,97 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package plc
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSimulatedPlcInitialState(t *testing.T) {
+	plc := NewSimulatedPlc()
+	assert.True(t, plc.IsEnabled())
+	assert.True(t, plc.IsHealthy())
+	assert.NotNil(t, plc.IoChangeNotifier())
+	plc.SetAddress("1.2.3.4")
+	assert.True(t, plc.IsEnabled())
+	assert.Nil(t, plc.handler)
+
+	assert.False(t, plc.GetFieldEStop())
+	redEStops, blueEStops := plc.GetTeamEStops()
+	assert.Equal(t, [3]bool{false, false, false}, redEStops)
+	assert.Equal(t, [3]bool{false, false, false}, blueEStops)
+	redAStops, blueAStops := plc.GetTeamAStops()
+	assert.Equal(t, [3]bool{false, false, false}, redAStops)
+	assert.Equal(t, [3]bool{false, false, false}, blueAStops)
+	redEthernets, blueEthernets := plc.GetEthernetConnected()
+	assert.Equal(t, [3]bool{true, true, true}, redEthernets)
+	assert.Equal(t, [3]bool{true, true, true}, blueEthernets)
+	assert.Equal(
+		t,
+		map[string]bool{"RedDs": true, "BlueDs": true, "RedIoLink": true, "BlueIoLink": true},
+		plc.GetArmorBlockStatuses(),
+	)
+}
+
+func TestSimulatedPlcInputsAndRegisters(t *testing.T) {
+	plc := NewSimulatedPlc()
+
+	assert.Nil(t, plc.SetInput(int(fieldEStop), false))
+	assert.Nil(t, plc.SetInput(int(red2AStop), false))
+	assert.Nil(t, plc.SetInput(int(blue3EStop), false))
+	assert.Nil(t, plc.SetInput(int(blueConnected1), false))
+	assert.True(t, plc.GetFieldEStop())
+	redAStops, _ := plc.GetTeamAStops()
+	assert.Equal(t, [3]bool{false, true, false}, redAStops)
+	_, blueEStops := plc.GetTeamEStops()
+	assert.Equal(t, [3]bool{false, false, true}, blueEStops)
+	_, blueEthernets := plc.GetEthernetConnected()
+	assert.Equal(t, [3]bool{false, true, true}, blueEthernets)
+
+	assert.Nil(t, plc.SetRegister(int(redProcessor), 3))
+	assert.Nil(t, plc.SetRegister(int(blueProcessor), 7))
+	redCount, blueCount := plc.GetProcessorCounts()
+	assert.Equal(t, 3, redCount)
+	assert.Equal(t, 7, blueCount)
+	assert.Nil(t, plc.SetRegister(int(fieldIoConnection), 0x05))
+	assert.Equal(
+		t,
+		map[string]bool{"RedDs": true, "BlueDs": false, "RedIoLink": true, "BlueIoLink": false},
+		plc.GetArmorBlockStatuses(),
+	)
+
+	err := plc.SetInput(int(inputCount), true)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "invalid PLC input index 19", err.Error())
+	}
+	assert.NotNil(t, plc.SetInput(-1, true))
+	err = plc.SetRegister(int(registerCount), 1)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "invalid PLC register index 3", err.Error())
+	}
+}
+
+func TestSimulatedPlcCoils(t *testing.T) {
+	plc := NewSimulatedPlc()
+
+	plc.SetStackLights(true, false, true, false)
+	plc.SetTrussLights([3]bool{true, false, false}, [3]bool{false, false, true})
+	plc.ResetMatch()
+	plc.update()
+	_, _, coils := plc.GetIoValues()
+	assert.True(t, coils[heartbeat])
+	assert.True(t, coils[matchReset])
+	assert.True(t, coils[stackLightRed])
+	assert.False(t, coils[stackLightBlue])
+	assert.True(t, coils[stackLightOrange])
+	assert.True(t, coils[redTrussLightOuter])
+	assert.True(t, coils[blueTrussLightInner])
+
+	// The match reset coil should only be pulsed briefly.
+	for i := 0; i < 6; i++ {
+		plc.update()
+	}
+	_, _, coils = plc.GetIoValues()
+	assert.False(t, coils[matchReset])
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Client-side logic for the Field Alerts page.
+
+var websocket;
+
+// Sends a websocket message to acknowledge the given alert.
+var acknowledgeAlert = function (alertId) {
+  websocket.send("acknowledgeAlert", alertId);
+};
+
+// Sends a websocket message to snooze the given alert for the duration entered on the page.
+var snoozeAlert = function (alertId) {
+  websocket.send("snoozeAlert", {Id: alertId, DurationMin: parseInt($("#snoozeDurationMin").val())});
+};
+
+// Handles a websocket message to update the list of active alerts.
+var handleFieldAlerts = function (data) {
+  const alertRows = $("#fieldAlerts");
+  alertRows.empty();
+  $.each(data.Alerts, function (i, alert) {
+    let status = "New";
+    if (alert.Snoozed) {
+      status = "Snoozed until " + moment(alert.SnoozedUntil).format("h:mm:ss A");
+    } else if (alert.Acknowledged) {
+      status = "Acknowledged";
+    }
+    const row = $("<tr>");
+    if (!alert.Acknowledged && !alert.Snoozed) {
+      row.addClass(alert.Severity === "critical" ? "table-danger" : "table-warning");
+    }
+    row.append($("<td>").text(alert.Severity));
+    row.append($("<td>").text(moment(alert.RaisedAt).format("h:mm:ss A")));
+    row.append($("<td>").text(alert.Message));
+    row.append($("<td>").text(status));
+    const buttons = $("<td>").addClass("text-end");
+    if (!alert.Acknowledged) {
+      buttons.append(
+        $("<button type='button' class='btn btn-sm btn-primary ms-1'>").text("Acknowledge")
+          .click(function () { acknowledgeAlert(alert.Id); })
+      );
+    }
+    buttons.append(
+      $("<button type='button' class='btn btn-sm btn-secondary ms-1'>").text("Snooze")
+        .click(function () { snoozeAlert(alert.Id); })
+    );
+    row.append(buttons);
+    alertRows.append(row);
+  });
+  $("#noFieldAlerts").toggle(data.Alerts.length === 0);
+};
+
+$(function () {
+  // Set up the websocket back to the server.
+  websocket = new CheesyWebsocket("/field_alerts/websocket", {
+    fieldAlerts: function (event) {
+      handleFieldAlerts(event.data);
+    },
+  });
+});
@@ -13,6 +13,11 @@ const loadMatch = function (matchId) {
   websocket.send("loadMatch", {matchId: matchId});
 }
 
+// Sends a websocket message to build a practice match from the teams in the walk-up queue and load it.
+const loadPracticeQueueMatch = function () {
+  websocket.send("loadPracticeQueueMatch");
+}
+
 // Sends a websocket message to load the results for the specified match into the display buffer.
 const showResult = function (matchId) {
   websocket.send("showResult", {matchId: matchId});
@@ -183,6 +188,13 @@ const handleArenaStatus = function (data) {
     }
   });
 
+  // Show which team is holding up the match if robot inspection is being enforced.
+  if (data.InspectionWarning) {
+    $("#inspectionWarning").text("Inspection: " + data.InspectionWarning).show();
+  } else {
+    $("#inspectionWarning").hide();
+  }
+
   // Enable/disable the buttons based on the current match state.
   switch (matchStates[data.MatchState]) {
     case "PRE_MATCH":
@@ -362,6 +374,16 @@ const updateScoreStatus = function (data, position, element, displayName) {
 };
 
 // Handles a websocket message to update the alliance station display screen selector.
+// Handles a websocket message to list the field alerts that haven't yet been acknowledged or snoozed.
+const handleFieldAlerts = function (data) {
+  const alerts = data.Alerts.filter(alert => !alert.Acknowledged && !alert.Snoozed);
+  $("#fieldAlertList").empty();
+  $.each(alerts, function (i, alert) {
+    $("#fieldAlertList").append($("<li>").text(alert.Message));
+  });
+  $("#fieldAlerts").toggle(alerts.length > 0);
+};
+
 const handleAllianceStationDisplayMode = function (data) {
   $("input[name=allianceStationDisplay]:checked").prop("checked", false);
   $("input[name=allianceStationDisplay][value=" + data + "]").prop("checked", true);
@@ -407,6 +429,9 @@ $(function () {
     eventStatus: function (event) {
       handleEventStatus(event.data);
     },
+    fieldAlerts: function (event) {
+      handleFieldAlerts(event.data);
+    },
     matchLoad: function (event) {
       handleMatchLoad(event.data);
     },
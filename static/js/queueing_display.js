@@ -12,6 +12,15 @@ var handleMatchLoad = function (data) {
     .then(html => $("#matches").html(html));
 };
 
+// Sends the team number that was entered to be checked in to the practice match walk-up queue.
+var checkInPracticeTeam = function () {
+  var teamId = parseInt($("#practiceQueueTeamId").val());
+  if (teamId) {
+    websocket.send("checkInPracticeTeam", teamId);
+  }
+  $("#practiceQueueTeamId").val("");
+};
+
 // Handles a websocket message to update the match time countdown.
 var handleMatchTime = function (data) {
   translateMatchTime(data, function (matchState, matchStateText, countdownSec) {
@@ -45,5 +54,8 @@ $(function () {
     matchTiming: function (event) {
       handleMatchTiming(event.data);
     },
+    practiceQueue: function (event) {
+      handleMatchLoad(event.data);
+    },
   });
 });
@@ -10,6 +10,16 @@ var playSound = function (sound) {
   websocket.send("playSound", sound);
 };
 
+// Sends a websocket message to flip the value of the given simulated PLC input.
+var togglePlcInput = function (index) {
+  websocket.send("setPlcInput", {Index: index, Value: $("#input" + index).attr("data-plc-value") !== "true"});
+};
+
+// Sends a websocket message to set the value of the given simulated PLC register.
+var setPlcRegister = function (index, value) {
+  websocket.send("setPlcRegister", {Index: index, Value: parseInt(value)});
+};
+
 // Handles a websocket message to update the PLC IO status.
 var handlePlcIoChange = function (data) {
   $.each(data.Inputs, function (index, input) {
@@ -18,7 +28,14 @@ var handlePlcIoChange = function (data) {
   });
 
   $.each(data.Registers, function (index, register) {
-    $("#register" + index).text(register)
+    const registerValue = $("#registerValue" + index);
+    if (registerValue.length > 0) {
+      if (!registerValue.is(":focus")) {
+        registerValue.val(register);
+      }
+    } else {
+      $("#register" + index).text(register)
+    }
   });
 
   $.each(data.Coils, function (index, coil) {
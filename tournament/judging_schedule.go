@@ -68,6 +68,11 @@ func BuildJudgingSchedule(database *model.Database, params JudgingScheduleParams
 		return fmt.Errorf("error getting schedule blocks: %v", err)
 	}
 
+	unavailabilities, err := database.GetAllTeamUnavailabilities()
+	if err != nil {
+		return fmt.Errorf("error getting team unavailabilities: %v", err)
+	}
+
 	// Create a map of teams to their matches.
 	teamMatches := createTeamMatchMap(teams, matches)
 
@@ -118,7 +123,7 @@ func BuildJudgingSchedule(database *model.Database, params JudgingScheduleParams
 				continue
 			}
 
-			slot, err := getNextSlotForTeam(team, candidateTime, teamMatches[team.Id], params)
+			slot, err := getNextSlotForTeam(team, candidateTime, teamMatches[team.Id], params, unavailabilities)
 			if err != nil {
 				return fmt.Errorf("error finding next slot for team %d: %v", team.Id, err)
 			}
@@ -214,12 +219,37 @@ func createTeamMatchMap(teams []model.Team, matches []model.Match) map[int][]mod
 	return teamMatches
 }
 
-// getNextSlotForTeam finds the next available judging slot for a team at or after the given candidate time.
+// getNextSlotForTeam finds the next available judging slot for a team at or after the given candidate time, skipping
+// past any windows during which the team is unavailable.
 func getNextSlotForTeam(
 	team model.Team,
 	candidateTime time.Time,
 	matches []model.Match,
 	params JudgingScheduleParams,
+	unavailabilities []model.TeamUnavailability,
+) (*model.JudgingSlot, error) {
+	for {
+		slot, err := getNextSlotForTeamIgnoringAvailability(team, candidateTime, matches, params)
+		if err != nil {
+			return nil, err
+		}
+		slotEndTime := slot.Time.Add(time.Duration(params.DurationMinutes) * time.Minute)
+		unavailability := findIntervalUnavailability(unavailabilities, team.Id, slot.Time, slotEndTime)
+		if unavailability == nil {
+			return slot, nil
+		}
+		// Each window can only be skipped once since the candidate time never moves backwards, so this terminates.
+		candidateTime = unavailability.EndTime
+	}
+}
+
+// getNextSlotForTeamIgnoringAvailability finds the next judging slot for a team at or after the given candidate time
+// that fits between its matches.
+func getNextSlotForTeamIgnoringAvailability(
+	team model.Team,
+	candidateTime time.Time,
+	matches []model.Match,
+	params JudgingScheduleParams,
 ) (*model.JudgingSlot, error) {
 	if len(matches) == 0 {
 		return nil, fmt.Errorf("no qualification matches for team")
@@ -62,7 +62,7 @@ func TestBuildJudgingSchedule(t *testing.T) {
 	for _, block := range scheduleBlocks {
 		assert.Nil(t, database.CreateScheduleBlock(&block))
 	}
-	matches, err := BuildRandomSchedule(teams, scheduleBlocks, model.Qualification)
+	matches, err := BuildRandomSchedule(teams, scheduleBlocks, model.Qualification, nil)
 	assert.Nil(t, err)
 	for _, match := range matches {
 		assert.Nil(t, database.CreateMatch(&match))
@@ -179,7 +179,7 @@ func TestGetNextSlotForTeamAfterLastMatch(t *testing.T) {
 	}
 	candidateTime := time.Date(2025, 4, 1, 11, 0, 0, 0, time.UTC)
 
-	slot, err := getNextSlotForTeam(team, candidateTime, matches, params)
+	slot, err := getNextSlotForTeam(team, candidateTime, matches, params, nil)
 	assert.Nil(t, err)
 	assert.Equal(t, candidateTime, slot.Time)
 }
@@ -262,3 +262,51 @@ func TestBuildJudgingScheduleAllowsEndDuringBreak(t *testing.T) {
 		assert.Equal(t, scheduleBlocks[0].StartTime.Add(10*time.Minute), slots[0].Time)
 	}
 }
+
+func TestGetNextSlotForTeamSkipsUnavailability(t *testing.T) {
+	params := JudgingScheduleParams{
+		NumJudges:              1,
+		DurationMinutes:        20,
+		PreviousSpacingMinutes: 30,
+		NextSpacingMinutes:     10,
+	}
+	team := model.Team{Id: 1}
+	matches := []model.Match{
+		{
+			Type:      model.Qualification,
+			TypeOrder: 1,
+			Time:      time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC),
+			Red1:      team.Id,
+		},
+		{
+			Type:      model.Qualification,
+			TypeOrder: 2,
+			Time:      time.Date(2025, 4, 1, 13, 0, 0, 0, time.UTC),
+			Red1:      team.Id,
+		},
+	}
+	unavailabilities := []model.TeamUnavailability{
+		{
+			TeamId:    2,
+			StartTime: time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2025, 4, 1, 18, 0, 0, 0, time.UTC),
+		},
+		{
+			TeamId:    team.Id,
+			StartTime: time.Date(2025, 4, 1, 9, 45, 0, 0, time.UTC),
+			EndTime:   time.Date(2025, 4, 1, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			TeamId:    team.Id,
+			StartTime: time.Date(2025, 4, 1, 10, 40, 0, 0, time.UTC),
+			EndTime:   time.Date(2025, 4, 1, 11, 0, 0, 0, time.UTC),
+		},
+	}
+
+	// The earliest slot would start at 9:30 and overlap the first window, and the next one would overlap the second.
+	slot, err := getNextSlotForTeam(team, matches[0].Time, matches, params, unavailabilities)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2025, 4, 1, 11, 0, 0, 0, time.UTC), slot.Time)
+	assert.Equal(t, 1, slot.PreviousMatchNumber)
+	assert.Equal(t, 2, slot.NextMatchNumber)
+}
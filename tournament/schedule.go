@@ -20,11 +20,20 @@ import (
 const (
 	schedulesDir  = "schedules"
 	TeamsPerMatch = 6
+
+	// Number of random mappings of teams onto the pre-randomized schedule to try when looking for one that doesn't
+	// schedule any team while it is unavailable.
+	maxTeamShuffleAttempts = 1000
 )
 
-// Creates a random schedule for the given parameters and returns it as a list of matches.
+// Creates a random schedule for the given parameters and returns it as a list of matches. If any teams have
+// unavailability windows, the mapping of teams onto the schedule is chosen to minimize the number of matches that start
+// while a team is unavailable.
 func BuildRandomSchedule(
-	teams []model.Team, scheduleBlocks []model.ScheduleBlock, matchType model.MatchType,
+	teams []model.Team,
+	scheduleBlocks []model.ScheduleBlock,
+	matchType model.MatchType,
+	unavailabilities []model.TeamUnavailability,
 ) ([]model.Match, error) {
 	// Load the anonymized, pre-randomized match schedule for the given number of teams and matches per team.
 	numTeams := len(teams)
@@ -61,23 +70,33 @@ func BuildRandomSchedule(
 		}
 	}
 
-	// Generate a random permutation of the team ordering to fill into the pre-randomized schedule.
-	teamShuffle := rand.Perm(numTeams)
+	// Generate a random permutation of the team ordering to fill into the pre-randomized schedule, retrying if needed to
+	// find one that respects the teams' availability.
+	matchTimes := getMatchTimes(scheduleBlocks, numMatches)
+	var teamShuffle []int
+	minConflicts := -1
+	for attempt := 0; attempt < maxTeamShuffleAttempts && minConflicts != 0; attempt++ {
+		candidateShuffle := rand.Perm(numTeams)
+		conflicts := 0
+		for i, anonMatch := range anonSchedule {
+			for j := 0; j < 12; j += 2 {
+				if isUnavailableForMatch(unavailabilities, teams[candidateShuffle[anonMatch[j]-1]].Id, matchTimes[i]) {
+					conflicts++
+				}
+			}
+		}
+		if minConflicts < 0 || conflicts < minConflicts {
+			teamShuffle = candidateShuffle
+			minConflicts = conflicts
+		}
+	}
+
 	matches := make([]model.Match, numMatches)
 	for i, anonMatch := range anonSchedule {
-		matches[i].Type = matchType
-		matches[i].TypeOrder = i + 1
-		if matchType == model.Practice {
-			matches[i].ShortName = fmt.Sprintf("P%d", i+1)
-			matches[i].LongName = fmt.Sprintf("Practice %d", i+1)
-			matches[i].TbaMatchKey.CompLevel = "p"
-		} else if matchType == model.Qualification {
-			matches[i].ShortName = fmt.Sprintf("Q%d", i+1)
-			matches[i].LongName = fmt.Sprintf("Qualification %d", i+1)
-			matches[i].TbaMatchKey.CompLevel = "qm"
-		} else {
-			return nil, fmt.Errorf("invalid match type %q", matchType)
+		if err = setScheduledMatchIdentity(&matches[i], matchType, i+1); err != nil {
+			return nil, err
 		}
+		matches[i].Time = matchTimes[i]
 		matches[i].Red1 = teams[teamShuffle[anonMatch[0]-1]].Id
 		matches[i].Red1IsSurrogate = anonMatch[1] == 1
 		matches[i].Red2 = teams[teamShuffle[anonMatch[2]-1]].Id
@@ -90,19 +109,39 @@ func BuildRandomSchedule(
 		matches[i].Blue2IsSurrogate = anonMatch[9] == 1
 		matches[i].Blue3 = teams[teamShuffle[anonMatch[10]-1]].Id
 		matches[i].Blue3IsSurrogate = anonMatch[11] == 1
-		matches[i].TbaMatchKey.MatchNumber = i + 1
 	}
 
-	// Fill in the match times.
-	matchIndex := 0
+	return matches, nil
+}
+
+// Sets the type, order, names, and TBA key of the given practice or qualification match.
+func setScheduledMatchIdentity(match *model.Match, matchType model.MatchType, typeOrder int) error {
+	match.Type = matchType
+	match.TypeOrder = typeOrder
+	if matchType == model.Practice {
+		match.ShortName = fmt.Sprintf("P%d", typeOrder)
+		match.LongName = fmt.Sprintf("Practice %d", typeOrder)
+		match.TbaMatchKey.CompLevel = "p"
+	} else if matchType == model.Qualification {
+		match.ShortName = fmt.Sprintf("Q%d", typeOrder)
+		match.LongName = fmt.Sprintf("Qualification %d", typeOrder)
+		match.TbaMatchKey.CompLevel = "qm"
+	} else {
+		return fmt.Errorf("invalid match type %q", matchType)
+	}
+	match.TbaMatchKey.MatchNumber = typeOrder
+	return nil
+}
+
+// Returns the start times of the given number of matches when run back-to-back within the given schedule blocks.
+func getMatchTimes(scheduleBlocks []model.ScheduleBlock, numMatches int) []time.Time {
+	matchTimes := make([]time.Time, 0, numMatches)
 	for _, block := range scheduleBlocks {
-		for i := 0; i < block.NumMatches && matchIndex < numMatches; i++ {
-			matches[matchIndex].Time = block.StartTime.Add(time.Duration(i*block.MatchSpacingSec) * time.Second)
-			matchIndex++
+		for i := 0; i < block.NumMatches && len(matchTimes) < numMatches; i++ {
+			matchTimes = append(matchTimes, block.StartTime.Add(time.Duration(i*block.MatchSpacingSec)*time.Second))
 		}
 	}
-
-	return matches, nil
+	return matchTimes
 }
 
 // Returns the total number of matches that can be run within the given schedule blocks.
@@ -0,0 +1,325 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Generation of practice and qualification match schedules for any number of teams and matches by optimizing a random
+// initial schedule using simulated annealing.
+
+package tournament
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+const (
+	// Number of annealing iterations to run for each team slot in the schedule.
+	annealingIterationsPerSlot  = 500
+	annealingInitialTemperature = 200.0
+	annealingFinalTemperature   = 0.1
+
+	// Relative costs of the undesirable properties of a schedule; the optimizer minimizes their weighted sum.
+	duplicateTeamCost      = 1000000
+	unavailableTeamCost    = 100000
+	turnaroundTooShortCost = 10000
+	turnaroundUnevenCost   = 20
+	repeatedPartnerCost    = 100
+	repeatedOpponentCost   = 40
+	allianceImbalanceCost  = 25
+	stationImbalanceCost   = 5
+)
+
+// Creates an optimized schedule for the given parameters and returns it as a list of matches. Unlike
+// BuildRandomSchedule, it doesn't depend on a pre-randomized template and so works for any number of teams and
+// matches. The schedule is optimized to avoid scheduling teams while they are unavailable, to give every team at least
+// the given turnaround time between matches, to spread each team's matches evenly, to balance each team's appearances
+// between alliances and stations, and to minimize the number of times any two teams are partnered with or against each
+// other more than once.
+func BuildOptimizedSchedule(
+	teams []model.Team,
+	scheduleBlocks []model.ScheduleBlock,
+	matchType model.MatchType,
+	minTurnaroundSec int,
+	unavailabilities []model.TeamUnavailability,
+) ([]model.Match, error) {
+	return buildOptimizedSchedule(
+		teams,
+		scheduleBlocks,
+		matchType,
+		minTurnaroundSec,
+		unavailabilities,
+		rand.New(rand.NewSource(time.Now().UnixNano())),
+	)
+}
+
+func buildOptimizedSchedule(
+	teams []model.Team,
+	scheduleBlocks []model.ScheduleBlock,
+	matchType model.MatchType,
+	minTurnaroundSec int,
+	unavailabilities []model.TeamUnavailability,
+	random *rand.Rand,
+) ([]model.Match, error) {
+	numTeams := len(teams)
+	if numTeams < TeamsPerMatch {
+		return nil, fmt.Errorf("There must be at least %d teams to generate a schedule", TeamsPerMatch)
+	}
+	numMatches := countMatches(scheduleBlocks)
+	matchesPerTeam := numMatches * TeamsPerMatch / numTeams
+	if matchesPerTeam == 0 {
+		return nil, fmt.Errorf("There are too few matches for every team to play at least once")
+	}
+
+	// Adjust the number of matches to remove any excess from non-perfect block scheduling.
+	numMatches = int(math.Ceil(float64(numTeams) * float64(matchesPerTeam) / TeamsPerMatch))
+	matchTimes := getMatchTimes(scheduleBlocks, numMatches)
+
+	optimizer := newScheduleOptimizer(numTeams, matchTimes, minTurnaroundSec, random)
+	for teamIndex, team := range teams {
+		for match, matchTime := range matchTimes {
+			optimizer.unavailable[teamIndex][match] = isUnavailableForMatch(unavailabilities, team.Id, matchTime)
+		}
+	}
+	optimizer.initialize()
+	optimizer.anneal()
+
+	matches := make([]model.Match, numMatches)
+	appearances := make([]int, numTeams)
+	for i := range matches {
+		if err := setScheduledMatchIdentity(&matches[i], matchType, i+1); err != nil {
+			return nil, err
+		}
+		matches[i].Time = matchTimes[i]
+		for station := 0; station < TeamsPerMatch; station++ {
+			teamIndex := optimizer.slots[i*TeamsPerMatch+station]
+			appearances[teamIndex]++
+			// Teams that must play an extra match to fill out the schedule do so as a surrogate in their third match
+			// (or their last one, if they play fewer), in keeping with the FRC convention.
+			isSurrogate := len(optimizer.teamMatches[teamIndex]) > matchesPerTeam &&
+				appearances[teamIndex] == min(3, matchesPerTeam+1)
+			setStation(&matches[i], station, teams[teamIndex].Id, isSurrogate)
+		}
+	}
+	if err := validateOptimizedSchedule(matches, unavailabilities); err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// Checks that no team appears more than once in the same match and that no team is scheduled while it is unavailable,
+// since the optimizer only penalizes these and so can leave them in place if it can't find a way around them.
+func validateOptimizedSchedule(matches []model.Match, unavailabilities []model.TeamUnavailability) error {
+	for _, match := range matches {
+		matchTeams := make(map[int]struct{})
+		for _, team := range []int{match.Red1, match.Red2, match.Red3, match.Blue1, match.Blue2, match.Blue3} {
+			if _, ok := matchTeams[team]; ok {
+				return fmt.Errorf("Unable to generate a schedule: team %d appears twice in %s", team, match.ShortName)
+			}
+			matchTeams[team] = struct{}{}
+		}
+	}
+	if conflicts := FindScheduleAvailabilityConflicts(matches, unavailabilities); len(conflicts) > 0 {
+		return fmt.Errorf("Unable to generate a schedule that avoids every unavailability window. %s", conflicts[0])
+	}
+	return nil
+}
+
+// Holds the working state of a schedule that is being optimized. The schedule is represented as a flat list of team
+// indices in which each consecutive group of six forms a match, in the order Red1-3 then Blue1-3.
+type scheduleOptimizer struct {
+	slots            []int
+	matchTimes       []time.Time
+	minTurnaroundSec int
+	idealGap         int
+	random           *rand.Rand
+	unavailable      [][]bool
+	teamMatches      [][]int
+	redCounts        []int
+	stationCounts    [][3]int
+	partnerCounts    [][]int
+	opponentCounts   [][]int
+	pairCost         int
+}
+
+// Creates an optimizer holding an empty schedule, so that availability constraints can be set before it is initialized.
+func newScheduleOptimizer(
+	numTeams int, matchTimes []time.Time, minTurnaroundSec int, random *rand.Rand,
+) *scheduleOptimizer {
+	numSlots := len(matchTimes) * TeamsPerMatch
+	optimizer := scheduleOptimizer{
+		matchTimes:       matchTimes,
+		minTurnaroundSec: minTurnaroundSec,
+		idealGap:         numTeams / TeamsPerMatch,
+		random:           random,
+		unavailable:      make([][]bool, numTeams),
+		teamMatches:      make([][]int, numTeams),
+		redCounts:        make([]int, numTeams),
+		stationCounts:    make([][3]int, numTeams),
+		partnerCounts:    make([][]int, numTeams),
+		opponentCounts:   make([][]int, numTeams),
+	}
+	for i := 0; i < numTeams; i++ {
+		optimizer.unavailable[i] = make([]bool, len(matchTimes))
+		optimizer.partnerCounts[i] = make([]int, numTeams)
+		optimizer.opponentCounts[i] = make([]int, numTeams)
+	}
+	optimizer.slots = make([]int, numSlots)
+	for slot := range optimizer.slots {
+		optimizer.slots[slot] = -1
+	}
+	return &optimizer
+}
+
+// Fills the empty schedule with a random one in which every team plays the same number of matches, plus one extra for
+// enough teams to fill the last match. Teams are placed one round at a time so that the starting point already spreads
+// out each team's matches.
+func (optimizer *scheduleOptimizer) initialize() {
+	numTeams := len(optimizer.teamMatches)
+	for slot := 0; slot < len(optimizer.slots); {
+		for _, team := range optimizer.random.Perm(numTeams) {
+			if slot < len(optimizer.slots) {
+				optimizer.addSlot(slot, team)
+				slot++
+			}
+		}
+	}
+}
+
+// Repeatedly swaps two randomly chosen teams in the schedule, keeping swaps that lower the cost and occasionally ones
+// that raise it (less often as the temperature falls) in order to escape local minima.
+func (optimizer *scheduleOptimizer) anneal() {
+	numSlots := len(optimizer.slots)
+	iterations := numSlots * annealingIterationsPerSlot
+	coolingRate := math.Pow(annealingFinalTemperature/annealingInitialTemperature, 1/float64(iterations))
+	temperature := annealingInitialTemperature
+	for i := 0; i < iterations; i++ {
+		slot1 := optimizer.random.Intn(numSlots)
+		slot2 := optimizer.random.Intn(numSlots)
+		if optimizer.slots[slot1] != optimizer.slots[slot2] {
+			delta := optimizer.swap(slot1, slot2)
+			if delta > 0 && optimizer.random.Float64() >= math.Exp(-float64(delta)/temperature) {
+				// Reject the swap by reverting it.
+				optimizer.swap(slot1, slot2)
+			}
+		}
+		temperature *= coolingRate
+	}
+}
+
+// Exchanges the teams in the given slots and returns the resulting change in the cost of the schedule.
+func (optimizer *scheduleOptimizer) swap(slot1, slot2 int) int {
+	team1 := optimizer.slots[slot1]
+	team2 := optimizer.slots[slot2]
+	oldCost := optimizer.pairCost + optimizer.teamCost(team1) + optimizer.teamCost(team2)
+
+	optimizer.removeSlot(slot1)
+	optimizer.removeSlot(slot2)
+	optimizer.addSlot(slot1, team2)
+	optimizer.addSlot(slot2, team1)
+
+	return optimizer.pairCost + optimizer.teamCost(team1) + optimizer.teamCost(team2) - oldCost
+}
+
+// Places the given team in the given empty slot.
+func (optimizer *scheduleOptimizer) addSlot(slot int, team int) {
+	optimizer.slots[slot] = team
+	optimizer.updateSlot(slot, 1)
+}
+
+// Removes the team from the given slot, leaving it empty.
+func (optimizer *scheduleOptimizer) removeSlot(slot int) {
+	optimizer.updateSlot(slot, -1)
+	optimizer.slots[slot] = -1
+}
+
+// Adds (if increment is 1) or removes (if it is -1) the contribution of the team in the given slot to the per-team
+// counts and to the cost of its pairings with the other teams currently in its match; empty slots are skipped so that
+// each pairing is counted exactly once.
+func (optimizer *scheduleOptimizer) updateSlot(slot int, increment int) {
+	team := optimizer.slots[slot]
+	match := slot / TeamsPerMatch
+	station := slot % TeamsPerMatch
+	isRed := station < 3
+
+	if increment > 0 {
+		optimizer.teamMatches[team] = append(optimizer.teamMatches[team], match)
+		sort.Ints(optimizer.teamMatches[team])
+	} else {
+		matches := optimizer.teamMatches[team]
+		for i, teamMatch := range matches {
+			if teamMatch == match {
+				optimizer.teamMatches[team] = append(matches[:i], matches[i+1:]...)
+				break
+			}
+		}
+	}
+	if isRed {
+		optimizer.redCounts[team] += increment
+	}
+	optimizer.stationCounts[team][station%3] += increment
+
+	for otherSlot := match * TeamsPerMatch; otherSlot < (match+1)*TeamsPerMatch; otherSlot++ {
+		otherTeam := optimizer.slots[otherSlot]
+		if otherSlot == slot || otherTeam == -1 {
+			continue
+		}
+		if otherTeam == team {
+			optimizer.pairCost += increment * duplicateTeamCost
+		} else if (otherSlot%TeamsPerMatch < 3) == isRed {
+			optimizer.pairCost += updatePairCount(optimizer.partnerCounts, team, otherTeam, increment) *
+				repeatedPartnerCost
+		} else {
+			optimizer.pairCost += updatePairCount(optimizer.opponentCounts, team, otherTeam, increment) *
+				repeatedOpponentCost
+		}
+	}
+}
+
+// Returns the cost of the given team's own schedule, i.e. its availability, turnaround times, and alliance and station
+// balance.
+func (optimizer *scheduleOptimizer) teamCost(team int) int {
+	cost := 0
+	matches := optimizer.teamMatches[team]
+	for _, match := range matches {
+		if optimizer.unavailable[team][match] {
+			cost += unavailableTeamCost
+		}
+	}
+	for i := 1; i < len(matches); i++ {
+		gapSec := int(optimizer.matchTimes[matches[i]].Sub(optimizer.matchTimes[matches[i-1]]).Seconds())
+		if gapSec < optimizer.minTurnaroundSec {
+			cost += turnaroundTooShortCost
+		}
+		if gap := matches[i] - matches[i-1]; gap < optimizer.idealGap {
+			cost += (optimizer.idealGap - gap) * (optimizer.idealGap - gap) * turnaroundUnevenCost
+		}
+	}
+
+	allianceImbalance := 2*optimizer.redCounts[team] - len(matches)
+	cost += allianceImbalance * allianceImbalance * allianceImbalanceCost
+	for _, stationCount := range optimizer.stationCounts[team] {
+		cost += stationCount * stationCount * stationImbalanceCost
+	}
+	return cost
+}
+
+// Increments or decrements the number of times the given two teams have been paired and returns the resulting change
+// in the number of repeated pairings, weighted so that each additional repeat of the same pairing costs more.
+func updatePairCount(counts [][]int, team1, team2, increment int) int {
+	oldCount := counts[team1][team2]
+	newCount := oldCount + increment
+	counts[team1][team2] = newCount
+	counts[team2][team1] = newCount
+	return pairingCost(newCount) - pairingCost(oldCount)
+}
+
+// Returns the cost of two teams having been paired the given number of times; pairing once is free.
+func pairingCost(count int) int {
+	if count <= 1 {
+		return 0
+	}
+	return (count - 1) * (count - 1)
+}
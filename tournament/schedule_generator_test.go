@@ -0,0 +1,161 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package tournament
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestBuildOptimizedSchedule(t *testing.T) {
+	teams := make([]model.Team, 38)
+	for i := range teams {
+		teams[i].Id = i + 101
+	}
+	startTime := time.Unix(1700000000, 0).UTC()
+	scheduleBlocks := []model.ScheduleBlock{
+		{StartTime: startTime, NumMatches: 40, MatchSpacingSec: 360},
+		{StartTime: startTime.Add(5 * time.Hour), NumMatches: 24, MatchSpacingSec: 360},
+	}
+	matches, err := buildOptimizedSchedule(
+		teams, scheduleBlocks, model.Qualification, 1200, nil, rand.New(rand.NewSource(0)),
+	)
+	assert.Nil(t, err)
+	if !assert.Equal(t, 64, len(matches)) {
+		return
+	}
+
+	assert.Equal(t, "Q1", matches[0].ShortName)
+	assert.Equal(t, "Qualification 64", matches[63].LongName)
+	assert.Equal(t, "qm64", matches[63].TbaMatchKey.String())
+	assert.Equal(t, startTime, matches[0].Time)
+	assert.Equal(t, startTime.Add(39*6*time.Minute), matches[39].Time)
+	assert.Equal(t, startTime.Add(5*time.Hour), matches[40].Time)
+
+	// Check that every team plays the same number of matches apart from their surrogate appearances.
+	nonSurrogateCounts := make(map[int]int)
+	for _, match := range matches {
+		teams := getMatchTeams(match)
+		surrogates := []bool{
+			match.Red1IsSurrogate,
+			match.Red2IsSurrogate,
+			match.Red3IsSurrogate,
+			match.Blue1IsSurrogate,
+			match.Blue2IsSurrogate,
+			match.Blue3IsSurrogate,
+		}
+		for i, team := range teams {
+			if !surrogates[i] {
+				nonSurrogateCounts[team]++
+			}
+		}
+	}
+	assert.Equal(t, 38, len(nonSurrogateCounts))
+	for team, count := range nonSurrogateCounts {
+		assert.Equal(t, 10, count, "team %d", team)
+	}
+
+	metrics := CalculateScheduleMetrics(matches)
+	assert.Equal(t, 38, metrics.NumTeams)
+	assert.Equal(t, 10, metrics.MinMatchesPerTeam)
+	assert.Equal(t, 11, metrics.MaxMatchesPerTeam)
+	assert.Equal(t, 4, metrics.NumSurrogates)
+	assert.GreaterOrEqual(t, metrics.MinTurnaround, 20*time.Minute)
+	assert.LessOrEqual(t, metrics.MaxAllianceImbalance, 2)
+	assert.LessOrEqual(t, metrics.MaxStationImbalance, 3)
+	assert.LessOrEqual(t, metrics.MaxTimesPartnered, 2)
+	assert.LessOrEqual(t, metrics.MaxTimesOpposed, 3)
+	assert.Nil(t, ValidateImportedSchedule(matches, teams))
+}
+
+func TestBuildOptimizedScheduleWithoutTemplate(t *testing.T) {
+	// No pre-randomized template exists for this many matches per team.
+	teams := make([]model.Team, 7)
+	for i := range teams {
+		teams[i].Id = i + 1
+	}
+	scheduleBlocks := []model.ScheduleBlock{{StartTime: time.Unix(0, 0).UTC(), NumMatches: 20, MatchSpacingSec: 360}}
+	_, err := BuildRandomSchedule(teams, scheduleBlocks, model.Practice, nil)
+	assert.NotNil(t, err)
+
+	matches, err := BuildOptimizedSchedule(teams, scheduleBlocks, model.Practice, 0, nil)
+	assert.Nil(t, err)
+	if assert.Equal(t, 20, len(matches)) {
+		assert.Equal(t, "P20", matches[19].ShortName)
+		assert.Equal(t, "p20", matches[19].TbaMatchKey.String())
+		metrics := CalculateScheduleMetrics(matches)
+		assert.Equal(t, 17, metrics.MinMatchesPerTeam)
+		assert.Equal(t, 18, metrics.MaxMatchesPerTeam)
+		assert.Equal(t, 1, metrics.NumSurrogates)
+		assert.Nil(t, ValidateImportedSchedule(matches, teams))
+	}
+}
+
+func TestBuildOptimizedScheduleErrors(t *testing.T) {
+	scheduleBlocks := []model.ScheduleBlock{{StartTime: time.Unix(0, 0).UTC(), NumMatches: 5, MatchSpacingSec: 360}}
+	_, err := BuildOptimizedSchedule(make([]model.Team, 5), scheduleBlocks, model.Qualification, 0, nil)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "There must be at least 6 teams to generate a schedule", err.Error())
+	}
+
+	_, err = BuildOptimizedSchedule(make([]model.Team, 31), scheduleBlocks, model.Qualification, 0, nil)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "There are too few matches for every team to play at least once", err.Error())
+	}
+
+	_, err = BuildOptimizedSchedule(make([]model.Team, 6), scheduleBlocks, model.Playoff, 0, nil)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "invalid match type \"Playoff\"", err.Error())
+	}
+}
+
+func TestBuildOptimizedScheduleUnavailability(t *testing.T) {
+	teams := make([]model.Team, 18)
+	for i := range teams {
+		teams[i].Id = i + 1
+	}
+	startTime := time.Date(2025, 4, 16, 9, 0, 0, 0, time.UTC)
+	scheduleBlocks := []model.ScheduleBlock{{StartTime: startTime, NumMatches: 24, MatchSpacingSec: 360}}
+
+	// Team 3 misses the first hour and team 4 leaves after the first hour and a half.
+	unavailabilities := []model.TeamUnavailability{
+		{TeamId: 3, StartTime: startTime.Add(-time.Hour), EndTime: startTime.Add(time.Hour)},
+		{TeamId: 4, StartTime: startTime.Add(90 * time.Minute), EndTime: startTime.Add(10 * time.Hour)},
+	}
+	matches, err := buildOptimizedSchedule(
+		teams, scheduleBlocks, model.Qualification, 0, unavailabilities, rand.New(rand.NewSource(0)),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, 24, len(matches))
+	assert.Empty(t, FindScheduleAvailabilityConflicts(matches, unavailabilities))
+
+	// Check that a window that can't be avoided results in an error rather than a conflicting schedule.
+	unavailabilities = append(
+		unavailabilities,
+		model.TeamUnavailability{TeamId: 5, StartTime: startTime, EndTime: startTime.Add(10 * time.Hour)},
+	)
+	matches, err = buildOptimizedSchedule(
+		teams, scheduleBlocks, model.Qualification, 0, unavailabilities, rand.New(rand.NewSource(0)),
+	)
+	assert.Nil(t, matches)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "Unable to generate a schedule that avoids every unavailability window.")
+		assert.Contains(t, err.Error(), "Team 5 is scheduled in")
+	}
+}
+
+func TestValidateOptimizedSchedule(t *testing.T) {
+	matches := []model.Match{
+		{ShortName: "Q1", Red1: 1, Red2: 2, Red3: 3, Blue1: 4, Blue2: 5, Blue3: 6},
+		{ShortName: "Q2", Red1: 1, Red2: 2, Red3: 3, Blue1: 4, Blue2: 5, Blue3: 2},
+	}
+	err := validateOptimizedSchedule(matches, nil)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "Unable to generate a schedule: team 2 appears twice in Q2", err.Error())
+	}
+	assert.Nil(t, validateOptimizedSchedule(matches[:1], nil))
+}
@@ -0,0 +1,234 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Functions for importing an existing practice or qualification match schedule instead of generating one.
+
+package tournament
+
+import (
+	"encoding/csv"
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Layout of the match times in the CSV schedule report.
+const scheduleCsvTimeLayout = "2006-01-02 15:04:05 -0700 MST"
+
+// Names of the columns in the CSV schedule report that are needed to import a schedule, in the order in which their
+// values are assigned to the team and surrogate fields of a match.
+var scheduleCsvStationColumns = []string{"Red1", "Red2", "Red3", "Blue1", "Blue2", "Blue3"}
+
+// Parses a schedule in the format of the CSV schedule report and returns it as a list of matches of the given type.
+// Columns are located by their header names, so additional or reordered columns are tolerated.
+func ImportScheduleFromCsv(reader io.Reader, matchType model.MatchType) ([]model.Match, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvLines, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV: %v", err)
+	}
+	if len(csvLines) == 0 {
+		return nil, fmt.Errorf("CSV file is empty")
+	}
+
+	columns := make(map[string]int)
+	for i, header := range csvLines[0] {
+		columns[strings.TrimSpace(header)] = i
+	}
+	requiredColumns := []string{"Match", "Type", "Time"}
+	for _, station := range scheduleCsvStationColumns {
+		requiredColumns = append(requiredColumns, station, station+"IsSurrogate")
+	}
+	for _, column := range requiredColumns {
+		if _, ok := columns[column]; !ok {
+			return nil, fmt.Errorf("CSV file is missing the %q column", column)
+		}
+	}
+
+	matches := []model.Match{}
+	for i, line := range csvLines[1:] {
+		lineNumber := i + 2
+		if len(line) == 1 && strings.TrimSpace(line[0]) == "" {
+			// Skip blank lines, such as the trailing one in the report.
+			continue
+		}
+		if len(line) < len(csvLines[0]) {
+			return nil, fmt.Errorf("line %d of CSV file has too few fields", lineNumber)
+		}
+		field := func(column string) string {
+			return strings.TrimSpace(line[columns[column]])
+		}
+
+		lineMatchType, err := model.MatchTypeFromString(field("Type"))
+		if err != nil {
+			return nil, fmt.Errorf("line %d of CSV file: %v", lineNumber, err)
+		}
+		if lineMatchType != matchType {
+			return nil, fmt.Errorf(
+				"line %d of CSV file is a %s match; expected only %s matches", lineNumber, lineMatchType, matchType,
+			)
+		}
+		typeOrder, err := strconv.Atoi(strings.TrimLeftFunc(field("Match"), unicode.IsLetter))
+		if err != nil {
+			return nil, fmt.Errorf("line %d of CSV file has invalid match name %q", lineNumber, field("Match"))
+		}
+
+		var match model.Match
+		if err = setScheduledMatchIdentity(&match, matchType, typeOrder); err != nil {
+			return nil, err
+		}
+		match.Time, err = parseScheduleCsvTime(field("Time"))
+		if err != nil {
+			return nil, fmt.Errorf("line %d of CSV file has invalid time %q", lineNumber, field("Time"))
+		}
+		for j, station := range scheduleCsvStationColumns {
+			team, err := strconv.Atoi(field(station))
+			if err != nil {
+				return nil, fmt.Errorf("line %d of CSV file has invalid %s team %q", lineNumber, station, field(station))
+			}
+			isSurrogate, err := strconv.ParseBool(field(station + "IsSurrogate"))
+			if err != nil {
+				return nil, fmt.Errorf(
+					"line %d of CSV file has invalid %s surrogate flag %q", lineNumber, station,
+					field(station+"IsSurrogate"),
+				)
+			}
+			setStation(&match, j, team, isSurrogate)
+		}
+		matches = append(matches, match)
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].TypeOrder < matches[j].TypeOrder
+	})
+	return matches, nil
+}
+
+// Converts the given list of matches published on The Blue Alliance into a qualification schedule. Matches of any
+// other competition level are ignored.
+func ImportScheduleFromTba(
+	tbaMatches []partner.TbaScheduledMatch, matchType model.MatchType,
+) ([]model.Match, error) {
+	if matchType != model.Qualification {
+		return nil, fmt.Errorf("only qualification schedules can be imported from The Blue Alliance")
+	}
+
+	matches := []model.Match{}
+	for _, tbaMatch := range tbaMatches {
+		if tbaMatch.CompLevel != "qm" {
+			continue
+		}
+
+		var match model.Match
+		if err := setScheduledMatchIdentity(&match, matchType, tbaMatch.MatchNumber); err != nil {
+			return nil, err
+		}
+		if tbaMatch.Time > 0 {
+			match.Time = time.Unix(tbaMatch.Time, 0)
+		}
+		for i, color := range []string{"red", "blue"} {
+			alliance := tbaMatch.Alliances[color]
+			if len(alliance.TeamKeys) != 3 {
+				return nil, fmt.Errorf(
+					"match %s has %d %s teams; expected 3", tbaMatch.Key, len(alliance.TeamKeys), color,
+				)
+			}
+			for j, teamKey := range alliance.TeamKeys {
+				team, err := parseTbaTeamKey(teamKey)
+				if err != nil {
+					return nil, fmt.Errorf("match %s: %v", tbaMatch.Key, err)
+				}
+				isSurrogate := false
+				for _, surrogateTeamKey := range alliance.SurrogateTeamKeys {
+					if surrogateTeamKey == teamKey {
+						isSurrogate = true
+					}
+				}
+				setStation(&match, 3*i+j, team, isSurrogate)
+			}
+		}
+		matches = append(matches, match)
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no qualification matches have been published on The Blue Alliance")
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].TypeOrder < matches[j].TypeOrder
+	})
+	return matches, nil
+}
+
+// Checks that the given imported schedule is consistent with the event's team list and that its matches are numbered
+// sequentially from 1, returning an error describing the first problem found.
+func ValidateImportedSchedule(matches []model.Match, teams []model.Team) error {
+	if len(matches) == 0 {
+		return fmt.Errorf("schedule contains no matches")
+	}
+	teamIds := make(map[int]struct{}, len(teams))
+	for _, team := range teams {
+		teamIds[team.Id] = struct{}{}
+	}
+
+	for i, match := range matches {
+		if match.TypeOrder != i+1 {
+			return fmt.Errorf(
+				"expected match %d but found %s; matches must be numbered sequentially", i+1, match.ShortName,
+			)
+		}
+		matchTeams := make(map[int]struct{})
+		for _, team := range []int{match.Red1, match.Red2, match.Red3, match.Blue1, match.Blue2, match.Blue3} {
+			if _, ok := teamIds[team]; !ok {
+				return fmt.Errorf("team %d in match %s is not in the team list", team, match.ShortName)
+			}
+			if _, ok := matchTeams[team]; ok {
+				return fmt.Errorf("team %d appears more than once in match %s", team, match.ShortName)
+			}
+			matchTeams[team] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// Assigns the given team to the station with the given index, in the order Red1-3 then Blue1-3.
+func setStation(match *model.Match, stationIndex int, team int, isSurrogate bool) {
+	switch stationIndex {
+	case 0:
+		match.Red1, match.Red1IsSurrogate = team, isSurrogate
+	case 1:
+		match.Red2, match.Red2IsSurrogate = team, isSurrogate
+	case 2:
+		match.Red3, match.Red3IsSurrogate = team, isSurrogate
+	case 3:
+		match.Blue1, match.Blue1IsSurrogate = team, isSurrogate
+	case 4:
+		match.Blue2, match.Blue2IsSurrogate = team, isSurrogate
+	case 5:
+		match.Blue3, match.Blue3IsSurrogate = team, isSurrogate
+	}
+}
+
+// Parses a match time as written by the CSV schedule report, tolerating the monotonic clock suffix that Go appends to
+// times that were not loaded from the database.
+func parseScheduleCsvTime(timeString string) (time.Time, error) {
+	if index := strings.Index(timeString, " m="); index >= 0 {
+		timeString = timeString[:index]
+	}
+	return time.Parse(scheduleCsvTimeLayout, timeString)
+}
+
+// Converts a TBA team key (e.g. "frc254") into the integer team number.
+func parseTbaTeamKey(teamKey string) (int, error) {
+	team, err := strconv.Atoi(strings.TrimPrefix(teamKey, "frc"))
+	if err != nil || !strings.HasPrefix(teamKey, "frc") {
+		return 0, fmt.Errorf("invalid team key %q", teamKey)
+	}
+	return team, nil
+}
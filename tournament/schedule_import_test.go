@@ -0,0 +1,180 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package tournament
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testScheduleCsv = "Match,Type,Time,Red1,Red1IsSurrogate,Red2,Red2IsSurrogate,Red3,Red3IsSurrogate,Blue1," +
+	"Blue1IsSurrogate,Blue2,Blue2IsSurrogate,Blue3,Blue3IsSurrogate\n" +
+	"Q2,Qualification,2025-04-16 09:07:00 -0700 PDT,7,false,8,false,9,false,10,false,11,false,12,true\n" +
+	"Q1,Qualification,2025-04-16 09:00:00 -0700 PDT,1,false,2,true,3,false,4,false,5,false,6,false\n"
+
+func TestImportScheduleFromCsv(t *testing.T) {
+	matches, err := ImportScheduleFromCsv(strings.NewReader(testScheduleCsv), model.Qualification)
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(matches)) {
+		assert.Equal(t, model.Qualification, matches[0].Type)
+		assert.Equal(t, 1, matches[0].TypeOrder)
+		assert.Equal(t, "Q1", matches[0].ShortName)
+		assert.Equal(t, "Qualification 1", matches[0].LongName)
+		assert.Equal(t, model.TbaMatchKey{CompLevel: "qm", MatchNumber: 1}, matches[0].TbaMatchKey)
+		assert.Equal(t, time.Date(2025, 4, 16, 16, 0, 0, 0, time.UTC), matches[0].Time.UTC())
+		assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, getMatchTeams(matches[0]))
+		assert.True(t, matches[0].Red2IsSurrogate)
+		assert.False(t, matches[0].Red1IsSurrogate)
+
+		assert.Equal(t, 2, matches[1].TypeOrder)
+		assert.Equal(t, "Q2", matches[1].ShortName)
+		assert.Equal(t, []int{7, 8, 9, 10, 11, 12}, getMatchTeams(matches[1]))
+		assert.True(t, matches[1].Blue3IsSurrogate)
+	}
+
+	// Check that a schedule exported with a monotonic clock reading in its times can still be read.
+	csvWithMonotonic := strings.Replace(testScheduleCsv, "-0700 PDT,7", "-0700 PDT m=+12.345678901,7", 1)
+	matches, err = ImportScheduleFromCsv(strings.NewReader(csvWithMonotonic), model.Qualification)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(matches))
+}
+
+func TestImportScheduleFromCsvErrors(t *testing.T) {
+	_, err := ImportScheduleFromCsv(strings.NewReader(""), model.Qualification)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "CSV file is empty", err.Error())
+	}
+
+	_, err = ImportScheduleFromCsv(strings.NewReader("Match,Type,Time\nQ1,Qualification,\n"), model.Qualification)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "CSV file is missing the \"Red1\" column", err.Error())
+	}
+
+	_, err = ImportScheduleFromCsv(strings.NewReader(testScheduleCsv), model.Practice)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "line 2 of CSV file is a Qualification match; expected only Practice matches", err.Error())
+	}
+
+	_, err = ImportScheduleFromCsv(
+		strings.NewReader(strings.Replace(testScheduleCsv, ",7,false", ",seven,false", 1)), model.Qualification,
+	)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "line 2 of CSV file has invalid Red1 team \"seven\"", err.Error())
+	}
+
+	_, err = ImportScheduleFromCsv(
+		strings.NewReader(strings.Replace(testScheduleCsv, "12,true", "12,maybe", 1)), model.Qualification,
+	)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "line 2 of CSV file has invalid Blue3 surrogate flag \"maybe\"", err.Error())
+	}
+
+	_, err = ImportScheduleFromCsv(
+		strings.NewReader(strings.Replace(testScheduleCsv, "2025-04-16 09:00:00", "9am", 1)), model.Qualification,
+	)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "line 3 of CSV file has invalid time \"9am -0700 PDT\"", err.Error())
+	}
+}
+
+func TestImportScheduleFromTba(t *testing.T) {
+	tbaMatches := []partner.TbaScheduledMatch{
+		{
+			Key:         "2025casj_qm2",
+			CompLevel:   "qm",
+			SetNumber:   1,
+			MatchNumber: 2,
+			Alliances: map[string]partner.TbaScheduledAlliance{
+				"red":  {TeamKeys: []string{"frc7", "frc8", "frc9"}},
+				"blue": {TeamKeys: []string{"frc10", "frc11", "frc12"}, SurrogateTeamKeys: []string{"frc11"}},
+			},
+			Time: 1744819620,
+		},
+		{
+			Key:         "2025casj_sf1m1",
+			CompLevel:   "sf",
+			SetNumber:   1,
+			MatchNumber: 1,
+		},
+		{
+			Key:         "2025casj_qm1",
+			CompLevel:   "qm",
+			SetNumber:   1,
+			MatchNumber: 1,
+			Alliances: map[string]partner.TbaScheduledAlliance{
+				"red":  {TeamKeys: []string{"frc1", "frc2", "frc3"}},
+				"blue": {TeamKeys: []string{"frc4", "frc5", "frc6"}},
+			},
+			Time: 1744819200,
+		},
+	}
+	matches, err := ImportScheduleFromTba(tbaMatches, model.Qualification)
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(matches)) {
+		assert.Equal(t, "Q1", matches[0].ShortName)
+		assert.Equal(t, "qm1", matches[0].TbaMatchKey.String())
+		assert.Equal(t, time.Unix(1744819200, 0), matches[0].Time)
+		assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, getMatchTeams(matches[0]))
+
+		assert.Equal(t, "Q2", matches[1].ShortName)
+		assert.Equal(t, "qm2", matches[1].TbaMatchKey.String())
+		assert.Equal(t, []int{7, 8, 9, 10, 11, 12}, getMatchTeams(matches[1]))
+		assert.True(t, matches[1].Blue2IsSurrogate)
+		assert.False(t, matches[1].Blue1IsSurrogate)
+	}
+
+	_, err = ImportScheduleFromTba(tbaMatches, model.Practice)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "only qualification schedules can be imported from The Blue Alliance", err.Error())
+	}
+
+	_, err = ImportScheduleFromTba(tbaMatches[1:2], model.Qualification)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "no qualification matches have been published on The Blue Alliance", err.Error())
+	}
+
+	tbaMatches[0].Alliances["red"] = partner.TbaScheduledAlliance{TeamKeys: []string{"frc7", "frc8"}}
+	_, err = ImportScheduleFromTba(tbaMatches, model.Qualification)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "match 2025casj_qm2 has 2 red teams; expected 3", err.Error())
+	}
+
+	tbaMatches[0].Alliances["red"] = partner.TbaScheduledAlliance{TeamKeys: []string{"frc7", "frc8", "254"}}
+	_, err = ImportScheduleFromTba(tbaMatches, model.Qualification)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "match 2025casj_qm2: invalid team key \"254\"", err.Error())
+	}
+}
+
+func TestValidateImportedSchedule(t *testing.T) {
+	teams := make([]model.Team, 12)
+	for i := range teams {
+		teams[i].Id = i + 1
+	}
+	matches, _ := ImportScheduleFromCsv(strings.NewReader(testScheduleCsv), model.Qualification)
+	assert.Nil(t, ValidateImportedSchedule(matches, teams))
+
+	assert.EqualError(t, ValidateImportedSchedule([]model.Match{}, teams), "schedule contains no matches")
+	assert.EqualError(
+		t,
+		ValidateImportedSchedule(matches, teams[:11]),
+		"team 12 in match Q2 is not in the team list",
+	)
+	assert.EqualError(
+		t,
+		ValidateImportedSchedule(matches[1:], teams),
+		"expected match 1 but found Q2; matches must be numbered sequentially",
+	)
+
+	matches[1].Blue1 = 7
+	assert.EqualError(t, ValidateImportedSchedule(matches, teams), "team 7 appears more than once in match Q2")
+}
+
+func getMatchTeams(match model.Match) []int {
+	return []int{match.Red1, match.Red2, match.Red3, match.Blue1, match.Blue2, match.Blue3}
+}
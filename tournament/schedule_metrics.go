@@ -0,0 +1,149 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Measures of the quality of a practice or qualification match schedule.
+
+package tournament
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"sort"
+	"time"
+)
+
+// Summarizes how fair a schedule is to the teams playing in it.
+type ScheduleMetrics struct {
+	NumMatches           int
+	NumTeams             int
+	MinMatchesPerTeam    int
+	MaxMatchesPerTeam    int
+	NumSurrogates        int
+	MinTurnaroundMatches int
+	MinTurnaround        time.Duration
+	MaxAllianceImbalance int
+	MaxStationImbalance  int
+	RepeatedPartners     int
+	MaxTimesPartnered    int
+	RepeatedOpponents    int
+	MaxTimesOpposed      int
+}
+
+// Calculates the quality metrics for the given schedule. The minimum turnaround is the shortest interval, in matches
+// and in time, between the starts of any team's consecutive matches. The alliance imbalance for a team is the
+// difference between the number of times it plays on red and on blue, and its station imbalance is the difference
+// between the number of times it plays in its most and least frequent station positions. A repeated partner or opponent
+// is counted for each time two teams are paired with or against each other beyond the first.
+func CalculateScheduleMetrics(matches []model.Match) ScheduleMetrics {
+	metrics := ScheduleMetrics{NumMatches: len(matches)}
+	if len(matches) == 0 {
+		return metrics
+	}
+
+	type teamStats struct {
+		matchIndices  []int
+		redCount      int
+		stationCounts [3]int
+	}
+	stats := make(map[int]*teamStats)
+	partnerCounts := make(map[[2]int]int)
+	opponentCounts := make(map[[2]int]int)
+	for i, match := range matches {
+		alliances := [2][3]int{{match.Red1, match.Red2, match.Red3}, {match.Blue1, match.Blue2, match.Blue3}}
+		surrogates := []bool{
+			match.Red1IsSurrogate,
+			match.Red2IsSurrogate,
+			match.Red3IsSurrogate,
+			match.Blue1IsSurrogate,
+			match.Blue2IsSurrogate,
+			match.Blue3IsSurrogate,
+		}
+		for allianceIndex, alliance := range alliances {
+			for station, team := range alliance {
+				if team == 0 {
+					continue
+				}
+				if _, ok := stats[team]; !ok {
+					stats[team] = new(teamStats)
+				}
+				stats[team].matchIndices = append(stats[team].matchIndices, i)
+				if allianceIndex == 0 {
+					stats[team].redCount++
+				}
+				stats[team].stationCounts[station]++
+				if surrogates[3*allianceIndex+station] {
+					metrics.NumSurrogates++
+				}
+
+				for _, partner := range alliance[station+1:] {
+					if partner != 0 {
+						partnerCounts[teamPair(team, partner)]++
+					}
+				}
+				if allianceIndex == 0 {
+					for _, opponent := range alliances[1] {
+						if opponent != 0 {
+							opponentCounts[teamPair(team, opponent)]++
+						}
+					}
+				}
+			}
+		}
+	}
+
+	metrics.NumTeams = len(stats)
+	metrics.MinMatchesPerTeam = len(matches)
+	metrics.MinTurnaroundMatches = len(matches)
+	metrics.MinTurnaround = -1
+	for _, stat := range stats {
+		numMatches := len(stat.matchIndices)
+		metrics.MinMatchesPerTeam = min(metrics.MinMatchesPerTeam, numMatches)
+		metrics.MaxMatchesPerTeam = max(metrics.MaxMatchesPerTeam, numMatches)
+		for i := 1; i < numMatches; i++ {
+			previousMatch := stat.matchIndices[i-1]
+			currentMatch := stat.matchIndices[i]
+			metrics.MinTurnaroundMatches = min(metrics.MinTurnaroundMatches, currentMatch-previousMatch)
+			turnaround := matches[currentMatch].Time.Sub(matches[previousMatch].Time)
+			if metrics.MinTurnaround < 0 || turnaround < metrics.MinTurnaround {
+				metrics.MinTurnaround = turnaround
+			}
+		}
+		metrics.MaxAllianceImbalance = max(metrics.MaxAllianceImbalance, abs(2*stat.redCount-numMatches))
+		stationCounts := stat.stationCounts[:]
+		sort.Ints(stationCounts)
+		metrics.MaxStationImbalance = max(metrics.MaxStationImbalance, stationCounts[2]-stationCounts[0])
+	}
+	if metrics.MinTurnaround < 0 {
+		// No team plays more than once.
+		metrics.MinTurnaroundMatches = 0
+		metrics.MinTurnaround = 0
+	}
+
+	metrics.RepeatedPartners, metrics.MaxTimesPartnered = countRepeatedPairings(partnerCounts)
+	metrics.RepeatedOpponents, metrics.MaxTimesOpposed = countRepeatedPairings(opponentCounts)
+	return metrics
+}
+
+// Returns a key that identifies the given two teams regardless of their order.
+func teamPair(team1, team2 int) [2]int {
+	if team1 > team2 {
+		return [2]int{team2, team1}
+	}
+	return [2]int{team1, team2}
+}
+
+// Returns the number of pairings beyond the first for each pair of teams, and the most times any pair was paired.
+func countRepeatedPairings(pairCounts map[[2]int]int) (int, int) {
+	repeated, maxCount := 0, 0
+	for _, count := range pairCounts {
+		repeated += count - 1
+		maxCount = max(maxCount, count)
+	}
+	return repeated, maxCount
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package tournament
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestCalculateScheduleMetrics(t *testing.T) {
+	assert.Equal(t, ScheduleMetrics{}, CalculateScheduleMetrics([]model.Match{}))
+
+	startTime := time.Unix(1700000000, 0).UTC()
+	matches := []model.Match{
+		{Time: startTime, Red1: 1, Red2: 2, Red3: 3, Blue1: 4, Blue2: 5, Blue3: 6},
+		{Time: startTime.Add(6 * time.Minute), Red1: 7, Red2: 8, Red3: 9, Blue1: 10, Blue2: 11, Blue3: 12},
+		{
+			Time:  startTime.Add(12 * time.Minute),
+			Red1:  4,
+			Red2:  5,
+			Red3:  1,
+			Blue1: 2,
+			Blue2: 3,
+			Blue3: 7,
+		},
+		{
+			Time:             startTime.Add(30 * time.Minute),
+			Red1:             12,
+			Red2:             11,
+			Red3:             10,
+			Blue1:            9,
+			Blue2:            8,
+			Blue3:            6,
+			Blue3IsSurrogate: true,
+		},
+	}
+	metrics := CalculateScheduleMetrics(matches)
+	assert.Equal(t, 4, metrics.NumMatches)
+	assert.Equal(t, 12, metrics.NumTeams)
+	assert.Equal(t, 2, metrics.MinMatchesPerTeam)
+	assert.Equal(t, 2, metrics.MaxMatchesPerTeam)
+	assert.Equal(t, 1, metrics.NumSurrogates)
+	assert.Equal(t, 1, metrics.MinTurnaroundMatches)
+	assert.Equal(t, 6*time.Minute, metrics.MinTurnaround)
+	assert.Equal(t, 2, metrics.MaxAllianceImbalance)
+	assert.Equal(t, 2, metrics.MaxStationImbalance)
+	// Teams 4 and 5 partner twice, as do 2 and 3, 8 and 9, 10 and 11, 10 and 12, and 11 and 12.
+	assert.Equal(t, 6, metrics.RepeatedPartners)
+	assert.Equal(t, 2, metrics.MaxTimesPartnered)
+	// Teams 2 and 3 each oppose 4 and 5 twice, and teams 8 and 9 each oppose 10, 11, and 12 twice.
+	assert.Equal(t, 10, metrics.RepeatedOpponents)
+	assert.Equal(t, 2, metrics.MaxTimesOpposed)
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Functions for re-flowing the times of the remaining matches in a schedule when the event is running early or late.
+
+package tournament
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"time"
+)
+
+// Gap between consecutive matches beyond which it is considered to be an unscheduled break (e.g. overnight) rather than
+// a regular cycle. Mirrors the threshold used by the arena when calculating how early or late the event is running.
+const retimingMaxMatchGap = 20 * time.Minute
+
+// Returns copies of the given remaining matches, in order, and of the scheduled breaks that fall between them, with
+// their times re-flowed so that the first match starts at the given time.
+//
+// If the event is running late, the delay is carried forward but each cycle between consecutive matches is shortened
+// by up to maxCompressionSec to catch back up, and no match or break is moved earlier than its original time. Scheduled
+// breaks keep their full duration, and the slack in any unscheduled gap is used to absorb the delay. If the event is
+// running early, matches are moved earlier by the same amount only until the next break or gap, since teams can't be
+// expected to show up before their originally published time after one.
+func RetimeSchedule(
+	matches []model.Match, scheduledBreaks []model.ScheduledBreak, firstMatchTime time.Time, maxCompressionSec int,
+) ([]model.Match, []model.ScheduledBreak) {
+	if len(matches) == 0 {
+		return nil, nil
+	}
+	maxCompression := time.Duration(maxCompressionSec) * time.Second
+
+	breaksByTypeOrder := make(map[int]model.ScheduledBreak, len(scheduledBreaks))
+	for _, scheduledBreak := range scheduledBreaks {
+		breaksByTypeOrder[scheduledBreak.TypeOrderBefore] = scheduledBreak
+	}
+
+	retimedMatches := make([]model.Match, len(matches))
+	copy(retimedMatches, matches)
+	retimedMatches[0].Time = firstMatchTime
+	var retimedBreaks []model.ScheduledBreak
+	earlyOffset := min(firstMatchTime.Sub(matches[0].Time), 0)
+	var lastCycle time.Duration
+	for i := 1; i < len(matches); i++ {
+		previousTime := matches[i-1].Time
+		previousNewTime := retimedMatches[i-1].Time
+		originalTime := matches[i].Time
+		var newTime time.Time
+		if scheduledBreak, ok := breaksByTypeOrder[matches[i].TypeOrder]; ok {
+			// Shift the break by any delay and keep the rest of the gap (i.e. the break's duration) intact.
+			earlyOffset = 0
+			originalBreakTime := scheduledBreak.Time
+			scheduledBreak.Time = latest(originalBreakTime, previousNewTime.Add(originalBreakTime.Sub(previousTime)))
+			retimedBreaks = append(retimedBreaks, scheduledBreak)
+			newTime = scheduledBreak.Time.Add(originalTime.Sub(originalBreakTime))
+		} else if gap := originalTime.Sub(previousTime); gap > retimingMaxMatchGap {
+			// Allow the previous match one regular cycle and absorb any remaining delay in the rest of the gap.
+			earlyOffset = 0
+			newTime = previousNewTime.Add(lastCycle)
+		} else {
+			lastCycle = gap
+			newTime = previousNewTime.Add(max(gap-maxCompression, 0))
+		}
+		retimedMatches[i].Time = latest(newTime, originalTime.Add(earlyOffset))
+	}
+	return retimedMatches, retimedBreaks
+}
+
+// Returns the later of the two given times.
+func latest(time1, time2 time.Time) time.Time {
+	if time1.After(time2) {
+		return time1
+	}
+	return time2
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package tournament
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var retimingStartTime = time.Date(2025, 4, 16, 9, 0, 0, 0, time.UTC)
+
+// Returns qualification matches at the given offsets in minutes from the start time.
+func buildRetimingMatches(offsetsMin ...int) []model.Match {
+	matches := make([]model.Match, len(offsetsMin))
+	for i, offsetMin := range offsetsMin {
+		matches[i] = model.Match{
+			Type:      model.Qualification,
+			TypeOrder: i + 1,
+			Time:      retimingStartTime.Add(time.Duration(offsetMin) * time.Minute),
+		}
+	}
+	return matches
+}
+
+func getRetimedOffsetsMin(matches []model.Match) []float64 {
+	offsetsMin := make([]float64, len(matches))
+	for i, match := range matches {
+		offsetsMin[i] = match.Time.Sub(retimingStartTime).Minutes()
+	}
+	return offsetsMin
+}
+
+func TestRetimeScheduleLate(t *testing.T) {
+	matches := buildRetimingMatches(0, 6, 12, 18, 24, 30)
+
+	// Check that the delay is recovered by at most the maximum compression per cycle.
+	retimedMatches, retimedBreaks := RetimeSchedule(matches, nil, retimingStartTime.Add(5*time.Minute), 120)
+	assert.Equal(t, []float64{5, 9, 13, 18, 24, 30}, getRetimedOffsetsMin(retimedMatches))
+	assert.Empty(t, retimedBreaks)
+
+	// Check that the original matches are left untouched.
+	assert.Equal(t, retimingStartTime, matches[0].Time)
+
+	// Check that the delay is carried forward unchanged without any compression.
+	retimedMatches, _ = RetimeSchedule(matches, nil, retimingStartTime.Add(5*time.Minute), 0)
+	assert.Equal(t, []float64{5, 11, 17, 23, 29, 35}, getRetimedOffsetsMin(retimedMatches))
+
+	// Check that a cycle is never compressed to less than nothing.
+	retimedMatches, _ = RetimeSchedule(matches, nil, retimingStartTime.Add(30*time.Minute), 600)
+	assert.Equal(t, []float64{30, 30, 30, 30, 30, 30}, getRetimedOffsetsMin(retimedMatches))
+}
+
+func TestRetimeScheduleEarly(t *testing.T) {
+	matches := buildRetimingMatches(0, 6, 12, 60, 66)
+
+	retimedMatches, _ := RetimeSchedule(matches, nil, retimingStartTime.Add(-3*time.Minute), 60)
+	assert.Equal(t, []float64{-3, 3, 9, 60, 66}, getRetimedOffsetsMin(retimedMatches))
+}
+
+func TestRetimeScheduleWithBreaks(t *testing.T) {
+	matches := buildRetimingMatches(0, 6, 12, 72, 78, 84)
+	scheduledBreak := model.ScheduledBreak{
+		Id:              1,
+		MatchType:       model.Qualification,
+		TypeOrderBefore: 4,
+		Time:            retimingStartTime.Add(18 * time.Minute),
+		DurationSec:     3240,
+		Description:     "Lunch",
+	}
+
+	// Check that the break is delayed but keeps its full duration.
+	retimedMatches, retimedBreaks := RetimeSchedule(
+		matches, []model.ScheduledBreak{scheduledBreak}, retimingStartTime.Add(10*time.Minute), 60,
+	)
+	assert.Equal(t, []float64{10, 15, 20, 80, 85, 90}, getRetimedOffsetsMin(retimedMatches))
+	if assert.Equal(t, 1, len(retimedBreaks)) {
+		assert.Equal(t, retimingStartTime.Add(26*time.Minute), retimedBreaks[0].Time)
+		assert.Equal(t, "Lunch", retimedBreaks[0].Description)
+	}
+
+	// Check that the break is never moved earlier, and that early matches resume their original times after it.
+	retimedMatches, retimedBreaks = RetimeSchedule(
+		matches, []model.ScheduledBreak{scheduledBreak}, retimingStartTime.Add(-4*time.Minute), 60,
+	)
+	assert.Equal(t, []float64{-4, 2, 8, 72, 78, 84}, getRetimedOffsetsMin(retimedMatches))
+	if assert.Equal(t, 1, len(retimedBreaks)) {
+		assert.Equal(t, scheduledBreak.Time, retimedBreaks[0].Time)
+	}
+}
+
+func TestRetimeScheduleAbsorbsDelayInGap(t *testing.T) {
+	// The gap between the third and fourth matches is overnight.
+	matches := buildRetimingMatches(0, 6, 12, 1440, 1446)
+
+	retimedMatches, _ := RetimeSchedule(matches, nil, retimingStartTime.Add(20*time.Minute), 0)
+	assert.Equal(t, []float64{20, 26, 32, 1440, 1446}, getRetimedOffsetsMin(retimedMatches))
+
+	retimedMatches, _ = RetimeSchedule(nil, nil, retimingStartTime, 60)
+	assert.Empty(t, retimedMatches)
+}
@@ -21,7 +21,7 @@ func TestMain(m *testing.M) {
 func TestNonExistentSchedule(t *testing.T) {
 	teams := make([]model.Team, 5)
 	scheduleBlocks := []model.ScheduleBlock{{0, model.Test, time.Unix(0, 0).UTC(), 2, 60}}
-	_, err := BuildRandomSchedule(teams, scheduleBlocks, model.Test)
+	_, err := BuildRandomSchedule(teams, scheduleBlocks, model.Test, nil)
 	expectedErr := "No schedule template exists for 5 teams and 2 matches"
 	if assert.NotNil(t, err) {
 		assert.Equal(t, expectedErr, err.Error())
@@ -36,7 +36,7 @@ func TestMalformedSchedule(t *testing.T) {
 	scheduleFile.Close()
 	teams := make([]model.Team, 5)
 	scheduleBlocks := []model.ScheduleBlock{{0, model.Test, time.Unix(0, 0).UTC(), 1, 60}}
-	_, err := BuildRandomSchedule(teams, scheduleBlocks, model.Test)
+	_, err := BuildRandomSchedule(teams, scheduleBlocks, model.Test, nil)
 	expectedErr := "Schedule file contains 2 matches, expected 1"
 	if assert.NotNil(t, err) {
 		assert.Equal(t, expectedErr, err.Error())
@@ -46,7 +46,7 @@ func TestMalformedSchedule(t *testing.T) {
 	scheduleFile, _ = os.Create(filename)
 	scheduleFile.WriteString("1,0,asdf,0,3,0,4,0,5,0,6,0\n")
 	scheduleFile.Close()
-	_, err = BuildRandomSchedule(teams, scheduleBlocks, model.Test)
+	_, err = BuildRandomSchedule(teams, scheduleBlocks, model.Test, nil)
 	if assert.NotNil(t, err) {
 		assert.Contains(t, err.Error(), "strconv.Atoi")
 	}
@@ -61,7 +61,7 @@ func TestScheduleTeams(t *testing.T) {
 		teams[i].Id = i + 101
 	}
 	scheduleBlocks := []model.ScheduleBlock{{0, model.Practice, time.Unix(0, 0).UTC(), 6, 60}}
-	matches, err := BuildRandomSchedule(teams, scheduleBlocks, model.Practice)
+	matches, err := BuildRandomSchedule(teams, scheduleBlocks, model.Practice, nil)
 	assert.Nil(t, err)
 	assertMatch(t, matches[0], model.Practice, 1, 0, "P1", "Practice 1", "p", 115, 111, 108, 109, 116, 117)
 	assertMatch(t, matches[1], model.Practice, 2, 60, "P2", "Practice 2", "p", 114, 112, 103, 101, 104, 118)
@@ -72,13 +72,13 @@ func TestScheduleTeams(t *testing.T) {
 
 	// Check with excess room for matches in the schedule.
 	scheduleBlocks = []model.ScheduleBlock{{0, model.Practice, time.Unix(0, 0).UTC(), 7, 60}}
-	matches, err = BuildRandomSchedule(teams, scheduleBlocks, model.Practice)
+	matches, err = BuildRandomSchedule(teams, scheduleBlocks, model.Practice, nil)
 	assert.Nil(t, err)
 
 	// Check with qualification matches.
 	rand.Seed(0)
 	scheduleBlocks = []model.ScheduleBlock{{0, model.Qualification, time.Unix(0, 0).UTC(), 6, 60}}
-	matches, err = BuildRandomSchedule(teams, scheduleBlocks, model.Qualification)
+	matches, err = BuildRandomSchedule(teams, scheduleBlocks, model.Qualification, nil)
 	assert.Nil(t, err)
 	assertMatch(t, matches[0], model.Qualification, 1, 0, "Q1", "Qualification 1", "qm", 115, 111, 108, 109, 116, 117)
 	assertMatch(t, matches[1], model.Qualification, 2, 60, "Q2", "Qualification 2", "qm", 114, 112, 103, 101, 104, 118)
@@ -95,7 +95,7 @@ func TestScheduleTiming(t *testing.T) {
 		{0, model.Qualification, time.Unix(20000, 0).UTC(), 5, 1000},
 		{0, model.Qualification, time.Unix(100000, 0).UTC(), 15, 29},
 	}
-	matches, err := BuildRandomSchedule(teams, scheduleBlocks, model.Qualification)
+	matches, err := BuildRandomSchedule(teams, scheduleBlocks, model.Qualification, nil)
 	assert.Nil(t, err)
 	assert.Equal(t, time.Unix(100, 0).UTC(), matches[0].Time)
 	assert.Equal(t, time.Unix(775, 0).UTC(), matches[9].Time)
@@ -114,7 +114,7 @@ func TestScheduleSurrogates(t *testing.T) {
 		teams[i].Id = i + 101
 	}
 	scheduleBlocks := []model.ScheduleBlock{{0, model.Qualification, time.Unix(0, 0).UTC(), 64, 60}}
-	matches, _ := BuildRandomSchedule(teams, scheduleBlocks, model.Qualification)
+	matches, _ := BuildRandomSchedule(teams, scheduleBlocks, model.Qualification, nil)
 	for i, match := range matches {
 		if i == 13 || i == 14 {
 			if !match.Red1IsSurrogate || match.Red2IsSurrogate || match.Red3IsSurrogate ||
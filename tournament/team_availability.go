@@ -0,0 +1,106 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Checking of match and judging schedules against the windows of time during which teams are unavailable.
+
+package tournament
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"time"
+)
+
+// Format in which times are shown in availability conflict warnings.
+const availabilityTimeFormat = "Mon 3:04 PM"
+
+// Returns true if the given team is unavailable to play a match starting at the given time.
+func isUnavailableForMatch(unavailabilities []model.TeamUnavailability, teamId int, matchTime time.Time) bool {
+	return findMatchUnavailability(unavailabilities, teamId, matchTime) != nil
+}
+
+// Returns the first of the given team's unavailability windows that contains the given match start time, or nil if
+// there is none.
+func findMatchUnavailability(
+	unavailabilities []model.TeamUnavailability, teamId int, matchTime time.Time,
+) *model.TeamUnavailability {
+	for i, unavailability := range unavailabilities {
+		if unavailability.TeamId == teamId && unavailability.Contains(matchTime) {
+			return &unavailabilities[i]
+		}
+	}
+	return nil
+}
+
+// Returns the first of the given team's unavailability windows that overlaps the given interval, or nil if there is
+// none.
+func findIntervalUnavailability(
+	unavailabilities []model.TeamUnavailability, teamId int, startTime, endTime time.Time,
+) *model.TeamUnavailability {
+	for i, unavailability := range unavailabilities {
+		if unavailability.TeamId == teamId && unavailability.Overlaps(startTime, endTime) {
+			return &unavailabilities[i]
+		}
+	}
+	return nil
+}
+
+// Returns a warning for each appearance of a team in the given schedule that starts while the team is unavailable.
+func FindScheduleAvailabilityConflicts(
+	matches []model.Match, unavailabilities []model.TeamUnavailability,
+) []string {
+	var conflicts []string
+	for _, match := range matches {
+		for _, team := range []int{match.Red1, match.Red2, match.Red3, match.Blue1, match.Blue2, match.Blue3} {
+			if unavailability := findMatchUnavailability(unavailabilities, team, match.Time); unavailability != nil {
+				conflicts = append(
+					conflicts,
+					fmt.Sprintf(
+						"Team %d is scheduled in %s at %s but is unavailable%s.",
+						team,
+						match.ShortName,
+						match.Time.Local().Format(availabilityTimeFormat),
+						formatUnavailabilityReason(unavailability),
+					),
+				)
+			}
+		}
+	}
+	return conflicts
+}
+
+// Returns a warning for each of the given judging slots, of the given duration, that overlaps a time when its team is
+// unavailable.
+func FindJudgingAvailabilityConflicts(
+	slots []model.JudgingSlot, durationMinutes int, unavailabilities []model.TeamUnavailability,
+) []string {
+	var conflicts []string
+	for _, slot := range slots {
+		endTime := slot.Time.Add(time.Duration(durationMinutes) * time.Minute)
+		unavailability := findIntervalUnavailability(unavailabilities, slot.TeamId, slot.Time, endTime)
+		if unavailability != nil {
+			conflicts = append(
+				conflicts,
+				fmt.Sprintf(
+					"Team %d is scheduled for judging at %s but is unavailable%s.",
+					slot.TeamId,
+					slot.Time.Local().Format(availabilityTimeFormat),
+					formatUnavailabilityReason(unavailability),
+				),
+			)
+		}
+	}
+	return conflicts
+}
+
+func formatUnavailabilityReason(unavailability *model.TeamUnavailability) string {
+	window := fmt.Sprintf(
+		" from %s to %s",
+		unavailability.StartTime.Local().Format(availabilityTimeFormat),
+		unavailability.EndTime.Local().Format(availabilityTimeFormat),
+	)
+	if unavailability.Reason == "" {
+		return window
+	}
+	return fmt.Sprintf("%s (%s)", window, unavailability.Reason)
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package tournament
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestFindScheduleAvailabilityConflicts(t *testing.T) {
+	startTime := time.Date(2025, 4, 16, 9, 0, 0, 0, time.Local)
+	matches := []model.Match{
+		{ShortName: "Q1", Time: startTime, Red1: 1, Red2: 2, Red3: 3, Blue1: 4, Blue2: 5, Blue3: 6},
+		{ShortName: "Q2", Time: startTime.Add(time.Hour), Red1: 1, Red2: 2, Red3: 3, Blue1: 4, Blue2: 5, Blue3: 6},
+	}
+	unavailabilities := []model.TeamUnavailability{
+		{TeamId: 2, StartTime: startTime.Add(-time.Hour), EndTime: startTime.Add(time.Minute), Reason: "Late bus"},
+		{TeamId: 6, StartTime: startTime.Add(time.Hour), EndTime: startTime.Add(8 * time.Hour)},
+		{TeamId: 7, StartTime: startTime, EndTime: startTime.Add(8 * time.Hour)},
+	}
+	assert.Empty(t, FindScheduleAvailabilityConflicts(matches, nil))
+	assert.Equal(
+		t,
+		[]string{
+			"Team 2 is scheduled in Q1 at Wed 9:00 AM but is unavailable from Wed 8:00 AM to Wed 9:01 AM (Late bus).",
+			"Team 6 is scheduled in Q2 at Wed 10:00 AM but is unavailable from Wed 10:00 AM to Wed 5:00 PM.",
+		},
+		FindScheduleAvailabilityConflicts(matches, unavailabilities),
+	)
+}
+
+func TestFindJudgingAvailabilityConflicts(t *testing.T) {
+	startTime := time.Date(2025, 4, 16, 9, 0, 0, 0, time.Local)
+	slots := []model.JudgingSlot{{TeamId: 1, Time: startTime}, {TeamId: 2, Time: startTime}}
+	unavailabilities := []model.TeamUnavailability{
+		{TeamId: 1, StartTime: startTime.Add(15 * time.Minute), EndTime: startTime.Add(time.Hour)},
+		{TeamId: 2, StartTime: startTime.Add(20 * time.Minute), EndTime: startTime.Add(time.Hour)},
+	}
+	assert.Equal(
+		t,
+		[]string{"Team 1 is scheduled for judging at Wed 9:00 AM but is unavailable from Wed 9:15 AM to Wed 10:00 AM."},
+		FindJudgingAvailabilityConflicts(slots, 20, unavailabilities),
+	)
+	assert.Empty(t, FindJudgingAvailabilityConflicts(slots, 15, unavailabilities))
+}
+
+func TestSchedulesRespectUnavailability(t *testing.T) {
+	rand.Seed(0)
+	teams := make([]model.Team, 18)
+	for i := range teams {
+		teams[i].Id = i + 1
+	}
+	startTime := time.Date(2025, 4, 16, 9, 0, 0, 0, time.UTC)
+	scheduleBlocks := []model.ScheduleBlock{{StartTime: startTime, NumMatches: 24, MatchSpacingSec: 360}}
+
+	// Team 1 arrives after the first two matches and team 2 leaves before the last two.
+	unavailabilities := []model.TeamUnavailability{
+		{TeamId: 1, StartTime: startTime.Add(-time.Hour), EndTime: startTime.Add(10 * time.Minute)},
+		{TeamId: 2, StartTime: startTime.Add(135 * time.Minute), EndTime: startTime.Add(10 * time.Hour)},
+	}
+
+	matches, err := BuildRandomSchedule(teams, scheduleBlocks, model.Qualification, unavailabilities)
+	assert.Nil(t, err)
+	assert.Equal(t, 24, len(matches))
+	assert.Empty(t, FindScheduleAvailabilityConflicts(matches, unavailabilities))
+
+	// The optimizer can satisfy much longer windows.
+	unavailabilities[0].EndTime = startTime.Add(time.Hour)
+	unavailabilities[1].StartTime = startTime.Add(100 * time.Minute)
+	matches, err = buildOptimizedSchedule(
+		teams, scheduleBlocks, model.Qualification, 0, unavailabilities, rand.New(rand.NewSource(0)),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, 24, len(matches))
+	assert.Empty(t, FindScheduleAvailabilityConflicts(matches, unavailabilities))
+}
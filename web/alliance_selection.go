@@ -8,11 +8,13 @@ package web
 import (
 	"fmt"
 	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
 	"github.com/Team254/cheesy-arena/tournament"
 	"github.com/Team254/cheesy-arena/websocket"
 	"io"
 	"log"
 	"net/http"
+	"slices"
 	"strconv"
 	"time"
 )
@@ -31,24 +33,18 @@ var allianceSelectionTicker *time.Ticker
 
 // Shows the alliance selection page.
 func (web *Web) allianceSelectionGetHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	web.renderAllianceSelection(w, r, "")
 }
 
 // Updates the cache with the latest input from the client.
 func (web *Web) allianceSelectionPostHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	if !web.canModifyAllianceSelection() {
 		web.renderAllianceSelection(w, r, "Alliance selection has already been finalized.")
 		return
 	}
 
+	previousTeamIds := web.getAllianceSelectionTeamIds()
+
 	// Reset picked state for each team in preparation for reconstructing it.
 	for i := range web.arena.AllianceSelectionRankedTeams {
 		web.arena.AllianceSelectionRankedTeams[i].Picked = false
@@ -95,16 +91,14 @@ func (web *Web) allianceSelectionPostHandler(w http.ResponseWriter, r *http.Requ
 		}
 	}
 
+	web.recordAuditEntry(r, "alliance_selection.edit", "", previousTeamIds, web.getAllianceSelectionTeamIds())
+
 	web.arena.AllianceSelectionNotifier.Notify()
 	http.Redirect(w, r, "/alliance_selection", 303)
 }
 
 // Sets up the empty alliances and populates the ranked team list.
 func (web *Web) allianceSelectionStartHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	if len(web.arena.AllianceSelectionAlliances) != 0 {
 		web.renderAllianceSelection(w, r, "Can't start alliance selection when it is already in progress.")
 		return
@@ -139,6 +133,7 @@ func (web *Web) allianceSelectionStartHandler(w http.ResponseWriter, r *http.Req
 			Picked: false,
 		}
 	}
+	web.recordAuditEntry(r, "alliance_selection.start", "", nil, nil)
 
 	web.arena.AllianceSelectionNotifier.Notify()
 	http.Redirect(w, r, "/alliance_selection", 303)
@@ -146,10 +141,6 @@ func (web *Web) allianceSelectionStartHandler(w http.ResponseWriter, r *http.Req
 
 // Resets the alliance selection process back to the starting point.
 func (web *Web) allianceSelectionResetHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	if !web.canResetAllianceSelection() {
 		web.renderAllianceSelection(w, r, "Cannot reset alliance selection; playoff matches have already started.")
 		return
@@ -170,16 +161,13 @@ func (web *Web) allianceSelectionResetHandler(w http.ResponseWriter, r *http.Req
 
 	web.arena.AllianceSelectionAlliances = []model.Alliance{}
 	web.arena.AllianceSelectionRankedTeams = []model.AllianceSelectionRankedTeam{}
+	web.recordAuditEntry(r, "alliance_selection.reset", "", nil, nil)
 	web.arena.AllianceSelectionNotifier.Notify()
 	http.Redirect(w, r, "/alliance_selection", 303)
 }
 
 // Saves the selected alliances to the database and generates the first round of playoff matches.
 func (web *Web) allianceSelectionFinalizeHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	if !web.canModifyAllianceSelection() {
 		web.renderAllianceSelection(w, r, "Alliance selection has already been finalized.")
 		return
@@ -236,17 +224,16 @@ func (web *Web) allianceSelectionFinalizeHandler(w http.ResponseWriter, r *http.
 		handleWebErr(w, err)
 		return
 	}
+	web.recordAuditEntry(r, "alliance_selection.finalize", "", nil, web.getAllianceSelectionTeamIds())
 
 	if web.arena.EventSettings.TbaPublishingEnabled {
-		// Publish alliances and schedule to The Blue Alliance.
-		err = web.arena.TbaClient.PublishAlliances(web.arena.Database)
-		if err != nil {
-			web.renderAllianceSelection(w, r, fmt.Sprintf("Failed to publish alliances: %s", err.Error()))
+		// Queue the alliances and schedule to be published to The Blue Alliance.
+		if err = web.arena.TbaPublisher.Enqueue(web.arena.Database, partner.TbaPublishAlliances, false); err != nil {
+			handleWebErr(w, err)
 			return
 		}
-		err = web.arena.TbaClient.PublishMatches(web.arena.Database)
-		if err != nil {
-			web.renderAllianceSelection(w, r, fmt.Sprintf("Failed to publish matches: %s", err.Error()))
+		if err = web.arena.TbaPublisher.Enqueue(web.arena.Database, partner.TbaPublishMatches, false); err != nil {
+			handleWebErr(w, err)
 			return
 		}
 	}
@@ -265,10 +252,6 @@ func (web *Web) allianceSelectionFinalizeHandler(w http.ResponseWriter, r *http.
 
 // The websocket endpoint for the alliance selection client to send control commands and receive status updates.
 func (web *Web) allianceSelectionWebsocketHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	ws, err := websocket.NewWebsocket(w, r)
 	if err != nil {
 		handleWebErr(w, err)
@@ -402,6 +385,15 @@ func (web *Web) renderAllianceSelection(w http.ResponseWriter, r *http.Request,
 	}
 }
 
+// Returns a copy of the team IDs currently assigned to each alliance, for recording in the audit trail.
+func (web *Web) getAllianceSelectionTeamIds() [][]int {
+	teamIds := make([][]int, len(web.arena.AllianceSelectionAlliances))
+	for i, alliance := range web.arena.AllianceSelectionAlliances {
+		teamIds[i] = slices.Clone(alliance.TeamIds)
+	}
+	return teamIds
+}
+
 // Returns true if it is safe to change the alliance selection (i.e. no playoff matches exist yet).
 func (web *Web) canModifyAllianceSelection() bool {
 	matches, err := web.arena.Database.GetMatchesByType(model.Playoff, true)
@@ -145,12 +145,16 @@ func TestAllianceSelectionErrors(t *testing.T) {
 	assert.Equal(t, 200, recorder.Code)
 	assert.Contains(t, recorder.Body.String(), "valid start time")
 
-	// Finalize for real and check that TBA publishing is triggered.
-	web.arena.TbaClient.BaseUrl = "fakeurl"
+	// Finalize for real and check that TBA publishing is queued.
 	web.arena.EventSettings.TbaPublishingEnabled = true
 	recorder = web.postHttpResponse("/alliance_selection/finalize", "startTime=2014-01-01 01:00:00 PM")
-	assert.Equal(t, 200, recorder.Code)
-	assert.Contains(t, recorder.Body.String(), "Failed to publish alliances")
+	assert.Equal(t, 303, recorder.Code)
+	for _, publishType := range []string{"alliances", "matches"} {
+		item, _ := web.arena.Database.GetTbaPublishItemByType(publishType)
+		if assert.NotNil(t, item) {
+			assert.True(t, item.Pending)
+		}
+	}
 
 	// Do other things after finalization.
 	recorder = web.postHttpResponse("/alliance_selection/finalize", "startTime=2014-01-01 01:00:00 PM")
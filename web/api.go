@@ -16,6 +16,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strconv"
 )
 
@@ -225,9 +226,9 @@ func (web *Web) teamAvatarsApiHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	avatarPath := fmt.Sprintf("%s/%d.png", partner.AvatarsDir, teamId)
+	avatarPath := filepath.Join(model.DataDir, partner.AvatarsDir, fmt.Sprintf("%d.png", teamId))
 	if _, err := os.Stat(avatarPath); os.IsNotExist(err) {
-		avatarPath = fmt.Sprintf("%s/0.png", partner.AvatarsDir)
+		avatarPath = filepath.Join(model.BaseDir, "static/img/avatars/0.png")
 	}
 
 	http.ServeFile(w, r, avatarPath)
@@ -7,10 +7,13 @@ import (
 	"encoding/json"
 	"github.com/Team254/cheesy-arena/game"
 	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
 	"github.com/Team254/cheesy-arena/tournament"
 	"github.com/Team254/cheesy-arena/websocket"
 	gorillawebsocket "github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 )
@@ -162,6 +165,24 @@ func TestAlliancesApi(t *testing.T) {
 	}
 }
 
+func TestTeamAvatarsApi(t *testing.T) {
+	web := setupTestWeb(t)
+	avatarsPath := filepath.Join(model.DataDir, partner.AvatarsDir)
+	assert.Nil(t, os.MkdirAll(avatarsPath, 0755))
+	assert.Nil(t, os.WriteFile(filepath.Join(avatarsPath, "254.png"), []byte("poofs"), 0644))
+
+	recorder := web.getHttpResponse("/api/teams/254/avatar")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "poofs", recorder.Body.String())
+
+	// Check that the default avatar is served for a team without one of its own.
+	defaultAvatar, err := os.ReadFile(filepath.Join(model.BaseDir, "static/img/avatars/0.png"))
+	assert.Nil(t, err)
+	recorder = web.getHttpResponse("/api/teams/1114/avatar")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, defaultAvatar, recorder.Body.Bytes())
+}
+
 func TestArenaWebsocketApi(t *testing.T) {
 	web := setupTestWeb(t)
 
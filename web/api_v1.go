@@ -0,0 +1,473 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Versioned REST API for reading and modifying event data and controlling the arena, authenticated with bearer tokens
+// that carry the permissions of a user role.
+
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"log"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const apiV1Prefix = "/api/v1"
+
+// Describes an API endpoint, both for routing requests to it and for documenting it in the OpenAPI specification.
+type apiV1Endpoint struct {
+	Method      string
+	Path        string // Relative to apiV1Prefix; wildcards are documented as integer path parameters.
+	Tag         string
+	Summary     string
+	Roles       []model.UserRole // Roles, beyond admin, that may call the endpoint; nil if it is public.
+	QueryParams []apiV1QueryParam
+	Request     any // Zero value of the type of the JSON request body, or nil if there is none.
+	Response    any // Zero value of the type of the JSON response body, or nil if there is none.
+	Status      int // Status code returned on success.
+	Handler     http.HandlerFunc
+}
+
+type apiV1QueryParam struct {
+	Name        string
+	Description string
+	Required    bool
+}
+
+// The body of every unsuccessful API response.
+type ApiV1Error struct {
+	Error string `json:"error"`
+}
+
+// Returns the full list of API endpoints.
+func (web *Web) apiV1Endpoints() []apiV1Endpoint {
+	matchTypeParam := apiV1QueryParam{
+		"type", "Restricts the results to the given match type (practice, qualification or playoff).", false,
+	}
+	scheduleTypeParam := apiV1QueryParam{"type", "The match type (practice or qualification) of the schedule.", true}
+	return []apiV1Endpoint{
+		{
+			Method:   "GET",
+			Path:     "/arena",
+			Tag:      "Arena",
+			Summary:  "Gets the state of the arena and the match loaded into it.",
+			Roles:    viewerRoles,
+			Response: ApiV1ArenaStatus{},
+			Status:   200,
+			Handler:  web.arenaApiV1GetHandler,
+		},
+		{
+			Method:   "POST",
+			Path:     "/arena/abort",
+			Tag:      "Arena",
+			Summary:  "Aborts the match in progress.",
+			Roles:    scorekeeperRoles,
+			Response: ApiV1ArenaStatus{},
+			Status:   200,
+			Handler:  web.arenaAbortApiV1PostHandler,
+		},
+		{
+			Method:   "POST",
+			Path:     "/arena/commit",
+			Tag:      "Arena",
+			Summary:  "Commits the score of the completed match and loads the next one in the schedule.",
+			Roles:    scorekeeperRoles,
+			Response: ApiV1ArenaStatus{},
+			Status:   200,
+			Handler:  web.arenaCommitApiV1PostHandler,
+		},
+		{
+			Method:   "POST",
+			Path:     "/arena/load",
+			Tag:      "Arena",
+			Summary:  "Loads the given match, or a test match if the match ID is zero.",
+			Roles:    scorekeeperRoles,
+			Request:  ApiV1LoadMatchRequest{},
+			Response: ApiV1ArenaStatus{},
+			Status:   200,
+			Handler:  web.arenaLoadApiV1PostHandler,
+		},
+		{
+			Method:   "POST",
+			Path:     "/arena/start",
+			Tag:      "Arena",
+			Summary:  "Starts the loaded match.",
+			Roles:    scorekeeperRoles,
+			Response: ApiV1ArenaStatus{},
+			Status:   200,
+			Handler:  web.arenaStartApiV1PostHandler,
+		},
+		{
+			Method:   "GET",
+			Path:     "/awards",
+			Tag:      "Awards",
+			Summary:  "Lists all awards.",
+			Roles:    viewerRoles,
+			Response: []ApiV1Award{},
+			Status:   200,
+			Handler:  web.awardsApiV1GetHandler,
+		},
+		{
+			Method:   "POST",
+			Path:     "/awards",
+			Tag:      "Awards",
+			Summary:  "Creates a judged award, along with its lower thirds.",
+			Roles:    scorekeeperRoles,
+			Request:  ApiV1Award{},
+			Response: ApiV1Award{},
+			Status:   201,
+			Handler:  web.awardsApiV1PostHandler,
+		},
+		{
+			Method:   "PUT",
+			Path:     "/awards/{awardId}",
+			Tag:      "Awards",
+			Summary:  "Updates an award, along with its lower thirds.",
+			Roles:    scorekeeperRoles,
+			Request:  ApiV1Award{},
+			Response: ApiV1Award{},
+			Status:   200,
+			Handler:  web.awardApiV1PutHandler,
+		},
+		{
+			Method:  "DELETE",
+			Path:    "/awards/{awardId}",
+			Tag:     "Awards",
+			Summary: "Deletes an award, along with its lower thirds.",
+			Roles:   scorekeeperRoles,
+			Status:  204,
+			Handler: web.awardApiV1DeleteHandler,
+		},
+		{
+			Method:   "GET",
+			Path:     "/lower_thirds",
+			Tag:      "Lower Thirds",
+			Summary:  "Lists all lower thirds in display order.",
+			Roles:    viewerRoles,
+			Response: []ApiV1LowerThird{},
+			Status:   200,
+			Handler:  web.lowerThirdsApiV1GetHandler,
+		},
+		{
+			Method:   "POST",
+			Path:     "/lower_thirds",
+			Tag:      "Lower Thirds",
+			Summary:  "Creates a lower third at the end of the display order.",
+			Roles:    scorekeeperRoles,
+			Request:  ApiV1LowerThird{},
+			Response: ApiV1LowerThird{},
+			Status:   201,
+			Handler:  web.lowerThirdsApiV1PostHandler,
+		},
+		{
+			Method:  "POST",
+			Path:    "/lower_thirds/hide",
+			Tag:     "Lower Thirds",
+			Summary: "Hides the lower third shown on the audience display.",
+			Roles:   scorekeeperRoles,
+			Status:  204,
+			Handler: web.lowerThirdHideApiV1PostHandler,
+		},
+		{
+			Method:   "PUT",
+			Path:     "/lower_thirds/{lowerThirdId}",
+			Tag:      "Lower Thirds",
+			Summary:  "Updates the text of a lower third.",
+			Roles:    scorekeeperRoles,
+			Request:  ApiV1LowerThird{},
+			Response: ApiV1LowerThird{},
+			Status:   200,
+			Handler:  web.lowerThirdApiV1PutHandler,
+		},
+		{
+			Method:  "DELETE",
+			Path:    "/lower_thirds/{lowerThirdId}",
+			Tag:     "Lower Thirds",
+			Summary: "Deletes a lower third.",
+			Roles:   scorekeeperRoles,
+			Status:  204,
+			Handler: web.lowerThirdApiV1DeleteHandler,
+		},
+		{
+			Method:  "POST",
+			Path:    "/lower_thirds/{lowerThirdId}/show",
+			Tag:     "Lower Thirds",
+			Summary: "Shows a lower third on the audience display.",
+			Roles:   scorekeeperRoles,
+			Status:  204,
+			Handler: web.lowerThirdShowApiV1PostHandler,
+		},
+		{
+			Method:  "GET",
+			Path:    "/match_logs",
+			Tag:     "Match Logs",
+			Summary: "Lists the recorded match logs, oldest first.",
+			Roles:   viewerRoles,
+			QueryParams: []apiV1QueryParam{
+				{"matchId", "Restricts the results to the logs recorded for the given match.", false},
+			},
+			Response: []ApiV1MatchLog{},
+			Status:   200,
+			Handler:  web.matchLogsApiV1GetHandler,
+		},
+		{
+			Method:  "GET",
+			Path:    "/match_logs/{matchId}/records",
+			Tag:     "Match Logs",
+			Summary: "Gets the records of a match log, filtered by the query parameters.",
+			Roles:   viewerRoles,
+			QueryParams: []apiV1QueryParam{
+				{"station", "Excludes the driver station records of all but the given station (e.g. R1).", false},
+				{"types", "Comma-separated record types (dsStatus, arenaState, plcIo or accessPoint) to include.", false},
+				{"from", "Excludes records before the given match time in seconds.", false},
+				{"to", "Excludes records after the given match time in seconds.", false},
+				{"startedAt", "Start time (RFC 3339) of the log to read, if the match was played more than once.", false},
+			},
+			Response: ApiV1MatchLogRecords{},
+			Status:   200,
+			Handler:  web.matchLogRecordsApiV1GetHandler,
+		},
+		{
+			Method:      "GET",
+			Path:        "/matches",
+			Tag:         "Matches",
+			Summary:     "Lists all visible matches, in schedule order.",
+			Roles:       viewerRoles,
+			QueryParams: []apiV1QueryParam{matchTypeParam},
+			Response:    []ApiV1Match{},
+			Status:      200,
+			Handler:     web.matchesApiV1GetHandler,
+		},
+		{
+			Method:   "GET",
+			Path:     "/matches/{matchId}",
+			Tag:      "Matches",
+			Summary:  "Gets a match and its result, if it has been played.",
+			Roles:    viewerRoles,
+			Response: ApiV1Match{},
+			Status:   200,
+			Handler:  web.matchApiV1GetHandler,
+		},
+		{
+			Method:   "PUT",
+			Path:     "/matches/{matchId}/result",
+			Tag:      "Matches",
+			Summary:  "Replaces the result of a match and commits it, updating the rankings and playoff tournament.",
+			Roles:    refereeRoles,
+			Request:  ApiV1MatchResultEdit{},
+			Response: ApiV1Match{},
+			Status:   200,
+			Handler:  web.matchResultApiV1PutHandler,
+		},
+		{
+			Method:  "GET",
+			Path:    "/openapi.json",
+			Tag:     "Documentation",
+			Summary: "Gets the OpenAPI specification of this API.",
+			Status:  200,
+			Handler: web.openApiV1GetHandler,
+		},
+		{
+			Method:   "GET",
+			Path:     "/rankings",
+			Tag:      "Rankings",
+			Summary:  "Lists the qualification rankings.",
+			Roles:    viewerRoles,
+			Response: []ApiV1Ranking{},
+			Status:   200,
+			Handler:  web.rankingsApiV1GetHandler,
+		},
+		{
+			Method:      "GET",
+			Path:        "/schedule_blocks",
+			Tag:         "Schedule",
+			Summary:     "Lists the blocks of time from which the schedule is generated.",
+			Roles:       viewerRoles,
+			QueryParams: []apiV1QueryParam{scheduleTypeParam},
+			Response:    []ApiV1ScheduleBlock{},
+			Status:      200,
+			Handler:     web.scheduleBlocksApiV1GetHandler,
+		},
+		{
+			Method:      "PUT",
+			Path:        "/schedule_blocks",
+			Tag:         "Schedule",
+			Summary:     "Replaces the schedule blocks for the given match type.",
+			Roles:       adminRoles,
+			QueryParams: []apiV1QueryParam{scheduleTypeParam},
+			Request:     []ApiV1ScheduleBlock{},
+			Response:    []ApiV1ScheduleBlock{},
+			Status:      200,
+			Handler:     web.scheduleBlocksApiV1PutHandler,
+		},
+		{
+			Method:   "GET",
+			Path:     "/teams",
+			Tag:      "Teams",
+			Summary:  "Lists all teams at the event.",
+			Roles:    viewerRoles,
+			Response: []ApiV1Team{},
+			Status:   200,
+			Handler:  web.teamsApiV1GetHandler,
+		},
+		{
+			Method:   "POST",
+			Path:     "/teams",
+			Tag:      "Teams",
+			Summary:  "Adds a team to the event.",
+			Roles:    adminRoles,
+			Request:  ApiV1Team{},
+			Response: ApiV1Team{},
+			Status:   201,
+			Handler:  web.teamsApiV1PostHandler,
+		},
+		{
+			Method:   "GET",
+			Path:     "/teams/{teamId}",
+			Tag:      "Teams",
+			Summary:  "Gets a team.",
+			Roles:    viewerRoles,
+			Response: ApiV1Team{},
+			Status:   200,
+			Handler:  web.teamApiV1GetHandler,
+		},
+		{
+			Method:   "PUT",
+			Path:     "/teams/{teamId}",
+			Tag:      "Teams",
+			Summary:  "Updates a team's details.",
+			Roles:    adminRoles,
+			Request:  ApiV1Team{},
+			Response: ApiV1Team{},
+			Status:   200,
+			Handler:  web.teamApiV1PutHandler,
+		},
+		{
+			Method:  "DELETE",
+			Path:    "/teams/{teamId}",
+			Tag:     "Teams",
+			Summary: "Removes a team from the event.",
+			Roles:   adminRoles,
+			Status:  204,
+			Handler: web.teamApiV1DeleteHandler,
+		},
+	}
+}
+
+// Registers the handlers for all API endpoints with the given mux.
+func (web *Web) addApiV1Routes(mux *http.ServeMux) {
+	for _, endpoint := range web.apiV1Endpoints() {
+		mux.HandleFunc(
+			endpoint.Method+" "+apiV1Prefix+endpoint.Path, web.authorizeApiV1(endpoint.Roles, endpoint.Handler),
+		)
+	}
+}
+
+// Wraps the given API handler so that it is only invoked for callers holding one of the given roles (or admins).
+// Callers authenticate with an API token in the Authorization header; a logged-in user's session cookie is also
+// accepted for read-only requests, but not for modifying ones since browsers send it along with cross-site requests.
+func (web *Web) authorizeApiV1(roles []model.UserRole, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if roles == nil {
+			handler(w, r)
+			return
+		}
+		authDisabled, err := web.authIsDisabled()
+		if err != nil {
+			handleApiV1Err(w, err)
+			return
+		}
+		if !authDisabled {
+			var role model.UserRole
+			if r.Header.Get("Authorization") != "" {
+				apiToken := web.getApiTokenFromHeader(r)
+				if apiToken == nil {
+					w.Header().Set("WWW-Authenticate", "Bearer")
+					writeApiV1Error(w, 401, "Invalid API token.")
+					return
+				}
+				role = apiToken.Role
+			} else if r.Method == "GET" {
+				role = web.getUserRoleFromCookie(r)
+			}
+			if role == "" {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				writeApiV1Error(w, 401, "An API token is required.")
+				return
+			}
+			if role != model.AdminRole && !slices.Contains(roles, role) {
+				writeApiV1Error(
+					w, 403, fmt.Sprintf("The %s role does not have access to this endpoint.", role.DisplayName()),
+				)
+				return
+			}
+		}
+		handler(w, r)
+	}
+}
+
+// Returns the API token given as a bearer token in the request's Authorization header, or nil if there is no valid one.
+func (web *Web) getApiTokenFromHeader(r *http.Request) *model.ApiToken {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return nil
+	}
+	apiToken, _ := web.arena.Database.GetApiTokenByToken(token)
+	return apiToken
+}
+
+// Parses the JSON request body into the given value, writing an error response and returning false if it is invalid.
+func decodeApiV1Request(w http.ResponseWriter, r *http.Request, value any) bool {
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/json" {
+		writeApiV1Error(w, 415, "Request body must be of type application/json.")
+		return false
+	}
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(value); err != nil {
+		writeApiV1Error(w, 400, fmt.Sprintf("Invalid request body: %v", err))
+		return false
+	}
+	return true
+}
+
+// Parses the integer path wildcard of the given name, writing an error response and returning false if it is invalid.
+func getApiV1PathId(w http.ResponseWriter, r *http.Request, name string) (int, bool) {
+	id, err := strconv.Atoi(r.PathValue(name))
+	if err != nil {
+		writeApiV1Error(w, 400, fmt.Sprintf("Invalid %s %q.", name, r.PathValue(name)))
+		return 0, false
+	}
+	return id, true
+}
+
+// Writes the given value out as the JSON response body with the given status code.
+func writeApiV1Json(w http.ResponseWriter, status int, value any) {
+	jsonData, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if _, err = w.Write(jsonData); err != nil {
+		log.Printf("HTTP request error: %v", err)
+	}
+}
+
+// Writes an error response with the given status code and message.
+func writeApiV1Error(w http.ResponseWriter, status int, message string) {
+	writeApiV1Json(w, status, ApiV1Error{message})
+}
+
+// Writes the given unexpected error out as an error response with a status code of 500.
+func handleApiV1Err(w http.ResponseWriter, err error) {
+	log.Printf("HTTP request error: %v", err)
+	writeApiV1Error(w, 500, "Internal server error: "+err.Error())
+}
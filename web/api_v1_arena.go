@@ -0,0 +1,131 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// REST API routes for monitoring the arena and controlling the flow of matches through it.
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/model"
+	"net/http"
+	"strconv"
+)
+
+type ApiV1ArenaStatus struct {
+	MatchState        string     `json:"matchState"`
+	CurrentMatch      ApiV1Match `json:"currentMatch"`
+	CanStartMatch     bool       `json:"canStartMatch"`
+	CannotStartReason string     `json:"cannotStartReason"`
+}
+
+type ApiV1LoadMatchRequest struct {
+	MatchId int `json:"matchId"`
+}
+
+var apiV1MatchStateNames = map[field.MatchState]string{
+	field.PreMatch:      "preMatch",
+	field.StartMatch:    "startMatch",
+	field.WarmupPeriod:  "warmupPeriod",
+	field.AutoPeriod:    "autoPeriod",
+	field.PausePeriod:   "pausePeriod",
+	field.TeleopPeriod:  "teleopPeriod",
+	field.PostMatch:     "postMatch",
+	field.TimeoutActive: "timeoutActive",
+	field.PostTimeout:   "postTimeout",
+}
+
+// Gets the current state of the arena.
+func (web *Web) arenaApiV1GetHandler(w http.ResponseWriter, r *http.Request) {
+	web.writeApiV1ArenaStatus(w)
+}
+
+// Loads the requested match into the arena, or a test match if no match is specified.
+func (web *Web) arenaLoadApiV1PostHandler(w http.ResponseWriter, r *http.Request) {
+	var request ApiV1LoadMatchRequest
+	if !decodeApiV1Request(w, r, &request) {
+		return
+	}
+	var match *model.Match
+	if request.MatchId != 0 {
+		var err error
+		if match, err = web.arena.Database.GetMatchById(request.MatchId); err != nil {
+			handleApiV1Err(w, err)
+			return
+		}
+		if match == nil {
+			writeApiV1Error(w, 404, fmt.Sprintf("Match %d does not exist.", request.MatchId))
+			return
+		}
+	}
+
+	err := web.arena.ResetMatch()
+	if err == nil {
+		if match == nil {
+			err = web.arena.LoadTestMatch()
+		} else {
+			err = web.arena.LoadMatch(match)
+		}
+	}
+	if err != nil {
+		writeApiV1Error(w, 409, err.Error())
+		return
+	}
+	web.writeApiV1ArenaStatus(w)
+}
+
+// Starts the match loaded into the arena.
+func (web *Web) arenaStartApiV1PostHandler(w http.ResponseWriter, r *http.Request) {
+	if err := web.arena.StartMatch(); err != nil {
+		writeApiV1Error(w, 409, err.Error())
+		return
+	}
+	web.recordAuditEntry(r, "match_play.start", strconv.Itoa(web.arena.CurrentMatch.Id), nil, nil)
+	web.writeApiV1ArenaStatus(w)
+}
+
+// Aborts the match in progress.
+func (web *Web) arenaAbortApiV1PostHandler(w http.ResponseWriter, r *http.Request) {
+	if err := web.arena.AbortMatch(); err != nil {
+		writeApiV1Error(w, 409, err.Error())
+		return
+	}
+	web.recordAuditEntry(r, "match_play.abort", strconv.Itoa(web.arena.CurrentMatch.Id), nil, nil)
+	web.writeApiV1ArenaStatus(w)
+}
+
+// Commits the realtime score of the completed match as its result and loads the next match in the schedule.
+func (web *Web) arenaCommitApiV1PostHandler(w http.ResponseWriter, r *http.Request) {
+	if web.arena.MatchState != field.PostMatch {
+		writeApiV1Error(w, 409, "cannot commit match while it is in progress")
+		return
+	}
+	if err := web.commitCurrentMatchScore(); err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	web.recordAuditEntry(r, "match_play.commit", strconv.Itoa(web.arena.CurrentMatch.Id), nil, nil)
+	if err := web.arena.ResetMatch(); err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	if err := web.arena.LoadNextMatch(true); err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	web.writeApiV1ArenaStatus(w)
+}
+
+func (web *Web) writeApiV1ArenaStatus(w http.ResponseWriter) {
+	status := ApiV1ArenaStatus{
+		MatchState:   apiV1MatchStateNames[web.arena.MatchState],
+		CurrentMatch: newApiV1Match(web.arena.CurrentMatch, nil),
+	}
+	if err := web.arena.CheckCanStartMatch(); err != nil {
+		status.CannotStartReason = err.Error()
+	} else {
+		status.CanStartMatch = true
+	}
+	writeApiV1Json(w, 200, status)
+}
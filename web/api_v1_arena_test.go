@@ -0,0 +1,82 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"encoding/json"
+	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func readApiV1ArenaStatus(t *testing.T, recorder *httptest.ResponseRecorder) ApiV1ArenaStatus {
+	var status ApiV1ArenaStatus
+	assert.Equal(t, 200, recorder.Code, recorder.Body.String())
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &status))
+	return status
+}
+
+func TestApiV1ArenaControl(t *testing.T) {
+	web := setupTestWeb(t)
+	for _, teamId := range []int{101, 102, 103, 104, 105, 106} {
+		web.arena.Database.CreateTeam(&model.Team{Id: teamId})
+	}
+	match := model.Match{
+		Type: model.Qualification, ShortName: "Q1", Red1: 101, Red2: 102, Red3: 103, Blue1: 104, Blue2: 105, Blue3: 106,
+	}
+	web.arena.Database.CreateMatch(&match)
+
+	status := readApiV1ArenaStatus(t, web.apiV1Response("GET", "/api/v1/arena", "", nil))
+	assert.Equal(t, "preMatch", status.MatchState)
+	assert.Equal(t, "test", status.CurrentMatch.Type)
+	assert.False(t, status.CanStartMatch)
+	assert.NotEmpty(t, status.CannotStartReason)
+
+	// Check loading a match.
+	recorder := web.apiV1Response("POST", "/api/v1/arena/load", "", ApiV1LoadMatchRequest{MatchId: 2})
+	assert.Equal(t, 404, recorder.Code)
+	status = readApiV1ArenaStatus(
+		t, web.apiV1Response("POST", "/api/v1/arena/load", "", ApiV1LoadMatchRequest{MatchId: match.Id}),
+	)
+	assert.Equal(t, "Q1", status.CurrentMatch.ShortName)
+	assert.Equal(t, []int{101, 102, 103}, status.CurrentMatch.RedTeams)
+	assert.Equal(t, match.Id, web.arena.CurrentMatch.Id)
+
+	// Check starting and aborting the match.
+	recorder = web.apiV1Response("POST", "/api/v1/arena/abort", "", nil)
+	assert.Equal(t, 409, recorder.Code)
+	assert.Contains(t, readApiV1Error(t, recorder), "cannot abort match")
+	recorder = web.apiV1Response("POST", "/api/v1/arena/start", "", nil)
+	assert.Equal(t, 409, recorder.Code)
+	assert.Contains(t, readApiV1Error(t, recorder), "cannot start match")
+	for _, allianceStation := range web.arena.AllianceStations {
+		allianceStation.Bypass = true
+	}
+	status = readApiV1ArenaStatus(t, web.apiV1Response("POST", "/api/v1/arena/start", "", nil))
+	assert.Equal(t, "startMatch", status.MatchState)
+	recorder = web.apiV1Response("POST", "/api/v1/arena/commit", "", nil)
+	assert.Equal(t, 409, recorder.Code)
+	assert.Equal(t, "cannot commit match while it is in progress", readApiV1Error(t, recorder))
+	recorder = web.apiV1Response("POST", "/api/v1/arena/load", "", ApiV1LoadMatchRequest{})
+	assert.Equal(t, 409, recorder.Code)
+	assert.Contains(t, readApiV1Error(t, recorder), "cannot reset match while it is in progress")
+	status = readApiV1ArenaStatus(t, web.apiV1Response("POST", "/api/v1/arena/abort", "", nil))
+	assert.Equal(t, "postMatch", status.MatchState)
+
+	// Check committing the result.
+	web.arena.RedRealtimeScore.CurrentScore.BargeAlgae = 6
+	status = readApiV1ArenaStatus(t, web.apiV1Response("POST", "/api/v1/arena/commit", "", nil))
+	assert.Equal(t, "preMatch", status.MatchState)
+	assert.Equal(t, field.PreMatch, web.arena.MatchState)
+	matchResult, _ := web.arena.Database.GetMatchResultForMatch(match.Id)
+	if assert.NotNil(t, matchResult) {
+		assert.Equal(t, 6, matchResult.RedScore.BargeAlgae)
+	}
+
+	// Check loading a test match.
+	status = readApiV1ArenaStatus(t, web.apiV1Response("POST", "/api/v1/arena/load", "", ApiV1LoadMatchRequest{}))
+	assert.Equal(t, "test", status.CurrentMatch.Type)
+}
@@ -0,0 +1,738 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// REST API routes and representations for the teams, matches, rankings, schedule, awards and lower thirds of the event.
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/tournament"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ApiV1Team struct {
+	Id              int    `json:"id"`
+	Name            string `json:"name"`
+	Nickname        string `json:"nickname"`
+	City            string `json:"city"`
+	StateProv       string `json:"stateProv"`
+	Country         string `json:"country"`
+	SchoolName      string `json:"schoolName"`
+	RookieYear      int    `json:"rookieYear"`
+	RobotName       string `json:"robotName"`
+	Accomplishments string `json:"accomplishments"`
+}
+
+type ApiV1Match struct {
+	Id            int               `json:"id"`
+	Type          string            `json:"type"`
+	TypeOrder     int               `json:"typeOrder"`
+	ShortName     string            `json:"shortName"`
+	LongName      string            `json:"longName"`
+	ScheduledTime time.Time         `json:"scheduledTime"`
+	RedTeams      []int             `json:"redTeams"`
+	BlueTeams     []int             `json:"blueTeams"`
+	Status        string            `json:"status"`
+	Result        *ApiV1MatchResult `json:"result"`
+}
+
+type ApiV1MatchResult struct {
+	PlayNumber int `json:"playNumber"`
+	RedScore   int `json:"redScore"`
+	BlueScore  int `json:"blueScore"`
+}
+
+type ApiV1MatchResultEdit struct {
+	RedScore  *game.Score       `json:"redScore"`
+	BlueScore *game.Score       `json:"blueScore"`
+	RedCards  map[string]string `json:"redCards"`
+	BlueCards map[string]string `json:"blueCards"`
+}
+
+type ApiV1Ranking struct {
+	Rank              int    `json:"rank"`
+	TeamId            int    `json:"teamId"`
+	Nickname          string `json:"nickname"`
+	RankingPoints     int    `json:"rankingPoints"`
+	MatchPoints       int    `json:"matchPoints"`
+	Wins              int    `json:"wins"`
+	Losses            int    `json:"losses"`
+	Ties              int    `json:"ties"`
+	Disqualifications int    `json:"disqualifications"`
+	Played            int    `json:"played"`
+}
+
+type ApiV1ScheduleBlock struct {
+	Id              int       `json:"id" openapi:"readOnly"`
+	StartTime       time.Time `json:"startTime"`
+	NumMatches      int       `json:"numMatches"`
+	MatchSpacingSec int       `json:"matchSpacingSec"`
+}
+
+type ApiV1Award struct {
+	Id         int    `json:"id" openapi:"readOnly"`
+	Type       string `json:"type" openapi:"readOnly"`
+	AwardName  string `json:"awardName"`
+	TeamId     int    `json:"teamId"`
+	PersonName string `json:"personName"`
+}
+
+type ApiV1LowerThird struct {
+	Id           int    `json:"id" openapi:"readOnly"`
+	TopText      string `json:"topText"`
+	BottomText   string `json:"bottomText"`
+	DisplayOrder int    `json:"displayOrder" openapi:"readOnly"`
+	AwardId      int    `json:"awardId" openapi:"readOnly"`
+}
+
+var apiV1MatchStatusNames = map[game.MatchStatus]string{
+	game.MatchScheduled: "scheduled",
+	game.MatchHidden:    "hidden",
+	game.RedWonMatch:    "redWon",
+	game.BlueWonMatch:   "blueWon",
+	game.TieMatch:       "tie",
+}
+
+var apiV1AwardTypeNames = map[model.AwardType]string{
+	model.JudgedAward:   "judged",
+	model.FinalistAward: "finalist",
+	model.WinnerAward:   "winner",
+}
+
+func newApiV1Team(team *model.Team) ApiV1Team {
+	return ApiV1Team{
+		Id:              team.Id,
+		Name:            team.Name,
+		Nickname:        team.Nickname,
+		City:            team.City,
+		StateProv:       team.StateProv,
+		Country:         team.Country,
+		SchoolName:      team.SchoolName,
+		RookieYear:      team.RookieYear,
+		RobotName:       team.RobotName,
+		Accomplishments: team.Accomplishments,
+	}
+}
+
+// Copies the details of the given API representation onto the team, leaving its number and other fields unchanged.
+func (apiTeam *ApiV1Team) applyTo(team *model.Team) {
+	team.Name = apiTeam.Name
+	team.Nickname = apiTeam.Nickname
+	team.City = apiTeam.City
+	team.StateProv = apiTeam.StateProv
+	team.Country = apiTeam.Country
+	team.SchoolName = apiTeam.SchoolName
+	team.RookieYear = apiTeam.RookieYear
+	team.RobotName = apiTeam.RobotName
+	team.Accomplishments = apiTeam.Accomplishments
+}
+
+func newApiV1Match(match *model.Match, matchResult *model.MatchResult) ApiV1Match {
+	apiMatch := ApiV1Match{
+		Id:            match.Id,
+		Type:          strings.ToLower(match.Type.String()),
+		TypeOrder:     match.TypeOrder,
+		ShortName:     match.ShortName,
+		LongName:      match.LongName,
+		ScheduledTime: match.Time,
+		RedTeams:      []int{match.Red1, match.Red2, match.Red3},
+		BlueTeams:     []int{match.Blue1, match.Blue2, match.Blue3},
+		Status:        apiV1MatchStatusNames[match.Status],
+	}
+	if matchResult != nil {
+		apiMatch.Result = &ApiV1MatchResult{
+			PlayNumber: matchResult.PlayNumber,
+			RedScore:   matchResult.RedScoreSummary().Score,
+			BlueScore:  matchResult.BlueScoreSummary().Score,
+		}
+	}
+	return apiMatch
+}
+
+func newApiV1Award(award *model.Award) ApiV1Award {
+	return ApiV1Award{
+		Id:         award.Id,
+		Type:       apiV1AwardTypeNames[award.Type],
+		AwardName:  award.AwardName,
+		TeamId:     award.TeamId,
+		PersonName: award.PersonName,
+	}
+}
+
+func newApiV1LowerThird(lowerThird *model.LowerThird) ApiV1LowerThird {
+	return ApiV1LowerThird{
+		Id:           lowerThird.Id,
+		TopText:      lowerThird.TopText,
+		BottomText:   lowerThird.BottomText,
+		DisplayOrder: lowerThird.DisplayOrder,
+		AwardId:      lowerThird.AwardId,
+	}
+}
+
+// Lists all teams.
+func (web *Web) teamsApiV1GetHandler(w http.ResponseWriter, r *http.Request) {
+	teams, err := web.arena.Database.GetAllTeams()
+	if err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	apiTeams := make([]ApiV1Team, len(teams))
+	for i, team := range teams {
+		apiTeams[i] = newApiV1Team(&team)
+	}
+	writeApiV1Json(w, 200, apiTeams)
+}
+
+// Adds a team with the given details.
+func (web *Web) teamsApiV1PostHandler(w http.ResponseWriter, r *http.Request) {
+	var apiTeam ApiV1Team
+	if !decodeApiV1Request(w, r, &apiTeam) {
+		return
+	}
+	if apiTeam.Id <= 0 {
+		writeApiV1Error(w, 400, "Team number must be a positive integer.")
+		return
+	}
+	if !web.canModifyTeamList() {
+		writeApiV1Error(w, 409, "Cannot modify the team list once the qualification schedule has been generated.")
+		return
+	}
+	existingTeam, err := web.arena.Database.GetTeamById(apiTeam.Id)
+	if err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	if existingTeam != nil {
+		writeApiV1Error(w, 409, fmt.Sprintf("Team %d is already present at the event.", apiTeam.Id))
+		return
+	}
+
+	team := model.Team{Id: apiTeam.Id}
+	apiTeam.applyTo(&team)
+	if err = web.arena.Database.CreateTeam(&team); err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	web.recordAuditEntry(r, "team.add", strconv.Itoa(team.Id), nil, team)
+	writeApiV1Json(w, 201, newApiV1Team(&team))
+}
+
+// Gets a single team.
+func (web *Web) teamApiV1GetHandler(w http.ResponseWriter, r *http.Request) {
+	team := web.getApiV1Team(w, r)
+	if team == nil {
+		return
+	}
+	writeApiV1Json(w, 200, newApiV1Team(team))
+}
+
+// Updates a team's details.
+func (web *Web) teamApiV1PutHandler(w http.ResponseWriter, r *http.Request) {
+	team := web.getApiV1Team(w, r)
+	if team == nil {
+		return
+	}
+	var apiTeam ApiV1Team
+	if !decodeApiV1Request(w, r, &apiTeam) {
+		return
+	}
+
+	previousTeam := *team
+	apiTeam.applyTo(team)
+	if err := web.arena.Database.UpdateTeam(team); err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	web.recordAuditEntry(r, "team.edit", strconv.Itoa(team.Id), previousTeam, *team)
+	writeApiV1Json(w, 200, newApiV1Team(team))
+}
+
+// Removes a team from the event.
+func (web *Web) teamApiV1DeleteHandler(w http.ResponseWriter, r *http.Request) {
+	team := web.getApiV1Team(w, r)
+	if team == nil {
+		return
+	}
+	if !web.canModifyTeamList() {
+		writeApiV1Error(w, 409, "Cannot modify the team list once the qualification schedule has been generated.")
+		return
+	}
+
+	if err := web.arena.Database.DeleteTeam(team.Id); err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	if err := web.arena.Database.DeleteTeamUnavailabilitiesByTeam(team.Id); err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	web.recordAuditEntry(r, "team.delete", strconv.Itoa(team.Id), *team, nil)
+	w.WriteHeader(204)
+}
+
+// Returns the team identified in the request path, or writes an error response and returns nil if there is none.
+func (web *Web) getApiV1Team(w http.ResponseWriter, r *http.Request) *model.Team {
+	teamId, ok := getApiV1PathId(w, r, "teamId")
+	if !ok {
+		return nil
+	}
+	team, err := web.arena.Database.GetTeamById(teamId)
+	if err != nil {
+		handleApiV1Err(w, err)
+		return nil
+	}
+	if team == nil {
+		writeApiV1Error(w, 404, fmt.Sprintf("Team %d is not present at the event.", teamId))
+	}
+	return team
+}
+
+// Lists the visible matches of the requested type, or of all types if none is given.
+func (web *Web) matchesApiV1GetHandler(w http.ResponseWriter, r *http.Request) {
+	matchTypes := []model.MatchType{model.Practice, model.Qualification, model.Playoff}
+	if matchTypeString := r.URL.Query().Get("type"); matchTypeString != "" {
+		matchType, err := model.MatchTypeFromString(matchTypeString)
+		if err != nil {
+			writeApiV1Error(w, 400, fmt.Sprintf("Invalid match type %q.", matchTypeString))
+			return
+		}
+		matchTypes = []model.MatchType{matchType}
+	}
+
+	apiMatches := make([]ApiV1Match, 0)
+	for _, matchType := range matchTypes {
+		matches, err := web.arena.Database.GetMatchesByType(matchType, false)
+		if err != nil {
+			handleApiV1Err(w, err)
+			return
+		}
+		for _, match := range matches {
+			matchResult, err := web.arena.Database.GetMatchResultForMatch(match.Id)
+			if err != nil {
+				handleApiV1Err(w, err)
+				return
+			}
+			apiMatches = append(apiMatches, newApiV1Match(&match, matchResult))
+		}
+	}
+	writeApiV1Json(w, 200, apiMatches)
+}
+
+// Gets a single match and its latest result.
+func (web *Web) matchApiV1GetHandler(w http.ResponseWriter, r *http.Request) {
+	matchId, ok := getApiV1PathId(w, r, "matchId")
+	if !ok {
+		return
+	}
+	match, err := web.arena.Database.GetMatchById(matchId)
+	if err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	if match == nil {
+		writeApiV1Error(w, 404, fmt.Sprintf("Match %d does not exist.", matchId))
+		return
+	}
+	matchResult, err := web.arena.Database.GetMatchResultForMatch(match.Id)
+	if err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	writeApiV1Json(w, 200, newApiV1Match(match, matchResult))
+}
+
+// Replaces the result of a match and commits it, recalculating the rankings and playoff tournament as needed, in the
+// same way as editing it from the match review page.
+func (web *Web) matchResultApiV1PutHandler(w http.ResponseWriter, r *http.Request) {
+	matchId, ok := getApiV1PathId(w, r, "matchId")
+	if !ok {
+		return
+	}
+	match, err := web.arena.Database.GetMatchById(matchId)
+	if err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	if match == nil {
+		writeApiV1Error(w, 404, fmt.Sprintf("Match %d does not exist.", matchId))
+		return
+	}
+	if match.Id == web.arena.CurrentMatch.Id && web.arena.MatchState != field.PreMatch &&
+		web.arena.MatchState != field.TimeoutActive {
+		writeApiV1Error(w, 409, fmt.Sprintf("Match %d is in progress and must be committed from the arena.", matchId))
+		return
+	}
+	var request ApiV1MatchResultEdit
+	if !decodeApiV1Request(w, r, &request) {
+		return
+	}
+	if request.RedScore == nil || request.BlueScore == nil {
+		writeApiV1Error(w, 400, "Both the red and blue scores are required.")
+		return
+	}
+
+	previousMatchResult, err := web.arena.Database.GetMatchResultForMatch(match.Id)
+	if err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	matchResult := model.NewMatchResult()
+	if previousMatchResult != nil {
+		// Edit the latest result in place, as the match review page does, rather than adding another play of the match.
+		matchResult.Id = previousMatchResult.Id
+		matchResult.PlayNumber = previousMatchResult.PlayNumber
+	} else {
+		previousMatchResult = model.NewMatchResult()
+	}
+	matchResult.MatchId = match.Id
+	matchResult.MatchType = match.Type
+	matchResult.RedScore = request.RedScore
+	matchResult.BlueScore = request.BlueScore
+	if request.RedCards != nil {
+		matchResult.RedCards = request.RedCards
+	}
+	if request.BlueCards != nil {
+		matchResult.BlueCards = request.BlueCards
+	}
+
+	scoreBefore := field.NewRealtimeScoreSnapshot(previousMatchResult)
+	scoreAfter := field.NewRealtimeScoreSnapshot(matchResult)
+	if err = web.commitMatchScore(match, matchResult, true); err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	web.recordAuditEntry(r, "match_review.edit", strconv.Itoa(match.Id), *previousMatchResult, *matchResult)
+	err = field.RecordMatchEventForMatch(
+		web.arena.Database, match.Id, 0, "api", web.getAuditUsername(r), "edit", scoreBefore, scoreAfter,
+	)
+	if err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	writeApiV1Json(w, 200, newApiV1Match(match, matchResult))
+}
+
+// Lists the qualification rankings.
+func (web *Web) rankingsApiV1GetHandler(w http.ResponseWriter, r *http.Request) {
+	rankings, err := web.arena.Database.GetAllRankings()
+	if err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	teams, err := web.arena.Database.GetAllTeams()
+	if err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	teamNicknames := make(map[int]string)
+	for _, team := range teams {
+		teamNicknames[team.Id] = team.Nickname
+	}
+
+	apiRankings := make([]ApiV1Ranking, len(rankings))
+	for i, ranking := range rankings {
+		apiRankings[i] = ApiV1Ranking{
+			Rank:              ranking.Rank,
+			TeamId:            ranking.TeamId,
+			Nickname:          teamNicknames[ranking.TeamId],
+			RankingPoints:     ranking.RankingPoints,
+			MatchPoints:       ranking.MatchPoints,
+			Wins:              ranking.Wins,
+			Losses:            ranking.Losses,
+			Ties:              ranking.Ties,
+			Disqualifications: ranking.Disqualifications,
+			Played:            ranking.Played,
+		}
+	}
+	writeApiV1Json(w, 200, apiRankings)
+}
+
+// Lists the schedule blocks of the requested match type.
+func (web *Web) scheduleBlocksApiV1GetHandler(w http.ResponseWriter, r *http.Request) {
+	matchType, ok := getApiV1ScheduleMatchType(w, r)
+	if !ok {
+		return
+	}
+	web.writeApiV1ScheduleBlocks(w, matchType)
+}
+
+// Replaces the schedule blocks of the requested match type with the given ones.
+func (web *Web) scheduleBlocksApiV1PutHandler(w http.ResponseWriter, r *http.Request) {
+	matchType, ok := getApiV1ScheduleMatchType(w, r)
+	if !ok {
+		return
+	}
+	var apiScheduleBlocks []ApiV1ScheduleBlock
+	if !decodeApiV1Request(w, r, &apiScheduleBlocks) {
+		return
+	}
+	for i, apiScheduleBlock := range apiScheduleBlocks {
+		if apiScheduleBlock.StartTime.IsZero() || apiScheduleBlock.NumMatches <= 0 ||
+			apiScheduleBlock.MatchSpacingSec <= 0 {
+			writeApiV1Error(
+				w,
+				400,
+				fmt.Sprintf(
+					"Schedule block %d must have a start time, a positive number of matches and a positive match "+
+						"spacing.",
+					i+1,
+				),
+			)
+			return
+		}
+	}
+
+	previousScheduleBlocks, err := web.arena.Database.GetScheduleBlocksByMatchType(matchType)
+	if err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	if err = web.arena.Database.DeleteScheduleBlocksByMatchType(matchType); err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	var scheduleBlocks []model.ScheduleBlock
+	for _, apiScheduleBlock := range apiScheduleBlocks {
+		scheduleBlock := model.ScheduleBlock{
+			MatchType:       matchType,
+			StartTime:       apiScheduleBlock.StartTime,
+			NumMatches:      apiScheduleBlock.NumMatches,
+			MatchSpacingSec: apiScheduleBlock.MatchSpacingSec,
+		}
+		if err = web.arena.Database.CreateScheduleBlock(&scheduleBlock); err != nil {
+			handleApiV1Err(w, err)
+			return
+		}
+		scheduleBlocks = append(scheduleBlocks, scheduleBlock)
+	}
+	web.recordAuditEntry(
+		r, "schedule_blocks.save", strings.ToLower(matchType.String()), previousScheduleBlocks, scheduleBlocks,
+	)
+	web.writeApiV1ScheduleBlocks(w, matchType)
+}
+
+// Returns the match type of the schedule blocks given in the request, or writes an error response and returns false if
+// it is invalid.
+func getApiV1ScheduleMatchType(w http.ResponseWriter, r *http.Request) (model.MatchType, bool) {
+	matchType, err := model.MatchTypeFromString(r.URL.Query().Get("type"))
+	if err != nil || (matchType != model.Practice && matchType != model.Qualification) {
+		writeApiV1Error(w, 400, "Match type must be practice or qualification.")
+		return 0, false
+	}
+	return matchType, true
+}
+
+func (web *Web) writeApiV1ScheduleBlocks(w http.ResponseWriter, matchType model.MatchType) {
+	scheduleBlocks, err := web.arena.Database.GetScheduleBlocksByMatchType(matchType)
+	if err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	apiScheduleBlocks := make([]ApiV1ScheduleBlock, len(scheduleBlocks))
+	for i, scheduleBlock := range scheduleBlocks {
+		apiScheduleBlocks[i] = ApiV1ScheduleBlock{
+			Id:              scheduleBlock.Id,
+			StartTime:       scheduleBlock.StartTime,
+			NumMatches:      scheduleBlock.NumMatches,
+			MatchSpacingSec: scheduleBlock.MatchSpacingSec,
+		}
+	}
+	writeApiV1Json(w, 200, apiScheduleBlocks)
+}
+
+// Lists all awards.
+func (web *Web) awardsApiV1GetHandler(w http.ResponseWriter, r *http.Request) {
+	awards, err := web.arena.Database.GetAllAwards()
+	if err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	apiAwards := make([]ApiV1Award, len(awards))
+	for i, award := range awards {
+		apiAwards[i] = newApiV1Award(&award)
+	}
+	writeApiV1Json(w, 200, apiAwards)
+}
+
+// Creates a judged award with the given details.
+func (web *Web) awardsApiV1PostHandler(w http.ResponseWriter, r *http.Request) {
+	var apiAward ApiV1Award
+	if !decodeApiV1Request(w, r, &apiAward) {
+		return
+	}
+	award := model.Award{
+		Type:       model.JudgedAward,
+		AwardName:  apiAward.AwardName,
+		TeamId:     apiAward.TeamId,
+		PersonName: apiAward.PersonName,
+	}
+	web.saveApiV1Award(w, r, &award, 201)
+}
+
+// Updates an award's details.
+func (web *Web) awardApiV1PutHandler(w http.ResponseWriter, r *http.Request) {
+	award := web.getApiV1Award(w, r)
+	if award == nil {
+		return
+	}
+	var apiAward ApiV1Award
+	if !decodeApiV1Request(w, r, &apiAward) {
+		return
+	}
+	award.AwardName = apiAward.AwardName
+	award.TeamId = apiAward.TeamId
+	award.PersonName = apiAward.PersonName
+	web.saveApiV1Award(w, r, award, 200)
+}
+
+// Deletes an award and its lower thirds.
+func (web *Web) awardApiV1DeleteHandler(w http.ResponseWriter, r *http.Request) {
+	award := web.getApiV1Award(w, r)
+	if award == nil {
+		return
+	}
+	if err := tournament.DeleteAward(web.arena.Database, award.Id); err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	web.recordAuditEntry(r, "award.delete", strconv.Itoa(award.Id), nil, nil)
+	w.WriteHeader(204)
+}
+
+// Creates or updates the given award and its lower thirds, writing it out with the given status code if successful.
+func (web *Web) saveApiV1Award(w http.ResponseWriter, r *http.Request, award *model.Award, status int) {
+	if err := tournament.CreateOrUpdateAward(web.arena.Database, award, true); err != nil {
+		writeApiV1Error(w, 400, err.Error())
+		return
+	}
+	web.recordAuditEntry(r, "award.save", strconv.Itoa(award.Id), nil, *award)
+	writeApiV1Json(w, status, newApiV1Award(award))
+}
+
+// Returns the award identified in the request path, or writes an error response and returns nil if there is none.
+func (web *Web) getApiV1Award(w http.ResponseWriter, r *http.Request) *model.Award {
+	awardId, ok := getApiV1PathId(w, r, "awardId")
+	if !ok {
+		return nil
+	}
+	award, err := web.arena.Database.GetAwardById(awardId)
+	if err != nil {
+		handleApiV1Err(w, err)
+		return nil
+	}
+	if award == nil {
+		writeApiV1Error(w, 404, fmt.Sprintf("Award %d does not exist.", awardId))
+	}
+	return award
+}
+
+// Lists all lower thirds.
+func (web *Web) lowerThirdsApiV1GetHandler(w http.ResponseWriter, r *http.Request) {
+	lowerThirds, err := web.arena.Database.GetAllLowerThirds()
+	if err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	apiLowerThirds := make([]ApiV1LowerThird, len(lowerThirds))
+	for i, lowerThird := range lowerThirds {
+		apiLowerThirds[i] = newApiV1LowerThird(&lowerThird)
+	}
+	writeApiV1Json(w, 200, apiLowerThirds)
+}
+
+// Creates a lower third with the given text at the end of the display order.
+func (web *Web) lowerThirdsApiV1PostHandler(w http.ResponseWriter, r *http.Request) {
+	var apiLowerThird ApiV1LowerThird
+	if !decodeApiV1Request(w, r, &apiLowerThird) {
+		return
+	}
+	lowerThird := model.LowerThird{
+		TopText:      apiLowerThird.TopText,
+		BottomText:   apiLowerThird.BottomText,
+		DisplayOrder: web.arena.Database.GetNextLowerThirdDisplayOrder(),
+	}
+	if err := web.arena.Database.CreateLowerThird(&lowerThird); err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	web.recordAuditEntry(r, "lower_third.save", strconv.Itoa(lowerThird.Id), nil, lowerThird)
+	writeApiV1Json(w, 201, newApiV1LowerThird(&lowerThird))
+}
+
+// Updates the text of a lower third.
+func (web *Web) lowerThirdApiV1PutHandler(w http.ResponseWriter, r *http.Request) {
+	lowerThird := web.getApiV1LowerThird(w, r)
+	if lowerThird == nil {
+		return
+	}
+	var apiLowerThird ApiV1LowerThird
+	if !decodeApiV1Request(w, r, &apiLowerThird) {
+		return
+	}
+	lowerThird.TopText = apiLowerThird.TopText
+	lowerThird.BottomText = apiLowerThird.BottomText
+	if err := web.arena.Database.UpdateLowerThird(lowerThird); err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	web.recordAuditEntry(r, "lower_third.save", strconv.Itoa(lowerThird.Id), nil, *lowerThird)
+	writeApiV1Json(w, 200, newApiV1LowerThird(lowerThird))
+}
+
+// Deletes a lower third.
+func (web *Web) lowerThirdApiV1DeleteHandler(w http.ResponseWriter, r *http.Request) {
+	lowerThird := web.getApiV1LowerThird(w, r)
+	if lowerThird == nil {
+		return
+	}
+	if err := web.arena.Database.DeleteLowerThird(lowerThird.Id); err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	web.recordAuditEntry(r, "lower_third.delete", strconv.Itoa(lowerThird.Id), nil, nil)
+	w.WriteHeader(204)
+}
+
+// Shows a lower third on the audience display.
+func (web *Web) lowerThirdShowApiV1PostHandler(w http.ResponseWriter, r *http.Request) {
+	lowerThird := web.getApiV1LowerThird(w, r)
+	if lowerThird == nil {
+		return
+	}
+	web.arena.LowerThird = lowerThird
+	web.arena.ShowLowerThird = true
+	web.arena.LowerThirdNotifier.Notify()
+	w.WriteHeader(204)
+}
+
+// Hides the lower third currently shown on the audience display.
+func (web *Web) lowerThirdHideApiV1PostHandler(w http.ResponseWriter, r *http.Request) {
+	web.arena.ShowLowerThird = false
+	web.arena.LowerThirdNotifier.Notify()
+	w.WriteHeader(204)
+}
+
+// Returns the lower third identified in the request path, or writes an error response and returns nil if there is
+// none.
+func (web *Web) getApiV1LowerThird(w http.ResponseWriter, r *http.Request) *model.LowerThird {
+	lowerThirdId, ok := getApiV1PathId(w, r, "lowerThirdId")
+	if !ok {
+		return nil
+	}
+	lowerThird, err := web.arena.Database.GetLowerThirdById(lowerThirdId)
+	if err != nil {
+		handleApiV1Err(w, err)
+		return nil
+	}
+	if lowerThird == nil {
+		writeApiV1Error(w, 404, fmt.Sprintf("Lower third %d does not exist.", lowerThirdId))
+	}
+	return lowerThird
+}
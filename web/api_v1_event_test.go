@@ -0,0 +1,285 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestApiV1Teams(t *testing.T) {
+	web := setupTestWeb(t)
+
+	// Check adding a team.
+	recorder := web.apiV1Response("POST", "/api/v1/teams", "", ApiV1Team{Id: 254, Nickname: "The Cheesy Poofs"})
+	assert.Equal(t, 201, recorder.Code, recorder.Body.String())
+	team, _ := web.arena.Database.GetTeamById(254)
+	if assert.NotNil(t, team) {
+		assert.Equal(t, "The Cheesy Poofs", team.Nickname)
+	}
+	recorder = web.apiV1Response("POST", "/api/v1/teams", "", ApiV1Team{Id: 254})
+	assert.Equal(t, 409, recorder.Code)
+	assert.Equal(t, "Team 254 is already present at the event.", readApiV1Error(t, recorder))
+	recorder = web.apiV1Response("POST", "/api/v1/teams", "", ApiV1Team{})
+	assert.Equal(t, 400, recorder.Code)
+
+	// Check that updating a team leaves the fields not exposed by the API unchanged.
+	team.WpaKey = "12345678"
+	web.arena.Database.UpdateTeam(team)
+	recorder = web.apiV1Response(
+		"PUT", "/api/v1/teams/254", "", ApiV1Team{Nickname: "Poofs", City: "San Jose", RookieYear: 1999},
+	)
+	assert.Equal(t, 200, recorder.Code, recorder.Body.String())
+	team, _ = web.arena.Database.GetTeamById(254)
+	assert.Equal(t, "Poofs", team.Nickname)
+	assert.Equal(t, "San Jose", team.City)
+	assert.Equal(t, "12345678", team.WpaKey)
+
+	recorder = web.apiV1Response("GET", "/api/v1/teams/254", "", nil)
+	assert.Equal(t, 200, recorder.Code)
+	var apiTeam ApiV1Team
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &apiTeam))
+	assert.Equal(t, ApiV1Team{Id: 254, Nickname: "Poofs", City: "San Jose", RookieYear: 1999}, apiTeam)
+	recorder = web.apiV1Response("GET", "/api/v1/teams/255", "", nil)
+	assert.Equal(t, 404, recorder.Code)
+	assert.Equal(t, "Team 255 is not present at the event.", readApiV1Error(t, recorder))
+
+	// Check that the team list can't be changed once the qualification schedule exists.
+	web.arena.Database.CreateMatch(&model.Match{Type: model.Qualification})
+	recorder = web.apiV1Response("DELETE", "/api/v1/teams/254", "", nil)
+	assert.Equal(t, 409, recorder.Code)
+	web.arena.Database.TruncateMatches()
+	recorder = web.apiV1Response("DELETE", "/api/v1/teams/254", "", nil)
+	assert.Equal(t, 204, recorder.Code)
+	recorder = web.apiV1Response("GET", "/api/v1/teams", "", nil)
+	assert.Equal(t, "[]", recorder.Body.String())
+}
+
+func TestApiV1Matches(t *testing.T) {
+	web := setupTestWeb(t)
+	match1 := model.Match{
+		Type: model.Qualification, TypeOrder: 1, ShortName: "Q1", Time: time.Unix(600, 0).UTC(), Red1: 1, Red2: 2,
+		Red3: 3, Blue1: 4, Blue2: 5, Blue3: 6, Status: game.RedWonMatch,
+	}
+	match2 := model.Match{Type: model.Practice, TypeOrder: 1, ShortName: "P1"}
+	web.arena.Database.CreateMatch(&match1)
+	web.arena.Database.CreateMatch(&match2)
+	matchResult := model.BuildTestMatchResult(match1.Id, 1)
+	web.arena.Database.CreateMatchResult(matchResult)
+
+	recorder := web.apiV1Response("GET", "/api/v1/matches", "", nil)
+	assert.Equal(t, 200, recorder.Code)
+	var apiMatches []ApiV1Match
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &apiMatches))
+	if assert.Equal(t, 2, len(apiMatches)) {
+		assert.Equal(t, "P1", apiMatches[0].ShortName)
+		assert.Nil(t, apiMatches[0].Result)
+		assert.Equal(t, "Q1", apiMatches[1].ShortName)
+	}
+
+	recorder = web.apiV1Response("GET", "/api/v1/matches?type=qualification", "", nil)
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &apiMatches))
+	if assert.Equal(t, 1, len(apiMatches)) {
+		assert.Equal(t, "qualification", apiMatches[0].Type)
+		assert.Equal(t, []int{1, 2, 3}, apiMatches[0].RedTeams)
+		assert.Equal(t, []int{4, 5, 6}, apiMatches[0].BlueTeams)
+		assert.Equal(t, "redWon", apiMatches[0].Status)
+		assert.Equal(t, time.Unix(600, 0).UTC(), apiMatches[0].ScheduledTime)
+		if assert.NotNil(t, apiMatches[0].Result) {
+			assert.Equal(t, matchResult.RedScoreSummary().Score, apiMatches[0].Result.RedScore)
+			assert.Equal(t, matchResult.BlueScoreSummary().Score, apiMatches[0].Result.BlueScore)
+		}
+	}
+	recorder = web.apiV1Response("GET", "/api/v1/matches?type=blorpy", "", nil)
+	assert.Equal(t, 400, recorder.Code)
+
+	recorder = web.apiV1Response("GET", "/api/v1/matches/2", "", nil)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "\"shortName\": \"P1\"")
+	recorder = web.apiV1Response("GET", "/api/v1/matches/3", "", nil)
+	assert.Equal(t, 404, recorder.Code)
+}
+
+func TestApiV1MatchResultEdit(t *testing.T) {
+	web := setupTestWeb(t)
+	match := model.Match{
+		Type: model.Qualification, TypeOrder: 1, ShortName: "Q1", Red1: 1, Red2: 2, Red3: 3, Blue1: 4, Blue2: 5, Blue3: 6,
+	}
+	web.arena.Database.CreateMatch(&match)
+	web.arena.Database.CreateMatchResult(model.BuildTestMatchResult(match.Id, 1))
+
+	// Check that the latest result is edited in place and committed.
+	request := ApiV1MatchResultEdit{
+		RedScore: game.TestScore1(), BlueScore: game.TestScore2(), RedCards: map[string]string{"1": "yellow"},
+	}
+	recorder := web.apiV1Response("PUT", fmt.Sprintf("/api/v1/matches/%d/result", match.Id), "", request)
+	assert.Equal(t, 200, recorder.Code, recorder.Body.String())
+	var apiMatch ApiV1Match
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &apiMatch))
+	assert.Equal(t, "blueWon", apiMatch.Status)
+	if assert.NotNil(t, apiMatch.Result) {
+		assert.Equal(t, 1, apiMatch.Result.PlayNumber)
+	}
+	matchResult, _ := web.arena.Database.GetMatchResultForMatch(match.Id)
+	assert.Equal(t, 1, matchResult.PlayNumber)
+	assert.Equal(t, game.TestScore1(), matchResult.RedScore)
+	assert.Equal(t, map[string]string{"1": "yellow"}, matchResult.RedCards)
+	assert.Equal(t, map[string]string{}, matchResult.BlueCards)
+	rankings, _ := web.arena.Database.GetAllRankings()
+	assert.NotEmpty(t, rankings)
+	matchEvents, _ := web.arena.Database.GetMatchEventsForMatch(match.Id)
+	if assert.Equal(t, 1, len(matchEvents)) {
+		assert.Equal(t, "api", matchEvents[0].Source)
+		assert.Equal(t, "edit", matchEvents[0].Command)
+	}
+
+	// Check that invalid requests are rejected.
+	recorder = web.apiV1Response("PUT", "/api/v1/matches/99/result", "", request)
+	assert.Equal(t, 404, recorder.Code)
+	recorder = web.apiV1Response(
+		"PUT", fmt.Sprintf("/api/v1/matches/%d/result", match.Id), "", ApiV1MatchResultEdit{RedScore: &game.Score{}},
+	)
+	assert.Equal(t, 400, recorder.Code)
+	assert.Equal(t, "Both the red and blue scores are required.", readApiV1Error(t, recorder))
+	web.arena.CurrentMatch = &match
+	web.arena.MatchState = field.PostMatch
+	recorder = web.apiV1Response("PUT", fmt.Sprintf("/api/v1/matches/%d/result", match.Id), "", request)
+	assert.Equal(t, 409, recorder.Code)
+}
+
+func TestApiV1Rankings(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.Database.CreateTeam(&model.Team{Id: 254, Nickname: "Poofs"})
+	web.arena.Database.CreateRanking(
+		&game.Ranking{TeamId: 254, Rank: 1, RankingFields: game.RankingFields{RankingPoints: 20, Wins: 5, Played: 6}},
+	)
+
+	recorder := web.apiV1Response("GET", "/api/v1/rankings", "", nil)
+	assert.Equal(t, 200, recorder.Code)
+	var apiRankings []ApiV1Ranking
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &apiRankings))
+	assert.Equal(
+		t,
+		[]ApiV1Ranking{{Rank: 1, TeamId: 254, Nickname: "Poofs", RankingPoints: 20, Wins: 5, Played: 6}},
+		apiRankings,
+	)
+}
+
+func TestApiV1ScheduleBlocks(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.Database.CreateScheduleBlock(&model.ScheduleBlock{MatchType: model.Practice, NumMatches: 1})
+
+	startTime := time.Unix(1000, 0).UTC()
+	recorder := web.apiV1Response(
+		"PUT",
+		"/api/v1/schedule_blocks?type=qualification",
+		"",
+		[]ApiV1ScheduleBlock{
+			{StartTime: startTime.Add(time.Hour), NumMatches: 5, MatchSpacingSec: 360},
+			{StartTime: startTime, NumMatches: 10, MatchSpacingSec: 480},
+		},
+	)
+	assert.Equal(t, 200, recorder.Code, recorder.Body.String())
+	var apiScheduleBlocks []ApiV1ScheduleBlock
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &apiScheduleBlocks))
+	if assert.Equal(t, 2, len(apiScheduleBlocks)) {
+		assert.Equal(t, ApiV1ScheduleBlock{3, startTime, 10, 480}, apiScheduleBlocks[0])
+		assert.Equal(t, 5, apiScheduleBlocks[1].NumMatches)
+	}
+	practiceBlocks, _ := web.arena.Database.GetScheduleBlocksByMatchType(model.Practice)
+	assert.Equal(t, 1, len(practiceBlocks))
+
+	recorder = web.apiV1Response("GET", "/api/v1/schedule_blocks?type=qualification", "", nil)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &apiScheduleBlocks))
+	assert.Equal(t, 2, len(apiScheduleBlocks))
+
+	// Check that invalid blocks are rejected without affecting the existing ones.
+	recorder = web.apiV1Response(
+		"PUT", "/api/v1/schedule_blocks?type=qualification", "", []ApiV1ScheduleBlock{{StartTime: startTime}},
+	)
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, readApiV1Error(t, recorder), "Schedule block 1 must have")
+	qualificationBlocks, _ := web.arena.Database.GetScheduleBlocksByMatchType(model.Qualification)
+	assert.Equal(t, 2, len(qualificationBlocks))
+	recorder = web.apiV1Response("GET", "/api/v1/schedule_blocks?type=playoff", "", nil)
+	assert.Equal(t, 400, recorder.Code)
+	assert.Equal(t, "Match type must be practice or qualification.", readApiV1Error(t, recorder))
+}
+
+func TestApiV1Awards(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.Database.CreateTeam(&model.Team{Id: 254, Nickname: "Poofs"})
+
+	recorder := web.apiV1Response("POST", "/api/v1/awards", "", ApiV1Award{AwardName: "Safety Award", TeamId: 254})
+	assert.Equal(t, 201, recorder.Code, recorder.Body.String())
+	var apiAward ApiV1Award
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &apiAward))
+	assert.Equal(t, ApiV1Award{Id: 1, Type: "judged", AwardName: "Safety Award", TeamId: 254}, apiAward)
+	lowerThirds, _ := web.arena.Database.GetLowerThirdsByAwardId(1)
+	assert.Equal(t, 2, len(lowerThirds))
+
+	recorder = web.apiV1Response("POST", "/api/v1/awards", "", ApiV1Award{AwardName: "Spirit Award", TeamId: 255})
+	assert.Equal(t, 400, recorder.Code)
+	assert.Equal(t, "Team 255 is not present at this event.", readApiV1Error(t, recorder))
+
+	recorder = web.apiV1Response("PUT", "/api/v1/awards/1", "", ApiV1Award{AwardName: "Safety", PersonName: "Bob"})
+	assert.Equal(t, 200, recorder.Code, recorder.Body.String())
+	award, _ := web.arena.Database.GetAwardById(1)
+	assert.Equal(t, model.Award{Id: 1, Type: model.JudgedAward, AwardName: "Safety", PersonName: "Bob"}, *award)
+
+	recorder = web.apiV1Response("GET", "/api/v1/awards", "", nil)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "\"personName\": \"Bob\"")
+
+	recorder = web.apiV1Response("DELETE", "/api/v1/awards/1", "", nil)
+	assert.Equal(t, 204, recorder.Code)
+	lowerThirds, _ = web.arena.Database.GetLowerThirdsByAwardId(1)
+	assert.Empty(t, lowerThirds)
+	recorder = web.apiV1Response("DELETE", "/api/v1/awards/1", "", nil)
+	assert.Equal(t, 404, recorder.Code)
+}
+
+func TestApiV1LowerThirds(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.Database.CreateLowerThird(&model.LowerThird{TopText: "Existing", DisplayOrder: 3})
+
+	recorder := web.apiV1Response("POST", "/api/v1/lower_thirds", "", ApiV1LowerThird{TopText: "Top", BottomText: "Bot"})
+	assert.Equal(t, 201, recorder.Code, recorder.Body.String())
+	var apiLowerThird ApiV1LowerThird
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &apiLowerThird))
+	assert.Equal(t, ApiV1LowerThird{Id: 2, TopText: "Top", BottomText: "Bot", DisplayOrder: 4}, apiLowerThird)
+
+	recorder = web.apiV1Response(
+		"PUT", "/api/v1/lower_thirds/2", "", ApiV1LowerThird{TopText: "New Top", DisplayOrder: 1},
+	)
+	assert.Equal(t, 200, recorder.Code, recorder.Body.String())
+	lowerThird, _ := web.arena.Database.GetLowerThirdById(2)
+	assert.Equal(t, model.LowerThird{Id: 2, TopText: "New Top", DisplayOrder: 4}, *lowerThird)
+
+	recorder = web.apiV1Response("POST", "/api/v1/lower_thirds/2/show", "", nil)
+	assert.Equal(t, 204, recorder.Code)
+	assert.True(t, web.arena.ShowLowerThird)
+	assert.Equal(t, "New Top", web.arena.LowerThird.TopText)
+	recorder = web.apiV1Response("POST", "/api/v1/lower_thirds/hide", "", nil)
+	assert.Equal(t, 204, recorder.Code)
+	assert.False(t, web.arena.ShowLowerThird)
+
+	recorder = web.apiV1Response("DELETE", "/api/v1/lower_thirds/2", "", nil)
+	assert.Equal(t, 204, recorder.Code)
+	recorder = web.apiV1Response("GET", "/api/v1/lower_thirds", "", nil)
+	var apiLowerThirds []ApiV1LowerThird
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &apiLowerThirds))
+	if assert.Equal(t, 1, len(apiLowerThirds)) {
+		assert.Equal(t, "Existing", apiLowerThirds[0].TopText)
+	}
+	recorder = web.apiV1Response("POST", "/api/v1/lower_thirds/2/show", "", nil)
+	assert.Equal(t, 404, recorder.Code)
+}
@@ -0,0 +1,209 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// REST API routes and representations for querying the driver station telemetry and field state recorded in the match
+// logs.
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/matchlog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ApiV1MatchLog struct {
+	MatchId        int            `json:"matchId"`
+	MatchType      string         `json:"matchType"`
+	MatchShortName string         `json:"matchShortName"`
+	StartedAt      time.Time      `json:"startedAt"`
+	Teams          map[string]int `json:"teams"`
+}
+
+type ApiV1MatchLogRecords struct {
+	MatchLog ApiV1MatchLog         `json:"matchLog"`
+	Records  []ApiV1MatchLogRecord `json:"records"`
+}
+
+type ApiV1MatchLogRecord struct {
+	Type              string         `json:"type"`
+	MatchTimeSec      float64        `json:"matchTimeSec"`
+	Station           string         `json:"station,omitempty"`
+	DsStatus          *ApiV1DsStatus `json:"dsStatus,omitempty"`
+	MatchState        string         `json:"matchState,omitempty"`
+	PlcIo             *ApiV1PlcIo    `json:"plcIo,omitempty"`
+	AccessPointStatus string         `json:"accessPointStatus,omitempty"`
+}
+
+type ApiV1DsStatus struct {
+	TeamId            int     `json:"teamId"`
+	DsLinked          bool    `json:"dsLinked"`
+	RadioLinked       bool    `json:"radioLinked"`
+	RioLinked         bool    `json:"rioLinked"`
+	RobotLinked       bool    `json:"robotLinked"`
+	Auto              bool    `json:"auto"`
+	Enabled           bool    `json:"enabled"`
+	EStop             bool    `json:"eStop"`
+	AStop             bool    `json:"aStop"`
+	BatteryVoltage    float64 `json:"batteryVoltage"`
+	MissedPacketCount int     `json:"missedPacketCount"`
+	TripTimeMs        int     `json:"tripTimeMs"`
+	RxRate            float64 `json:"rxRate"`
+	TxRate            float64 `json:"txRate"`
+	SignalNoiseRatio  int     `json:"signalNoiseRatio"`
+}
+
+type ApiV1PlcIo struct {
+	Inputs    map[string]bool   `json:"inputs"`
+	Registers map[string]uint16 `json:"registers"`
+	Coils     map[string]bool   `json:"coils"`
+}
+
+// Lists the recorded match logs, optionally only those for a given match.
+func (web *Web) matchLogsApiV1GetHandler(w http.ResponseWriter, r *http.Request) {
+	var filenames []string
+	var err error
+	if matchIdString := r.URL.Query().Get("matchId"); matchIdString != "" {
+		var matchId int
+		if matchId, err = strconv.Atoi(matchIdString); err != nil {
+			writeApiV1Error(w, 400, fmt.Sprintf("Invalid match ID %q.", matchIdString))
+			return
+		}
+		filenames, err = matchlog.ListForMatch(field.LogsDir, matchId)
+	} else {
+		filenames, err = matchlog.List(field.LogsDir)
+	}
+	if err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+
+	apiMatchLogs := make([]ApiV1MatchLog, 0)
+	for _, filename := range filenames {
+		header, err := matchlog.ReadHeader(filename)
+		if err != nil {
+			continue
+		}
+		apiMatchLogs = append(apiMatchLogs, newApiV1MatchLog(header))
+	}
+	writeApiV1Json(w, 200, apiMatchLogs)
+}
+
+// Gets the records of a match log that satisfy the query parameters.
+func (web *Web) matchLogRecordsApiV1GetHandler(w http.ResponseWriter, r *http.Request) {
+	matchId, ok := getApiV1PathId(w, r, "matchId")
+	if !ok {
+		return
+	}
+	query, ok := parseApiV1MatchLogQuery(w, r)
+	if !ok {
+		return
+	}
+
+	filenames, err := matchlog.ListForMatch(field.LogsDir, matchId)
+	if err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	var bundle *matchlog.Bundle
+	startedAtString := r.URL.Query().Get("startedAt")
+	for i := len(filenames) - 1; i >= 0 && bundle == nil; i-- {
+		candidate, err := matchlog.Read(filenames[i])
+		if err != nil {
+			handleApiV1Err(w, err)
+			return
+		}
+		if startedAtString == "" || candidate.Header.StartedAt.Format(time.RFC3339Nano) == startedAtString {
+			bundle = candidate
+		}
+	}
+	if bundle == nil {
+		writeApiV1Error(w, 404, fmt.Sprintf("No match log found for match %d.", matchId))
+		return
+	}
+
+	inputNames, registerNames, coilNames :=
+		web.arena.Plc.GetInputNames(), web.arena.Plc.GetRegisterNames(), web.arena.Plc.GetCoilNames()
+	apiRecords := make([]ApiV1MatchLogRecord, 0)
+	for _, record := range bundle.Query(query) {
+		apiRecord := ApiV1MatchLogRecord{
+			Type: record.Type.String(), MatchTimeSec: record.MatchTimeSec, Station: record.Station,
+		}
+		switch record.Type {
+		case matchlog.DsStatusRecord:
+			dsStatus := ApiV1DsStatus(*record.DsStatus)
+			apiRecord.DsStatus = &dsStatus
+		case matchlog.ArenaStateRecord:
+			apiRecord.MatchState = apiV1MatchStateNames[field.MatchState(record.MatchState)]
+		case matchlog.PlcIoRecord:
+			apiRecord.PlcIo = &ApiV1PlcIo{
+				Inputs:    nameApiV1PlcValues(inputNames, record.PlcIo.Inputs),
+				Registers: nameApiV1PlcValues(registerNames, record.PlcIo.Registers),
+				Coils:     nameApiV1PlcValues(coilNames, record.PlcIo.Coils),
+			}
+		case matchlog.AccessPointRecord:
+			apiRecord.AccessPointStatus = record.AccessPoint
+		}
+		apiRecords = append(apiRecords, apiRecord)
+	}
+	writeApiV1Json(w, 200, ApiV1MatchLogRecords{newApiV1MatchLog(bundle.Header), apiRecords})
+}
+
+// Builds the match log query from the request's query parameters, writing an error response and returning false if
+// any of them are invalid.
+func parseApiV1MatchLogQuery(w http.ResponseWriter, r *http.Request) (matchlog.Query, bool) {
+	var query matchlog.Query
+	query.Station = r.URL.Query().Get("station")
+	if query.Station != "" && !slices.Contains([]string{"R1", "R2", "R3", "B1", "B2", "B3"}, query.Station) {
+		writeApiV1Error(w, 400, fmt.Sprintf("Invalid station %q.", query.Station))
+		return query, false
+	}
+	if typesString := r.URL.Query().Get("types"); typesString != "" {
+		for _, typeName := range strings.Split(typesString, ",") {
+			recordType, ok := matchlog.ParseRecordType(strings.TrimSpace(typeName))
+			if !ok {
+				writeApiV1Error(w, 400, fmt.Sprintf("Invalid record type %q.", typeName))
+				return query, false
+			}
+			query.Types = append(query.Types, recordType)
+		}
+	}
+	for name, value := range map[string]*float64{"from": &query.FromSec, "to": &query.ToSec} {
+		if valueString := r.URL.Query().Get(name); valueString != "" {
+			var err error
+			if *value, err = strconv.ParseFloat(valueString, 64); err != nil {
+				writeApiV1Error(w, 400, fmt.Sprintf("Invalid %s time %q.", name, valueString))
+				return query, false
+			}
+		}
+	}
+	return query, true
+}
+
+func newApiV1MatchLog(header matchlog.Header) ApiV1MatchLog {
+	return ApiV1MatchLog{
+		MatchId:        header.MatchId,
+		MatchType:      strings.ToLower(header.MatchType),
+		MatchShortName: header.MatchShortName,
+		StartedAt:      header.StartedAt,
+		Teams:          header.TeamIds,
+	}
+}
+
+// Keys the given PLC I/O values by their names, falling back to their indices if the names are unknown.
+func nameApiV1PlcValues[T bool | uint16](names []string, values []T) map[string]T {
+	namedValues := make(map[string]T, len(values))
+	for i, value := range values {
+		if i < len(names) {
+			namedValues[names[i]] = value
+		} else {
+			namedValues[strconv.Itoa(i)] = value
+		}
+	}
+	return namedValues
+}
@@ -0,0 +1,139 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"encoding/json"
+	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/matchlog"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestApiV1MatchLogs(t *testing.T) {
+	web := setupTestWeb(t)
+	match := model.Match{Type: model.Qualification, ShortName: "Q7"}
+	web.arena.Database.CreateMatch(&match)
+	startedAt := time.Date(2025, 3, 21, 14, 25, 33, 0, time.Local)
+	writer, err := matchlog.Create(
+		field.LogsDir,
+		matchlog.Header{
+			MatchId:        match.Id,
+			MatchType:      match.Type.String(),
+			MatchShortName: match.ShortName,
+			StartedAt:      startedAt,
+			TeamIds:        map[string]int{"R1": 254, "B2": 1114},
+		},
+	)
+	assert.Nil(t, err)
+	records := []matchlog.Record{
+		{Type: matchlog.ArenaStateRecord, MatchState: int(field.AutoPeriod)},
+		{
+			Type:         matchlog.DsStatusRecord,
+			MatchTimeSec: 0.5,
+			Station:      "R1",
+			DsStatus:     &matchlog.DsStatus{TeamId: 254, DsLinked: true, BatteryVoltage: 12.3, SignalNoiseRatio: 40},
+		},
+		{
+			Type:         matchlog.DsStatusRecord,
+			MatchTimeSec: 0.5,
+			Station:      "B2",
+			DsStatus:     &matchlog.DsStatus{TeamId: 1114, DsLinked: true, BatteryVoltage: 11.9},
+		},
+		{
+			Type:         matchlog.PlcIoRecord,
+			MatchTimeSec: 4,
+			PlcIo:        &matchlog.PlcIo{Inputs: []bool{true, false}, Registers: []uint16{5}},
+		},
+		{Type: matchlog.AccessPointRecord, MatchTimeSec: 10, AccessPoint: "ERROR"},
+	}
+	for _, record := range records {
+		assert.Nil(t, writer.Write(record))
+	}
+	assert.Nil(t, writer.Close())
+
+	// Check listing the match logs.
+	var matchLogs []ApiV1MatchLog
+	recorder := web.apiV1Response("GET", "/api/v1/match_logs", "", nil)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &matchLogs))
+	if assert.Equal(t, 1, len(matchLogs)) {
+		assert.Equal(t, match.Id, matchLogs[0].MatchId)
+		assert.Equal(t, "qualification", matchLogs[0].MatchType)
+		assert.Equal(t, "Q7", matchLogs[0].MatchShortName)
+		assert.True(t, startedAt.Equal(matchLogs[0].StartedAt))
+		assert.Equal(t, map[string]int{"R1": 254, "B2": 1114}, matchLogs[0].Teams)
+	}
+	recorder = web.apiV1Response("GET", "/api/v1/match_logs?matchId=99", "", nil)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "[]", recorder.Body.String())
+	recorder = web.apiV1Response("GET", "/api/v1/match_logs?matchId=blorpy", "", nil)
+	assert.Equal(t, 400, recorder.Code)
+	assert.Equal(t, "Invalid match ID \"blorpy\".", readApiV1Error(t, recorder))
+
+	// Check getting all of the records.
+	var matchLogRecords ApiV1MatchLogRecords
+	recorder = web.apiV1Response("GET", "/api/v1/match_logs/1/records", "", nil)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &matchLogRecords))
+	assert.Equal(t, "Q7", matchLogRecords.MatchLog.MatchShortName)
+	if assert.Equal(t, 5, len(matchLogRecords.Records)) {
+		assert.Equal(t, ApiV1MatchLogRecord{Type: "arenaState", MatchState: "autoPeriod"}, matchLogRecords.Records[0])
+		assert.Equal(t, "dsStatus", matchLogRecords.Records[1].Type)
+		assert.Equal(t, "R1", matchLogRecords.Records[1].Station)
+		assert.Equal(
+			t,
+			ApiV1DsStatus{TeamId: 254, DsLinked: true, BatteryVoltage: 12.3, SignalNoiseRatio: 40},
+			*matchLogRecords.Records[1].DsStatus,
+		)
+		assert.Equal(t, "plcIo", matchLogRecords.Records[3].Type)
+		inputNames := web.arena.Plc.GetInputNames()
+		assert.Equal(
+			t,
+			map[string]bool{inputNames[0]: true, inputNames[1]: false},
+			matchLogRecords.Records[3].PlcIo.Inputs,
+		)
+		assert.Equal(
+			t, map[string]uint16{web.arena.Plc.GetRegisterNames()[0]: 5}, matchLogRecords.Records[3].PlcIo.Registers,
+		)
+		assert.Equal(t, "ERROR", matchLogRecords.Records[4].AccessPointStatus)
+	}
+
+	// Check filtering the records.
+	recorder = web.apiV1Response("GET", "/api/v1/match_logs/1/records?station=B2&types=dsStatus,plcIo", "", nil)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &matchLogRecords))
+	if assert.Equal(t, 2, len(matchLogRecords.Records)) {
+		assert.Equal(t, 1114, matchLogRecords.Records[0].DsStatus.TeamId)
+		assert.Equal(t, "plcIo", matchLogRecords.Records[1].Type)
+	}
+	recorder = web.apiV1Response("GET", "/api/v1/match_logs/1/records?from=1&to=5", "", nil)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &matchLogRecords))
+	if assert.Equal(t, 1, len(matchLogRecords.Records)) {
+		assert.Equal(t, "plcIo", matchLogRecords.Records[0].Type)
+	}
+	recorder = web.apiV1Response(
+		"GET", "/api/v1/match_logs/1/records?startedAt="+startedAt.Format(time.RFC3339Nano), "", nil,
+	)
+	assert.Equal(t, 200, recorder.Code)
+
+	// Check invalid requests.
+	recorder = web.apiV1Response("GET", "/api/v1/match_logs/1/records?station=R4", "", nil)
+	assert.Equal(t, 400, recorder.Code)
+	assert.Equal(t, "Invalid station \"R4\".", readApiV1Error(t, recorder))
+	recorder = web.apiV1Response("GET", "/api/v1/match_logs/1/records?types=dsStatus,bogus", "", nil)
+	assert.Equal(t, 400, recorder.Code)
+	assert.Equal(t, "Invalid record type \"bogus\".", readApiV1Error(t, recorder))
+	recorder = web.apiV1Response("GET", "/api/v1/match_logs/1/records?to=soon", "", nil)
+	assert.Equal(t, 400, recorder.Code)
+	assert.Equal(t, "Invalid to time \"soon\".", readApiV1Error(t, recorder))
+	recorder = web.apiV1Response("GET", "/api/v1/match_logs/2/records", "", nil)
+	assert.Equal(t, 404, recorder.Code)
+	assert.Equal(t, "No match log found for match 2.", readApiV1Error(t, recorder))
+	recorder = web.apiV1Response("GET", "/api/v1/match_logs/1/records?startedAt=2020-01-01T00:00:00Z", "", nil)
+	assert.Equal(t, 404, recorder.Code)
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Makes an API request with the given method, bearer token (if any) and JSON body (if any).
+func (web *Web) apiV1Response(method, path, token string, body any) *httptest.ResponseRecorder {
+	var bodyReader bytes.Reader
+	if body != nil {
+		jsonBody, _ := json.Marshal(body)
+		bodyReader.Reset(jsonBody)
+	}
+	recorder := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, path, &bodyReader)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	web.newHandler().ServeHTTP(recorder, req)
+	return recorder
+}
+
+// Creates an API token with the given role and returns the token to authenticate with.
+func createTestApiToken(t *testing.T, web *Web, token string, role model.UserRole) string {
+	apiToken := model.ApiToken{Name: string(role), Role: role, TokenHash: model.HashApiToken(token)}
+	assert.Nil(t, web.arena.Database.CreateApiToken(&apiToken))
+	return token
+}
+
+func readApiV1Error(t *testing.T, recorder *httptest.ResponseRecorder) string {
+	var apiError ApiV1Error
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &apiError))
+	return apiError.Error
+}
+
+func TestApiV1Authorization(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.Database.CreateTeam(&model.Team{Id: 254})
+
+	// Check that everything is accessible while authentication is disabled.
+	recorder := web.apiV1Response("GET", "/api/v1/teams", "", nil)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+	web.arena.EventSettings.AdminPassword = "admin"
+	readOnlyToken := createTestApiToken(t, web, "read-only-token", model.ReadOnlyRole)
+	adminToken := createTestApiToken(t, web, "admin-token", model.AdminRole)
+
+	// Check that a missing or invalid token is rejected.
+	recorder = web.apiV1Response("GET", "/api/v1/teams", "", nil)
+	assert.Equal(t, 401, recorder.Code)
+	assert.Equal(t, "Bearer", recorder.Header().Get("WWW-Authenticate"))
+	assert.Equal(t, "An API token is required.", readApiV1Error(t, recorder))
+	recorder = web.apiV1Response("GET", "/api/v1/teams", "blorpy", nil)
+	assert.Equal(t, 401, recorder.Code)
+	assert.Equal(t, "Invalid API token.", readApiV1Error(t, recorder))
+
+	// Check that the token's role determines what it can access.
+	recorder = web.apiV1Response("GET", "/api/v1/teams", readOnlyToken, nil)
+	assert.Equal(t, 200, recorder.Code)
+	recorder = web.apiV1Response("PUT", "/api/v1/teams/254", readOnlyToken, ApiV1Team{Nickname: "Poofs"})
+	assert.Equal(t, 403, recorder.Code)
+	assert.Equal(t, "The Read-Only role does not have access to this endpoint.", readApiV1Error(t, recorder))
+	recorder = web.apiV1Response("PUT", "/api/v1/teams/254", adminToken, ApiV1Team{Nickname: "Poofs"})
+	assert.Equal(t, 200, recorder.Code)
+
+	// Check that actions taken with a token are attributed to it in the audit trail.
+	auditEntries, _ := web.arena.Database.GetAllAuditEntries()
+	if assert.Equal(t, 1, len(auditEntries)) {
+		assert.Equal(t, "api:admin", auditEntries[0].Username)
+		assert.Equal(t, "team.edit", auditEntries[0].Action)
+	}
+
+	// Check that a session cookie is accepted for reads but not for writes.
+	headers := loginHeaders(web, "admin")
+	recorder = web.getHttpResponseWithHeaders("/api/v1/teams", headers)
+	assert.Equal(t, 200, recorder.Code)
+	recorder = web.postHttpResponseWithHeaders("/api/v1/lower_thirds/hide", "", headers)
+	assert.Equal(t, 401, recorder.Code)
+
+	// Check that the OpenAPI specification is public.
+	recorder = web.apiV1Response("GET", "/api/v1/openapi.json", "", nil)
+	assert.Equal(t, 200, recorder.Code)
+}
+
+func TestApiV1RequestValidation(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.postHttpResponse("/api/v1/teams", "id=254")
+	assert.Equal(t, 415, recorder.Code)
+	assert.Equal(t, "Request body must be of type application/json.", readApiV1Error(t, recorder))
+
+	recorder = web.apiV1Response("POST", "/api/v1/teams", "", map[string]any{"id": 254, "blorpy": true})
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, readApiV1Error(t, recorder), "unknown field \"blorpy\"")
+
+	recorder = web.apiV1Response("GET", "/api/v1/teams/blorpy", "", nil)
+	assert.Equal(t, 400, recorder.Code)
+	assert.Equal(t, "Invalid teamId \"blorpy\".", readApiV1Error(t, recorder))
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web routes for the FTA panel listing the alerts raised by the field health monitor.
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/websocket"
+	"github.com/mitchellh/mapstructure"
+	"io"
+	"log"
+	"net/http"
+)
+
+// Shows the Field Alerts page.
+func (web *Web) fieldAlertsGetHandler(w http.ResponseWriter, r *http.Request) {
+	template, err := web.parseFiles("templates/field_alerts.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		MaxSnoozeMin int
+	}{web.arena.EventSettings, field.FieldAlertMaxSnoozeMin}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// The websocket endpoint for sending realtime updates to the Field Alerts page and receiving acknowledgements and
+// snoozes.
+func (web *Web) fieldAlertsWebsocketHandler(w http.ResponseWriter, r *http.Request) {
+	ws, err := websocket.NewWebsocket(w, r)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	defer ws.Close()
+
+	// Subscribe the websocket to the notifiers whose messages will be passed on to the client, in a separate goroutine.
+	go ws.HandleNotifiers(web.arena.FieldAlertsNotifier)
+
+	// Loop, waiting for commands and responding to them, until the client closes the connection.
+	for {
+		messageType, data, err := ws.Read()
+		if err != nil {
+			if err == io.EOF {
+				// Client has closed the connection; nothing to do here.
+				return
+			}
+			log.Println(err)
+			return
+		}
+
+		switch messageType {
+		case "acknowledgeAlert":
+			alertId, ok := data.(string)
+			if !ok {
+				ws.WriteError(fmt.Sprintf("Failed to parse '%s' message.", messageType))
+				continue
+			}
+			if err = web.arena.AcknowledgeFieldAlert(alertId); err != nil {
+				ws.WriteError(err.Error())
+				continue
+			}
+			web.recordAuditEntry(r, "field_alert.acknowledge", alertId, nil, nil)
+		case "snoozeAlert":
+			args := struct {
+				Id          string
+				DurationMin int
+			}{}
+			err = mapstructure.Decode(data, &args)
+			if err != nil {
+				ws.WriteError(err.Error())
+				continue
+			}
+			if err = web.arena.SnoozeFieldAlert(args.Id, args.DurationMin); err != nil {
+				ws.WriteError(err.Error())
+				continue
+			}
+			web.recordAuditEntry(r, "field_alert.snooze", args.Id, nil, nil)
+		default:
+			ws.WriteError(fmt.Sprintf("Invalid message type '%s'.", messageType))
+		}
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"github.com/Team254/cheesy-arena/websocket"
+	gorillawebsocket "github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFieldAlerts(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/field_alerts")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Field Alerts")
+	assert.Contains(t, recorder.Body.String(), "max=\"60\"")
+}
+
+func TestFieldAlertsWebsocket(t *testing.T) {
+	web := setupTestWeb(t)
+
+	server, wsUrl := web.startTestServer()
+	defer server.Close()
+	conn, _, err := gorillawebsocket.DefaultDialer.Dial(wsUrl+"/field_alerts/websocket", nil)
+	assert.Nil(t, err)
+	defer conn.Close()
+	ws := websocket.NewTestWebsocket(conn)
+	message := readWebsocketType(t, ws, "fieldAlerts").(map[string]any)
+	assert.Empty(t, message["Alerts"])
+
+	// The alert IDs and snooze durations are validated by the arena.
+	ws.Write("acknowledgeAlert", "plcUnhealthy")
+	assert.Equal(t, "Field alert \"plcUnhealthy\" is not active.", readWebsocketError(t, ws))
+	ws.Write("snoozeAlert", map[string]any{"Id": "plcUnhealthy", "DurationMin": 90})
+	assert.Equal(t, "Snooze duration must be between 1 and 60 minutes.", readWebsocketError(t, ws))
+	ws.Write("bogus", nil)
+	assert.Equal(t, "Invalid message type 'bogus'.", readWebsocketError(t, ws))
+}
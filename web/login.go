@@ -11,9 +11,20 @@ import (
 	"github.com/google/uuid"
 	"net/http"
 	"net/url"
+	"slices"
 	"time"
 )
 
+// Groups of roles, beyond admin, that are permitted to access each class of protected route.
+var (
+	adminRoles       = []model.UserRole{model.AdminRole}
+	scorekeeperRoles = []model.UserRole{model.ScorekeeperRole}
+	refereeRoles     = []model.UserRole{model.ScorekeeperRole, model.HeadRefereeRole}
+	scorerRoles      = []model.UserRole{model.ScorekeeperRole, model.HeadRefereeRole, model.ScorerRole}
+	queuerRoles      = []model.UserRole{model.ScorekeeperRole, model.QueuerRole}
+	viewerRoles      = model.UserRoles
+)
+
 // Shows the login form.
 func (web *Web) loginHandler(w http.ResponseWriter, r *http.Request) {
 	web.renderLogin(w, r, "")
@@ -58,16 +69,21 @@ func (web *Web) renderLogin(w http.ResponseWriter, r *http.Request, errorMessage
 	}
 }
 
-// Returns true if the given user is authorized for admin operations. Used for HTTP cookie authentication.
-func (web *Web) userIsAdmin(w http.ResponseWriter, r *http.Request) bool {
-	if web.arena.EventSettings.AdminPassword == "" {
-		// Disable auth if there is no password configured.
-		return true
+// Returns true if the user making the request holds one of the given roles or is an admin. Used for HTTP cookie
+// authentication. Redirects to the login page if the user isn't logged in, and rejects the request if they are but lack
+// the required role.
+func (web *Web) userHasRole(w http.ResponseWriter, r *http.Request, roles ...model.UserRole) bool {
+	authDisabled, err := web.authIsDisabled()
+	if err != nil {
+		handleWebErr(w, err)
+		return false
 	}
-	session := web.getUserSessionFromCookie(r)
-	if session != nil && session.Username == adminUser {
+	if authDisabled {
 		return true
-	} else {
+	}
+
+	role := web.getUserRoleFromCookie(r)
+	if role == "" {
 		redirect := r.URL.Path
 		if r.URL.RawQuery != "" {
 			redirect += "?" + r.URL.RawQuery
@@ -75,6 +91,34 @@ func (web *Web) userIsAdmin(w http.ResponseWriter, r *http.Request) bool {
 		http.Redirect(w, r, "/login?redirect="+url.QueryEscape(redirect), 307)
 		return false
 	}
+	if role == model.AdminRole || slices.Contains(roles, role) {
+		return true
+	}
+	http.Error(w, fmt.Sprintf("Forbidden: the %s role does not have access to this page.", role.DisplayName()), 403)
+	return false
+}
+
+// Returns true if the user making the request holds one of the given roles or is an admin, without writing a response.
+// Used for checking individual commands received over a websocket that doesn't itself require a login to open.
+func (web *Web) requestHasRole(r *http.Request, roles ...model.UserRole) (bool, error) {
+	authDisabled, err := web.authIsDisabled()
+	if err != nil || authDisabled {
+		return authDisabled, err
+	}
+	role := web.getUserRoleFromCookie(r)
+	return role != "" && (role == model.AdminRole || slices.Contains(roles, role)), nil
+}
+
+// Returns true if authentication is turned off because neither an admin password nor any user accounts are configured.
+func (web *Web) authIsDisabled() (bool, error) {
+	if web.arena.EventSettings.AdminPassword != "" {
+		return false, nil
+	}
+	users, err := web.arena.Database.GetAllUsers()
+	if err != nil {
+		return false, err
+	}
+	return len(users) == 0, nil
 }
 
 func (web *Web) getUserSessionFromCookie(r *http.Request) *model.UserSession {
@@ -86,10 +130,39 @@ func (web *Web) getUserSessionFromCookie(r *http.Request) *model.UserSession {
 	return session
 }
 
-func (web *Web) checkAuthPassword(user, password string) error {
-	if user == adminUser && password == web.arena.EventSettings.AdminPassword {
+// Returns the username of the logged-in user making the request, or an empty string if there is none.
+func (web *Web) getUsernameFromCookie(r *http.Request) string {
+	if session := web.getUserSessionFromCookie(r); session != nil {
+		return session.Username
+	}
+	return ""
+}
+
+// Returns the role of the logged-in user making the request, or an empty string if there is none.
+func (web *Web) getUserRoleFromCookie(r *http.Request) model.UserRole {
+	session := web.getUserSessionFromCookie(r)
+	if session == nil {
+		return ""
+	}
+	if session.Username == adminUser {
+		// The built-in admin user authenticates with the admin password from the event settings.
+		return model.AdminRole
+	}
+	user, _ := web.arena.Database.GetUserByUsername(session.Username)
+	if user == nil {
+		return ""
+	}
+	return user.Role
+}
+
+func (web *Web) checkAuthPassword(username, password string) error {
+	if username == adminUser {
+		adminPassword := web.arena.EventSettings.AdminPassword
+		if adminPassword != "" && password == adminPassword {
+			return nil
+		}
+	} else if user, _ := web.arena.Database.GetUserByUsername(username); user != nil && user.CheckPassword(password) {
 		return nil
-	} else {
-		return fmt.Errorf("Invalid login credentials.")
 	}
+	return fmt.Errorf("Invalid login credentials.")
 }
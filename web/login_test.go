@@ -4,6 +4,8 @@
 package web
 
 import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -48,3 +50,78 @@ func TestLoginDisplay(t *testing.T) {
 	recorder = web.getHttpResponseWithHeaders("/match_play?p1=v1&p2=v2", map[string]string{"Cookie": cookie})
 	assert.Equal(t, 200, recorder.Code)
 }
+
+func TestLoginWithUserRoles(t *testing.T) {
+	web := setupTestWeb(t)
+
+	// Check that creating an account turns on authentication even without an admin password.
+	recorder := web.getHttpResponse("/panels/scoring/red_near")
+	assert.Equal(t, 200, recorder.Code)
+	scorer := model.User{Username: "scorer", Role: model.ScorerRole}
+	assert.Nil(t, scorer.SetPassword("reefs"))
+	assert.Nil(t, web.arena.Database.CreateUser(&scorer))
+	admin := model.User{Username: "boss", Role: model.AdminRole}
+	assert.Nil(t, admin.SetPassword("boss"))
+	assert.Nil(t, web.arena.Database.CreateUser(&admin))
+	recorder = web.getHttpResponse("/panels/scoring/red_near")
+	assert.Equal(t, 307, recorder.Code)
+
+	// Check that the built-in admin account can't log in without an admin password configured.
+	recorder = web.postHttpResponse("/login", "username=admin&password=")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Invalid login credentials.")
+
+	recorder = web.postHttpResponse("/login", "username=scorer&password=blorpy")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Invalid login credentials.")
+	recorder = web.postHttpResponse("/login", "username=scorer&password=reefs")
+	assert.Equal(t, 303, recorder.Code)
+	headers := map[string]string{"Cookie": recorder.Header().Get("Set-Cookie")}
+
+	// Check that the scorer can reach the scoring panel but not privileged pages.
+	recorder = web.getHttpResponseWithHeaders("/panels/scoring/red_near", headers)
+	assert.Equal(t, 200, recorder.Code)
+	match := model.Match{Type: model.Qualification, ShortName: "Q1", LongName: "Qualification 1"}
+	assert.Nil(t, web.arena.Database.CreateMatch(&match))
+	recorder = web.getHttpResponseWithHeaders(fmt.Sprintf("/match_review/%d/events", match.Id), headers)
+	assert.Equal(t, 200, recorder.Code)
+	recorder = web.getHttpResponseWithHeaders("/panels/referee", headers)
+	assert.Equal(t, 403, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "the Scorer role does not have access to this page")
+	recorder = web.postHttpResponseWithHeaders("/setup/db/clear/all", "", headers)
+	assert.Equal(t, 403, recorder.Code)
+	recorder = web.getHttpResponseWithHeaders("/practice_queue", headers)
+	assert.Equal(t, 403, recorder.Code)
+	recorder = web.postHttpResponseWithHeaders("/practice_queue/check_in", "teamId=254", headers)
+	assert.Equal(t, 403, recorder.Code)
+	recorder = web.postHttpResponseWithHeaders("/practice_queue/254/check_out", "", headers)
+	assert.Equal(t, 403, recorder.Code)
+
+	// Check that a queuer can reach the practice queue but not the scoring panels.
+	queuer := model.User{Username: "queuer", Role: model.QueuerRole}
+	assert.Nil(t, queuer.SetPassword("line"))
+	assert.Nil(t, web.arena.Database.CreateUser(&queuer))
+	recorder = web.postHttpResponse("/login", "username=queuer&password=line")
+	assert.Equal(t, 303, recorder.Code)
+	queuerHeaders := map[string]string{"Cookie": recorder.Header().Get("Set-Cookie")}
+	recorder = web.getHttpResponseWithHeaders("/practice_queue", queuerHeaders)
+	assert.Equal(t, 200, recorder.Code)
+	recorder = web.postHttpResponse("/practice_queue/check_in", "teamId=254")
+	assert.Equal(t, 307, recorder.Code)
+	recorder = web.getHttpResponseWithHeaders("/panels/scoring/red_near", queuerHeaders)
+	assert.Equal(t, 403, recorder.Code)
+
+	// Check that an admin can reach everything.
+	recorder = web.postHttpResponse("/login", "username=boss&password=boss")
+	assert.Equal(t, 303, recorder.Code)
+	headers = map[string]string{"Cookie": recorder.Header().Get("Set-Cookie")}
+	recorder = web.getHttpResponseWithHeaders("/panels/referee", headers)
+	assert.Equal(t, 200, recorder.Code)
+	recorder = web.getHttpResponseWithHeaders("/setup/settings", headers)
+	assert.Equal(t, 200, recorder.Code)
+
+	// Check that deleting the account invalidates the login.
+	assert.Nil(t, web.arena.Database.DeleteUser(admin.Id))
+	recorder = web.getHttpResponseWithHeaders("/setup/settings", headers)
+	assert.Equal(t, 307, recorder.Code)
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web routes for viewing and exporting the log of scoring and foul changes made to a match.
+
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"net/http"
+	"strconv"
+)
+
+// Shows the timeline of scoring and foul changes for a match.
+func (web *Web) matchEventsGetHandler(w http.ResponseWriter, r *http.Request) {
+	match, matchEvents, err := web.getMatchEventsFromRequest(r)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	template, err := web.parseFiles("templates/match_events.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		Match       *model.Match
+		MatchEvents []model.MatchEvent
+	}{web.arena.EventSettings, match, matchEvents}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Generates a CSV-formatted export of the timeline of scoring and foul changes for a match, with one row per change.
+func (web *Web) matchEventsCsvHandler(w http.ResponseWriter, r *http.Request) {
+	_, matchEvents, err := web.getMatchEventsFromRequest(r)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	// Don't set the content type as "text/csv", as that will trigger an automatic download in the browser.
+	w.Header().Set("Content-Type", "text/plain")
+	template, err := web.parseFiles("templates/match_events.csv")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	var buf bytes.Buffer
+	err = template.ExecuteTemplate(&buf, "match_events.csv", matchEvents)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	// Strip out carriage returns to ensure consistent behavior across platforms.
+	cleaned := bytes.ReplaceAll(buf.Bytes(), []byte("\r"), []byte(""))
+	w.Write(cleaned)
+}
+
+// Generates a JSON export of the timeline of scoring and foul changes for a match.
+func (web *Web) matchEventsJsonHandler(w http.ResponseWriter, r *http.Request) {
+	_, matchEvents, err := web.getMatchEventsFromRequest(r)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	if matchEvents == nil {
+		// Go marshals an empty slice to null, so explicitly create it so that it appears as an empty JSON array.
+		matchEvents = make([]model.MatchEvent, 0)
+	}
+	jsonData, err := json.MarshalIndent(matchEvents, "", "  ")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	_, err = w.Write(jsonData)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Loads the match referenced in the HTTP request path and its recorded events.
+func (web *Web) getMatchEventsFromRequest(r *http.Request) (*model.Match, []model.MatchEvent, error) {
+	matchId, _ := strconv.Atoi(r.PathValue("matchId"))
+	match, err := web.arena.Database.GetMatchById(matchId)
+	if err != nil {
+		return nil, nil, err
+	}
+	if match == nil {
+		return nil, nil, fmt.Errorf("Error: No such match: %d", matchId)
+	}
+	matchEvents, err := web.arena.Database.GetMatchEventsForMatch(matchId)
+	if err != nil {
+		return nil, nil, err
+	}
+	return match, matchEvents, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/websocket"
+	gorillawebsocket "github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMatchEvents(t *testing.T) {
+	web := setupTestWeb(t)
+
+	match := model.Match{Type: model.Qualification, ShortName: "Q1", LongName: "Qualification 1"}
+	assert.Nil(t, web.arena.Database.CreateMatch(&match))
+	assert.Nil(t, web.arena.LoadMatch(&match))
+
+	server, wsUrl := web.startTestServer()
+	defer server.Close()
+	conn, _, err := gorillawebsocket.DefaultDialer.Dial(wsUrl+"/panels/scoring/red_near/websocket", nil)
+	assert.Nil(t, err)
+	defer conn.Close()
+	ws := websocket.NewTestWebsocket(conn)
+	readWebsocketType(t, ws, "resetLocalState")
+	readWebsocketType(t, ws, "matchLoad")
+	readWebsocketType(t, ws, "matchTime")
+	readWebsocketType(t, ws, "realtimeScore")
+
+	// Make a scoring change and check that it is recorded in the match's event log.
+	web.arena.MatchState = field.AutoPeriod
+	ws.Write("leave", map[string]any{"TeamPosition": 2})
+	readWebsocketType(t, ws, "realtimeScore")
+	ws.Write("barge", map[string]any{"Adjustment": -1})
+	readWebsocketType(t, ws, "realtimeScore")
+	matchEvents, err := web.arena.Database.GetMatchEventsForMatch(match.Id)
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(matchEvents)) {
+		assert.Equal(t, "scoring/red_near", matchEvents[0].Source)
+		assert.Equal(t, "leave", matchEvents[0].Command)
+		assert.Equal(
+			t,
+			[]model.FieldChange{{Field: "Red.CurrentScore.LeaveStatuses.1", Before: "false", After: "true"}},
+			matchEvents[0].Changes,
+		)
+	}
+
+	recorder := web.getHttpResponse(fmt.Sprintf("/match_review/%d/events", match.Id))
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Qualification 1 Timeline")
+	assert.Contains(t, recorder.Body.String(), "Red.CurrentScore.LeaveStatuses.1")
+
+	recorder = web.getHttpResponse(fmt.Sprintf("/match_review/%d/events/csv", match.Id))
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "text/plain", recorder.Header()["Content-Type"][0])
+	assert.Contains(
+		t,
+		recorder.Body.String(),
+		"\"scoring/red_near\",\"\",\"leave\",\"Red.CurrentScore.LeaveStatuses.1\",\"false\",\"true\"\n",
+	)
+
+	recorder = web.getHttpResponse(fmt.Sprintf("/match_review/%d/events/json", match.Id))
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header()["Content-Type"][0])
+	assert.Contains(t, recorder.Body.String(), "\"Command\": \"leave\"")
+
+	recorder = web.getHttpResponse("/match_review/12345/events")
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "No such match")
+}
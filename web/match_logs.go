@@ -8,12 +8,14 @@ package web
 import (
 	"encoding/csv"
 	"fmt"
+	"log"
 	"net/http"
-	"os"
-	"path/filepath"
+	"slices"
 	"strconv"
 
+	"github.com/Team254/cheesy-arena/field"
 	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/matchlog"
 	"github.com/Team254/cheesy-arena/model"
 )
 
@@ -134,6 +136,69 @@ func (web *Web) matchLogsViewGetHandler(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// Exports the driver station records of one station from a match log as CSV, for analysis in other tools. The log is
+// selected by its start time if there are several for the match, defaulting to the most recent.
+func (web *Web) matchLogsCsvGetHandler(w http.ResponseWriter, r *http.Request) {
+	match, matchLogs, _, err := web.getMatchLogFromRequest(r)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if matchLogs == nil || len(matchLogs.Logs) == 0 {
+		handleWebErr(w, fmt.Errorf("No match logs for station %s", r.PathValue("stationId")))
+		return
+	}
+	matchLog := matchLogs.Logs[len(matchLogs.Logs)-1]
+	if startTime := r.URL.Query().Get("startTime"); startTime != "" {
+		index := slices.IndexFunc(matchLogs.Logs, func(log MatchLog) bool { return log.StartTime == startTime })
+		if index < 0 {
+			handleWebErr(w, fmt.Errorf("No match log started at %s", startTime))
+			return
+		}
+		matchLog = matchLogs.Logs[index]
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set(
+		"Content-Disposition",
+		fmt.Sprintf(
+			"attachment; filename=%s_Match_%s_%d.csv", matchLog.StartTime, match.ShortName, matchLogs.TeamId,
+		),
+	)
+	writer := csv.NewWriter(w)
+	writer.Write(
+		[]string{
+			"matchTimeSec", "teamId", "allianceStation", "dsLinked", "radioLinked", "rioLinked", "robotLinked",
+			"auto", "enabled", "emergencyStop", "autonomousStop", "batteryVoltage", "missedPacketCount",
+			"dsRobotTripTimeMs", "rxRate", "txRate", "signalNoiseRatio",
+		},
+	)
+	for _, row := range matchLog.Rows {
+		writer.Write(
+			[]string{
+				strconv.FormatFloat(row.MatchTimeSec, 'f', 3, 64),
+				strconv.Itoa(row.TeamId),
+				row.AllianceStation,
+				strconv.FormatBool(row.DsLinked),
+				strconv.FormatBool(row.RadioLinked),
+				strconv.FormatBool(row.RioLinked),
+				strconv.FormatBool(row.RobotLinked),
+				strconv.FormatBool(row.Auto),
+				strconv.FormatBool(row.Enabled),
+				strconv.FormatBool(row.EmergencyStop),
+				strconv.FormatBool(row.AutonomousStop),
+				strconv.FormatFloat(row.BatteryVoltage, 'f', 3, 64),
+				strconv.Itoa(row.MissedPacketCount),
+				strconv.Itoa(row.DsRobotTripTimeMs),
+				strconv.FormatFloat(row.RxRate, 'f', 1, 64),
+				strconv.FormatFloat(row.TxRate, 'f', 1, 64),
+				strconv.Itoa(row.SignalNoiseRatio),
+			},
+		)
+	}
+	writer.Flush()
+}
+
 // Load the match logs for the match referenced in the HTTP query string.
 func (web *Web) getMatchLogFromRequest(r *http.Request) (*model.Match, *MatchLogs, bool, error) {
 	matchId, _ := strconv.Atoi(r.PathValue("matchId"))
@@ -164,78 +229,61 @@ func (web *Web) getMatchLogFromRequest(r *http.Request) (*model.Match, *MatchLog
 	case "B3":
 		logs.TeamId = match.Blue3
 	}
-	headerMap := make(map[string]int)
-	// rows []MatchLogRow
-	// Load a csv file.
 	if logs.TeamId == 0 {
 		return nil, nil, false, nil
 	}
-	var files []string
-	files, _ = filepath.Glob(
-		filepath.Join(".", "static", "logs", "*_*_Match_"+match.ShortName+"_"+strconv.Itoa(logs.TeamId)+".csv"),
-	)
-	if len(files) == 0 {
-		return match, &logs, false, nil
+	filenames, err := matchlog.ListForMatch(field.LogsDir, match.Id)
+	if err != nil {
+		return nil, nil, false, err
 	}
 
-	for _, filename := range files {
-		f, _ := os.Open(filename)
-		defer f.Close()
-		// Create a new reader.
-		reader := csv.NewReader(f)
-
-		// Read row
-		header, _ := reader.Read()
-
-		// Add mapping: Column/property name --> record index
-		for i, v := range header {
-			headerMap[v] = i
-		}
-		records, _ := reader.ReadAll()
-
-		var curlog = MatchLog{
-			Filename:  filename,
-			StartTime: filename[12:26],
-			Rows:      make([]MatchLogRow, len(records)),
-		}
-		for i, record := range records {
-			var curRow MatchLogRow
-			curRow.MatchTimeSec, _ = strconv.ParseFloat(record[headerMap["matchTimeSec"]], 64)
-			curRow.PacketType, _ = strconv.Atoi(record[headerMap["packetType"]])
-			curRow.TeamId, _ = strconv.Atoi(record[headerMap["teamId"]])
-			curRow.AllianceStation = record[headerMap["allianceStation"]]
-			curRow.DsLinked, _ = strconv.ParseBool(record[headerMap["dsLinked"]])
-			curRow.RadioLinked, _ = strconv.ParseBool(record[headerMap["radioLinked"]])
-			curRow.RioLinked, _ = strconv.ParseBool(record[headerMap["rioLinked"]])
-			curRow.RobotLinked, _ = strconv.ParseBool(record[headerMap["robotLinked"]])
-			curRow.Auto, _ = strconv.ParseBool(record[headerMap["auto"]])
-			curRow.Enabled, _ = strconv.ParseBool(record[headerMap["enabled"]])
-			curRow.EmergencyStop, _ = strconv.ParseBool(record[headerMap["emergencyStop"]])
-			curRow.AutonomousStop, _ = strconv.ParseBool(record[headerMap["autonomousStop"]])
-			curRow.BatteryVoltage, _ = strconv.ParseFloat(record[headerMap["batteryVoltage"]], 64)
-			curRow.MissedPacketCount, _ = strconv.Atoi(record[headerMap["missedPacketCount"]])
-			curRow.DsRobotTripTimeMs, _ = strconv.Atoi(record[headerMap["dsRobotTripTimeMs"]])
-			if len(headerMap) > 13 {
-
-				curRow.TxRate, _ = strconv.ParseFloat(record[headerMap["txRate"]], 64)
-				curRow.RxRate, _ = strconv.ParseFloat(record[headerMap["rxRate"]], 64)
-				curRow.SignalNoiseRatio, _ = strconv.Atoi(record[headerMap["signalNoiseRatio"]])
-			} else {
-				curRow.TxRate = -1
-				curRow.RxRate = -1
-				curRow.SignalNoiseRatio = -1
-			}
-
-			// Create new person and add to persons array
-			curlog.Rows[i] = curRow
+	for _, filename := range filenames {
+		bundle, err := matchlog.Read(filename)
+		if err != nil {
+			log.Println(err)
+			continue
 		}
-
-		logs.Logs = append(logs.Logs, curlog)
-
+		logs.Logs = append(logs.Logs, matchLogFromBundle(bundle, stationId))
 	}
 	return match, &logs, false, nil
 }
 
+// Extracts the driver station records for the given station from the given bundle.
+func matchLogFromBundle(bundle *matchlog.Bundle, station string) MatchLog {
+	matchLog := MatchLog{
+		Filename:  bundle.Filename,
+		StartTime: bundle.Header.StartedAt.Format("20060102150405"),
+		Rows:      []MatchLogRow{},
+	}
+	query := matchlog.Query{Station: station, Types: []matchlog.RecordType{matchlog.DsStatusRecord}}
+	for _, record := range bundle.Query(query) {
+		dsStatus := record.DsStatus
+		matchLog.Rows = append(
+			matchLog.Rows,
+			MatchLogRow{
+				MatchTimeSec:      record.MatchTimeSec,
+				TeamId:            dsStatus.TeamId,
+				AllianceStation:   record.Station,
+				DsLinked:          dsStatus.DsLinked,
+				RadioLinked:       dsStatus.RadioLinked,
+				RioLinked:         dsStatus.RioLinked,
+				RobotLinked:       dsStatus.RobotLinked,
+				Auto:              dsStatus.Auto,
+				Enabled:           dsStatus.Enabled,
+				EmergencyStop:     dsStatus.EStop,
+				AutonomousStop:    dsStatus.AStop,
+				BatteryVoltage:    dsStatus.BatteryVoltage,
+				MissedPacketCount: dsStatus.MissedPacketCount,
+				DsRobotTripTimeMs: dsStatus.TripTimeMs,
+				TxRate:            dsStatus.TxRate,
+				RxRate:            dsStatus.RxRate,
+				SignalNoiseRatio:  dsStatus.SignalNoiseRatio,
+			},
+		)
+	}
+	return matchLog
+}
+
 // Constructs the list of matches to display in the match Logs interface.
 func (web *Web) buildMatchLogsList(matchType model.MatchType) ([]MatchLogsListItem, error) {
 	matches, err := web.arena.Database.GetMatchesByType(matchType, false)
@@ -11,11 +11,13 @@ import (
 	"log"
 	"net/http"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/Team254/cheesy-arena/field"
 	"github.com/Team254/cheesy-arena/game"
 	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
 	"github.com/Team254/cheesy-arena/tournament"
 	"github.com/Team254/cheesy-arena/websocket"
 	"github.com/mitchellh/mapstructure"
@@ -33,10 +35,6 @@ type MatchPlayList []MatchPlayListItem
 
 // Shows the match play control interface.
 func (web *Web) matchPlayHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	template, err := web.parseFiles(
 		"templates/match_play.html", "templates/audience_display_radio_buttons.html", "templates/base.html",
 	)
@@ -62,10 +60,6 @@ func (web *Web) matchPlayHandler(w http.ResponseWriter, r *http.Request) {
 
 // Renders a partial template containing the list of matches.
 func (web *Web) matchPlayMatchLoadHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	practiceMatches, err := web.buildMatchPlayList(model.Practice)
 	if err != nil {
 		handleWebErr(w, err)
@@ -98,11 +92,13 @@ func (web *Web) matchPlayMatchLoadHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 	data := struct {
-		MatchesByType    map[model.MatchType]MatchPlayList
-		CurrentMatchType model.MatchType
+		MatchesByType              map[model.MatchType]MatchPlayList
+		CurrentMatchType           model.MatchType
+		PracticeWalkUpQueueEnabled bool
 	}{
 		matchesByType,
 		currentMatchType,
+		web.arena.EventSettings.PracticeWalkUpQueueEnabled,
 	}
 	err = template.ExecuteTemplate(w, "match_play_match_load.html", data)
 	if err != nil {
@@ -113,10 +109,6 @@ func (web *Web) matchPlayMatchLoadHandler(w http.ResponseWriter, r *http.Request
 
 // The websocket endpoint for the match play client to send control commands and receive status updates.
 func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	ws, err := websocket.NewWebsocket(w, r)
 	if err != nil {
 		handleWebErr(w, err)
@@ -131,6 +123,7 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 		web.arena.ArenaStatusNotifier,
 		web.arena.AudienceDisplayModeNotifier,
 		web.arena.EventStatusNotifier,
+		web.arena.FieldAlertsNotifier,
 		web.arena.MatchLoadNotifier,
 		web.arena.MatchTimeNotifier,
 		web.arena.RealtimeScoreNotifier,
@@ -183,6 +176,15 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 				ws.WriteError(err.Error())
 				continue
 			}
+		case "loadPracticeQueueMatch":
+			if err = web.arena.ResetMatch(); err != nil {
+				ws.WriteError(err.Error())
+				continue
+			}
+			if err = web.arena.LoadNextPracticeQueueMatch(); err != nil {
+				ws.WriteError(err.Error())
+				continue
+			}
 		case "showResult":
 			args := struct {
 				MatchId int
@@ -248,6 +250,7 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 				ws.WriteError(err.Error())
 				continue
 			}
+			web.recordAuditEntry(r, "match_play.substitute_teams", strconv.Itoa(web.arena.CurrentMatch.Id), nil, args)
 		case "toggleBypass":
 			station, ok := data.(string)
 			if !ok {
@@ -277,12 +280,14 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 				ws.WriteError(err.Error())
 				continue
 			}
+			web.recordAuditEntry(r, "match_play.start", strconv.Itoa(web.arena.CurrentMatch.Id), nil, nil)
 		case "abortMatch":
 			err = web.arena.AbortMatch()
 			if err != nil {
 				ws.WriteError(err.Error())
 				continue
 			}
+			web.recordAuditEntry(r, "match_play.abort", strconv.Itoa(web.arena.CurrentMatch.Id), nil, nil)
 		case "signalVolunteers":
 			if web.arena.MatchState != field.PostMatch && web.arena.MatchState != field.PreMatch {
 				// Don't allow clearing the field until the match is over.
@@ -310,6 +315,7 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 				ws.WriteError(err.Error())
 				continue
 			}
+			web.recordAuditEntry(r, "match_play.commit", strconv.Itoa(web.arena.CurrentMatch.Id), nil, nil)
 			err = web.arena.ResetMatch()
 			if err != nil {
 				ws.WriteError(err.Error())
@@ -321,6 +327,7 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 				continue
 			}
 		case "discardResults":
+			discardedMatchId := web.arena.CurrentMatch.Id
 			err = web.arena.ResetMatch()
 			if err != nil {
 				ws.WriteError(err.Error())
@@ -331,6 +338,7 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 				ws.WriteError(err.Error())
 				continue
 			}
+			web.recordAuditEntry(r, "match_play.discard", strconv.Itoa(discardedMatchId), nil, nil)
 		case "setAudienceDisplay":
 			mode, ok := data.(string)
 			if !ok {
@@ -434,6 +442,7 @@ func (web *Web) commitMatchScore(match *model.Match, matchResult *model.MatchRes
 				return err
 			}
 			updatedRankings = rankings
+			web.arena.RankingsNotifier.Notify()
 		}
 
 		if match.ShouldUpdatePlayoffMatches() {
@@ -466,22 +475,28 @@ func (web *Web) commitMatchScore(match *model.Match, matchResult *model.MatchRes
 		}
 
 		if web.arena.EventSettings.TbaPublishingEnabled && match.Type != model.Practice {
-			// Publish asynchronously to The Blue Alliance.
-			go func() {
-				if err = web.arena.TbaClient.PublishMatches(web.arena.Database); err != nil {
-					log.Printf("Failed to publish matches: %s", err.Error())
-				}
-				if match.ShouldUpdateRankings() {
-					if err = web.arena.TbaClient.PublishRankings(web.arena.Database); err != nil {
-						log.Printf("Failed to publish rankings: %s", err.Error())
-					}
+			// Queue the results to be published asynchronously to The Blue Alliance.
+			if err = web.arena.TbaPublisher.Enqueue(web.arena.Database, partner.TbaPublishMatches, false); err != nil {
+				return err
+			}
+			if match.ShouldUpdateRankings() {
+				if err = web.arena.TbaPublisher.Enqueue(web.arena.Database, partner.TbaPublishRankings, false); err != nil {
+					return err
 				}
-			}()
+			}
+		}
+
+		if web.arena.EventSettings.NexusEnabled && web.arena.NexusClient.HasEventApiKey() &&
+			web.arena.Replication == nil {
+			// Publish the result to Nexus asynchronously so that a slow connection doesn't hold up the next match.
+			go web.arena.PublishNexusMatchResult(match, redScoreSummary.Score, blueScoreSummary.Score)
 		}
 
 		// Back up the database, but don't error out if it fails.
-		err = web.arena.Database.Backup(
-			web.arena.EventSettings.Name, fmt.Sprintf("post_%s_match_%s", match.Type, match.ShortName),
+		err = web.arena.BackupScheduler.Backup(
+			web.arena.Database,
+			web.arena.EventSettings.Name,
+			fmt.Sprintf("post_%s_match_%s", match.Type, match.ShortName),
 		)
 		if err != nil {
 			log.Println(err)
@@ -4,7 +4,6 @@
 package web
 
 import (
-	"bytes"
 	"github.com/Team254/cheesy-arena/field"
 	"github.com/Team254/cheesy-arena/game"
 	"github.com/Team254/cheesy-arena/model"
@@ -13,7 +12,6 @@ import (
 	gorillawebsocket "github.com/gorilla/websocket"
 	"github.com/mitchellh/mapstructure"
 	"github.com/stretchr/testify/assert"
-	"log"
 	"testing"
 	"time"
 )
@@ -95,16 +93,16 @@ func TestCommitMatch(t *testing.T) {
 	match, _ = web.arena.Database.GetMatchById(1)
 	assert.Equal(t, game.TieMatch, match.Status)
 
-	// Verify TBA publishing by checking the log for the expected failure messages.
-	web.arena.TbaClient.BaseUrl = "fakeUrl"
+	// Verify that the results are queued for publishing to TBA.
 	web.arena.EventSettings.TbaPublishingEnabled = true
-	var writer bytes.Buffer
-	log.SetOutput(&writer)
 	err = web.commitMatchScore(match, matchResult, true)
 	assert.Nil(t, err)
-	time.Sleep(time.Millisecond * 100) // Allow some time for the asynchronous publishing to happen.
-	assert.Contains(t, writer.String(), "Failed to publish matches")
-	assert.Contains(t, writer.String(), "Failed to publish rankings")
+	for _, publishType := range []string{"matches", "rankings"} {
+		item, _ := web.arena.Database.GetTbaPublishItemByType(publishType)
+		if assert.NotNil(t, item) {
+			assert.True(t, item.Pending)
+		}
+	}
 }
 
 func TestCommitTiebreak(t *testing.T) {
@@ -283,6 +281,7 @@ func TestMatchPlayWebsocketCommands(t *testing.T) {
 	readWebsocketType(t, ws, "arenaStatus")
 	readWebsocketType(t, ws, "audienceDisplayMode")
 	readWebsocketType(t, ws, "eventStatus")
+	readWebsocketType(t, ws, "fieldAlerts")
 	readWebsocketType(t, ws, "matchLoad")
 	readWebsocketType(t, ws, "matchTime")
 	readWebsocketType(t, ws, "realtimeScore")
@@ -367,7 +366,7 @@ func TestMatchPlayWebsocketLoadMatch(t *testing.T) {
 	ws := websocket.NewTestWebsocket(conn)
 
 	// Should get a few status updates right after connection.
-	readWebsocketMultiple(t, ws, 10)
+	readWebsocketMultiple(t, ws, 11)
 
 	web.arena.Database.CreateTeam(&model.Team{Id: 101})
 	web.arena.Database.CreateTeam(&model.Team{Id: 102})
@@ -428,7 +427,7 @@ func TestMatchPlayWebsocketShowAndClearResult(t *testing.T) {
 	ws := websocket.NewTestWebsocket(conn)
 
 	// Should get a few status updates right after connection.
-	readWebsocketMultiple(t, ws, 10)
+	readWebsocketMultiple(t, ws, 11)
 
 	matchIdMessage := struct{ MatchId int }{1}
 	ws.Write("showResult", matchIdMessage)
@@ -465,7 +464,7 @@ func TestMatchPlayWebsocketNotifications(t *testing.T) {
 	ws := websocket.NewTestWebsocket(conn)
 
 	// Should get a few status updates right after connection.
-	readWebsocketMultiple(t, ws, 10)
+	readWebsocketMultiple(t, ws, 11)
 
 	web.arena.AllianceStations["R1"].Bypass = true
 	web.arena.AllianceStations["R2"].Bypass = true
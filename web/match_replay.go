@@ -0,0 +1,36 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web routes for replaying a previously played match's score progression to the displays.
+
+package web
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// Starts replaying the recorded score progression of the given match to the audience display.
+func (web *Web) matchReplayStartHandler(w http.ResponseWriter, r *http.Request) {
+	matchId, _ := strconv.Atoi(r.PathValue("matchId"))
+	speed := 1.0
+	if speedValue := r.PostFormValue("speed"); speedValue != "" {
+		var err error
+		if speed, err = strconv.ParseFloat(speedValue, 64); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+	}
+	if err := web.arena.StartMatchReplay(matchId, speed); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	http.Redirect(w, r, "/match_review", 303)
+}
+
+// Stops any match replay in progress and returns the displays to the live match.
+func (web *Web) matchReplayStopHandler(w http.ResponseWriter, r *http.Request) {
+	web.arena.StopMatchReplay()
+	http.Redirect(w, r, "/match_review", 303)
+}
@@ -0,0 +1,40 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMatchReplay(t *testing.T) {
+	web := setupTestWeb(t)
+
+	match := model.Match{Type: model.Qualification, ShortName: "Q1"}
+	assert.Nil(t, web.arena.Database.CreateMatch(&match))
+	recorder := web.postHttpResponse(fmt.Sprintf("/match_review/%d/replay", match.Id), "speed=2")
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "no score snapshots have been recorded")
+
+	snapshot := model.ScoreSnapshot{MatchId: match.Id, MatchTimeSec: 150, MatchState: int(field.PostMatch)}
+	assert.Nil(t, web.arena.Database.CreateScoreSnapshot(&snapshot))
+	recorder = web.postHttpResponse(fmt.Sprintf("/match_review/%d/replay", match.Id), "speed=2")
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	if assert.NotNil(t, web.arena.MatchReplay()) {
+		assert.Equal(t, 2.0, web.arena.MatchReplay().Speed)
+	}
+	recorder = web.getHttpResponse("/match_review")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "match Q1 at")
+	assert.Contains(t, recorder.Body.String(), "Stop Replay")
+
+	recorder = web.postHttpResponse("/match_review/replay/stop", "")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Nil(t, web.arena.MatchReplay())
+	recorder = web.getHttpResponse("/match_review")
+	assert.NotContains(t, recorder.Body.String(), "Stop Replay")
+}
@@ -8,6 +8,7 @@ package web
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/Team254/cheesy-arena/field"
 	"github.com/Team254/cheesy-arena/game"
 	"github.com/Team254/cheesy-arena/model"
 	"net/http"
@@ -62,7 +63,8 @@ func (web *Web) matchReviewHandler(w http.ResponseWriter, r *http.Request) {
 		*model.EventSettings
 		MatchesByType    map[model.MatchType][]MatchReviewListItem
 		CurrentMatchType model.MatchType
-	}{web.arena.EventSettings, matchesByType, currentMatchType}
+		MatchReplay      *field.MatchReplay
+	}{web.arena.EventSettings, matchesByType, currentMatchType, web.arena.MatchReplay()}
 	err = template.ExecuteTemplate(w, "base", data)
 	if err != nil {
 		handleWebErr(w, err)
@@ -72,10 +74,6 @@ func (web *Web) matchReviewHandler(w http.ResponseWriter, r *http.Request) {
 
 // Shows the page to edit the results for a match.
 func (web *Web) matchReviewEditGetHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	match, matchResult, isCurrent, err := web.getMatchResultFromRequest(r)
 	if err != nil {
 		handleWebErr(w, err)
@@ -108,15 +106,12 @@ func (web *Web) matchReviewEditGetHandler(w http.ResponseWriter, r *http.Request
 
 // Updates the results for a match.
 func (web *Web) matchReviewEditPostHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
-	match, _, isCurrent, err := web.getMatchResultFromRequest(r)
+	match, previousMatchResult, isCurrent, err := web.getMatchResultFromRequest(r)
 	if err != nil {
 		handleWebErr(w, err)
 		return
 	}
+	username := web.getUsernameFromCookie(r)
 
 	var matchResult model.MatchResult
 	if err = json.Unmarshal([]byte(r.PostFormValue("matchResultJson")), &matchResult); err != nil {
@@ -130,20 +125,38 @@ func (web *Web) matchReviewEditPostHandler(w http.ResponseWriter, r *http.Reques
 
 	if isCurrent {
 		// If editing the current match, just save it back to memory.
+		scoreBefore := web.arena.SnapshotRealtimeScores()
 		web.arena.RedRealtimeScore.CurrentScore = *matchResult.RedScore
 		web.arena.BlueRealtimeScore.CurrentScore = *matchResult.BlueScore
 		web.arena.RedRealtimeScore.Cards = matchResult.RedCards
 		web.arena.BlueRealtimeScore.Cards = matchResult.BlueCards
+		web.recordAuditEntry(r, "match_review.edit", strconv.Itoa(match.Id), *previousMatchResult, matchResult)
+		if err = web.arena.RecordMatchEvent("match_review", username, "edit", scoreBefore); err != nil {
+			handleWebErr(w, err)
+			return
+		}
 
 		web.arena.RealtimeScoreNotifier.Notify()
 
 		http.Redirect(w, r, "/match_play", 303)
 	} else {
+		// Capture the edited scores before committing, but only record the event once the commit has succeeded.
+		scoreBefore := field.NewRealtimeScoreSnapshot(previousMatchResult)
+		scoreAfter := field.NewRealtimeScoreSnapshot(&matchResult)
 		err = web.commitMatchScore(match, &matchResult, true)
 		if err != nil {
 			handleWebErr(w, err)
 			return
 		}
+		web.recordAuditEntry(r, "match_review.edit", strconv.Itoa(match.Id), *previousMatchResult, matchResult)
+
+		err = field.RecordMatchEventForMatch(
+			web.arena.Database, match.Id, 0, "match_review", username, "edit", scoreBefore, scoreAfter,
+		)
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
 
 		http.Redirect(w, r, "/match_review", 303)
 	}
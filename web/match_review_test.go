@@ -84,6 +84,14 @@ func TestMatchReviewEditExistingResult(t *testing.T) {
 	assert.Contains(t, recorder.Body.String(), ">QF4-3<")
 	assert.Contains(t, recorder.Body.String(), ">10<") // The red score
 	assert.Contains(t, recorder.Body.String(), ">42<") // The blue score
+
+	// Check that the edit was recorded in the match's event log once committed.
+	matchEvents, err := web.arena.Database.GetMatchEventsForMatch(match.Id)
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(matchEvents)) {
+		assert.Equal(t, "match_review", matchEvents[0].Source)
+		assert.Equal(t, "edit", matchEvents[0].Command)
+	}
 }
 
 func TestMatchReviewCreateNewResult(t *testing.T) {
@@ -173,3 +181,20 @@ func TestMatchReviewEditCurrentMatch(t *testing.T) {
 	assert.Equal(t, 1, len(web.arena.RedRealtimeScore.Cards))
 	assert.Equal(t, 0, len(web.arena.BlueRealtimeScore.Cards))
 }
+
+func TestMatchReviewEditFailedCommitNotAudited(t *testing.T) {
+	web := setupTestWeb(t)
+
+	// A qualification match with empty stations can't have its rankings calculated, so the commit fails.
+	match := model.Match{Type: model.Qualification, ShortName: "Q1", Red1: 254}
+	assert.Nil(t, web.arena.Database.CreateMatch(&match))
+	postBody := fmt.Sprintf(
+		"matchResultJson={\"MatchId\":%d,\"RedScore\":{},\"BlueScore\":{},\"RedCards\":{},\"BlueCards\":{}}",
+		match.Id,
+	)
+	recorder := web.postHttpResponse(fmt.Sprintf("/match_review/%d/edit", match.Id), postBody)
+	assert.Equal(t, 500, recorder.Code)
+	auditEntries, err := web.arena.Database.GetAllAuditEntries()
+	assert.Nil(t, err)
+	assert.Empty(t, auditEntries)
+}
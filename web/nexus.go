@@ -0,0 +1,176 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web routes for monitoring the Nexus integration and for entering data into the local Nexus stand-in.
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+// Data held by the local Nexus stand-in for a single match.
+type nexusLocalMatch struct {
+	MatchKey string
+	Lineup   *[6]int
+	Status   partner.NexusMatchStatus
+	Result   *partner.NexusMatchResult
+}
+
+// Shows the state of the Nexus integration and the information that teams have reported through it.
+func (web *Web) nexusGetHandler(w http.ResponseWriter, r *http.Request) {
+	web.renderNexus(w, r, "")
+}
+
+// Pulls the latest team statuses and parts requests from Nexus without waiting for the next periodic poll.
+func (web *Web) nexusRefreshPostHandler(w http.ResponseWriter, r *http.Request) {
+	web.arena.PollNexus()
+	http.Redirect(w, r, "/nexus", 303)
+}
+
+// Sets the lineup that the local Nexus stand-in will return for a match.
+func (web *Web) nexusLocalLineupPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.checkNexusLocalServerEnabled(w, r) {
+		return
+	}
+	matchKey := r.PostFormValue("matchKey")
+	if matchKey == "" {
+		web.renderNexus(w, r, "A match key must be specified.")
+		return
+	}
+	var lineup [6]int
+	for i, position := range []string{"red1", "red2", "red3", "blue1", "blue2", "blue3"} {
+		if value := r.PostFormValue(position); value != "" {
+			teamId, err := strconv.Atoi(value)
+			if err != nil {
+				web.renderNexus(w, r, fmt.Sprintf("Invalid team number %q.", value))
+				return
+			}
+			lineup[i] = teamId
+		}
+	}
+	web.arena.NexusLocalServer.SetLineup(matchKey, lineup)
+	http.Redirect(w, r, "/nexus", 303)
+}
+
+// Sets or clears the status of a team in the local Nexus stand-in.
+func (web *Web) nexusLocalTeamStatusPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.checkNexusLocalServerEnabled(w, r) {
+		return
+	}
+	teamId, err := strconv.Atoi(r.PostFormValue("teamId"))
+	if err != nil {
+		web.renderNexus(w, r, "Invalid team number specified.")
+		return
+	}
+	web.arena.NexusLocalServer.SetTeamStatus(teamId, r.PostFormValue("status"), r.PostFormValue("notes"))
+	web.arena.PollNexus()
+	http.Redirect(w, r, "/nexus", 303)
+}
+
+// Adds a parts request from a team to the local Nexus stand-in.
+func (web *Web) nexusLocalPartsRequestPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.checkNexusLocalServerEnabled(w, r) {
+		return
+	}
+	teamId, err := strconv.Atoi(r.PostFormValue("teamId"))
+	if err != nil {
+		web.renderNexus(w, r, "Invalid team number specified.")
+		return
+	}
+	parts := r.PostFormValue("parts")
+	if parts == "" {
+		web.renderNexus(w, r, "The requested parts must be specified.")
+		return
+	}
+	web.arena.NexusLocalServer.AddPartsRequest(teamId, parts)
+	web.arena.PollNexus()
+	http.Redirect(w, r, "/nexus", 303)
+}
+
+// Marks a parts request in the local Nexus stand-in as fulfilled.
+func (web *Web) nexusLocalPartsRequestFulfillPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.checkNexusLocalServerEnabled(w, r) {
+		return
+	}
+	partsRequestId, _ := strconv.Atoi(r.PathValue("partsRequestId"))
+	if err := web.arena.NexusLocalServer.FulfillPartsRequest(partsRequestId); err != nil {
+		web.renderNexus(w, r, err.Error())
+		return
+	}
+	web.arena.PollNexus()
+	http.Redirect(w, r, "/nexus", 303)
+}
+
+// Returns true if the local Nexus stand-in is in use; otherwise renders an error and returns false.
+func (web *Web) checkNexusLocalServerEnabled(w http.ResponseWriter, r *http.Request) bool {
+	if !web.arena.EventSettings.NexusLocalServerEnabled {
+		web.renderNexus(w, r, "The local Nexus stand-in is not enabled.")
+		return false
+	}
+	return true
+}
+
+func (web *Web) renderNexus(w http.ResponseWriter, r *http.Request, errorMessage string) {
+	template, err := web.parseFiles("templates/nexus.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		BaseUrl          string
+		ConnectionStatus partner.NexusConnectionStatus
+		TeamStatuses     []partner.NexusTeamStatus
+		PartsRequests    []partner.NexusPartsRequest
+		LocalMatches     []nexusLocalMatch
+		ErrorMessage     string
+	}{
+		web.arena.EventSettings,
+		web.arena.NexusClient.BaseUrl,
+		web.arena.NexusClient.Status(),
+		web.arena.NexusTeamStatuses,
+		web.arena.NexusPartsRequests,
+		buildNexusLocalMatches(web.arena.NexusLocalServer.State()),
+		errorMessage,
+	}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Collates the lineups, queueing statuses, and results held by the local Nexus stand-in by match.
+func buildNexusLocalMatches(state partner.NexusLocalState) []nexusLocalMatch {
+	matchesByKey := make(map[string]*nexusLocalMatch)
+	getMatch := func(matchKey string) *nexusLocalMatch {
+		if _, ok := matchesByKey[matchKey]; !ok {
+			matchesByKey[matchKey] = &nexusLocalMatch{MatchKey: matchKey}
+		}
+		return matchesByKey[matchKey]
+	}
+	for matchKey, lineup := range state.Lineups {
+		getMatch(matchKey).Lineup = &lineup
+	}
+	for matchKey, status := range state.MatchStatuses {
+		getMatch(matchKey).Status = status
+	}
+	for matchKey, result := range state.MatchResults {
+		getMatch(matchKey).Result = &result
+	}
+
+	var matches []nexusLocalMatch
+	for _, match := range matchesByKey {
+		matches = append(matches, *match)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].MatchKey < matches[j].MatchKey
+	})
+	return matches
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNexus(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/nexus")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "The Nexus integration is disabled")
+	assert.NotContains(t, recorder.Body.String(), "Local Nexus Stand-in")
+
+	recorder = web.postHttpResponse("/nexus/local/team_status", "teamId=254&status=Ready")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "The local Nexus stand-in is not enabled.")
+
+	web.arena.EventSettings.NexusEnabled = true
+	web.arena.EventSettings.NexusLocalServerEnabled = true
+	assert.Nil(t, web.arena.Database.UpdateEventSettings(web.arena.EventSettings))
+	assert.Nil(t, web.arena.LoadSettings())
+
+	recorder = web.postHttpResponse("/nexus/local/lineup", "matchKey=p3&red1=254&red2=&red3=1114&blue1=2056")
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	assert.Equal(t, [6]int{254, 0, 1114, 2056, 0, 0}, web.arena.NexusLocalServer.State().Lineups["p3"])
+	recorder = web.postHttpResponse("/nexus/local/lineup", "matchKey=p3&red1=blorpy")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Invalid team number")
+	recorder = web.postHttpResponse("/nexus/local/lineup", "red1=254")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "A match key must be specified.")
+
+	recorder = web.postHttpResponse("/nexus/local/team_status", "teamId=1114&status=Repairing&notes=Broken+chain")
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	recorder = web.postHttpResponse("/nexus/local/team_status", "teamId=&status=Ready")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Invalid team number specified.")
+
+	recorder = web.postHttpResponse("/nexus/local/parts_requests", "teamId=2056&parts=Spare+bumper")
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	recorder = web.postHttpResponse("/nexus/local/parts_requests", "teamId=2056&parts=")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "The requested parts must be specified.")
+
+	recorder = web.getHttpResponse("/nexus")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Local Nexus Stand-in")
+	assert.Contains(t, recorder.Body.String(), "Broken chain")
+	assert.Contains(t, recorder.Body.String(), "Spare bumper")
+	assert.Contains(t, recorder.Body.String(), "/nexus/local/parts_requests/1/fulfill")
+	assert.Contains(t, recorder.Body.String(), "254 &ndash; 1114 2056")
+
+	recorder = web.postHttpResponse("/nexus/local/parts_requests/1/fulfill", "")
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	if assert.Equal(t, 1, len(web.arena.NexusPartsRequests)) {
+		assert.True(t, web.arena.NexusPartsRequests[0].Fulfilled)
+	}
+	recorder = web.postHttpResponse("/nexus/local/parts_requests/5/fulfill", "")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "parts request 5 does not exist")
+
+	recorder = web.postHttpResponse("/nexus/refresh", "")
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+}
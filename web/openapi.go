@@ -0,0 +1,202 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Generation of the OpenAPI specification for the REST API from its endpoint list and the Go types of its requests and
+// responses.
+
+package web
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Matches the wildcards in an endpoint path.
+var openApiPathParamRe = regexp.MustCompile(`\{(\w+)\}`)
+
+// Serves the OpenAPI specification of the REST API.
+func (web *Web) openApiV1GetHandler(w http.ResponseWriter, r *http.Request) {
+	writeApiV1Json(w, 200, web.generateOpenApiSpec())
+}
+
+// Builds the OpenAPI 3 document describing every API endpoint.
+func (web *Web) generateOpenApiSpec() map[string]any {
+	schemas := make(map[string]any)
+	errorResponse := map[string]any{
+		"description": "Error",
+		"content":     openApiJsonContent(openApiSchema(reflect.TypeOf(ApiV1Error{}), schemas)),
+	}
+
+	paths := make(map[string]any)
+	for _, endpoint := range web.apiV1Endpoints() {
+		operation := map[string]any{
+			"summary":     endpoint.Summary,
+			"tags":        []string{endpoint.Tag},
+			"operationId": strings.ToLower(endpoint.Method) + openApiOperationName(endpoint.Path),
+		}
+
+		var parameters []any
+		for _, match := range openApiPathParamRe.FindAllStringSubmatch(endpoint.Path, -1) {
+			parameters = append(
+				parameters,
+				map[string]any{"name": match[1], "in": "path", "required": true, "schema": map[string]any{
+					"type": "integer",
+				}},
+			)
+		}
+		for _, queryParam := range endpoint.QueryParams {
+			parameters = append(
+				parameters,
+				map[string]any{
+					"name":        queryParam.Name,
+					"in":          "query",
+					"description": queryParam.Description,
+					"required":    queryParam.Required,
+					"schema":      map[string]any{"type": "string"},
+				},
+			)
+		}
+		if parameters != nil {
+			operation["parameters"] = parameters
+		}
+
+		if endpoint.Request != nil {
+			operation["requestBody"] = map[string]any{
+				"required": true,
+				"content":  openApiJsonContent(openApiSchema(reflect.TypeOf(endpoint.Request), schemas)),
+			}
+		}
+
+		successResponse := map[string]any{"description": http.StatusText(endpoint.Status)}
+		if endpoint.Response != nil {
+			successResponse["content"] = openApiJsonContent(openApiSchema(reflect.TypeOf(endpoint.Response), schemas))
+		}
+		operation["responses"] = map[string]any{
+			strconv.Itoa(endpoint.Status): successResponse,
+			"default":                     errorResponse,
+		}
+		if endpoint.Roles != nil {
+			var roles []string
+			for _, role := range endpoint.Roles {
+				roles = append(roles, string(role))
+			}
+			operation["security"] = []any{map[string]any{"bearerAuth": []string{}}}
+			operation["description"] = "Requires an API token for one of the following roles, or for an admin: " +
+				strings.Join(roles, ", ") + "."
+		}
+
+		path := apiV1Prefix + endpoint.Path
+		if _, ok := paths[path]; !ok {
+			paths[path] = make(map[string]any)
+		}
+		paths[path].(map[string]any)[strings.ToLower(endpoint.Method)] = operation
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "Cheesy Arena API",
+			"version": "1",
+			"description": "API for reading and modifying event data and controlling the arena. Read-only fields are " +
+				"ignored in request bodies.",
+		},
+		"servers": []any{map[string]any{"url": "/"}},
+		"paths":   paths,
+		"components": map[string]any{
+			"schemas": schemas,
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{"type": "http", "scheme": "bearer"},
+			},
+		},
+	}
+}
+
+// Returns the JSON schema for the given type, adding the schemas of any named struct types it references to the given
+// map of reusable schemas.
+func openApiSchema(schemaType reflect.Type, schemas map[string]any) map[string]any {
+	if schemaType == reflect.TypeOf(time.Time{}) {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+
+	switch schemaType.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8,
+		reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": openApiSchema(schemaType.Elem(), schemas)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": openApiSchema(schemaType.Elem(), schemas)}
+	case reflect.Pointer:
+		// OpenAPI 3.0 doesn't allow siblings of a reference, so wrap it to mark it as nullable.
+		return map[string]any{"nullable": true, "allOf": []any{openApiSchema(schemaType.Elem(), schemas)}}
+	case reflect.Struct:
+		ref := map[string]any{"$ref": "#/components/schemas/" + schemaType.Name()}
+		if _, ok := schemas[schemaType.Name()]; ok {
+			return ref
+		}
+		// Reserve the name before recursing, in case the type refers to itself.
+		schemas[schemaType.Name()] = nil
+
+		properties := make(map[string]any)
+		var required []string
+		for i := 0; i < schemaType.NumField(); i++ {
+			structField := schemaType.Field(i)
+			if !structField.IsExported() {
+				continue
+			}
+			name, options, _ := strings.Cut(structField.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = structField.Name
+			}
+			property := openApiSchema(structField.Type, schemas)
+			if structField.Tag.Get("openapi") == "readOnly" {
+				property = map[string]any{"readOnly": true, "allOf": []any{property}}
+			}
+			properties[name] = property
+			if !strings.Contains(options, "omitempty") {
+				required = append(required, name)
+			}
+		}
+		schema := map[string]any{"type": "object", "properties": properties}
+		if required != nil {
+			schema["required"] = required
+		}
+		schemas[schemaType.Name()] = schema
+		return ref
+	default:
+		return map[string]any{}
+	}
+}
+
+func openApiJsonContent(schema map[string]any) map[string]any {
+	return map[string]any{"application/json": map[string]any{"schema": schema}}
+}
+
+// Converts the given endpoint path into a camel-cased name to use in the operation ID (e.g. "/teams/{teamId}/edit"
+// becomes "TeamsByTeamIdEdit").
+func openApiOperationName(path string) string {
+	var name strings.Builder
+	for _, segment := range strings.Split(path, "/") {
+		if paramName, ok := strings.CutPrefix(segment, "{"); ok {
+			name.WriteString("By")
+			segment = strings.TrimSuffix(paramName, "}")
+		}
+		for _, word := range strings.FieldsFunc(segment, func(r rune) bool { return r == '_' || r == '.' }) {
+			name.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return name.String()
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestOpenApiSpec(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/api/v1/openapi.json")
+	assert.Equal(t, 200, recorder.Code)
+	var spec struct {
+		OpenApi string `json:"openapi"`
+		Paths   map[string]map[string]struct {
+			OperationId string `json:"operationId"`
+			Parameters  []struct {
+				Name     string `json:"name"`
+				In       string `json:"in"`
+				Required bool   `json:"required"`
+			} `json:"parameters"`
+			RequestBody map[string]any            `json:"requestBody"`
+			Responses   map[string]map[string]any `json:"responses"`
+			Security    []map[string][]string     `json:"security"`
+		} `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]map[string]any `json:"properties"`
+				Required   []string                  `json:"required"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &spec))
+	assert.Equal(t, "3.0.3", spec.OpenApi)
+
+	// Check that every endpoint is documented.
+	for _, endpoint := range web.apiV1Endpoints() {
+		operations, ok := spec.Paths["/api/v1"+endpoint.Path]
+		if assert.True(t, ok, endpoint.Path) {
+			assert.Contains(t, operations, map[string]string{"GET": "get", "POST": "post", "PUT": "put",
+				"DELETE": "delete"}[endpoint.Method])
+		}
+	}
+
+	operation := spec.Paths["/api/v1/teams/{teamId}"]["put"]
+	assert.Equal(t, "putTeamsByTeamId", operation.OperationId)
+	if assert.Equal(t, 1, len(operation.Parameters)) {
+		assert.Equal(t, "teamId", operation.Parameters[0].Name)
+		assert.Equal(t, "path", operation.Parameters[0].In)
+		assert.True(t, operation.Parameters[0].Required)
+	}
+	assert.NotNil(t, operation.RequestBody)
+	assert.Contains(t, operation.Responses, "200")
+	assert.Contains(t, operation.Responses, "default")
+	assert.Equal(t, []map[string][]string{{"bearerAuth": {}}}, operation.Security)
+	assert.Nil(t, spec.Paths["/api/v1/openapi.json"]["get"].Security)
+	assert.Contains(t, spec.Paths["/api/v1/awards/{awardId}"]["delete"].Responses, "204")
+
+	// Check that the schemas are generated from the Go types.
+	matchSchema := spec.Components.Schemas["ApiV1Match"]
+	assert.Equal(t, map[string]any{"type": "string", "format": "date-time"}, matchSchema.Properties["scheduledTime"])
+	assert.Equal(
+		t, map[string]any{"type": "array", "items": map[string]any{"type": "integer"}}, matchSchema.Properties["redTeams"],
+	)
+	assert.Equal(t, true, matchSchema.Properties["result"]["nullable"])
+	assert.Contains(t, matchSchema.Required, "shortName")
+	assert.Contains(t, spec.Components.Schemas, "ApiV1MatchResult")
+	assert.Equal(t, true, spec.Components.Schemas["ApiV1Award"].Properties["id"]["readOnly"])
+	assert.Contains(t, spec.Components.Schemas, "ApiV1Error")
+}
+
+func TestOpenApiOperationName(t *testing.T) {
+	assert.Equal(t, "Teams", openApiOperationName("/teams"))
+	assert.Equal(t, "LowerThirdsByLowerThirdIdShow", openApiOperationName("/lower_thirds/{lowerThirdId}/show"))
+	assert.Equal(t, "OpenapiJson", openApiOperationName("/openapi.json"))
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web routes for teams to check in to and out of the practice match walk-up queue.
+
+package web
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"net/http"
+	"strconv"
+)
+
+// Shows the practice match walk-up queue and the form for teams to check in.
+func (web *Web) practiceQueueGetHandler(w http.ResponseWriter, r *http.Request) {
+	web.renderPracticeQueue(w, r, "")
+}
+
+// Adds a team to the end of the practice match walk-up queue.
+func (web *Web) practiceQueueCheckInPostHandler(w http.ResponseWriter, r *http.Request) {
+	teamId, err := strconv.Atoi(r.PostFormValue("teamId"))
+	if err != nil {
+		web.renderPracticeQueue(w, r, "Invalid team number specified.")
+		return
+	}
+	if err = web.arena.CheckInPracticeTeam(teamId); err != nil {
+		web.renderPracticeQueue(w, r, err.Error())
+		return
+	}
+	web.recordAuditEntry(r, "practice_queue.check_in", strconv.Itoa(teamId), nil, nil)
+	http.Redirect(w, r, "/practice_queue", 303)
+}
+
+// Removes a team from the practice match walk-up queue.
+func (web *Web) practiceQueueCheckOutPostHandler(w http.ResponseWriter, r *http.Request) {
+	teamId, _ := strconv.Atoi(r.PathValue("teamId"))
+	if err := web.arena.CheckOutPracticeTeam(teamId); err != nil {
+		web.renderPracticeQueue(w, r, err.Error())
+		return
+	}
+	web.recordAuditEntry(r, "practice_queue.check_out", strconv.Itoa(teamId), nil, nil)
+	http.Redirect(w, r, "/practice_queue", 303)
+}
+
+func (web *Web) renderPracticeQueue(w http.ResponseWriter, r *http.Request, errorMessage string) {
+	entries, err := web.arena.Database.GetAllPracticeQueueEntries()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	template, err := web.parseFiles("templates/practice_queue.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		Entries      []model.PracticeQueueEntry
+		ErrorMessage string
+	}{web.arena.EventSettings, entries, errorMessage}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
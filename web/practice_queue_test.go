@@ -0,0 +1,47 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPracticeQueue(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.Database.CreateTeam(&model.Team{Id: 254})
+	web.arena.Database.CreateTeam(&model.Team{Id: 1114})
+
+	recorder := web.postHttpResponse("/practice_queue/check_in", "teamId=254")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "The practice match walk-up queue is not enabled.")
+
+	web.arena.EventSettings.PracticeWalkUpQueueEnabled = true
+	recorder = web.postHttpResponse("/practice_queue/check_in", "teamId=254")
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	recorder = web.postHttpResponse("/practice_queue/check_in", "teamId=1114")
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	recorder = web.postHttpResponse("/practice_queue/check_in", "teamId=blorpy")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Invalid team number specified.")
+	recorder = web.postHttpResponse("/practice_queue/check_in", "teamId=254")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Team 254 is already checked in.")
+
+	recorder = web.getHttpResponse("/practice_queue")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "254")
+	assert.Contains(t, recorder.Body.String(), "1114")
+
+	recorder = web.postHttpResponse("/practice_queue/254/check_out", "")
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	entries, _ := web.arena.Database.GetAllPracticeQueueEntries()
+	if assert.Equal(t, 1, len(entries)) {
+		assert.Equal(t, 1114, entries[0].TeamId)
+	}
+	recorder = web.postHttpResponse("/practice_queue/254/check_out", "")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Team 254 is not checked in.")
+}
@@ -6,10 +6,14 @@
 package web
 
 import (
+	"fmt"
 	"github.com/Team254/cheesy-arena/field"
 	"github.com/Team254/cheesy-arena/model"
 	"github.com/Team254/cheesy-arena/websocket"
+	"io"
+	"log"
 	"net/http"
+	"strconv"
 	"time"
 )
 
@@ -30,10 +34,17 @@ func (web *Web) queueingDisplayHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	canCheckIn, err := web.requestHasRole(r, queuerRoles...)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
 	data := struct {
 		*model.EventSettings
+		CanCheckIn bool
 	}{
 		web.arena.EventSettings,
+		canCheckIn,
 	}
 	err = template.ExecuteTemplate(w, "queueing_display.html", data)
 	if err != nil {
@@ -84,6 +95,17 @@ func (web *Web) queueingDisplayMatchLoadHandler(w http.ResponseWriter, r *http.R
 		}
 	}
 
+	// Show the teams waiting in the walk-up queue while practice matches are being built from it.
+	var practiceQueueEntries []model.PracticeQueueEntry
+	showPracticeQueue := web.arena.EventSettings.PracticeWalkUpQueueEnabled &&
+		(web.arena.CurrentMatch.Type == model.Practice || web.arena.CurrentMatch.Type == model.Test)
+	if showPracticeQueue {
+		if practiceQueueEntries, err = web.arena.Database.GetAllPracticeQueueEntries(); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+	}
+
 	template, err := web.parseFiles("templates/queueing_display_match_load.html")
 	if err != nil {
 		handleWebErr(w, err)
@@ -91,13 +113,17 @@ func (web *Web) queueingDisplayMatchLoadHandler(w http.ResponseWriter, r *http.R
 	}
 
 	data := struct {
-		Matches           []model.Match
-		RedOffFieldTeams  [][]int
-		BlueOffFieldTeams [][]int
+		Matches              []model.Match
+		RedOffFieldTeams     [][]int
+		BlueOffFieldTeams    [][]int
+		ShowPracticeQueue    bool
+		PracticeQueueEntries []model.PracticeQueueEntry
 	}{
 		upcomingMatches,
 		redOffFieldTeamsByMatch,
 		blueOffFieldTeamsByMatch,
+		showPracticeQueue,
+		practiceQueueEntries,
 	}
 	err = template.ExecuteTemplate(w, "queueing_display_match_load.html", data)
 	if err != nil {
@@ -122,13 +148,53 @@ func (web *Web) queueingDisplayWebsocketHandler(w http.ResponseWriter, r *http.R
 	}
 	defer ws.Close()
 
-	// Subscribe the websocket to the notifiers whose messages will be passed on to the client.
-	ws.HandleNotifiers(
+	// Subscribe the websocket to the notifiers whose messages will be passed on to the client, in a separate goroutine.
+	go ws.HandleNotifiers(
 		display.Notifier,
 		web.arena.MatchTimingNotifier,
 		web.arena.MatchLoadNotifier,
 		web.arena.MatchTimeNotifier,
 		web.arena.EventStatusNotifier,
+		web.arena.PracticeQueueNotifier,
 		web.arena.ReloadDisplaysNotifier,
 	)
+
+	// Loop, waiting for commands and responding to them, until the client closes the connection.
+	for {
+		messageType, data, err := ws.Read()
+		if err != nil {
+			if err == io.EOF {
+				// Client has closed the connection; nothing to do here.
+				return
+			}
+			log.Println(err)
+			return
+		}
+
+		switch messageType {
+		case "checkInPracticeTeam":
+			// The display itself doesn't require a login, so check that the viewer is allowed to check teams in.
+			canCheckIn, err := web.requestHasRole(r, queuerRoles...)
+			if err != nil {
+				ws.WriteError(err.Error())
+				continue
+			}
+			if !canCheckIn {
+				ws.WriteError("Log in as a queuer to check teams in to the practice queue.")
+				continue
+			}
+			teamId, ok := data.(float64)
+			if !ok {
+				ws.WriteError(fmt.Sprintf("Failed to parse '%s' message.", messageType))
+				continue
+			}
+			if err = web.arena.CheckInPracticeTeam(int(teamId)); err != nil {
+				ws.WriteError(err.Error())
+				continue
+			}
+			web.recordAuditEntry(r, "practice_queue.check_in", strconv.Itoa(int(teamId)), nil, nil)
+		default:
+			ws.WriteError(fmt.Sprintf("Invalid message type '%s'.", messageType))
+		}
+	}
 }
@@ -4,9 +4,11 @@
 package web
 
 import (
+	"github.com/Team254/cheesy-arena/model"
 	"github.com/Team254/cheesy-arena/websocket"
 	gorillawebsocket "github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
+	"net/http"
 	"testing"
 )
 
@@ -34,4 +36,60 @@ func TestQueueingDisplayWebsocket(t *testing.T) {
 	readWebsocketType(t, ws, "matchLoad")
 	readWebsocketType(t, ws, "matchTime")
 	readWebsocketType(t, ws, "eventStatus")
+	readWebsocketType(t, ws, "practiceQueue")
+
+	// Check that teams can check in to the practice match walk-up queue from the display.
+	web.arena.EventSettings.PracticeWalkUpQueueEnabled = true
+	web.arena.Database.CreateTeam(&model.Team{Id: 254})
+	ws.Write("checkInPracticeTeam", 254)
+	readWebsocketType(t, ws, "practiceQueue")
+	entry, _ := web.arena.Database.GetPracticeQueueEntryByTeam(254)
+	assert.NotNil(t, entry)
+}
+
+func TestQueueingDisplayCheckInRequiresQueuer(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.EventSettings.PracticeWalkUpQueueEnabled = true
+	web.arena.Database.CreateTeam(&model.Team{Id: 254})
+	queuer := model.User{Username: "queuer", Role: model.QueuerRole}
+	assert.Nil(t, queuer.SetPassword("line"))
+	assert.Nil(t, web.arena.Database.CreateUser(&queuer))
+
+	// Check that the check-in field is only shown to a logged-in queuer.
+	recorder := web.getHttpResponse("/displays/queueing?displayId=1")
+	assert.Equal(t, 200, recorder.Code)
+	assert.NotContains(t, recorder.Body.String(), "practiceQueueTeamId")
+	recorder = web.postHttpResponse("/login", "username=queuer&password=line")
+	assert.Equal(t, 303, recorder.Code)
+	cookie := recorder.Header().Get("Set-Cookie")
+	recorder = web.getHttpResponseWithHeaders("/displays/queueing?displayId=1", map[string]string{"Cookie": cookie})
+	assert.Contains(t, recorder.Body.String(), "practiceQueueTeamId")
+
+	server, wsUrl := web.startTestServer()
+	defer server.Close()
+	dialDisplay := func(header http.Header) *websocket.Websocket {
+		conn, _, err := gorillawebsocket.DefaultDialer.Dial(wsUrl+"/displays/queueing/websocket?displayId=1", header)
+		assert.Nil(t, err)
+		t.Cleanup(func() { conn.Close() })
+		ws := websocket.NewTestWebsocket(conn)
+		for _, messageType := range []string{
+			"displayConfiguration", "matchTiming", "matchLoad", "matchTime", "eventStatus", "practiceQueue",
+		} {
+			readWebsocketType(t, ws, messageType)
+		}
+		return ws
+	}
+
+	// Check that a display without a login can't check teams in.
+	ws := dialDisplay(nil)
+	ws.Write("checkInPracticeTeam", 254)
+	assert.Contains(t, readWebsocketError(t, ws), "Log in as a queuer")
+	entry, _ := web.arena.Database.GetPracticeQueueEntryByTeam(254)
+	assert.Nil(t, entry)
+
+	ws = dialDisplay(http.Header{"Cookie": []string{cookie}})
+	ws.Write("checkInPracticeTeam", 254)
+	readWebsocketType(t, ws, "practiceQueue")
+	entry, _ = web.arena.Database.GetPracticeQueueEntryByTeam(254)
+	assert.NotNil(t, entry)
 }
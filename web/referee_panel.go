@@ -20,10 +20,6 @@ import (
 
 // Renders the referee interface for assigning fouls.
 func (web *Web) refereePanelHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	template, err := web.parseFiles("templates/referee_panel.html", "templates/base.html")
 	if err != nil {
 		handleWebErr(w, err)
@@ -68,16 +64,13 @@ func (web *Web) refereePanelFoulListHandler(w http.ResponseWriter, r *http.Reque
 
 // The websocket endpoint for the refereee interface client to send control commands and receive status updates.
 func (web *Web) refereePanelWebsocketHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	ws, err := websocket.NewWebsocket(w, r)
 	if err != nil {
 		handleWebErr(w, err)
 		return
 	}
 	defer ws.Close()
+	username := web.getUsernameFromCookie(r)
 
 	// Subscribe the websocket to the notifiers whose messages will be passed on to the client, in a separate goroutine.
 	go ws.HandleNotifiers(
@@ -100,6 +93,7 @@ func (web *Web) refereePanelWebsocketHandler(w http.ResponseWriter, r *http.Requ
 			return
 		}
 
+		scoreBefore := web.arena.SnapshotRealtimeScores()
 		switch messageType {
 		case "addFoul":
 			args := struct {
@@ -227,5 +221,8 @@ func (web *Web) refereePanelWebsocketHandler(w http.ResponseWriter, r *http.Requ
 		default:
 			ws.WriteError(fmt.Sprintf("Invalid message type '%s'.", messageType))
 		}
+		if err = web.arena.RecordMatchEvent("referee", username, messageType, scoreBefore); err != nil {
+			log.Printf("Failed to record match event: %v", err)
+		}
 	}
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/jung-kurt/gofpdf"
 	"math"
 	"net/http"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"time"
@@ -355,7 +356,7 @@ func drawCenteredText(pdf gofpdf.Pdf, txt string, x float64, y float64) {
 
 func drawPdfLogo(pdf gofpdf.Pdf, x float64, y float64, width float64) {
 	pdf.ImageOptions(
-		"static/img/game-logo.png",
+		filepath.Join(model.BaseDir, "static/img/game-logo.png"),
 		x-(width/2),
 		y-25,
 		width,
@@ -650,10 +651,6 @@ func (web *Web) teamsPdfReportHandler(w http.ResponseWriter, r *http.Request) {
 
 // Generates a CSV-formatted report of the WPA keys, for import into the radio kiosk.
 func (web *Web) wpaKeysCsvReportHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	teams, err := web.arena.Database.GetAllTeams()
 	if err != nil {
 		handleWebErr(w, err)
@@ -74,10 +74,6 @@ var positionParameters = map[string]ScoringPosition{
 
 // Renders the scoring interface which enables input of scores in real-time.
 func (web *Web) scoringPanelHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	position := r.PathValue("position")
 	parameters, ok := positionParameters[position]
 	if !ok {
@@ -105,16 +101,13 @@ func (web *Web) scoringPanelHandler(w http.ResponseWriter, r *http.Request) {
 
 // The websocket endpoint for the scoring interface client to send control commands and receive status updates.
 func (web *Web) scoringPanelWebsocketHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	position := r.PathValue("position")
 	if position != "red_near" && position != "red_far" && position != "blue_near" && position != "blue_far" {
 		handleWebErr(w, fmt.Errorf("Invalid position '%s'.", position))
 		return
 	}
 	alliance := strings.Split(position, "_")[0]
+	username := web.getUsernameFromCookie(r)
 
 	var realtimeScore **field.RealtimeScore
 	if alliance == "red" {
@@ -158,6 +151,7 @@ func (web *Web) scoringPanelWebsocketHandler(w http.ResponseWriter, r *http.Requ
 		}
 		score := &(*realtimeScore).CurrentScore
 		scoreChanged := false
+		scoreBefore := web.arena.SnapshotRealtimeScores()
 
 		if command == "commitMatch" {
 			if web.arena.MatchState != field.PostMatch {
@@ -167,63 +161,6 @@ func (web *Web) scoringPanelWebsocketHandler(w http.ResponseWriter, r *http.Requ
 			}
 			web.arena.ScoringPanelRegistry.SetScoreCommitted(position, ws)
 			web.arena.ScoringStatusNotifier.Notify()
-		} else if command == "reef" {
-			args := struct {
-				ReefPosition int
-				ReefLevel    int
-				Current      bool
-				Autonomous   bool
-			}{}
-			err = mapstructure.Decode(data, &args)
-			if err != nil {
-				ws.WriteError(err.Error())
-				continue
-			}
-
-			if args.ReefPosition >= 1 && args.ReefPosition <= 12 && args.ReefLevel >= 2 && args.ReefLevel <= 4 {
-				level := game.Level(args.ReefLevel - 2)
-				reefIndex := args.ReefPosition - 1
-				if args.Current {
-					score.Reef.Branches[level][reefIndex] = !score.Reef.Branches[level][reefIndex]
-					scoreChanged = true
-				}
-				if args.Autonomous {
-					score.Reef.AutoBranches[level][reefIndex] = !score.Reef.AutoBranches[level][reefIndex]
-					scoreChanged = true
-				}
-				scoreChanged = true
-			}
-
-		} else if command == "endgame" {
-			args := struct {
-				TeamPosition  int
-				EndgameStatus int
-			}{}
-			err = mapstructure.Decode(data, &args)
-			if err != nil {
-				ws.WriteError(err.Error())
-				continue
-			}
-
-			if args.TeamPosition >= 1 && args.TeamPosition <= 3 && args.EndgameStatus >= 0 && args.EndgameStatus <= 3 {
-				endgameStatus := game.EndgameStatus(args.EndgameStatus)
-				score.EndgameStatuses[args.TeamPosition-1] = endgameStatus
-				scoreChanged = true
-			}
-		} else if command == "leave" {
-			args := struct {
-				TeamPosition int
-			}{}
-			err = mapstructure.Decode(data, &args)
-			if err != nil {
-				ws.WriteError(err.Error())
-				continue
-			}
-
-			if args.TeamPosition >= 1 && args.TeamPosition <= 3 {
-				score.LeaveStatuses[args.TeamPosition-1] = !score.LeaveStatuses[args.TeamPosition-1]
-				scoreChanged = true
-			}
 		} else if command == "addFoul" {
 			args := struct {
 				Alliance string
@@ -247,47 +184,18 @@ func (web *Web) scoringPanelWebsocketHandler(w http.ResponseWriter, r *http.Requ
 			}
 			web.arena.RealtimeScoreNotifier.Notify()
 		} else {
-			args := struct {
-				Adjustment int
-				Current    bool
-				Autonomous bool
-				NearSide   bool
-			}{}
-			err = mapstructure.Decode(data, &args)
+			scoreChanged, err = game.CurrentGame().ApplyScoringCommand(score, command, data)
 			if err != nil {
 				ws.WriteError(err.Error())
 				continue
 			}
-
-			switch command {
-			case "barge":
-				score.BargeAlgae = max(0, score.BargeAlgae+args.Adjustment)
-				scoreChanged = true
-			case "processor":
-				score.ProcessorAlgae = max(0, score.ProcessorAlgae+args.Adjustment)
-				scoreChanged = true
-			case "trough":
-				if args.Current {
-					if args.NearSide {
-						score.Reef.TroughNear = max(0, score.Reef.TroughNear+args.Adjustment)
-					} else {
-						score.Reef.TroughFar = max(0, score.Reef.TroughFar+args.Adjustment)
-					}
-					scoreChanged = true
-				}
-				if args.Autonomous {
-					if args.NearSide {
-						score.Reef.AutoTroughNear = max(0, score.Reef.AutoTroughNear+args.Adjustment)
-					} else {
-						score.Reef.AutoTroughFar = max(0, score.Reef.AutoTroughFar+args.Adjustment)
-					}
-					scoreChanged = true
-				}
-			}
 		}
 
 		if scoreChanged {
 			web.arena.RealtimeScoreNotifier.Notify()
 		}
+		if err = web.arena.RecordMatchEvent("scoring/"+position, username, command, scoreBefore); err != nil {
+			log.Printf("Failed to record match event: %v", err)
+		}
 	}
 }
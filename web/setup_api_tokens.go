@@ -0,0 +1,84 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web routes for issuing and revoking the tokens that external systems use to access the REST API.
+
+package web
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/google/uuid"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Shows the API token configuration page.
+func (web *Web) apiTokensGetHandler(w http.ResponseWriter, r *http.Request) {
+	web.renderApiTokens(w, r, "", "")
+}
+
+// Issues a new API token and shows it to the user, or revokes an existing one.
+func (web *Web) apiTokensPostHandler(w http.ResponseWriter, r *http.Request) {
+	if r.PostFormValue("action") == "delete" {
+		apiTokenId, _ := strconv.Atoi(r.PostFormValue("id"))
+		apiToken, err := web.arena.Database.GetApiTokenById(apiTokenId)
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if apiToken == nil {
+			web.renderApiTokens(w, r, "", "No such API token.")
+			return
+		}
+		if err = web.arena.Database.DeleteApiToken(apiToken.Id); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		web.recordAuditEntry(r, "api_token.delete", strconv.Itoa(apiToken.Id), *apiToken, nil)
+		http.Redirect(w, r, "/setup/api_tokens", 303)
+		return
+	}
+
+	token := uuid.New().String()
+	apiToken := model.ApiToken{
+		Name:      strings.TrimSpace(r.PostFormValue("name")),
+		Role:      model.UserRole(r.PostFormValue("role")),
+		TokenHash: model.HashApiToken(token),
+		CreatedAt: time.Now(),
+	}
+	if err := web.arena.Database.CreateApiToken(&apiToken); err != nil {
+		web.renderApiTokens(w, r, "", err.Error())
+		return
+	}
+	web.recordAuditEntry(r, "api_token.create", strconv.Itoa(apiToken.Id), nil, apiToken)
+
+	// Render the page directly rather than redirecting, since this is the only time the token itself is available.
+	web.renderApiTokens(w, r, token, "")
+}
+
+func (web *Web) renderApiTokens(w http.ResponseWriter, r *http.Request, newToken, errorMessage string) {
+	template, err := web.parseFiles("templates/setup_api_tokens.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	apiTokens, err := web.arena.Database.GetAllApiTokens()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		ApiTokens    []model.ApiToken
+		Roles        []model.UserRole
+		NewToken     string
+		ErrorMessage string
+	}{web.arena.EventSettings, apiTokens, model.UserRoles, newToken, errorMessage}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
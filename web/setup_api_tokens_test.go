@@ -0,0 +1,49 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+)
+
+func TestSetupApiTokens(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/setup/api_tokens")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "API Tokens")
+
+	// Check that the new token is shown once and can then be used to access the API.
+	recorder = web.postHttpResponse("/setup/api_tokens", "name=Scoreboard&role=scorekeeper")
+	assert.Equal(t, 200, recorder.Code)
+	matches := regexp.MustCompile(`<code id="newToken">([\w-]+)</code>`).FindStringSubmatch(recorder.Body.String())
+	if assert.Equal(t, 2, len(matches)) {
+		apiToken, _ := web.arena.Database.GetApiTokenByToken(matches[1])
+		if assert.NotNil(t, apiToken) {
+			assert.Equal(t, "Scoreboard", apiToken.Name)
+			assert.Equal(t, model.ScorekeeperRole, apiToken.Role)
+		}
+		web.arena.EventSettings.AdminPassword = "admin"
+		assert.Equal(t, 204, web.apiV1Response("POST", "/api/v1/lower_thirds/hide", matches[1], nil).Code)
+		web.arena.EventSettings.AdminPassword = ""
+	}
+	recorder = web.getHttpResponse("/setup/api_tokens")
+	assert.Contains(t, recorder.Body.String(), "Scoreboard")
+	assert.NotContains(t, recorder.Body.String(), "newToken")
+
+	recorder = web.postHttpResponse("/setup/api_tokens", "name=&role=scorekeeper")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "name cannot be blank")
+
+	// Check revoking the token.
+	recorder = web.postHttpResponse("/setup/api_tokens", "id=1&action=delete")
+	assert.Equal(t, 303, recorder.Code)
+	apiTokens, _ := web.arena.Database.GetAllApiTokens()
+	assert.Empty(t, apiTokens)
+	recorder = web.postHttpResponse("/setup/api_tokens", "id=1&action=delete")
+	assert.Contains(t, recorder.Body.String(), "No such API token.")
+}
@@ -0,0 +1,185 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Recording of administrative actions to the audit trail, and web routes for searching and exporting it.
+
+package web
+
+import (
+	"bytes"
+	"github.com/Team254/cheesy-arena/model"
+	"log"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Matches the paths of fields whose values should never be written to the audit trail.
+var redactedAuditFieldRe = regexp.MustCompile(`(?i)(password|secret|apikey|wpakey|tokenhash)`)
+
+// Represents the search criteria for filtering the audit trail.
+type auditFilter struct {
+	Username string
+	Action   string
+	Query    string
+}
+
+// Shows the searchable audit trail of administrative actions.
+func (web *Web) auditGetHandler(w http.ResponseWriter, r *http.Request) {
+	filter := getAuditFilterFromRequest(r)
+	auditEntries, err := web.getFilteredAuditEntries(filter)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	allAuditEntries, err := web.arena.Database.GetAllAuditEntries()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	var usernames, actions []string
+	for _, auditEntry := range allAuditEntries {
+		if !slices.Contains(usernames, auditEntry.Username) {
+			usernames = append(usernames, auditEntry.Username)
+		}
+		if !slices.Contains(actions, auditEntry.Action) {
+			actions = append(actions, auditEntry.Action)
+		}
+	}
+	slices.Sort(usernames)
+	slices.Sort(actions)
+
+	template, err := web.parseFiles("templates/setup_audit.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		Filter       auditFilter
+		RawQuery     string
+		Usernames    []string
+		Actions      []string
+		AuditEntries []model.AuditEntry
+	}{web.arena.EventSettings, filter, r.URL.RawQuery, usernames, actions, auditEntries}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Generates a CSV-formatted export of the audit trail matching the search criteria, with one row per change.
+func (web *Web) auditCsvHandler(w http.ResponseWriter, r *http.Request) {
+	auditEntries, err := web.getFilteredAuditEntries(getAuditFilterFromRequest(r))
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	// Don't set the content type as "text/csv", as that will trigger an automatic download in the browser.
+	w.Header().Set("Content-Type", "text/plain")
+	template, err := web.parseFiles("templates/audit.csv")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	var buf bytes.Buffer
+	err = template.ExecuteTemplate(&buf, "audit.csv", auditEntries)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	// Strip out carriage returns to ensure consistent behavior across platforms.
+	cleaned := bytes.ReplaceAll(buf.Bytes(), []byte("\r"), []byte(""))
+	w.Write(cleaned)
+}
+
+// Appends an entry to the audit trail recording that the logged-in user performed the given action on the given
+// target. If before and after are both non-nil, the differences between them are recorded as well. Failures are logged
+// rather than returned so as not to interfere with the action itself.
+func (web *Web) recordAuditEntry(r *http.Request, action, targetId string, before, after any) {
+	auditEntry := model.AuditEntry{
+		Timestamp: time.Now(),
+		Username:  web.getAuditUsername(r),
+		Action:    action,
+		TargetId:  targetId,
+	}
+	if before != nil || after != nil {
+		changes, err := model.DiffFields(before, after)
+		if err != nil {
+			log.Printf("Failed to compute audit entry changes for %s: %v", action, err)
+		}
+		for _, change := range changes {
+			if redactedAuditFieldRe.MatchString(change.Field) {
+				change.Before = "(redacted)"
+				change.After = "(redacted)"
+			}
+			auditEntry.Changes = append(auditEntry.Changes, change)
+		}
+	}
+	if err := web.arena.Database.CreateAuditEntry(&auditEntry); err != nil {
+		log.Printf("Failed to record audit entry for %s: %v", action, err)
+	}
+}
+
+// Returns the name to attribute an action to: the API token it was made with, or else the logged-in user.
+func (web *Web) getAuditUsername(r *http.Request) string {
+	if apiToken := web.getApiTokenFromHeader(r); apiToken != nil {
+		return "api:" + apiToken.Name
+	}
+	return web.getUsernameFromCookie(r)
+}
+
+func getAuditFilterFromRequest(r *http.Request) auditFilter {
+	return auditFilter{
+		Username: r.URL.Query().Get("username"),
+		Action:   r.URL.Query().Get("action"),
+		Query:    strings.TrimSpace(r.URL.Query().Get("q")),
+	}
+}
+
+// Returns the audit entries matching the given filter, most recent first.
+func (web *Web) getFilteredAuditEntries(filter auditFilter) ([]model.AuditEntry, error) {
+	auditEntries, err := web.arena.Database.GetAllAuditEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	var matchingAuditEntries []model.AuditEntry
+	for _, auditEntry := range auditEntries {
+		if filter.matches(&auditEntry) {
+			matchingAuditEntries = append(matchingAuditEntries, auditEntry)
+		}
+	}
+	return matchingAuditEntries, nil
+}
+
+// Returns true if the given audit entry satisfies all of the filter's criteria.
+func (filter *auditFilter) matches(auditEntry *model.AuditEntry) bool {
+	if filter.Username != "" && auditEntry.Username != filter.Username {
+		return false
+	}
+	if filter.Action != "" && auditEntry.Action != filter.Action {
+		return false
+	}
+	if filter.Query == "" {
+		return true
+	}
+
+	// Search the free-text query case-insensitively across all of the entry's text.
+	query := strings.ToLower(filter.Query)
+	fields := []string{auditEntry.Username, auditEntry.Action, auditEntry.TargetId}
+	for _, change := range auditEntry.Changes {
+		fields = append(fields, change.Field, change.Before, change.After)
+	}
+	for _, field := range fields {
+		if strings.Contains(strings.ToLower(field), query) {
+			return true
+		}
+	}
+	return false
+}
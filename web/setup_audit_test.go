@@ -0,0 +1,88 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestSetupAudit(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/setup/audit")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "No matching audit entries.")
+
+	// Perform some audited actions.
+	assert.Nil(t, web.arena.Database.CreateTeam(&model.Team{Id: 254, Nickname: "The Cheesy Poofs"}))
+	recorder = web.postHttpResponse("/setup/teams/254/edit", "nickname=Teh Chezy Pofs")
+	assert.Equal(t, 303, recorder.Code)
+	recorder = web.postHttpResponse("/setup/teams/254/delete", "")
+	assert.Equal(t, 303, recorder.Code)
+	recorder = web.postHttpResponse("/setup/settings", "name=Cheesy Champs&adminPassword=sekrit")
+	assert.Equal(t, 303, recorder.Code)
+
+	auditEntries, err := web.arena.Database.GetAllAuditEntries()
+	assert.Nil(t, err)
+	if assert.Equal(t, 3, len(auditEntries)) {
+		assert.Equal(t, "settings.edit", auditEntries[0].Action)
+		assert.Contains(
+			t, auditEntries[0].Changes, model.FieldChange{Field: "Name", Before: "Untitled Event", After: "Cheesy Champs"},
+		)
+		assert.Contains(
+			t,
+			auditEntries[0].Changes,
+			model.FieldChange{Field: "AdminPassword", Before: "(redacted)", After: "(redacted)"},
+		)
+		assert.Equal(t, "team.delete", auditEntries[1].Action)
+		assert.Equal(t, "254", auditEntries[1].TargetId)
+		assert.Equal(t, "team.edit", auditEntries[2].Action)
+		assert.Equal(
+			t,
+			[]model.FieldChange{{Field: "Nickname", Before: "The Cheesy Poofs", After: "Teh Chezy Pofs"}},
+			auditEntries[2].Changes,
+		)
+	}
+
+	// Log in since the admin password is now set, and check that the username is recorded.
+	recorder = web.postHttpResponse("/login", "username=admin&password=sekrit")
+	headers := map[string]string{"Cookie": recorder.Header().Get("Set-Cookie")}
+	recorder = web.postHttpResponseWithHeaders("/setup/judging/clear", "", headers)
+	assert.Equal(t, 303, recorder.Code)
+	auditEntries, _ = web.arena.Database.GetAllAuditEntries()
+	assert.Equal(t, "judging_schedule.clear", auditEntries[0].Action)
+	assert.Equal(t, "admin", auditEntries[0].Username)
+
+	// Check the search filters.
+	recorder = web.getHttpResponseWithHeaders("/setup/audit?q=cheesy", headers)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "team.edit")
+	assert.Contains(t, recorder.Body.String(), "settings.edit")
+	assert.NotContains(t, recorder.Body.String(), "<td>team.delete</td>")
+	recorder = web.getHttpResponseWithHeaders("/setup/audit?action=team.delete", headers)
+	assert.Contains(t, recorder.Body.String(), "<td>team.delete</td>")
+	assert.NotContains(t, recorder.Body.String(), "<td>team.edit</td>")
+	recorder = web.getHttpResponseWithHeaders("/setup/audit?username=admin", headers)
+	assert.Contains(t, recorder.Body.String(), "<td>judging_schedule.clear</td>")
+	assert.NotContains(t, recorder.Body.String(), "<td>team.edit</td>")
+
+	recorder = web.getHttpResponseWithHeaders("/setup/audit/csv?action=team.edit", headers)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "text/plain", recorder.Header()["Content-Type"][0])
+	lines := strings.Split(strings.TrimSpace(recorder.Body.String()), "\n")
+	if assert.Equal(t, 2, len(lines)) {
+		assert.Equal(t, "Timestamp,Username,Action,TargetId,Field,Before,After", lines[0])
+		assert.Contains(t, lines[1], ",\"\",\"team.edit\",\"254\",\"Nickname\",\"The Cheesy Poofs\",\"Teh Chezy Pofs\"")
+	}
+	recorder = web.getHttpResponseWithHeaders("/setup/audit/csv?action=team.delete", headers)
+	assert.Contains(t, recorder.Body.String(), ",\"\",\"team.delete\",\"254\",\"Nickname\",\"Teh Chezy Pofs\",\"\"")
+	recorder = web.getHttpResponseWithHeaders("/setup/audit/csv?action=judging_schedule.clear", headers)
+	lines = strings.Split(strings.TrimSpace(recorder.Body.String()), "\n")
+	if assert.Equal(t, 2, len(lines)) {
+		assert.Contains(t, lines[1], ",\"admin\",\"judging_schedule.clear\",\"\",\"\",\"\",\"\"")
+	}
+}
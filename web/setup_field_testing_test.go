@@ -44,3 +44,51 @@ func TestSetupFieldTestingWebsocket(t *testing.T) {
 	ws.Write("playSound", "resume")
 	assert.Equal(t, "resume", readWebsocketType(t, audienceWs, "playSound"))
 }
+
+func TestSetupFieldTestingSimulatedPlc(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/setup/field_testing")
+	assert.NotContains(t, recorder.Body.String(), "togglePlcInput")
+
+	web.arena.EventSettings.PlcSimulated = true
+	assert.Nil(t, web.arena.Database.UpdateEventSettings(web.arena.EventSettings))
+	assert.Nil(t, web.arena.LoadSettings())
+	web.arena.Update()
+	recorder = web.getHttpResponse("/setup/field_testing")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "PLC (Simulated)")
+	assert.Contains(t, recorder.Body.String(), "togglePlcInput(0)")
+	assert.Contains(t, recorder.Body.String(), "registerValue1")
+}
+
+func TestSetupFieldTestingWebsocketSimulatedPlc(t *testing.T) {
+	web := setupTestWeb(t)
+
+	server, wsUrl := web.startTestServer()
+	defer server.Close()
+	conn, _, err := gorillawebsocket.DefaultDialer.Dial(wsUrl+"/setup/field_testing/websocket", nil)
+	assert.Nil(t, err)
+	defer conn.Close()
+	ws := websocket.NewTestWebsocket(conn)
+	readWebsocketType(t, ws, "plcIoChange")
+
+	// Inputs can't be set on the real PLC.
+	ws.Write("setPlcInput", map[string]any{"Index": 0, "Value": false})
+	assert.Equal(t, "The PLC is not simulated.", readWebsocketError(t, ws))
+
+	// Check that the page is told to reload once the PLC is switched, so that it picks up the new PLC.
+	web.arena.EventSettings.PlcSimulated = true
+	assert.Nil(t, web.arena.Database.UpdateEventSettings(web.arena.EventSettings))
+	assert.Nil(t, web.arena.LoadSettings())
+	web.arena.Update()
+	readWebsocketType(t, ws, "reload")
+
+	ws.Write("setPlcInput", map[string]any{"Index": 0, "Value": false})
+	ws.Write("setPlcRegister", map[string]any{"Index": 1, "Value": 4})
+	ws.Write("setPlcInput", map[string]any{"Index": 99, "Value": true})
+	assert.Equal(t, "invalid PLC input index 99", readWebsocketError(t, ws))
+	assert.True(t, web.arena.Plc.GetFieldEStop())
+	redCount, _ := web.arena.Plc.GetProcessorCounts()
+	assert.Equal(t, 4, redCount)
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web routes for tracking the robot inspection status of each team.
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Shows the robot inspection page.
+func (web *Web) inspectionGetHandler(w http.ResponseWriter, r *http.Request) {
+	web.renderInspection(w, r, "")
+}
+
+// Saves the inspection status of a single team.
+func (web *Web) inspectionPostHandler(w http.ResponseWriter, r *http.Request) {
+	teamId, _ := strconv.Atoi(r.PathValue("teamId"))
+	team, err := web.arena.Database.GetTeamById(teamId)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if team == nil {
+		http.Error(w, fmt.Sprintf("Error: No such team: %d", teamId), 400)
+		return
+	}
+	previousTeam := *team
+
+	status, err := strconv.Atoi(r.PostFormValue("inspectionStatus"))
+	if err != nil || !model.InspectionStatus(status).IsValid() {
+		web.renderInspection(w, r, "Invalid inspection status specified.")
+		return
+	}
+	weightLbs := 0.0
+	if weightString := strings.TrimSpace(r.PostFormValue("inspectionWeightLbs")); weightString != "" {
+		weightLbs, err = strconv.ParseFloat(weightString, 64)
+		if err != nil || weightLbs < 0 {
+			web.renderInspection(w, r, "Robot weight must be a non-negative number.")
+			return
+		}
+	}
+	team.InspectionStatus = model.InspectionStatus(status)
+	team.InspectionWeightLbs = weightLbs
+	team.InspectionNotes = r.PostFormValue("inspectionNotes")
+	team.InspectionInspector = r.PostFormValue("inspectionInspector")
+	if err = web.arena.Database.UpdateTeam(team); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	web.recordAuditEntry(r, "team.inspection", strconv.Itoa(team.Id), previousTeam, *team)
+
+	// Make sure the change takes effect immediately if the team is in the currently loaded match.
+	web.arena.UpdateAssignedTeam(team)
+
+	http.Redirect(w, r, "/setup/inspection", 303)
+}
+
+func (web *Web) renderInspection(w http.ResponseWriter, r *http.Request, errorMessage string) {
+	teams, err := web.arena.Database.GetAllTeams()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	numPassed := 0
+	for _, team := range teams {
+		if team.InspectionStatus == model.InspectionPassed {
+			numPassed++
+		}
+	}
+
+	template, err := web.parseFiles("templates/setup_inspection.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		Teams              []model.Team
+		NumPassed          int
+		InspectionStatuses []model.InspectionStatus
+		ErrorMessage       string
+	}{web.arena.EventSettings, teams, numPassed, model.InspectionStatuses, errorMessage}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSetupInspection(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.Database.CreateTeam(&model.Team{Id: 254})
+	web.arena.Database.CreateTeam(&model.Team{Id: 1114})
+
+	recorder := web.getHttpResponse("/setup/inspection")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Robot Inspection (0 of 2 passed)")
+	assert.Contains(t, recorder.Body.String(), "Needs Re-inspection")
+
+	recorder = web.postHttpResponse(
+		"/setup/inspection/254", "inspectionStatus=2&inspectionWeightLbs=114.5&inspectionInspector=Bob&"+
+			"inspectionNotes=Bumpers+look+good",
+	)
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	team, _ := web.arena.Database.GetTeamById(254)
+	assert.Equal(t, model.InspectionPassed, team.InspectionStatus)
+	assert.Equal(t, 114.5, team.InspectionWeightLbs)
+	assert.Equal(t, "Bob", team.InspectionInspector)
+	assert.Equal(t, "Bumpers look good", team.InspectionNotes)
+	recorder = web.getHttpResponse("/setup/inspection")
+	assert.Contains(t, recorder.Body.String(), "Robot Inspection (1 of 2 passed)")
+
+	// Check the inspection status column in the FTA report.
+	recorder = web.getHttpResponse("/reports/csv/fta")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Number,HasConnected,InspectionStatus,FtaNotes")
+	assert.Contains(t, recorder.Body.String(), "254,false,Passed,")
+	assert.Contains(t, recorder.Body.String(), "1114,false,Not Started,")
+}
+
+func TestSetupInspectionErrors(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.Database.CreateTeam(&model.Team{Id: 254})
+
+	recorder := web.postHttpResponse("/setup/inspection/254", "inspectionStatus=7")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Invalid inspection status specified.")
+
+	recorder = web.postHttpResponse("/setup/inspection/254", "inspectionStatus=1&inspectionWeightLbs=-3")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Robot weight must be a non-negative number.")
+
+	recorder = web.postHttpResponse("/setup/inspection/1114", "inspectionStatus=1")
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "No such team")
+}
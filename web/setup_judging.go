@@ -23,19 +23,11 @@ var judgingScheduleParams = tournament.JudgingScheduleParams{
 
 // Shows the judging schedule setup page.
 func (web *Web) judgingGetHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	web.renderJudging(w, r, "")
 }
 
 // Generates a judging schedule based on the parameters and saves it to the database.
 func (web *Web) judgingGeneratePostHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	numJudges, err := strconv.Atoi(r.PostFormValue("numJudges"))
 	if err != nil || numJudges <= 0 {
 		web.renderJudging(w, r, "Number of judges must be a positive integer.")
@@ -84,20 +76,18 @@ func (web *Web) judgingGeneratePostHandler(w http.ResponseWriter, r *http.Reques
 		web.renderJudging(w, r, fmt.Sprintf("Error generating judging schedule: %s", err.Error()))
 		return
 	}
+	web.recordAuditEntry(r, "judging_schedule.generate", "", nil, judgingScheduleParams)
 
 	http.Redirect(w, r, "/setup/judging", 303)
 }
 
 // Clears the judging schedule.
 func (web *Web) judgingClearPostHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	if err := web.arena.Database.TruncateJudgingSlots(); err != nil {
 		handleWebErr(w, err)
 		return
 	}
+	web.recordAuditEntry(r, "judging_schedule.clear", "", nil, nil)
 
 	http.Redirect(w, r, "/setup/judging", 303)
 }
@@ -121,6 +111,15 @@ func (web *Web) renderJudging(w http.ResponseWriter, r *http.Request, errorMessa
 		},
 	)
 
+	unavailabilities, err := web.arena.Database.GetAllTeamUnavailabilities()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	warnings := tournament.FindJudgingAvailabilityConflicts(
+		slots, judgingScheduleParams.DurationMinutes, unavailabilities,
+	)
+
 	template, err := web.parseFiles("templates/setup_judging.html", "templates/base.html")
 	if err != nil {
 		handleWebErr(w, err)
@@ -130,8 +129,9 @@ func (web *Web) renderJudging(w http.ResponseWriter, r *http.Request, errorMessa
 		*model.EventSettings
 		JudgingScheduleParams tournament.JudgingScheduleParams
 		JudgingSlots          []model.JudgingSlot
+		Warnings              []string
 		ErrorMessage          string
-	}{web.arena.EventSettings, judgingScheduleParams, slots, errorMessage}
+	}{web.arena.EventSettings, judgingScheduleParams, slots, warnings, errorMessage}
 	err = template.ExecuteTemplate(w, "base", data)
 	if err != nil {
 		handleWebErr(w, err)
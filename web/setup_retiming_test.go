@@ -0,0 +1,102 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSetupRetiming(t *testing.T) {
+	web := setupTestWeb(t)
+
+	startTime := time.Date(2025, 4, 16, 9, 0, 0, 0, time.Local)
+	for i := 0; i < 4; i++ {
+		match := model.Match{
+			Type:      model.Qualification,
+			TypeOrder: i + 1,
+			Time:      startTime.Add(time.Duration(6*i) * time.Minute),
+			ShortName: fmt.Sprintf("Q%d", i+1),
+			LongName:  fmt.Sprintf("Qualification %d", i+1),
+		}
+		if i == 0 {
+			match.Status = game.RedWonMatch
+		}
+		assert.Nil(t, web.arena.Database.CreateMatch(&match))
+	}
+	web.arena.Database.CreateScheduledBreak(
+		&model.ScheduledBreak{
+			MatchType:       model.Qualification,
+			TypeOrderBefore: 4,
+			Time:            startTime.Add(15 * time.Minute),
+			DurationSec:     180,
+			Description:     "Field Reset",
+		},
+	)
+
+	recorder := web.getHttpResponse("/setup/retiming?matchType=qualification")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Schedule Re-timing")
+
+	// Preview the re-timed schedule and check that nothing is saved yet.
+	params := "matchType=qualification&firstMatchTime=2025-04-16T09:10&maxCompressionSec=60"
+	recorder = web.postHttpResponse("/setup/retiming/preview", params)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Qualification 2")
+	assert.Contains(t, recorder.Body.String(), "Field Reset")
+	assert.NotContains(t, recorder.Body.String(), "Qualification 1<")
+	match, _ := web.arena.Database.GetMatchById(2)
+	assert.Equal(t, startTime.Add(6*time.Minute).Unix(), match.Time.Unix())
+
+	// Apply the re-timing and check that the remaining matches and break were updated.
+	web.arena.EventSettings.TbaPublishingEnabled = true
+	recorder = web.postHttpResponse("/setup/retiming/apply", params+"&publishToTba=on")
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	match, _ = web.arena.Database.GetMatchById(1)
+	assert.Equal(t, startTime.Unix(), match.Time.Unix())
+	match, _ = web.arena.Database.GetMatchById(2)
+	assert.Equal(t, startTime.Add(10*time.Minute).Unix(), match.Time.Unix())
+	match, _ = web.arena.Database.GetMatchById(3)
+	assert.Equal(t, startTime.Add(15*time.Minute).Unix(), match.Time.Unix())
+	match, _ = web.arena.Database.GetMatchById(4)
+	assert.Equal(t, startTime.Add(21*time.Minute).Unix(), match.Time.Unix())
+	scheduledBreak, _ := web.arena.Database.GetScheduledBreakById(1)
+	assert.Equal(t, startTime.Add(18*time.Minute).Unix(), scheduledBreak.Time.Unix())
+	item, _ := web.arena.Database.GetTbaPublishItemByType("matches")
+	if assert.NotNil(t, item) {
+		assert.True(t, item.Pending)
+	}
+}
+
+func TestSetupRetimingErrors(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.postHttpResponse(
+		"/setup/retiming/preview", "matchType=qualification&firstMatchTime=2025-04-16T09:10&maxCompressionSec=60",
+	)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "There are no remaining Qualification matches to re-time.")
+
+	recorder = web.postHttpResponse(
+		"/setup/retiming/preview", "matchType=qualification&firstMatchTime=blorpy&maxCompressionSec=60",
+	)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Invalid next match start time specified.")
+
+	recorder = web.postHttpResponse(
+		"/setup/retiming/apply", "matchType=qualification&firstMatchTime=2025-04-16T09:10&maxCompressionSec=-5",
+	)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Maximum compression per cycle must be a non-negative integer.")
+
+	recorder = web.postHttpResponse(
+		"/setup/retiming/preview", "matchType=test&firstMatchTime=2025-04-16T09:10&maxCompressionSec=60",
+	)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Test matches cannot be re-timed.")
+}
@@ -1,7 +1,7 @@
 // Copyright 2014 Team 254. All Rights Reserved.
 // Author: pat@patfairbank.com (Patrick Fairbank)
 //
-// Web routes for generating practice and qualification schedules.
+// Web routes for generating or importing practice and qualification schedules.
 
 package web
 
@@ -20,10 +20,6 @@ var cachedTeamFirstMatches = make(map[model.MatchType]map[int]string)
 
 // Shows the schedule editing page.
 func (web *Web) scheduleGetHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	matchTypeString := getMatchType(r)
 	matchType, _ := model.MatchTypeFromString(matchTypeString)
 	if matchType != model.Practice && matchType != model.Qualification {
@@ -36,10 +32,6 @@ func (web *Web) scheduleGetHandler(w http.ResponseWriter, r *http.Request) {
 
 // Generates the schedule, presents it for review without saving it, and saves the schedule blocks to the database.
 func (web *Web) scheduleGeneratePostHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	matchTypeString := getMatchType(r)
 	matchType, err := model.MatchTypeFromString(matchTypeString)
 	if err != nil {
@@ -90,40 +82,106 @@ func (web *Web) scheduleGeneratePostHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	matches, err := tournament.BuildRandomSchedule(teams, scheduleBlocks, matchType)
+	unavailabilities, err := web.arena.Database.GetAllTeamUnavailabilities()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	var matches []model.Match
+	if r.PostFormValue("generator") == "optimized" {
+		minTurnaroundMin, err := strconv.Atoi(r.PostFormValue("minTurnaroundMin"))
+		if err != nil || minTurnaroundMin < 0 {
+			web.renderSchedule(w, r, "Invalid minimum turnaround time specified.")
+			return
+		}
+		matches, err = tournament.BuildOptimizedSchedule(
+			teams, scheduleBlocks, matchType, minTurnaroundMin*60, unavailabilities,
+		)
+	} else {
+		matches, err = tournament.BuildRandomSchedule(teams, scheduleBlocks, matchType, unavailabilities)
+	}
 	if err != nil {
 		web.renderSchedule(w, r, fmt.Sprintf("Error generating schedule: %s.", err.Error()))
 		return
 	}
 	cachedMatches[matchType] = matches
+	cachedTeamFirstMatches[matchType] = getTeamFirstMatches(matches)
+	web.recordAuditEntry(
+		r,
+		"schedule.generate",
+		matchType.String(),
+		nil,
+		map[string]any{"ScheduleBlocks": scheduleBlocks, "Generator": r.PostFormValue("generator")},
+	)
 
-	// Determine each team's first match.
-	teamFirstMatches := make(map[int]string)
-	for _, match := range matches {
-		checkTeam := func(team int) {
-			_, ok := teamFirstMatches[team]
-			if !ok {
-				teamFirstMatches[team] = match.ShortName
-			}
+	http.Redirect(w, r, "/setup/schedule?matchType="+matchTypeString, 303)
+}
+
+// Imports an existing schedule from The Blue Alliance or an uploaded CSV file and presents it for review without saving
+// it.
+func (web *Web) scheduleImportPostHandler(w http.ResponseWriter, r *http.Request) {
+	matchTypeString := getMatchType(r)
+	matchType, err := model.MatchTypeFromString(matchTypeString)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	source := r.FormValue("source")
+	var matches []model.Match
+	switch source {
+	case "tba":
+		tbaMatches, err := web.arena.TbaClient.GetMatches()
+		if err != nil {
+			web.renderSchedule(w, r, fmt.Sprintf("Failed to get schedule from The Blue Alliance: %s", err.Error()))
+			return
 		}
-		checkTeam(match.Red1)
-		checkTeam(match.Red2)
-		checkTeam(match.Red3)
-		checkTeam(match.Blue1)
-		checkTeam(match.Blue2)
-		checkTeam(match.Blue3)
+		matches, err = tournament.ImportScheduleFromTba(tbaMatches, matchType)
+		if err != nil {
+			web.renderSchedule(w, r, fmt.Sprintf("Error importing schedule: %s.", err.Error()))
+			return
+		}
+	case "csv":
+		file, _, err := r.FormFile("scheduleFile")
+		if err != nil {
+			web.renderSchedule(w, r, "No schedule file was specified.")
+			return
+		}
+		defer file.Close()
+		matches, err = tournament.ImportScheduleFromCsv(file, matchType)
+		if err != nil {
+			web.renderSchedule(w, r, fmt.Sprintf("Error importing schedule: %s.", err.Error()))
+			return
+		}
+	default:
+		web.renderSchedule(w, r, fmt.Sprintf("Invalid schedule import source %q.", source))
+		return
 	}
-	cachedTeamFirstMatches[matchType] = teamFirstMatches
+
+	teams, err := web.arena.Database.GetAllTeams()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if err = tournament.ValidateImportedSchedule(matches, teams); err != nil {
+		web.renderSchedule(w, r, fmt.Sprintf("Error importing schedule: %s.", err.Error()))
+		return
+	}
+	cachedMatches[matchType] = matches
+	cachedTeamFirstMatches[matchType] = getTeamFirstMatches(matches)
+	web.recordAuditEntry(
+		r,
+		"schedule.import",
+		matchType.String(),
+		nil,
+		map[string]any{"Source": source, "NumMatches": len(matches)},
+	)
 
 	http.Redirect(w, r, "/setup/schedule?matchType="+matchTypeString, 303)
 }
 
 // Saves the generated schedule to the database.
 func (web *Web) scheduleSavePostHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	matchTypeString := getMatchType(r)
 	matchType, err := model.MatchTypeFromString(matchTypeString)
 	if err != nil {
@@ -164,6 +222,9 @@ func (web *Web) scheduleSavePostHandler(w http.ResponseWriter, r *http.Request)
 		handleWebErr(w, err)
 		return
 	}
+	web.recordAuditEntry(
+		r, "schedule.save", matchType.String(), nil, map[string]int{"NumMatches": len(cachedMatches[matchType])},
+	)
 
 	http.Redirect(w, r, "/setup/schedule?matchType="+matchTypeString, 303)
 }
@@ -187,6 +248,11 @@ func (web *Web) renderSchedule(w http.ResponseWriter, r *http.Request, errorMess
 		handleWebErr(w, err)
 		return
 	}
+	unavailabilities, err := web.arena.Database.GetAllTeamUnavailabilities()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
 	template, err := web.parseFiles("templates/setup_schedule.html", "templates/base.html")
 	if err != nil {
 		handleWebErr(w, err)
@@ -198,6 +264,8 @@ func (web *Web) renderSchedule(w http.ResponseWriter, r *http.Request, errorMess
 		ScheduleBlocks   []model.ScheduleBlock
 		NumTeams         int
 		Matches          []model.Match
+		Metrics          tournament.ScheduleMetrics
+		Warnings         []string
 		TeamFirstMatches map[int]string
 		ErrorMessage     string
 	}{
@@ -206,6 +274,8 @@ func (web *Web) renderSchedule(w http.ResponseWriter, r *http.Request, errorMess
 		scheduleBlocks,
 		len(teams),
 		cachedMatches[matchType],
+		tournament.CalculateScheduleMetrics(cachedMatches[matchType]),
+		tournament.FindScheduleAvailabilityConflicts(cachedMatches[matchType], unavailabilities),
 		cachedTeamFirstMatches[matchType],
 		errorMessage,
 	}
@@ -244,6 +314,26 @@ func getScheduleBlocks(r *http.Request) ([]model.ScheduleBlock, error) {
 	return scheduleBlocks, returnErr
 }
 
+// Returns a map of each team in the given schedule to the short name of their first match.
+func getTeamFirstMatches(matches []model.Match) map[int]string {
+	teamFirstMatches := make(map[int]string)
+	for _, match := range matches {
+		checkTeam := func(team int) {
+			_, ok := teamFirstMatches[team]
+			if !ok {
+				teamFirstMatches[team] = match.ShortName
+			}
+		}
+		checkTeam(match.Red1)
+		checkTeam(match.Red2)
+		checkTeam(match.Red3)
+		checkTeam(match.Blue1)
+		checkTeam(match.Blue2)
+		checkTeam(match.Blue3)
+	}
+	return teamFirstMatches
+}
+
 func getMatchType(r *http.Request) string {
 	if matchType, ok := r.URL.Query()["matchType"]; ok {
 		return matchType[0]
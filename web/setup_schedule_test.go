@@ -4,8 +4,11 @@
 package web
 
 import (
+	"bytes"
 	"github.com/Team254/cheesy-arena/model"
 	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 )
@@ -95,3 +98,144 @@ func TestSetupScheduleErrors(t *testing.T) {
 	assert.Equal(t, 200, recorder.Code)
 	assert.Contains(t, recorder.Body.String(), "schedule of 2 Practice matches already exists")
 }
+
+func TestSetupScheduleImportFromCsv(t *testing.T) {
+	web := setupTestWeb(t)
+
+	for i := 0; i < 12; i++ {
+		web.arena.Database.CreateTeam(&model.Team{Id: i + 101})
+	}
+	scheduleCsv := "Match,Type,Time,Red1,Red1IsSurrogate,Red2,Red2IsSurrogate,Red3,Red3IsSurrogate,Blue1," +
+		"Blue1IsSurrogate,Blue2,Blue2IsSurrogate,Blue3,Blue3IsSurrogate\n" +
+		"Q1,Qualification,2025-04-16 09:00:00 -0700 PDT,101,false,102,false,103,false,104,false,105,false,106,false\n" +
+		"Q2,Qualification,2025-04-16 09:07:00 -0700 PDT,107,false,108,false,109,true,110,false,111,false,112,false\n"
+
+	// Import the schedule and check that it is presented for review but not yet saved.
+	recorder := web.postFileHttpResponse(
+		"/setup/schedule/import?matchType=qualification&source=csv", "scheduleFile", bytes.NewBufferString(scheduleCsv),
+	)
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	recorder = web.getHttpResponse("/setup/schedule?matchType=qualification")
+	assert.Contains(t, recorder.Body.String(), "Qualification 2")
+	matches, _ := web.arena.Database.GetMatchesByType(model.Qualification, true)
+	assert.Empty(t, matches)
+
+	// Save the schedule and check that it was persisted as imported.
+	recorder = web.postHttpResponse("/setup/schedule/save?matchType=qualification", "")
+	assert.Equal(t, 303, recorder.Code)
+	matches, _ = web.arena.Database.GetMatchesByType(model.Qualification, true)
+	if assert.Equal(t, 2, len(matches)) {
+		assert.Equal(t, "qm2", matches[1].TbaMatchKey.String())
+		assert.Equal(t, 107, matches[1].Red1)
+		assert.True(t, matches[1].Red3IsSurrogate)
+		assert.Equal(t, time.Date(2025, 4, 16, 16, 7, 0, 0, time.UTC).Unix(), matches[1].Time.Unix())
+	}
+
+	// Check that a schedule containing teams not in the team list is rejected.
+	recorder = web.postFileHttpResponse(
+		"/setup/schedule/import?matchType=practice&source=csv",
+		"scheduleFile",
+		bytes.NewBufferString(
+			"Match,Type,Time,Red1,Red1IsSurrogate,Red2,Red2IsSurrogate,Red3,Red3IsSurrogate,Blue1,"+
+				"Blue1IsSurrogate,Blue2,Blue2IsSurrogate,Blue3,Blue3IsSurrogate\n"+
+				"P1,Practice,2025-04-16 09:00:00 -0700 PDT,101,false,102,false,103,false,104,false,105,false,254,false\n",
+		),
+	)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "team 254 in match P1 is not in the team list")
+
+	recorder = web.postHttpResponse("/setup/schedule/import?matchType=practice", "source=csv")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "No schedule file was specified.")
+}
+
+func TestSetupScheduleAvailabilityWarnings(t *testing.T) {
+	web := setupTestWeb(t)
+
+	for i := 0; i < 6; i++ {
+		web.arena.Database.CreateTeam(&model.Team{Id: i + 101})
+	}
+	unavailability := model.TeamUnavailability{
+		TeamId:    103,
+		StartTime: time.Date(2025, 4, 16, 8, 30, 0, 0, time.UTC),
+		EndTime:   time.Date(2025, 4, 16, 17, 0, 0, 0, time.UTC),
+		Reason:    "Stuck in traffic",
+	}
+	assert.Nil(t, web.arena.Database.CreateTeamUnavailability(&unavailability))
+
+	// Import a schedule that conflicts with the team's unavailability and check that it is flagged for review.
+	recorder := web.postFileHttpResponse(
+		"/setup/schedule/import?matchType=qualification&source=csv",
+		"scheduleFile",
+		bytes.NewBufferString(
+			"Match,Type,Time,Red1,Red1IsSurrogate,Red2,Red2IsSurrogate,Red3,Red3IsSurrogate,Blue1,"+
+				"Blue1IsSurrogate,Blue2,Blue2IsSurrogate,Blue3,Blue3IsSurrogate\n"+
+				"Q1,Qualification,2025-04-16 09:00:00 -0700 PDT,101,false,102,false,103,false,104,false,105,false,106,"+
+				"false\n",
+		),
+	)
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	recorder = web.getHttpResponse("/setup/schedule?matchType=qualification")
+	assert.Contains(t, recorder.Body.String(), "Team 103 is scheduled in Q1")
+	assert.Contains(t, recorder.Body.String(), "(Stuck in traffic)")
+	assert.NotContains(t, recorder.Body.String(), "Team 101 is scheduled")
+}
+
+func TestSetupScheduleImportFromTba(t *testing.T) {
+	web := setupTestWeb(t)
+
+	for i := 0; i < 6; i++ {
+		web.arena.Database.CreateTeam(&model.Team{Id: i + 101})
+	}
+	tbaServer := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Write(
+					[]byte(
+						`[{"key":"2025casj_qm1","comp_level":"qm","set_number":1,"match_number":1,"alliances":` +
+							`{"red":{"team_keys":["frc101","frc102","frc103"],"surrogate_team_keys":[]},` +
+							`"blue":{"team_keys":["frc104","frc105","frc106"],"surrogate_team_keys":["frc105"]}},` +
+							`"time":1744819200}]`,
+					),
+				)
+			},
+		),
+	)
+	defer tbaServer.Close()
+	web.arena.TbaClient.BaseUrl = tbaServer.URL
+
+	recorder := web.postHttpResponse("/setup/schedule/import?matchType=qualification", "source=tba")
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	assert.Equal(t, 1, len(cachedMatches[model.Qualification]))
+	assert.True(t, cachedMatches[model.Qualification][0].Blue2IsSurrogate)
+	assert.Equal(t, "Q1", cachedTeamFirstMatches[model.Qualification][106])
+
+	// Practice schedules aren't published on TBA.
+	recorder = web.postHttpResponse("/setup/schedule/import?matchType=practice", "source=tba")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "only qualification schedules can be imported from The Blue Alliance")
+}
+
+func TestSetupScheduleOptimized(t *testing.T) {
+	web := setupTestWeb(t)
+
+	for i := 0; i < 7; i++ {
+		web.arena.Database.CreateTeam(&model.Team{Id: i + 101})
+	}
+
+	// Generate a schedule for which no pre-randomized template exists.
+	postData := "numScheduleBlocks=1&startTime0=2014-01-01 09:00:00 AM&numMatches0=20&matchSpacingSec0=360&" +
+		"matchType=practice&generator=optimized&minTurnaroundMin=5"
+	recorder := web.postHttpResponse("/setup/schedule/generate", postData)
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	assert.Equal(t, 20, len(cachedMatches[model.Practice]))
+	recorder = web.getHttpResponse("/setup/schedule?matchType=practice")
+	assert.Contains(t, recorder.Body.String(), "Schedule Quality")
+	assert.Contains(t, recorder.Body.String(), "17&ndash;18")
+
+	postData = "numScheduleBlocks=1&startTime0=2014-01-01 09:00:00 AM&numMatches0=20&matchSpacingSec0=360&" +
+		"matchType=practice&generator=optimized&minTurnaroundMin=soon"
+	recorder = web.postHttpResponse("/setup/schedule/generate", postData)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Invalid minimum turnaround time specified.")
+}
@@ -11,29 +11,26 @@ import (
 	"io/ioutil"
 	"net/http"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/game"
 	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
 )
 
 // Shows the event settings editing page.
 func (web *Web) settingsGetHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	web.renderSettings(w, r, "")
 }
 
 // Saves the event settings.
 func (web *Web) settingsPostHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	eventSettings := web.arena.EventSettings
+	previousEventSettings := *eventSettings
 
 	previousEventName := eventSettings.Name
 	eventSettings.Name = r.PostFormValue("name")
@@ -74,12 +71,23 @@ func (web *Web) settingsPostHandler(w http.ResponseWriter, r *http.Request) {
 	eventSettings.SelectionRound2Order = r.PostFormValue("selectionRound2Order")
 	eventSettings.SelectionRound3Order = r.PostFormValue("selectionRound3Order")
 	eventSettings.SelectionShowUnpickedTeams = r.PostFormValue("selectionShowUnpickedTeams") == "on"
+	eventSettings.PracticeWalkUpQueueEnabled = r.PostFormValue("practiceWalkUpQueueEnabled") == "on"
+	switch r.PostFormValue("inspectionEnforcement") {
+	case "InspectionWarning":
+		eventSettings.InspectionEnforcement = model.InspectionWarning
+	case "InspectionRequired":
+		eventSettings.InspectionEnforcement = model.InspectionRequired
+	default:
+		eventSettings.InspectionEnforcement = model.InspectionNotEnforced
+	}
 	eventSettings.TbaDownloadEnabled = r.PostFormValue("tbaDownloadEnabled") == "on"
 	eventSettings.TbaPublishingEnabled = r.PostFormValue("tbaPublishingEnabled") == "on"
 	eventSettings.TbaEventCode = r.PostFormValue("tbaEventCode")
 	eventSettings.TbaSecretId = r.PostFormValue("tbaSecretId")
 	eventSettings.TbaSecret = r.PostFormValue("tbaSecret")
 	eventSettings.NexusEnabled = r.PostFormValue("nexusEnabled") == "on"
+	eventSettings.NexusLocalServerEnabled = r.PostFormValue("nexusLocalServerEnabled") == "on"
+	eventSettings.NexusEventApiKey = r.PostFormValue("nexusEventApiKey")
 	eventSettings.NetworkSecurityEnabled = r.PostFormValue("networkSecurityEnabled") == "on"
 	eventSettings.ApAddress = r.PostFormValue("apAddress")
 	eventSettings.ApPassword = r.PostFormValue("apPassword")
@@ -94,7 +102,29 @@ func (web *Web) settingsPostHandler(w http.ResponseWriter, r *http.Request) {
 	eventSettings.SCCUpCommands = r.PostFormValue("sccUpCommands")
 	eventSettings.SCCDownCommands = r.PostFormValue("sccDownCommands")
 	eventSettings.PlcAddress = r.PostFormValue("plcAddress")
+	eventSettings.PlcSimulated = r.PostFormValue("plcSimulated") == "on"
+	if eventSettings.PlcSimulated != previousEventSettings.PlcSimulated && web.arena.MatchState != field.PreMatch {
+		*eventSettings = previousEventSettings
+		web.renderSettings(w, r, "The PLC can't be switched while a match is in progress.")
+		return
+	}
 	eventSettings.AdminPassword = r.PostFormValue("adminPassword")
+	if eventSettings.AdminPassword == "" && previousAdminPassword != "" {
+		users, err := web.arena.Database.GetAllUsers()
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if err = web.checkAdminAccessRetained(users); err != nil {
+			*eventSettings = previousEventSettings
+			web.renderSettings(w, r, err.Error())
+			return
+		}
+	}
+	eventSettings.BackupIntervalMin, _ = strconv.Atoi(r.PostFormValue("backupIntervalMin"))
+	eventSettings.BackupRetainCount, _ = strconv.Atoi(r.PostFormValue("backupRetainCount"))
+	eventSettings.BackupRetainHourly = r.PostFormValue("backupRetainHourly") == "on"
+	eventSettings.MatchLogRetainDays, _ = strconv.Atoi(r.PostFormValue("matchLogRetainDays"))
 	eventSettings.TeamSignRed1Id, _ = strconv.Atoi(r.PostFormValue("teamSignRed1Id"))
 	eventSettings.TeamSignRed2Id, _ = strconv.Atoi(r.PostFormValue("teamSignRed2Id"))
 	eventSettings.TeamSignRed3Id, _ = strconv.Atoi(r.PostFormValue("teamSignRed3Id"))
@@ -139,6 +169,13 @@ func (web *Web) settingsPostHandler(w http.ResponseWriter, r *http.Request) {
 	eventSettings.PauseDurationSec, _ = strconv.Atoi(r.PostFormValue("pauseDurationSec"))
 	eventSettings.TeleopDurationSec, _ = strconv.Atoi(r.PostFormValue("teleopDurationSec"))
 	eventSettings.WarningRemainingDurationSec, _ = strconv.Atoi(r.PostFormValue("warningRemainingDurationSec"))
+	eventSettings.GameName = r.PostFormValue("gameName")
+	if eventSettings.GameName != "" && !slices.Contains(game.GetGameNames(), eventSettings.GameName) {
+		// Discard the other unsaved changes too, since the arena can't load settings that name an unknown game.
+		*eventSettings = previousEventSettings
+		web.renderSettings(w, r, fmt.Sprintf("Unknown game: %s", r.PostFormValue("gameName")))
+		return
+	}
 	eventSettings.AutoBonusCoralThreshold, _ = strconv.Atoi(r.PostFormValue("autoBonusCoralThreshold"))
 	eventSettings.CoralBonusPerLevelThreshold, _ = strconv.Atoi(r.PostFormValue("coralBonusPerLevelThreshold"))
 	eventSettings.CoralBonusCoopEnabled = r.PostFormValue("coralBonusCoopEnabled") == "on"
@@ -150,6 +187,7 @@ func (web *Web) settingsPostHandler(w http.ResponseWriter, r *http.Request) {
 		handleWebErr(w, err)
 		return
 	}
+	web.recordAuditEntry(r, "settings.edit", "", previousEventSettings, *eventSettings)
 
 	// Refresh the arena in case any of the settings changed.
 	err = web.arena.LoadSettings()
@@ -158,9 +196,13 @@ func (web *Web) settingsPostHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if eventSettings.PracticeWalkUpQueueEnabled != previousEventSettings.PracticeWalkUpQueueEnabled {
+		web.arena.PracticeQueueNotifier.Notify()
+	}
+
 	if eventSettings.AdminPassword != previousAdminPassword {
-		// Delete any existing user sessions to force a logout.
-		if err := web.arena.Database.TruncateUserSessions(); err != nil {
+		// Delete any existing admin sessions to force a logout.
+		if err := web.arena.Database.DeleteUserSessionsForUsername(adminUser); err != nil {
 			handleWebErr(w, err)
 			return
 		}
@@ -171,10 +213,6 @@ func (web *Web) settingsPostHandler(w http.ResponseWriter, r *http.Request) {
 
 // Sends a copy of the event database file to the client as a download.
 func (web *Web) saveDbHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	filename := fmt.Sprintf(
 		"%s-%s.db", strings.Replace(web.arena.EventSettings.Name, " ", "_", -1), time.Now().Format("20060102150405"),
 	)
@@ -186,17 +224,33 @@ func (web *Web) saveDbHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// Accepts an event database file as an upload and loads it.
+// Accepts an event database file, either as an upload or as the name of a backup on disk, and loads it.
 func (web *Web) restoreDbHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
-	file, _, err := r.FormFile("databaseFile")
-	if err != nil {
-		web.renderSettings(w, r, "No database backup file was specified.")
-		return
+	var file io.ReadCloser
+	if backupFilename := r.PostFormValue("backupFilename"); backupFilename != "" {
+		backup, err := model.GetBackup(backupFilename)
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if backup == nil {
+			web.renderSettings(w, r, fmt.Sprintf("No such database backup: %s", backupFilename))
+			return
+		}
+		file, err = os.Open(backup.Path)
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+	} else {
+		var err error
+		file, _, err = r.FormFile("databaseFile")
+		if err != nil {
+			web.renderSettings(w, r, "No database backup file was specified.")
+			return
+		}
 	}
+	defer file.Close()
 
 	// Write the file to a temporary location on disk and verify that it can be opened as a database.
 	tempFile, err := ioutil.TempFile(".", "uploaded-db-")
@@ -216,7 +270,11 @@ func (web *Web) restoreDbHandler(w http.ResponseWriter, r *http.Request) {
 	tempDb, err := model.OpenDatabase(tempFilePath)
 	if err != nil {
 		web.renderSettings(
-			w, r, "Could not read uploaded database backup file. Please verify that it a valid database file.",
+			w,
+			r,
+			fmt.Sprintf(
+				"Could not read uploaded database backup file. Please verify that it a valid database file: %v", err,
+			),
 		)
 		return
 	}
@@ -251,16 +309,13 @@ func (web *Web) restoreDbHandler(w http.ResponseWriter, r *http.Request) {
 		handleWebErr(w, err)
 		return
 	}
+	web.recordAuditEntry(r, "db.restore", r.PostFormValue("backupFilename"), nil, nil)
 
 	http.Redirect(w, r, "/setup/settings", 303)
 }
 
 // Deletes all match data including and beyond the given tournament stage.
 func (web *Web) clearDbHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	matchType, err := model.MatchTypeFromString(r.PathValue("type"))
 	if err != nil || matchType == model.Test {
 		web.renderSettings(w, r, "Invalid tournament stage to clear.")
@@ -281,6 +336,11 @@ func (web *Web) clearDbHandler(w http.ResponseWriter, r *http.Request) {
 			handleWebErr(w, err)
 			return
 		}
+		if err = web.arena.Database.TruncatePracticeQueueEntries(); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		web.arena.PracticeQueueNotifier.Notify()
 	case model.Qualification:
 		if err = web.deleteMatchDataForType(model.Qualification); err != nil {
 			handleWebErr(w, err)
@@ -302,113 +362,34 @@ func (web *Web) clearDbHandler(w http.ResponseWriter, r *http.Request) {
 		web.arena.AllianceSelectionAlliances = []model.Alliance{}
 		web.arena.AllianceSelectionRankedTeams = []model.AllianceSelectionRankedTeam{}
 	}
+	web.recordAuditEntry(r, "db.clear", matchType.String(), nil, nil)
 
 	http.Redirect(w, r, "/setup/settings", 303)
 }
 
 // Publishes the playoff alliances to the web.
 func (web *Web) settingsPublishAlliancesHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
-	if web.arena.EventSettings.TbaPublishingEnabled {
-		err := web.arena.TbaClient.PublishAlliances(web.arena.Database)
-		if err != nil {
-			http.Error(w, "Failed to publish alliances: "+err.Error(), 500)
-			return
-		}
-	} else {
-		http.Error(w, "TBA publishing is not enabled", 500)
-		return
-	}
-
-	http.Redirect(w, r, "/setup/settings", 303)
+	web.enqueueTbaPublish(w, r, partner.TbaPublishAlliances, false)
 }
 
 // Publishes the awards to the web.
 func (web *Web) settingsPublishAwardsHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
-	if web.arena.EventSettings.TbaPublishingEnabled {
-		err := web.arena.TbaClient.PublishAwards(web.arena.Database)
-		if err != nil {
-			http.Error(w, "Failed to publish awards: "+err.Error(), 500)
-			return
-		}
-	} else {
-		http.Error(w, "TBA publishing is not enabled", 500)
-		return
-	}
-
-	http.Redirect(w, r, "/setup/settings", 303)
+	web.enqueueTbaPublish(w, r, partner.TbaPublishAwards, false)
 }
 
-// Publishes the match schedule and results to the web.
+// Publishes the match schedule and results to the web, replacing any previously published matches.
 func (web *Web) settingsPublishMatchesHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
-	if web.arena.EventSettings.TbaPublishingEnabled {
-		err := web.arena.TbaClient.DeletePublishedMatches()
-		if err != nil {
-			http.Error(w, "Failed to delete published matches: "+err.Error(), 500)
-			return
-		}
-		err = web.arena.TbaClient.PublishMatches(web.arena.Database)
-		if err != nil {
-			http.Error(w, "Failed to publish matches: "+err.Error(), 500)
-			return
-		}
-	} else {
-		http.Error(w, "TBA publishing is not enabled", 500)
-		return
-	}
-
-	http.Redirect(w, r, "/setup/settings", 303)
+	web.enqueueTbaPublish(w, r, partner.TbaPublishMatches, true)
 }
 
 // Publishes the standings to the web.
 func (web *Web) settingsPublishRankingsHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
-	if web.arena.EventSettings.TbaPublishingEnabled {
-		err := web.arena.TbaClient.PublishRankings(web.arena.Database)
-		if err != nil {
-			http.Error(w, "Failed to publish rankings: "+err.Error(), 500)
-			return
-		}
-	} else {
-		http.Error(w, "TBA publishing is not enabled", 500)
-		return
-	}
-
-	http.Redirect(w, r, "/setup/settings", 303)
+	web.enqueueTbaPublish(w, r, partner.TbaPublishRankings, false)
 }
 
 // Publishes the team list to the web.
 func (web *Web) settingsPublishTeamsHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
-	if web.arena.EventSettings.TbaPublishingEnabled {
-		err := web.arena.TbaClient.PublishTeams(web.arena.Database)
-		if err != nil {
-			http.Error(w, "Failed to publish teams: "+err.Error(), 500)
-			return
-		}
-	} else {
-		http.Error(w, "TBA publishing is not enabled", 500)
-		return
-	}
-
-	http.Redirect(w, r, "/setup/settings", 303)
+	web.enqueueTbaPublish(w, r, partner.TbaPublishTeams, false)
 }
 
 func (web *Web) renderSettings(w http.ResponseWriter, r *http.Request, errorMessage string) {
@@ -417,10 +398,17 @@ func (web *Web) renderSettings(w http.ResponseWriter, r *http.Request, errorMess
 		handleWebErr(w, err)
 		return
 	}
+	backups, err := model.ListBackups()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
 	data := struct {
 		*model.EventSettings
+		GameNames    []string
+		Backups      []model.BackupFile
 		ErrorMessage string
-	}{web.arena.EventSettings, errorMessage}
+	}{web.arena.EventSettings, game.GetGameNames(), backups, errorMessage}
 	err = template.ExecuteTemplate(w, "base", data)
 	if err != nil {
 		handleWebErr(w, err)
@@ -428,7 +416,7 @@ func (web *Web) renderSettings(w http.ResponseWriter, r *http.Request, errorMess
 	}
 }
 
-// Deletes all match data (matches, results, and scheduled breaks) for the given match type.
+// Deletes all match data (matches, results, event logs, score snapshots, and scheduled breaks) for the given match type.
 func (web *Web) deleteMatchDataForType(matchType model.MatchType) error {
 	matches, err := web.arena.Database.GetMatchesByType(matchType, true)
 	if err != nil {
@@ -449,6 +437,12 @@ func (web *Web) deleteMatchDataForType(matchType model.MatchType) error {
 				return err
 			}
 		}
+		if err = web.arena.Database.DeleteMatchEventsForMatch(match.Id); err != nil {
+			return err
+		}
+		if err = web.arena.Database.DeleteScoreSnapshotsForMatch(match.Id); err != nil {
+			return err
+		}
 
 		if err = web.arena.Database.DeleteMatch(match.Id); err != nil {
 			return err
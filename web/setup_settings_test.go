@@ -5,14 +5,18 @@ package web
 
 import (
 	"bytes"
+	"github.com/Team254/cheesy-arena/field"
 	"github.com/Team254/cheesy-arena/game"
 	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
 	"github.com/Team254/cheesy-arena/tournament"
 	"github.com/stretchr/testify/assert"
 	"io"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"strings"
 	"testing"
 )
 
@@ -40,6 +44,11 @@ func TestSetupSettings(t *testing.T) {
 	assert.Contains(t, recorder.Body.String(), "2014cc")
 	assert.Contains(t, recorder.Body.String(), "secretId")
 	assert.Contains(t, recorder.Body.String(), "tbasec")
+	assert.Equal(t, model.InspectionNotEnforced, web.arena.EventSettings.InspectionEnforcement)
+
+	recorder = web.postHttpResponse("/setup/settings", "inspectionEnforcement=InspectionRequired")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, model.InspectionRequired, web.arena.EventSettings.InspectionEnforcement)
 }
 
 func TestSetupSettingsDoubleElimination(t *testing.T) {
@@ -60,6 +69,26 @@ func TestSetupSettingsInvalidValues(t *testing.T) {
 	recorder = web.postHttpResponse("/setup/settings", "playoffType=SingleEliminationPlayoff&numAlliances=1")
 	assert.Contains(t, recorder.Body.String(), "must be between 2 and 16")
 
+	// Selecting a game that isn't registered.
+	recorder = web.postHttpResponse(
+		"/setup/settings",
+		"playoffType=SingleEliminationPlayoff&numPlayoffAlliances=8&name=Bogus Event&gameName=Nonexistent",
+	)
+	assert.Contains(t, recorder.Body.String(), "Unknown game: Nonexistent")
+	assert.Equal(t, "Untitled Event", web.arena.EventSettings.Name)
+	assert.Equal(t, game.DefaultGameName, game.CurrentGame().Name())
+	settings, _ := web.arena.Database.GetEventSettings()
+	assert.NotEqual(t, "Nonexistent", settings.GameName)
+
+	// Switching the PLC while a match is in progress.
+	web.arena.MatchState = field.AutoPeriod
+	recorder = web.postHttpResponse(
+		"/setup/settings", "playoffType=SingleEliminationPlayoff&numPlayoffAlliances=8&plcSimulated=on",
+	)
+	assert.Contains(t, recorder.Body.String(), "The PLC can't be switched while a match is in progress.")
+	assert.False(t, web.arena.EventSettings.PlcSimulated)
+	web.arena.MatchState = field.PreMatch
+
 	// Changing the playoff type after alliance selection is finalized.
 	assert.Nil(t, web.arena.Database.CreateAlliance(&model.Alliance{Id: 1}))
 	recorder = web.postHttpResponse("/setup/settings", "playoffType=DoubleEliminationPlayoff")
@@ -207,31 +236,83 @@ func TestSetupSettingsBackupRestoreDb(t *testing.T) {
 	assert.Equal(t, "Chezy Champs", web.arena.EventSettings.Name)
 }
 
-func TestSetupSettingsPublishToTba(t *testing.T) {
+func TestSetupSettingsRestoreAutomaticBackup(t *testing.T) {
 	web := setupTestWeb(t)
 
-	web.arena.TbaClient.BaseUrl = "fakeurl"
-	web.arena.EventSettings.TbaPublishingEnabled = true
+	// Take a backup and then modify a parameter so that we know when the database has been restored.
+	web.arena.EventSettings.Name = "Chezy Champs"
+	assert.Nil(t, web.arena.Database.UpdateEventSettings(web.arena.EventSettings))
+	assert.Nil(t, web.arena.BackupScheduler.Backup(web.arena.Database, "Restore Test", "periodic"))
+	backups, err := model.ListBackups()
+	assert.Nil(t, err)
+	var backup *model.BackupFile
+	for _, listedBackup := range backups {
+		if strings.HasPrefix(listedBackup.Filename, "Restore_Test_") {
+			backup = &listedBackup
+			break
+		}
+	}
+	if !assert.NotNil(t, backup) {
+		return
+	}
+	defer os.Remove(backup.Path)
+	web.arena.EventSettings.Name = "Chezy Slams"
+	assert.Nil(t, web.arena.Database.UpdateEventSettings(web.arena.EventSettings))
 
-	recorder := web.getHttpResponse("/setup/settings/publish_alliances")
-	assert.Equal(t, 500, recorder.Code)
-	assert.Contains(t, recorder.Body.String(), "Failed to publish alliances")
+	recorder := web.getHttpResponse("/setup/settings")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), backup.Filename)
 
-	recorder = web.getHttpResponse("/setup/settings/publish_awards")
-	assert.Equal(t, 500, recorder.Code)
-	assert.Contains(t, recorder.Body.String(), "Failed to publish awards")
+	// Check restoring a backup that doesn't exist or is outside the backups directory.
+	recorder = web.postHttpResponse("/setup/db/restore", "backupFilename=../../cheesy-arena.db")
+	assert.Contains(t, recorder.Body.String(), "No such database backup")
+	assert.Equal(t, "Chezy Slams", web.arena.EventSettings.Name)
 
-	recorder = web.getHttpResponse("/setup/settings/publish_matches")
-	assert.Equal(t, 500, recorder.Code)
-	assert.Contains(t, recorder.Body.String(), "Failed to delete published matches")
+	recorder = web.postHttpResponse("/setup/db/restore", "backupFilename="+backup.Filename)
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, "Chezy Champs", web.arena.EventSettings.Name)
+	_, err = os.Stat(backup.Path)
+	assert.Nil(t, err)
+}
 
-	recorder = web.getHttpResponse("/setup/settings/publish_rankings")
-	assert.Equal(t, 500, recorder.Code)
-	assert.Contains(t, recorder.Body.String(), "Failed to publish rankings")
+func TestSetupSettingsPublishToTba(t *testing.T) {
+	web := setupTestWeb(t)
 
-	recorder = web.getHttpResponse("/setup/settings/publish_teams")
+	recorder := web.getHttpResponse("/setup/settings/publish_teams")
 	assert.Equal(t, 500, recorder.Code)
-	assert.Contains(t, recorder.Body.String(), "Failed to publish teams")
+	assert.Contains(t, recorder.Body.String(), "TBA publishing is not enabled")
+
+	web.arena.TbaClient.BaseUrl = "fakeurl"
+	web.arena.EventSettings.TbaPublishingEnabled = true
+	for _, publishType := range []string{"alliances", "awards", "matches", "rankings", "teams"} {
+		recorder = web.getHttpResponse("/setup/settings/publish_" + publishType)
+		assert.Equal(t, 303, recorder.Code, publishType)
+		assert.Equal(t, "/setup/tba_publishing", recorder.Header().Get("Location"))
+		item, _ := web.arena.Database.GetTbaPublishItemByType(publishType)
+		if assert.NotNil(t, item) {
+			assert.True(t, item.Pending)
+			assert.Equal(t, publishType == "matches", item.ClearExisting)
+		}
+	}
+	recorder = web.getHttpResponse("/setup/tba_publishing")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Pending")
+
+	// Check that failures are shown on the status page and that the items remain pending.
+	for i := 0; i < partner.TbaPublishFailureThreshold; i++ {
+		assert.Nil(t, web.arena.TbaPublisher.RetryNow(web.arena.Database))
+		web.arena.TbaPublisher.PublishDue(web.arena.Database, web.arena.TbaClient)
+	}
+	recorder = web.getHttpResponse("/setup/tba_publishing")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Failed")
+	assert.Contains(t, recorder.Body.String(), "failed to delete published matches")
+	item, _ := web.arena.Database.GetTbaPublishItemByType("teams")
+	assert.True(t, item.Pending)
+	assert.Equal(t, partner.TbaPublishFailureThreshold, item.Attempts)
+
+	recorder = web.postHttpResponse("/setup/tba_publishing/retry", "")
+	assert.Equal(t, 303, recorder.Code)
 }
 
 func (web *Web) postFileHttpResponse(path string, paramName string, file *bytes.Buffer) *httptest.ResponseRecorder {
@@ -13,10 +13,6 @@ import (
 
 // Shows the sponsor slides configuration page.
 func (web *Web) sponsorSlidesGetHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	template, err := web.parseFiles("templates/setup_sponsor_slides.html", "templates/base.html")
 	if err != nil {
 		handleWebErr(w, err)
@@ -44,10 +40,6 @@ func (web *Web) sponsorSlidesGetHandler(w http.ResponseWriter, r *http.Request)
 
 // Saves the new or modified sponsor slides to the database.
 func (web *Web) sponsorSlidesPostHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	sponsorSlideId, _ := strconv.Atoi(r.PostFormValue("id"))
 	sponsorSlide, err := web.arena.Database.GetSponsorSlideById(sponsorSlideId)
 	if err != nil {
@@ -61,8 +53,10 @@ func (web *Web) sponsorSlidesPostHandler(w http.ResponseWriter, r *http.Request)
 			handleWebErr(w, err)
 			return
 		}
+		web.recordAuditEntry(r, "sponsor_slide.delete", strconv.Itoa(sponsorSlide.Id), *sponsorSlide, nil)
 	case "save":
 		displayTimeSec, _ := strconv.Atoi(r.PostFormValue("displayTimeSec"))
+		var previousSponsorSlide any
 		if sponsorSlide == nil {
 			sponsorSlide = &model.SponsorSlide{
 				Subtitle:       r.PostFormValue("subtitle"),
@@ -74,6 +68,7 @@ func (web *Web) sponsorSlidesPostHandler(w http.ResponseWriter, r *http.Request)
 			}
 			err = web.arena.Database.CreateSponsorSlide(sponsorSlide)
 		} else {
+			previousSponsorSlide = *sponsorSlide
 			sponsorSlide.Subtitle = r.PostFormValue("subtitle")
 			sponsorSlide.Line1 = r.PostFormValue("line1")
 			sponsorSlide.Line2 = r.PostFormValue("line2")
@@ -85,6 +80,9 @@ func (web *Web) sponsorSlidesPostHandler(w http.ResponseWriter, r *http.Request)
 			handleWebErr(w, err)
 			return
 		}
+		web.recordAuditEntry(
+			r, "sponsor_slide.save", strconv.Itoa(sponsorSlide.Id), previousSponsorSlide, *sponsorSlide,
+		)
 	case "reorderUp":
 		if err = web.reorderSponsorSlide(sponsorSlideId, true); err != nil {
 			handleWebErr(w, err)
@@ -0,0 +1,101 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web routes for queueing data to be published to The Blue Alliance and monitoring the state of the outbox.
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
+	"net/http"
+)
+
+// Represents the publishing state of one type of data, for display on the status page.
+type TbaPublishStatus struct {
+	Type partner.TbaPublishType
+	model.TbaPublishItem
+	Failed bool
+}
+
+// Shows the state of the TBA publishing outbox.
+func (web *Web) tbaPublishingGetHandler(w http.ResponseWriter, r *http.Request) {
+	web.renderTbaPublishing(w, r, "")
+}
+
+// Makes all pending publishes be retried immediately.
+func (web *Web) tbaPublishingRetryPostHandler(w http.ResponseWriter, r *http.Request) {
+	if err := web.arena.TbaPublisher.RetryNow(web.arena.Database); err != nil {
+		web.renderTbaPublishing(w, r, err.Error())
+		return
+	}
+	web.recordAuditEntry(r, "tba.retry_publishing", "", nil, nil)
+	http.Redirect(w, r, "/setup/tba_publishing", 303)
+}
+
+// Queues the given type of data to be published to TBA and redirects to the publishing status page.
+func (web *Web) enqueueTbaPublish(
+	w http.ResponseWriter, r *http.Request, publishType partner.TbaPublishType, clearExisting bool,
+) {
+	if !web.arena.EventSettings.TbaPublishingEnabled {
+		http.Error(w, "TBA publishing is not enabled", 500)
+		return
+	}
+	if err := web.arena.TbaPublisher.Enqueue(web.arena.Database, publishType, clearExisting); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	web.recordAuditEntry(r, fmt.Sprintf("tba.publish_%s", publishType), "", nil, nil)
+	http.Redirect(w, r, "/setup/tba_publishing", 303)
+}
+
+func (web *Web) renderTbaPublishing(w http.ResponseWriter, r *http.Request, errorMessage string) {
+	template, err := web.parseFiles("templates/setup_tba_publishing.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	statuses, err := web.getTbaPublishStatuses()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		Statuses     []TbaPublishStatus
+		ErrorMessage string
+	}{web.arena.EventSettings, statuses, errorMessage}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Returns the publishing state of each type of data, including those that have never been published.
+func (web *Web) getTbaPublishStatuses() ([]TbaPublishStatus, error) {
+	items, err := web.arena.Database.GetAllTbaPublishItems()
+	if err != nil {
+		return nil, err
+	}
+	itemsByType := make(map[string]model.TbaPublishItem, len(items))
+	for _, item := range items {
+		itemsByType[item.Type] = item
+	}
+
+	var statuses []TbaPublishStatus
+	for _, publishType := range partner.TbaPublishTypes {
+		item := itemsByType[string(publishType)]
+		statuses = append(
+			statuses,
+			TbaPublishStatus{
+				Type:           publishType,
+				TbaPublishItem: item,
+				Failed:         item.Attempts >= partner.TbaPublishFailureThreshold,
+			},
+		)
+	}
+	return statuses, nil
+}
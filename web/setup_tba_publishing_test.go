@@ -0,0 +1,81 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSetupTbaPublishing(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/setup/tba_publishing")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "TBA publishing is disabled in the settings")
+	assert.Contains(t, recorder.Body.String(), "Never Published")
+	assert.NotContains(t, recorder.Body.String(), "Failed")
+
+	// Check that an item that has failed repeatedly is shown as such.
+	nextAttemptAt := time.Now().Add(time.Hour)
+	item := model.TbaPublishItem{
+		Type:          string(partner.TbaPublishRankings),
+		Pending:       true,
+		Attempts:      partner.TbaPublishFailureThreshold,
+		NextAttemptAt: nextAttemptAt,
+		LastError:     "TBA returned status 500",
+	}
+	assert.Nil(t, web.arena.Database.CreateTbaPublishItem(&item))
+	web.arena.EventSettings.TbaPublishingEnabled = true
+	recorder = web.getHttpResponse("/setup/tba_publishing")
+	assert.Equal(t, 200, recorder.Code)
+	assert.NotContains(t, recorder.Body.String(), "TBA publishing is disabled in the settings")
+	assert.Contains(t, recorder.Body.String(), "Failed")
+	assert.Contains(t, recorder.Body.String(), "TBA returned status 500")
+
+	// Check that retrying makes the failed item due immediately.
+	recorder = web.postHttpResponse("/setup/tba_publishing/retry", "")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, "/setup/tba_publishing", recorder.Header().Get("Location"))
+	updatedItem, _ := web.arena.Database.GetTbaPublishItemByType(item.Type)
+	if assert.NotNil(t, updatedItem) {
+		assert.True(t, updatedItem.Pending)
+		assert.True(t, updatedItem.NextAttemptAt.Before(nextAttemptAt))
+		assert.False(t, updatedItem.NextAttemptAt.After(time.Now()))
+	}
+	auditEntries, _ := web.arena.Database.GetAllAuditEntries()
+	if assert.Equal(t, 1, len(auditEntries)) {
+		assert.Equal(t, "tba.retry_publishing", auditEntries[0].Action)
+	}
+}
+
+func TestSetupTbaPublishingRequiresAdmin(t *testing.T) {
+	web := setupTestWeb(t)
+	nextAttemptAt := time.Now().Add(time.Hour)
+	item := model.TbaPublishItem{
+		Type: string(partner.TbaPublishTeams), Pending: true, Attempts: 1, NextAttemptAt: nextAttemptAt,
+	}
+	assert.Nil(t, web.arena.Database.CreateTbaPublishItem(&item))
+	scorekeeper := model.User{Username: "scorekeeper", Role: model.ScorekeeperRole}
+	assert.Nil(t, scorekeeper.SetPassword("field"))
+	assert.Nil(t, web.arena.Database.CreateUser(&scorekeeper))
+
+	recorder := web.getHttpResponse("/setup/tba_publishing")
+	assert.Equal(t, 307, recorder.Code)
+	recorder = web.postHttpResponse("/login", "username=scorekeeper&password=field")
+	assert.Equal(t, 303, recorder.Code)
+	headers := map[string]string{"Cookie": recorder.Header().Get("Set-Cookie")}
+
+	recorder = web.getHttpResponseWithHeaders("/setup/tba_publishing", headers)
+	assert.Equal(t, 403, recorder.Code)
+	recorder = web.postHttpResponseWithHeaders("/setup/tba_publishing/retry", "", headers)
+	assert.Equal(t, 403, recorder.Code)
+	updatedItem, _ := web.arena.Database.GetTbaPublishItemByType(item.Type)
+	if assert.NotNil(t, updatedItem) {
+		assert.True(t, updatedItem.NextAttemptAt.Equal(nextAttemptAt))
+	}
+}
@@ -19,24 +19,19 @@ import (
 
 const wpaKeyLength = 8
 
+// Layout of the times submitted by the datetime-local inputs on the team edit page.
+const unavailabilityTimeLayout = "2006-01-02T15:04"
+
 // Global var to hold the team download progress percentage.
 var progressPercentage float64 = 5
 
 // Shows the team list.
 func (web *Web) teamsGetHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	web.renderTeams(w, r, false)
 }
 
 // Adds teams to the team list.
 func (web *Web) teamsPostHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	if !web.canModifyTeamList() {
 		web.renderTeams(w, r, true)
 		return
@@ -65,6 +60,8 @@ func (web *Web) teamsPostHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		web.recordAuditEntry(r, "team.add", strconv.Itoa(team.Id), nil, team)
+
 		progressPercentage += progressIncrement
 	}
 	progressPercentage = 100
@@ -74,10 +71,6 @@ func (web *Web) teamsPostHandler(w http.ResponseWriter, r *http.Request) {
 
 // Re-downloads the data for all teams from TBA and overwrites any local edits.
 func (web *Web) teamsRefreshHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	teams, err := web.arena.Database.GetAllTeams()
 	if err != nil {
 		handleWebErr(w, err)
@@ -87,6 +80,7 @@ func (web *Web) teamsRefreshHandler(w http.ResponseWriter, r *http.Request) {
 	progInc := 95.00 / float64(len(teams))
 
 	for _, team := range teams {
+		previousTeam := team
 		if err = web.populateOfficialTeamInfo(&team); err != nil {
 			handleWebErr(w, err)
 			return
@@ -95,6 +89,7 @@ func (web *Web) teamsRefreshHandler(w http.ResponseWriter, r *http.Request) {
 			handleWebErr(w, err)
 			return
 		}
+		web.recordAuditEntry(r, "team.refresh", strconv.Itoa(team.Id), previousTeam, team)
 
 		progressPercentage += progInc
 	}
@@ -106,10 +101,6 @@ func (web *Web) teamsRefreshHandler(w http.ResponseWriter, r *http.Request) {
 
 // Clears the team list.
 func (web *Web) teamsClearHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	if !web.canModifyTeamList() {
 		web.renderTeams(w, r, true)
 		return
@@ -120,15 +111,16 @@ func (web *Web) teamsClearHandler(w http.ResponseWriter, r *http.Request) {
 		handleWebErr(w, err)
 		return
 	}
+	if err = web.arena.Database.TruncateTeamUnavailabilities(); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	web.recordAuditEntry(r, "teams.clear", "", nil, nil)
 	http.Redirect(w, r, "/setup/teams", 303)
 }
 
 // Shows the page to edit a team's fields.
 func (web *Web) teamEditGetHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	teamId, _ := strconv.Atoi(r.PathValue("id"))
 	team, err := web.arena.Database.GetTeamById(teamId)
 	if err != nil {
@@ -140,28 +132,11 @@ func (web *Web) teamEditGetHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	template, err := web.parseFiles("templates/edit_team.html", "templates/base.html")
-	if err != nil {
-		handleWebErr(w, err)
-		return
-	}
-	data := struct {
-		*model.EventSettings
-		*model.Team
-	}{web.arena.EventSettings, team}
-	err = template.ExecuteTemplate(w, "base", data)
-	if err != nil {
-		handleWebErr(w, err)
-		return
-	}
+	web.renderTeamEdit(w, r, team, "")
 }
 
 // Updates a team's fields.
 func (web *Web) teamEditPostHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	teamId, _ := strconv.Atoi(r.PathValue("id"))
 	team, err := web.arena.Database.GetTeamById(teamId)
 	if err != nil {
@@ -173,6 +148,7 @@ func (web *Web) teamEditPostHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	previousTeam := *team
 	team.Name = r.PostFormValue("name")
 	team.Nickname = r.PostFormValue("nickname")
 	team.City = r.PostFormValue("city")
@@ -195,15 +171,12 @@ func (web *Web) teamEditPostHandler(w http.ResponseWriter, r *http.Request) {
 		handleWebErr(w, err)
 		return
 	}
+	web.recordAuditEntry(r, "team.edit", strconv.Itoa(team.Id), previousTeam, *team)
 	http.Redirect(w, r, "/setup/teams", 303)
 }
 
 // Removes a team from the team list.
 func (web *Web) teamDeletePostHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	if !web.canModifyTeamList() {
 		web.renderTeams(w, r, true)
 		return
@@ -224,15 +197,80 @@ func (web *Web) teamDeletePostHandler(w http.ResponseWriter, r *http.Request) {
 		handleWebErr(w, err)
 		return
 	}
+	if err = web.arena.Database.DeleteTeamUnavailabilitiesByTeam(team.Id); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	web.recordAuditEntry(r, "team.delete", strconv.Itoa(team.Id), *team, nil)
 	http.Redirect(w, r, "/setup/teams", 303)
 }
 
-// Generates random WPA keys and saves them to the team models.
-func (web *Web) teamsGenerateWpaKeysHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
+// Adds a window of time during which a team is unavailable for matches and judging visits.
+func (web *Web) teamUnavailabilityPostHandler(w http.ResponseWriter, r *http.Request) {
+	teamId, _ := strconv.Atoi(r.PathValue("id"))
+	team, err := web.arena.Database.GetTeamById(teamId)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if team == nil {
+		http.Error(w, fmt.Sprintf("Error: No such team: %d", teamId), 400)
+		return
+	}
+
+	startTime, err := time.ParseInLocation(unavailabilityTimeLayout, r.PostFormValue("startTime"), time.Local)
+	if err != nil {
+		web.renderTeamEdit(w, r, team, "Invalid start time specified.")
+		return
+	}
+	endTime, err := time.ParseInLocation(unavailabilityTimeLayout, r.PostFormValue("endTime"), time.Local)
+	if err != nil {
+		web.renderTeamEdit(w, r, team, "Invalid end time specified.")
+		return
+	}
+	if !endTime.After(startTime) {
+		web.renderTeamEdit(w, r, team, "End time must be after start time.")
+		return
+	}
+
+	unavailability := model.TeamUnavailability{
+		TeamId:    team.Id,
+		StartTime: startTime,
+		EndTime:   endTime,
+		Reason:    strings.TrimSpace(r.PostFormValue("reason")),
+	}
+	if err = web.arena.Database.CreateTeamUnavailability(&unavailability); err != nil {
+		handleWebErr(w, err)
 		return
 	}
+	web.recordAuditEntry(r, "team.unavailability.add", strconv.Itoa(team.Id), nil, unavailability)
+	http.Redirect(w, r, fmt.Sprintf("/setup/teams/%d/edit", team.Id), 303)
+}
 
+// Removes one of a team's unavailability windows.
+func (web *Web) teamUnavailabilityDeletePostHandler(w http.ResponseWriter, r *http.Request) {
+	teamId, _ := strconv.Atoi(r.PathValue("id"))
+	unavailabilityId, _ := strconv.Atoi(r.PathValue("unavailabilityId"))
+	unavailability, err := web.arena.Database.GetTeamUnavailabilityById(unavailabilityId)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if unavailability == nil || unavailability.TeamId != teamId {
+		http.Error(w, fmt.Sprintf("Error: No such unavailability for team %d: %d", teamId, unavailabilityId), 400)
+		return
+	}
+
+	if err = web.arena.Database.DeleteTeamUnavailability(unavailability.Id); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	web.recordAuditEntry(r, "team.unavailability.delete", strconv.Itoa(teamId), *unavailability, nil)
+	http.Redirect(w, r, fmt.Sprintf("/setup/teams/%d/edit", teamId), 303)
+}
+
+// Generates random WPA keys and saves them to the team models.
+func (web *Web) teamsGenerateWpaKeysHandler(w http.ResponseWriter, r *http.Request) {
 	generateAllKeys := false
 	if all, ok := r.URL.Query()["all"]; ok {
 		generateAllKeys = all[0] == "true"
@@ -249,6 +287,7 @@ func (web *Web) teamsGenerateWpaKeysHandler(w http.ResponseWriter, r *http.Reque
 			web.arena.Database.UpdateTeam(&team)
 		}
 	}
+	web.recordAuditEntry(r, "teams.generate_wpa_keys", "", nil, nil)
 
 	http.Redirect(w, r, "/setup/teams", 303)
 }
@@ -283,6 +322,31 @@ func (web *Web) renderTeams(w http.ResponseWriter, r *http.Request, showErrorMes
 	}
 }
 
+func (web *Web) renderTeamEdit(w http.ResponseWriter, r *http.Request, team *model.Team, errorMessage string) {
+	unavailabilities, err := web.arena.Database.GetTeamUnavailabilitiesByTeam(team.Id)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	template, err := web.parseFiles("templates/edit_team.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		*model.Team
+		Unavailabilities []model.TeamUnavailability
+		ErrorMessage     string
+	}{web.arena.EventSettings, team, unavailabilities, errorMessage}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
 // Returns true if it is safe to change the team list (i.e. no matches/results exist yet).
 func (web *Web) canModifyTeamList() bool {
 	matches, err := web.arena.Database.GetMatchesByType(model.Qualification, true)
@@ -11,6 +11,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestSetupTeams(t *testing.T) {
@@ -218,6 +219,65 @@ func TestSetupTeamsWpaKeys(t *testing.T) {
 	assert.Contains(t, recorder.Body.String(), "WPA key must be between 8 and 63 characters")
 }
 
+func TestSetupTeamsUnavailability(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.Database.CreateTeam(&model.Team{Id: 254})
+	web.arena.Database.CreateTeam(&model.Team{Id: 1114})
+
+	// Add an unavailability window and check that it is shown on the team edit page.
+	recorder := web.postHttpResponse(
+		"/setup/teams/254/unavailability",
+		"startTime=2025-04-16T10:00&endTime=2025-04-16T11:30&reason=Robot+inspection",
+	)
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	assert.Equal(t, "/setup/teams/254/edit", recorder.Header().Get("Location"))
+	unavailabilities, _ := web.arena.Database.GetTeamUnavailabilitiesByTeam(254)
+	if assert.Equal(t, 1, len(unavailabilities)) {
+		assert.Equal(t, time.Date(2025, 4, 16, 10, 0, 0, 0, time.Local).Unix(), unavailabilities[0].StartTime.Unix())
+		assert.Equal(t, time.Date(2025, 4, 16, 11, 30, 0, 0, time.Local).Unix(), unavailabilities[0].EndTime.Unix())
+		assert.Equal(t, "Robot inspection", unavailabilities[0].Reason)
+	}
+	recorder = web.getHttpResponse("/setup/teams/254/edit")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Robot inspection")
+
+	// Check that invalid windows are rejected.
+	recorder = web.postHttpResponse("/setup/teams/254/unavailability", "startTime=blorpy&endTime=2025-04-16T11:30")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Invalid start time specified.")
+	recorder = web.postHttpResponse(
+		"/setup/teams/254/unavailability", "startTime=2025-04-16T11:30&endTime=2025-04-16T10:00",
+	)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "End time must be after start time.")
+	assert.Contains(t, recorder.Body.String(), "Robot inspection")
+	recorder = web.postHttpResponse(
+		"/setup/teams/9999/unavailability", "startTime=2025-04-16T10:00&endTime=2025-04-16T11:30",
+	)
+	assert.Equal(t, 400, recorder.Code)
+
+	// Check that a window can't be deleted through another team.
+	recorder = web.postHttpResponse(
+		fmt.Sprintf("/setup/teams/1114/unavailability/%d/delete", unavailabilities[0].Id), "",
+	)
+	assert.Equal(t, 400, recorder.Code)
+	recorder = web.postHttpResponse(
+		fmt.Sprintf("/setup/teams/254/unavailability/%d/delete", unavailabilities[0].Id), "",
+	)
+	assert.Equal(t, 303, recorder.Code)
+	unavailabilities, _ = web.arena.Database.GetTeamUnavailabilitiesByTeam(254)
+	assert.Empty(t, unavailabilities)
+
+	// Check that deleting a team also deletes its windows.
+	web.arena.Database.CreateTeamUnavailability(
+		&model.TeamUnavailability{TeamId: 254, StartTime: time.Now(), EndTime: time.Now().Add(time.Hour)},
+	)
+	recorder = web.postHttpResponse("/setup/teams/254/delete", "")
+	assert.Equal(t, 303, recorder.Code)
+	unavailabilities, _ = web.arena.Database.GetAllTeamUnavailabilities()
+	assert.Empty(t, unavailabilities)
+}
+
 func TestSetupTeamsProgress(t *testing.T) {
 	web := setupTestWeb(t)
 	progressPercentage = 25.4
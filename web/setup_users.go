@@ -0,0 +1,152 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web routes for managing user accounts and their roles.
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"net/http"
+	"strconv"
+)
+
+// Shows the user account configuration page.
+func (web *Web) usersGetHandler(w http.ResponseWriter, r *http.Request) {
+	web.renderUsers(w, r, "")
+}
+
+// Saves the new or modified user account to the database, or deletes it.
+func (web *Web) usersPostHandler(w http.ResponseWriter, r *http.Request) {
+	userId, _ := strconv.Atoi(r.PostFormValue("id"))
+	var user model.User
+	if userId > 0 {
+		existingUser, err := web.arena.Database.GetUserById(userId)
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if existingUser == nil {
+			handleWebErr(w, fmt.Errorf("Error: No such user: %d", userId))
+			return
+		}
+		user = *existingUser
+	}
+	previousUser := user
+	previousUsername := user.Username
+
+	users, err := web.arena.Database.GetAllUsers()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	var updatedUsers []model.User
+	for _, existingUser := range users {
+		if existingUser.Id != userId {
+			updatedUsers = append(updatedUsers, existingUser)
+		}
+	}
+
+	if r.PostFormValue("action") == "delete" {
+		if err = web.checkAdminAccessRetained(updatedUsers); err != nil {
+			web.renderUsers(w, r, err.Error())
+			return
+		}
+		if err = web.arena.Database.DeleteUser(userId); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		web.recordAuditEntry(r, "user.delete", strconv.Itoa(userId), previousUser, nil)
+	} else {
+		user.Username = r.PostFormValue("username")
+		user.Role = model.UserRole(r.PostFormValue("role"))
+		if user.Username == adminUser {
+			web.renderUsers(w, r, fmt.Sprintf("The username %q is reserved for the built-in admin account.", adminUser))
+			return
+		}
+		password := r.PostFormValue("password")
+		if password != "" || userId == 0 {
+			if err = user.SetPassword(password); err != nil {
+				web.renderUsers(w, r, err.Error())
+				return
+			}
+		}
+		if err = web.checkAdminAccessRetained(append(updatedUsers, user)); err != nil {
+			web.renderUsers(w, r, err.Error())
+			return
+		}
+
+		if userId > 0 {
+			err = web.arena.Database.UpdateUser(&user)
+		} else {
+			err = web.arena.Database.CreateUser(&user)
+		}
+		if err != nil {
+			web.renderUsers(w, r, err.Error())
+			return
+		}
+		if userId > 0 {
+			web.recordAuditEntry(r, "user.edit", strconv.Itoa(user.Id), previousUser, user)
+		} else {
+			web.recordAuditEntry(r, "user.create", strconv.Itoa(user.Id), nil, user)
+		}
+		if userId > 0 && password == "" && user.Username == previousUsername {
+			// Leave the user's existing sessions in place since their credentials haven't changed.
+			http.Redirect(w, r, "/setup/users", 303)
+			return
+		}
+	}
+
+	// Force the user to log in again with their new credentials, if they have any.
+	if previousUsername != "" {
+		if err = web.arena.Database.DeleteUserSessionsForUsername(previousUsername); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+	}
+
+	http.Redirect(w, r, "/setup/users", 303)
+}
+
+func (web *Web) renderUsers(w http.ResponseWriter, r *http.Request, errorMessage string) {
+	template, err := web.parseFiles("templates/setup_users.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	users, err := web.arena.Database.GetAllUsers()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	// Append a blank user to the end that can be used to add a new one.
+	users = append(users, model.User{Role: model.ReadOnlyRole})
+
+	data := struct {
+		*model.EventSettings
+		Users        []model.User
+		Roles        []model.UserRole
+		ErrorMessage string
+	}{web.arena.EventSettings, users, model.UserRoles, errorMessage}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Returns an error if the given set of user accounts would leave nobody able to log in as an admin.
+func (web *Web) checkAdminAccessRetained(users []model.User) error {
+	if web.arena.EventSettings.AdminPassword != "" || len(users) == 0 {
+		// Either the built-in admin account is usable or authentication is disabled altogether.
+		return nil
+	}
+	for _, user := range users {
+		if user.Role == model.AdminRole {
+			return nil
+		}
+	}
+	return fmt.Errorf("At least one account must have the admin role unless an admin password is set.")
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSetupUsers(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.EventSettings.AdminPassword = "admin"
+	recorder := web.postHttpResponse("/login", "username=admin&password=admin")
+	headers := map[string]string{"Cookie": recorder.Header().Get("Set-Cookie")}
+
+	recorder = web.getHttpResponseWithHeaders("/setup/users", headers)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Head Referee")
+
+	recorder = web.postHttpResponseWithHeaders(
+		"/setup/users", "action=save&id=0&username=ref&role=head_referee&password=whistle", headers,
+	)
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	user, _ := web.arena.Database.GetUserByUsername("ref")
+	if assert.NotNil(t, user) {
+		assert.Equal(t, model.HeadRefereeRole, user.Role)
+		assert.True(t, user.CheckPassword("whistle"))
+	}
+	recorder = web.getHttpResponseWithHeaders("/setup/users", headers)
+	assert.Contains(t, recorder.Body.String(), "value=\"ref\"")
+
+	// Check validation failures.
+	recorder = web.postHttpResponseWithHeaders(
+		"/setup/users", "action=save&id=0&username=ref&role=scorer&password=foo", headers,
+	)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "a user with the username \"ref\" already exists")
+	recorder = web.postHttpResponseWithHeaders(
+		"/setup/users", "action=save&id=0&username=admin&role=scorer&password=foo", headers,
+	)
+	assert.Contains(t, recorder.Body.String(), "is reserved for the built-in admin account")
+	recorder = web.postHttpResponseWithHeaders("/setup/users", "action=save&id=0&username=x&role=scorer", headers)
+	assert.Contains(t, recorder.Body.String(), "password cannot be blank")
+
+	// Check that changing the role without a password keeps the existing password and sessions.
+	session := model.UserSession{Token: "ref-token", Username: "ref", CreatedAt: time.Now()}
+	assert.Nil(t, web.arena.Database.CreateUserSession(&session))
+	recorder = web.postHttpResponseWithHeaders(
+		"/setup/users", "action=save&id="+strconv.Itoa(user.Id)+"&username=ref&role=scorer&password=", headers,
+	)
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	user, _ = web.arena.Database.GetUserById(user.Id)
+	assert.Equal(t, model.ScorerRole, user.Role)
+	assert.True(t, user.CheckPassword("whistle"))
+	session2, _ := web.arena.Database.GetUserSessionByToken("ref-token")
+	assert.NotNil(t, session2)
+
+	// Check that an admin account is required once there is no admin password.
+	web.arena.EventSettings.AdminPassword = ""
+	recorder = web.postHttpResponseWithHeaders(
+		"/setup/users", "action=save&id=0&username=a&role=queuer&password=b", headers,
+	)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "At least one account must have the admin role")
+	web.arena.EventSettings.AdminPassword = "admin"
+
+	// Check that deleting the account logs it out.
+	recorder = web.postHttpResponseWithHeaders("/setup/users", "action=delete&id="+strconv.Itoa(user.Id), headers)
+	assert.Equal(t, 303, recorder.Code)
+	user, _ = web.arena.Database.GetUserById(user.Id)
+	assert.Nil(t, user)
+	session2, _ = web.arena.Database.GetUserSessionByToken("ref-token")
+	assert.Nil(t, session2)
+
+	// Check that the admin password can't be cleared from the settings page while no account has the admin role.
+	queuer := model.User{Username: "queuer", Role: model.QueuerRole}
+	assert.Nil(t, queuer.SetPassword("line"))
+	assert.Nil(t, web.arena.Database.CreateUser(&queuer))
+	recorder = web.postHttpResponseWithHeaders("/setup/settings", "name=Cleared&adminPassword=", headers)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "At least one account must have the admin role")
+	assert.Equal(t, "admin", web.arena.EventSettings.AdminPassword)
+	assert.NotEqual(t, "Cleared", web.arena.EventSettings.Name)
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web routes for managing outbound webhooks and monitoring the log of deliveries made to them.
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+)
+
+// Maximum number of deliveries to show in the log on the webhooks page.
+const webhookDeliveriesShown = 100
+
+// Shows the webhook configuration and delivery log page.
+func (web *Web) webhooksGetHandler(w http.ResponseWriter, r *http.Request) {
+	web.renderWebhooks(w, r, "")
+}
+
+// Saves the new or modified webhook to the database, or deletes it.
+func (web *Web) webhooksPostHandler(w http.ResponseWriter, r *http.Request) {
+	webhookId, _ := strconv.Atoi(r.PostFormValue("id"))
+	var webhook model.Webhook
+	if webhookId > 0 {
+		existingWebhook, err := web.arena.Database.GetWebhookById(webhookId)
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if existingWebhook == nil {
+			handleWebErr(w, fmt.Errorf("Error: No such webhook: %d", webhookId))
+			return
+		}
+		webhook = *existingWebhook
+	}
+	previousWebhook := webhook
+
+	if r.PostFormValue("action") == "delete" {
+		if err := web.arena.Database.DeleteWebhook(webhookId); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if err := web.arena.Database.DeleteWebhookDeliveriesByWebhook(webhookId); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		web.recordAuditEntry(r, "webhook.delete", strconv.Itoa(webhookId), previousWebhook, nil)
+		http.Redirect(w, r, "/setup/webhooks", 303)
+		return
+	}
+
+	webhook.Url = r.PostFormValue("url")
+	if parsedUrl, err := url.Parse(webhook.Url); err != nil ||
+		(parsedUrl.Scheme != "http" && parsedUrl.Scheme != "https") || parsedUrl.Host == "" {
+		web.renderWebhooks(w, r, "Webhook URL must be an absolute http or https URL.")
+		return
+	}
+	if secret := r.PostFormValue("secret"); secret != "" || webhookId == 0 {
+		webhook.Secret = secret
+	}
+	webhook.Enabled = r.PostFormValue("enabled") == "on"
+	webhook.Events = nil
+	validEvents := web.arena.WebhookEvents()
+	for _, event := range r.Form["events"] {
+		if !slices.Contains(validEvents, event) {
+			web.renderWebhooks(w, r, fmt.Sprintf("Invalid webhook event %q.", event))
+			return
+		}
+		webhook.Events = append(webhook.Events, event)
+	}
+
+	var err error
+	if webhookId > 0 {
+		err = web.arena.Database.UpdateWebhook(&webhook)
+	} else {
+		err = web.arena.Database.CreateWebhook(&webhook)
+	}
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if webhookId > 0 {
+		web.recordAuditEntry(r, "webhook.edit", strconv.Itoa(webhook.Id), previousWebhook, webhook)
+	} else {
+		web.recordAuditEntry(r, "webhook.create", strconv.Itoa(webhook.Id), nil, webhook)
+	}
+
+	http.Redirect(w, r, "/setup/webhooks", 303)
+}
+
+// Queues the given delivery to be sent again immediately.
+func (web *Web) webhookRedeliverPostHandler(w http.ResponseWriter, r *http.Request) {
+	deliveryId, _ := strconv.Atoi(r.PathValue("deliveryId"))
+	if err := web.arena.WebhookDispatcher.Redeliver(web.arena.Database, deliveryId); err != nil {
+		web.renderWebhooks(w, r, err.Error())
+		return
+	}
+	web.recordAuditEntry(r, "webhook.redeliver", strconv.Itoa(deliveryId), nil, nil)
+	http.Redirect(w, r, "/setup/webhooks", 303)
+}
+
+func (web *Web) renderWebhooks(w http.ResponseWriter, r *http.Request, errorMessage string) {
+	template, err := web.parseFiles("templates/setup_webhooks.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	webhooks, err := web.arena.Database.GetAllWebhooks()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	deliveries, err := web.arena.Database.GetAllWebhookDeliveries()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if len(deliveries) > webhookDeliveriesShown {
+		deliveries = deliveries[:webhookDeliveriesShown]
+	}
+
+	// Append a blank webhook to the end that can be used to add a new one.
+	webhooks = append(webhooks, model.Webhook{Enabled: true})
+
+	data := struct {
+		*model.EventSettings
+		Webhooks        []model.Webhook
+		Events          []string
+		Deliveries      []model.WebhookDelivery
+		MaxAttempts     int
+		SignatureHeader string
+		ErrorMessage    string
+	}{
+		web.arena.EventSettings,
+		webhooks,
+		web.arena.WebhookEvents(),
+		deliveries,
+		partner.WebhookMaxAttempts,
+		partner.WebhookSignatureHeader,
+		errorMessage,
+	}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
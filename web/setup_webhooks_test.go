@@ -0,0 +1,80 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSetupWebhooks(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/setup/webhooks")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Delivery Log")
+	assert.Contains(t, recorder.Body.String(), "scorePosted")
+
+	// Create a webhook.
+	recorder = web.postHttpResponse(
+		"/setup/webhooks",
+		"url=http://scoreboard.local/hook&secret=shh&events=matchLoad&events=scorePosted&enabled=on",
+	)
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	webhook, _ := web.arena.Database.GetWebhookById(1)
+	if assert.NotNil(t, webhook) {
+		assert.Equal(t, "http://scoreboard.local/hook", webhook.Url)
+		assert.Equal(t, "shh", webhook.Secret)
+		assert.Equal(t, []string{"matchLoad", "scorePosted"}, webhook.Events)
+		assert.True(t, webhook.Enabled)
+	}
+	recorder = web.getHttpResponse("/setup/webhooks")
+	assert.Contains(t, recorder.Body.String(), "http://scoreboard.local/hook")
+	assert.NotContains(t, recorder.Body.String(), "shh")
+
+	// Edit the webhook and check that the secret is left unchanged if not given.
+	recorder = web.postHttpResponse("/setup/webhooks", "id=1&url=https://scoreboard.local/hook2")
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	webhook, _ = web.arena.Database.GetWebhookById(1)
+	assert.Equal(t, "https://scoreboard.local/hook2", webhook.Url)
+	assert.Equal(t, "shh", webhook.Secret)
+	assert.Empty(t, webhook.Events)
+	assert.False(t, webhook.Enabled)
+
+	// Redeliver a delivery.
+	delivery := model.WebhookDelivery{WebhookId: 1, Event: "matchLoad", Payload: "{}", Attempts: 8}
+	web.arena.Database.CreateWebhookDelivery(&delivery)
+	recorder = web.postHttpResponse("/setup/webhooks/deliveries/1/redeliver", "")
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	deliveries, _ := web.arena.Database.GetPendingWebhookDeliveries()
+	assert.Equal(t, 1, len(deliveries))
+
+	// Delete the webhook and check that its deliveries are also deleted.
+	recorder = web.postHttpResponse("/setup/webhooks", "id=1&action=delete")
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	webhooks, _ := web.arena.Database.GetAllWebhooks()
+	assert.Empty(t, webhooks)
+	deliveries, _ = web.arena.Database.GetAllWebhookDeliveries()
+	assert.Empty(t, deliveries)
+}
+
+func TestSetupWebhooksErrors(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.postHttpResponse("/setup/webhooks", "url=scoreboard.local")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Webhook URL must be an absolute http or https URL.")
+
+	recorder = web.postHttpResponse("/setup/webhooks", "url=http://scoreboard.local&events=blorpy")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Invalid webhook event &#34;blorpy&#34;.")
+
+	recorder = web.postHttpResponse("/setup/webhooks/deliveries/5/redeliver", "")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "webhook delivery 5 does not exist")
+
+	webhooks, _ := web.arena.Database.GetAllWebhooks()
+	assert.Empty(t, webhooks)
+}
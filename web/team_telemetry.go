@@ -0,0 +1,336 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web routes for viewing each team's driver station telemetry aggregated across all of its match logs.
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/matchlog"
+	"github.com/Team254/cheesy-arena/model"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+const (
+	// Battery voltage below which the roboRIO browns out and disables its outputs.
+	brownoutVoltage = 6.8
+
+	// Number of most recent matches considered when looking for trends.
+	telemetryTrendMatches = 3
+
+	// Thresholds beyond which a team's recent telemetry is flagged for the FTA.
+	lowBatteryVoltage    = 7.5
+	decliningVoltageMax  = 9.0
+	highTripTimeMs       = 20.0
+	risingTripTimeFactor = 2.0
+	highMissedPackets    = 50
+)
+
+// Summary of a team's driver station telemetry over a single match log.
+type MatchTelemetry struct {
+	StartTime          string
+	MatchType          string
+	MatchShortName     string
+	MatchId            int
+	AllianceStation    string
+	SampleCount        int
+	MinBatteryVoltage  float64
+	AvgBatteryVoltage  float64
+	AvgTripTimeMs      float64
+	MaxTripTimeMs      int
+	MaxMissedPackets   int
+	BrownoutCount      int
+	LinkDropCount      int
+	RobotLinkedPercent float64
+}
+
+// A team's telemetry across every match it has a log for, in chronological order.
+type TeamTelemetry struct {
+	TeamId  int
+	Matches []MatchTelemetry
+	Flags   []string
+}
+
+// Returns the lowest battery voltage the team reported in any match.
+func (telemetry *TeamTelemetry) MinBatteryVoltage() float64 {
+	minVoltage := 0.0
+	for _, match := range telemetry.Matches {
+		if match.MinBatteryVoltage > 0 && (minVoltage == 0 || match.MinBatteryVoltage < minVoltage) {
+			minVoltage = match.MinBatteryVoltage
+		}
+	}
+	return minVoltage
+}
+
+// Returns the average of the team's per-match average trip times.
+func (telemetry *TeamTelemetry) AvgTripTimeMs() float64 {
+	return averageTripTimeMs(telemetry.Matches)
+}
+
+// Returns the total number of brownouts across all of the team's matches.
+func (telemetry *TeamTelemetry) BrownoutCount() int {
+	count := 0
+	for _, match := range telemetry.Matches {
+		count += match.BrownoutCount
+	}
+	return count
+}
+
+// Returns the total number of robot link drops across all of the team's matches.
+func (telemetry *TeamTelemetry) LinkDropCount() int {
+	count := 0
+	for _, match := range telemetry.Matches {
+		count += match.LinkDropCount
+	}
+	return count
+}
+
+// Shows the per-team telemetry overview, with flagged teams listed first.
+func (web *Web) teamTelemetryHandler(w http.ResponseWriter, r *http.Request) {
+	telemetryByTeam, err := web.buildTeamTelemetry()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	var flaggedTeams, otherTeams []*TeamTelemetry
+	for _, telemetry := range telemetryByTeam {
+		if len(telemetry.Flags) > 0 {
+			flaggedTeams = append(flaggedTeams, telemetry)
+		} else {
+			otherTeams = append(otherTeams, telemetry)
+		}
+	}
+	sortTeamTelemetry(flaggedTeams)
+	sortTeamTelemetry(otherTeams)
+
+	template, err := web.parseFiles("templates/team_telemetry.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		FlaggedTeams []*TeamTelemetry
+		OtherTeams   []*TeamTelemetry
+	}{web.arena.EventSettings, flaggedTeams, otherTeams}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Shows the charts of a single team's telemetry across all of its matches.
+func (web *Web) teamTelemetryViewHandler(w http.ResponseWriter, r *http.Request) {
+	teamId, err := strconv.Atoi(r.PathValue("teamId"))
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	telemetryByTeam, err := web.buildTeamTelemetry()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	telemetry, ok := telemetryByTeam[teamId]
+	if !ok {
+		telemetry = &TeamTelemetry{TeamId: teamId}
+	}
+
+	template, err := web.parseFiles("templates/view_team_telemetry.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		Telemetry *TeamTelemetry
+	}{web.arena.EventSettings, telemetry}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Reads every match log and groups the resulting per-station summaries by team.
+func (web *Web) buildTeamTelemetry() (map[int]*TeamTelemetry, error) {
+	filenames, err := matchlog.List(field.LogsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	telemetryByTeam := make(map[int]*TeamTelemetry)
+	for _, filename := range filenames {
+		bundle, err := matchlog.Read(filename)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		for _, station := range []string{"R1", "R2", "R3", "B1", "B2", "B3"} {
+			teamId := bundle.Header.TeamIds[station]
+			if teamId == 0 {
+				continue
+			}
+			matchTelemetry := summarizeMatchLog(matchLogFromBundle(bundle, station))
+			matchTelemetry.MatchType = bundle.Header.MatchType
+			matchTelemetry.MatchShortName = bundle.Header.MatchShortName
+			matchTelemetry.MatchId = bundle.Header.MatchId
+			matchTelemetry.AllianceStation = station
+
+			telemetry, ok := telemetryByTeam[teamId]
+			if !ok {
+				telemetry = &TeamTelemetry{TeamId: teamId}
+				telemetryByTeam[teamId] = telemetry
+			}
+			telemetry.Matches = append(telemetry.Matches, matchTelemetry)
+		}
+	}
+
+	for _, telemetry := range telemetryByTeam {
+		telemetry.Flags = flagTelemetryTrends(telemetry.Matches)
+	}
+	return telemetryByTeam, nil
+}
+
+// Reduces the rows of the given match log to the statistics that are tracked across matches.
+func summarizeMatchLog(matchLog MatchLog) MatchTelemetry {
+	telemetry := MatchTelemetry{StartTime: matchLog.StartTime, SampleCount: len(matchLog.Rows)}
+
+	var voltageSum, tripTimeSum float64
+	var voltageCount, linkedCount int
+	wasLinked, wasBrownedOut := false, false
+	for _, row := range matchLog.Rows {
+		if row.RobotLinked {
+			linkedCount++
+			tripTimeSum += float64(row.DsRobotTripTimeMs)
+			telemetry.MaxTripTimeMs = max(telemetry.MaxTripTimeMs, row.DsRobotTripTimeMs)
+
+			// The driver station only reports the battery voltage while it can reach the robot.
+			if row.BatteryVoltage > 0 {
+				voltageSum += row.BatteryVoltage
+				voltageCount++
+				if telemetry.MinBatteryVoltage == 0 || row.BatteryVoltage < telemetry.MinBatteryVoltage {
+					telemetry.MinBatteryVoltage = row.BatteryVoltage
+				}
+				isBrownedOut := row.BatteryVoltage < brownoutVoltage
+				if isBrownedOut && !wasBrownedOut {
+					telemetry.BrownoutCount++
+				}
+				wasBrownedOut = isBrownedOut
+			}
+		} else if wasLinked {
+			telemetry.LinkDropCount++
+		}
+		wasLinked = row.RobotLinked
+		telemetry.MaxMissedPackets = max(telemetry.MaxMissedPackets, row.MissedPacketCount)
+	}
+
+	if voltageCount > 0 {
+		telemetry.AvgBatteryVoltage = voltageSum / float64(voltageCount)
+	}
+	if linkedCount > 0 {
+		telemetry.AvgTripTimeMs = tripTimeSum / float64(linkedCount)
+	}
+	if len(matchLog.Rows) > 0 {
+		telemetry.RobotLinkedPercent = 100 * float64(linkedCount) / float64(len(matchLog.Rows))
+	}
+	return telemetry
+}
+
+// Returns a description of each worrying trend in the team's most recent matches, or nil if there are none.
+func flagTelemetryTrends(matches []MatchTelemetry) []string {
+	if len(matches) == 0 {
+		return nil
+	}
+	recentMatches := matches[max(len(matches)-telemetryTrendMatches, 0):]
+	latest := matches[len(matches)-1]
+	var flags []string
+
+	brownoutMatches, linkDropMatches := 0, 0
+	for _, match := range recentMatches {
+		if match.BrownoutCount > 0 {
+			brownoutMatches++
+		}
+		if match.LinkDropCount > 0 {
+			linkDropMatches++
+		}
+	}
+	if brownoutMatches > 0 {
+		flags = append(
+			flags, fmt.Sprintf("Browned out in %d of the last %d matches", brownoutMatches, len(recentMatches)),
+		)
+	}
+	if linkDropMatches > 1 || latest.LinkDropCount > 1 {
+		flags = append(
+			flags, fmt.Sprintf("Lost robot link in %d of the last %d matches", linkDropMatches, len(recentMatches)),
+		)
+	}
+
+	if latest.MinBatteryVoltage > 0 && latest.MinBatteryVoltage < lowBatteryVoltage {
+		flags = append(flags, fmt.Sprintf("Battery dipped to %.1f V in the last match", latest.MinBatteryVoltage))
+	} else if len(recentMatches) == telemetryTrendMatches && latest.MinBatteryVoltage < decliningVoltageMax {
+		isDeclining := true
+		for i := 1; i < len(recentMatches); i++ {
+			if recentMatches[i].MinBatteryVoltage <= 0 ||
+				recentMatches[i].MinBatteryVoltage >= recentMatches[i-1].MinBatteryVoltage {
+				isDeclining = false
+			}
+		}
+		if isDeclining {
+			flags = append(
+				flags,
+				fmt.Sprintf(
+					"Minimum battery voltage has dropped for %d straight matches (now %.1f V)",
+					len(recentMatches),
+					latest.MinBatteryVoltage,
+				),
+			)
+		}
+	}
+
+	if latest.AvgTripTimeMs >= highTripTimeMs {
+		if earlierAvg := averageTripTimeMs(matches[:len(matches)-1]); earlierAvg > 0 &&
+			latest.AvgTripTimeMs >= risingTripTimeFactor*earlierAvg {
+			flags = append(
+				flags,
+				fmt.Sprintf("Average trip time rose to %.0f ms from %.0f ms", latest.AvgTripTimeMs, earlierAvg),
+			)
+		} else {
+			flags = append(flags, fmt.Sprintf("Average trip time was %.0f ms in the last match", latest.AvgTripTimeMs))
+		}
+	}
+
+	if latest.MaxMissedPackets >= highMissedPackets {
+		flags = append(flags, fmt.Sprintf("Missed up to %d packets in the last match", latest.MaxMissedPackets))
+	}
+
+	return flags
+}
+
+// Returns the average of the given matches' average trip times, ignoring matches where the robot never connected.
+func averageTripTimeMs(matches []MatchTelemetry) float64 {
+	sum, count := 0.0, 0
+	for _, match := range matches {
+		if match.AvgTripTimeMs > 0 {
+			sum += match.AvgTripTimeMs
+			count++
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+	return sum / float64(count)
+}
+
+func sortTeamTelemetry(telemetry []*TeamTelemetry) {
+	sort.Slice(telemetry, func(i, j int) bool {
+		return telemetry[i].TeamId < telemetry[j].TeamId
+	})
+}
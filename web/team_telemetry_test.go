@@ -0,0 +1,178 @@
+// Copyright 2025 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/matchlog"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTeamTelemetry(t *testing.T) {
+	web := setupTestWeb(t)
+	match := model.Match{Type: model.Qualification, ShortName: "Q1", Red1: 254}
+	assert.Nil(t, web.arena.Database.CreateMatch(&match))
+
+	recorder := web.getHttpResponse("/team_telemetry")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "No match logs have been recorded yet.")
+
+	writeTestMatchLog(
+		t,
+		&match,
+		map[string]testStationLog{
+			"R1": {254, []testLogSample{{true, 12.5, 3}, {true, 12.1, 4}}},
+			"B1": {1114, []testLogSample{{true, 12.0, 3}, {true, 6.5, 3}}},
+		},
+	)
+	recorder = web.getHttpResponse("/team_telemetry")
+	assert.Equal(t, 200, recorder.Code)
+	body := recorder.Body.String()
+	assert.Contains(t, body, "/team_telemetry/254")
+	assert.Contains(t, body, "Browned out in 1 of the last 1 matches")
+	// Flagged teams are listed first.
+	assert.Less(t, strings.Index(body, "/team_telemetry/1114"), strings.Index(body, "/team_telemetry/254"))
+
+	recorder = web.getHttpResponse("/team_telemetry/254")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Team Telemetry: 254")
+	assert.Contains(t, recorder.Body.String(), fmt.Sprintf("/match_logs/%d/R1/log", match.Id))
+	assert.Contains(t, recorder.Body.String(), fmt.Sprintf("/match_logs/%d/R1/csv", match.Id))
+
+	recorder = web.getHttpResponse("/team_telemetry/9999")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "No match logs have been recorded for this team yet.")
+
+	// Check that the pages require a scorekeeper login once authentication is enabled.
+	web.arena.EventSettings.AdminPassword = "admin"
+	recorder = web.getHttpResponse("/team_telemetry")
+	assert.Equal(t, 307, recorder.Code)
+	recorder = web.getHttpResponse("/team_telemetry/254")
+	assert.Equal(t, 307, recorder.Code)
+}
+
+func TestSummarizeMatchLog(t *testing.T) {
+	samples := []testLogSample{
+		{true, 12.0, 2}, {true, 6.5, 4}, {false, 6.5, 0}, {true, 6.7, 6}, {true, 11.0, 4}, {true, 6.6, 4},
+	}
+	matchLog := MatchLog{StartTime: "20250321100000"}
+	for _, sample := range samples {
+		matchLog.Rows = append(
+			matchLog.Rows,
+			MatchLogRow{
+				RobotLinked:       sample.robotLinked,
+				BatteryVoltage:    sample.batteryVoltage,
+				DsRobotTripTimeMs: sample.tripTimeMs,
+			},
+		)
+	}
+	telemetry := summarizeMatchLog(matchLog)
+	assert.Equal(t, "20250321100000", telemetry.StartTime)
+	assert.Equal(t, 6, telemetry.SampleCount)
+	assert.Equal(t, 6.5, telemetry.MinBatteryVoltage)
+	assert.InDelta(t, 8.56, telemetry.AvgBatteryVoltage, 0.001)
+	assert.Equal(t, 4.0, telemetry.AvgTripTimeMs)
+	assert.Equal(t, 6, telemetry.MaxTripTimeMs)
+	// Losing the robot link partway through a brownout doesn't count as a second one, but recovering does.
+	assert.Equal(t, 2, telemetry.BrownoutCount)
+	assert.Equal(t, 1, telemetry.LinkDropCount)
+	assert.InDelta(t, 83.33, telemetry.RobotLinkedPercent, 0.01)
+}
+
+func TestFlagTelemetryTrends(t *testing.T) {
+	healthy := MatchTelemetry{MinBatteryVoltage: 11.5, AvgTripTimeMs: 4, MaxMissedPackets: 2}
+	assert.Nil(t, flagTelemetryTrends(nil))
+	assert.Nil(t, flagTelemetryTrends([]MatchTelemetry{healthy, healthy, healthy, healthy}))
+
+	// A brownout or link drop that has aged out of the trend window is no longer flagged.
+	brownout := healthy
+	brownout.BrownoutCount = 1
+	linkDrop := healthy
+	linkDrop.LinkDropCount = 1
+	assert.Nil(t, flagTelemetryTrends([]MatchTelemetry{brownout, linkDrop, linkDrop, healthy, healthy, healthy}))
+	assert.Equal(
+		t,
+		[]string{"Browned out in 1 of the last 3 matches", "Lost robot link in 2 of the last 3 matches"},
+		flagTelemetryTrends([]MatchTelemetry{healthy, brownout, linkDrop, linkDrop}),
+	)
+
+	lowBattery := healthy
+	lowBattery.MinBatteryVoltage = 7.2
+	assert.Equal(
+		t, []string{"Battery dipped to 7.2 V in the last match"}, flagTelemetryTrends([]MatchTelemetry{lowBattery}),
+	)
+	declining := []MatchTelemetry{healthy, healthy, healthy, healthy}
+	declining[1].MinBatteryVoltage = 10.2
+	declining[2].MinBatteryVoltage = 9.4
+	declining[3].MinBatteryVoltage = 8.6
+	assert.Equal(
+		t,
+		[]string{"Minimum battery voltage has dropped for 3 straight matches (now 8.6 V)"},
+		flagTelemetryTrends(declining),
+	)
+
+	slow := healthy
+	slow.AvgTripTimeMs = 25
+	assert.Equal(
+		t,
+		[]string{"Average trip time rose to 25 ms from 4 ms"},
+		flagTelemetryTrends([]MatchTelemetry{healthy, healthy, slow}),
+	)
+	assert.Equal(t, []string{"Average trip time was 25 ms in the last match"}, flagTelemetryTrends([]MatchTelemetry{slow}))
+
+	lossy := healthy
+	lossy.MaxMissedPackets = 80
+	assert.Equal(t, []string{"Missed up to 80 packets in the last match"}, flagTelemetryTrends([]MatchTelemetry{lossy}))
+}
+
+type testLogSample struct {
+	robotLinked    bool
+	batteryVoltage float64
+	tripTimeMs     int
+}
+
+type testStationLog struct {
+	teamId  int
+	samples []testLogSample
+}
+
+// Writes a match log bundle for the given match containing the given driver station samples.
+func writeTestMatchLog(t *testing.T, match *model.Match, stationLogs map[string]testStationLog) {
+	header := matchlog.Header{
+		MatchId:        match.Id,
+		MatchType:      match.Type.String(),
+		MatchShortName: match.ShortName,
+		StartedAt:      time.Now(),
+		TeamIds:        make(map[string]int),
+	}
+	for station, stationLog := range stationLogs {
+		header.TeamIds[station] = stationLog.teamId
+	}
+	writer, err := matchlog.Create(field.LogsDir, header)
+	assert.Nil(t, err)
+	for station, stationLog := range stationLogs {
+		for i, sample := range stationLog.samples {
+			record := matchlog.Record{
+				Type:         matchlog.DsStatusRecord,
+				MatchTimeSec: float64(i) * 0.25,
+				Station:      station,
+				DsStatus: &matchlog.DsStatus{
+					TeamId:         stationLog.teamId,
+					DsLinked:       true,
+					RobotLinked:    sample.robotLinked,
+					Enabled:        true,
+					BatteryVoltage: sample.batteryVoltage,
+					TripTimeMs:     sample.tripTimeMs,
+				},
+			}
+			assert.Nil(t, writer.Write(record))
+		}
+	}
+	assert.Nil(t, writer.Close())
+}
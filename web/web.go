@@ -11,6 +11,7 @@ import (
 	"log"
 	"net/http"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"text/template"
@@ -21,9 +22,12 @@ import (
 
 const (
 	sessionTokenCookie = "session_token"
-	adminUser          = "admin"
+	adminUser          = model.AdminUsername
 )
 
+// Paths that still accept non-GET requests while this instance is a standby, so that it can be administered.
+var standbyWritablePaths = []string{"/login", "/logout", "/setup/replication/promote"}
+
 type Web struct {
 	arena           *field.Arena
 	templateHelpers template.FuncMap
@@ -52,6 +56,9 @@ func NewWeb(arena *field.Arena) *Web {
 		"add": func(a, b int) int {
 			return a + b
 		},
+		"contains": func(values []string, value string) bool {
+			return slices.Contains(values, value)
+		},
 		"itoa": func(a int) string {
 			return strconv.Itoa(a)
 		},
@@ -86,14 +93,23 @@ func NewWeb(arena *field.Arena) *Web {
 	return web
 }
 
-// Starts the webserver and blocks, waiting on requests. Does not return until the application exits.
-func (web *Web) ServeWebInterface(port int) {
-	http.Handle("/static/", http.StripPrefix("/static/", addNoCacheHeader(http.FileServer(http.Dir("static/")))))
+// Starts the webserver on the given address (e.g. ":8080") and blocks, waiting on requests. Serves HTTPS instead of
+// HTTP if a TLS certificate and key file are given. Does not return until the application exits.
+func (web *Web) ServeWebInterface(address, tlsCertFile, tlsKeyFile string) {
+	staticDir := http.Dir(filepath.Join(model.BaseDir, "static"))
+	http.Handle("/static/", http.StripPrefix("/static/", addNoCacheHeader(http.FileServer(staticDir))))
 	http.Handle("/", web.newHandler())
-	log.Printf("Serving HTTP requests on port %d", port)
 
 	// Start Server
-	http.ListenAndServe(fmt.Sprintf(":%d", port), nil)
+	var err error
+	if tlsCertFile != "" {
+		log.Printf("Serving HTTPS requests on %s", address)
+		err = http.ListenAndServeTLS(address, tlsCertFile, tlsKeyFile, nil)
+	} else {
+		log.Printf("Serving HTTP requests on %s", address)
+		err = http.ListenAndServe(address, nil)
+	}
+	log.Fatalf("Error serving web interface: %v", err)
 }
 
 // Serves the root page of Cheesy Arena.
@@ -127,12 +143,16 @@ func addNoCacheHeader(handler http.Handler) http.Handler {
 func (web *Web) newHandler() http.Handler {
 	mux := http.NewServeMux()
 	mux.HandleFunc("GET /", web.indexHandler)
-	mux.HandleFunc("GET /alliance_selection", web.allianceSelectionGetHandler)
-	mux.HandleFunc("POST /alliance_selection", web.allianceSelectionPostHandler)
-	mux.HandleFunc("GET /alliance_selection/websocket", web.allianceSelectionWebsocketHandler)
-	mux.HandleFunc("POST /alliance_selection/finalize", web.allianceSelectionFinalizeHandler)
-	mux.HandleFunc("POST /alliance_selection/reset", web.allianceSelectionResetHandler)
-	mux.HandleFunc("POST /alliance_selection/start", web.allianceSelectionStartHandler)
+	mux.HandleFunc("GET /alliance_selection", web.authorize(scorekeeperRoles, web.allianceSelectionGetHandler))
+	mux.HandleFunc("POST /alliance_selection", web.authorize(scorekeeperRoles, web.allianceSelectionPostHandler))
+	mux.HandleFunc(
+		"GET /alliance_selection/websocket", web.authorize(scorekeeperRoles, web.allianceSelectionWebsocketHandler),
+	)
+	mux.HandleFunc(
+		"POST /alliance_selection/finalize", web.authorize(scorekeeperRoles, web.allianceSelectionFinalizeHandler),
+	)
+	mux.HandleFunc("POST /alliance_selection/reset", web.authorize(scorekeeperRoles, web.allianceSelectionResetHandler))
+	mux.HandleFunc("POST /alliance_selection/start", web.authorize(scorekeeperRoles, web.allianceSelectionStartHandler))
 	mux.HandleFunc("GET /api/alliances", web.alliancesApiHandler)
 	mux.HandleFunc("GET /api/arena/websocket", web.arenaWebsocketApiHandler)
 	mux.HandleFunc("GET /api/bracket/svg", web.bracketSvgApiHandler)
@@ -167,27 +187,55 @@ func (web *Web) newHandler() http.Handler {
 	mux.HandleFunc("GET /displays/wall/websocket", web.wallDisplayWebsocketHandler)
 	mux.HandleFunc("GET /displays/webpage", web.webpageDisplayHandler)
 	mux.HandleFunc("GET /displays/webpage/websocket", web.webpageDisplayWebsocketHandler)
+	mux.HandleFunc("GET /field_alerts", web.authorize(scorekeeperRoles, web.fieldAlertsGetHandler))
+	mux.HandleFunc("GET /field_alerts/websocket", web.authorize(scorekeeperRoles, web.fieldAlertsWebsocketHandler))
 	mux.HandleFunc("GET /login", web.loginHandler)
 	mux.HandleFunc("POST /login", web.loginPostHandler)
-	mux.HandleFunc("GET /match_play", web.matchPlayHandler)
-	mux.HandleFunc("GET /match_play/match_load", web.matchPlayMatchLoadHandler)
-	mux.HandleFunc("GET /match_play/websocket", web.matchPlayWebsocketHandler)
+	mux.HandleFunc("GET /match_play", web.authorize(scorekeeperRoles, web.matchPlayHandler))
+	mux.HandleFunc("GET /match_play/match_load", web.authorize(scorekeeperRoles, web.matchPlayMatchLoadHandler))
+	mux.HandleFunc("GET /match_play/websocket", web.authorize(scorekeeperRoles, web.matchPlayWebsocketHandler))
 	mux.HandleFunc("GET /match_logs", web.matchLogsHandler)
+	mux.HandleFunc("GET /match_logs/{matchId}/{stationId}/csv", web.matchLogsCsvGetHandler)
 	mux.HandleFunc("GET /match_logs/{matchId}/{stationId}/log", web.matchLogsViewGetHandler)
 	mux.HandleFunc("GET /match_review", web.matchReviewHandler)
-	mux.HandleFunc("GET /match_review/{matchId}/edit", web.matchReviewEditGetHandler)
-	mux.HandleFunc("POST /match_review/{matchId}/edit", web.matchReviewEditPostHandler)
-	mux.HandleFunc("GET /panels/scoring/{position}", web.scoringPanelHandler)
-	mux.HandleFunc("GET /panels/scoring/{position}/websocket", web.scoringPanelWebsocketHandler)
-	mux.HandleFunc("GET /panels/referee", web.refereePanelHandler)
+	mux.HandleFunc("GET /match_review/{matchId}/edit", web.authorize(refereeRoles, web.matchReviewEditGetHandler))
+	mux.HandleFunc("POST /match_review/{matchId}/edit", web.authorize(refereeRoles, web.matchReviewEditPostHandler))
+	mux.HandleFunc("GET /match_review/{matchId}/events", web.authorize(viewerRoles, web.matchEventsGetHandler))
+	mux.HandleFunc("GET /match_review/{matchId}/events/csv", web.authorize(viewerRoles, web.matchEventsCsvHandler))
+	mux.HandleFunc("GET /match_review/{matchId}/events/json", web.authorize(viewerRoles, web.matchEventsJsonHandler))
+	mux.HandleFunc("POST /match_review/{matchId}/replay", web.authorize(scorekeeperRoles, web.matchReplayStartHandler))
+	mux.HandleFunc("POST /match_review/replay/stop", web.authorize(scorekeeperRoles, web.matchReplayStopHandler))
+	mux.HandleFunc("GET /nexus", web.authorize(scorekeeperRoles, web.nexusGetHandler))
+	mux.HandleFunc("POST /nexus/local/lineup", web.authorize(scorekeeperRoles, web.nexusLocalLineupPostHandler))
+	mux.HandleFunc(
+		"POST /nexus/local/parts_requests", web.authorize(scorekeeperRoles, web.nexusLocalPartsRequestPostHandler),
+	)
+	mux.HandleFunc(
+		"POST /nexus/local/parts_requests/{partsRequestId}/fulfill",
+		web.authorize(scorekeeperRoles, web.nexusLocalPartsRequestFulfillPostHandler),
+	)
+	mux.HandleFunc(
+		"POST /nexus/local/team_status", web.authorize(scorekeeperRoles, web.nexusLocalTeamStatusPostHandler),
+	)
+	mux.HandleFunc("POST /nexus/refresh", web.authorize(scorekeeperRoles, web.nexusRefreshPostHandler))
+	mux.HandleFunc("GET /panels/scoring/{position}", web.authorize(scorerRoles, web.scoringPanelHandler))
+	mux.HandleFunc(
+		"GET /panels/scoring/{position}/websocket", web.authorize(scorerRoles, web.scoringPanelWebsocketHandler),
+	)
+	mux.HandleFunc("GET /panels/referee", web.authorize(refereeRoles, web.refereePanelHandler))
 	mux.HandleFunc("GET /panels/referee/foul_list", web.refereePanelFoulListHandler)
-	mux.HandleFunc("GET /panels/referee/websocket", web.refereePanelWebsocketHandler)
+	mux.HandleFunc("GET /panels/referee/websocket", web.authorize(refereeRoles, web.refereePanelWebsocketHandler))
+	mux.HandleFunc("GET /practice_queue", web.authorize(queuerRoles, web.practiceQueueGetHandler))
+	mux.HandleFunc("POST /practice_queue/check_in", web.authorize(queuerRoles, web.practiceQueueCheckInPostHandler))
+	mux.HandleFunc(
+		"POST /practice_queue/{teamId}/check_out", web.authorize(queuerRoles, web.practiceQueueCheckOutPostHandler),
+	)
 	mux.HandleFunc("GET /reports/csv/backups", web.backupTeamsCsvReportHandler)
 	mux.HandleFunc("GET /reports/csv/fta", web.ftaCsvReportHandler)
 	mux.HandleFunc("GET /reports/csv/rankings", web.rankingsCsvReportHandler)
 	mux.HandleFunc("GET /reports/csv/schedule/{type}", web.scheduleCsvReportHandler)
 	mux.HandleFunc("GET /reports/csv/teams", web.teamsCsvReportHandler)
-	mux.HandleFunc("GET /reports/csv/wpa_keys", web.wpaKeysCsvReportHandler)
+	mux.HandleFunc("GET /reports/csv/wpa_keys", web.authorize(adminRoles, web.wpaKeysCsvReportHandler))
 	mux.HandleFunc("GET /reports/pdf/alliances", web.alliancesPdfReportHandler)
 	mux.HandleFunc("GET /reports/pdf/backups", web.backupsPdfReportHandler)
 	mux.HandleFunc("GET /reports/pdf/bracket", web.bracketPdfReportHandler)
@@ -197,44 +245,118 @@ func (web *Web) newHandler() http.Handler {
 	mux.HandleFunc("GET /reports/pdf/rankings", web.rankingsPdfReportHandler)
 	mux.HandleFunc("GET /reports/pdf/schedule/{type}", web.schedulePdfReportHandler)
 	mux.HandleFunc("GET /reports/pdf/teams", web.teamsPdfReportHandler)
-	mux.HandleFunc("GET /setup/awards", web.awardsGetHandler)
-	mux.HandleFunc("POST /setup/awards", web.awardsPostHandler)
-	mux.HandleFunc("GET /setup/breaks", web.breaksGetHandler)
-	mux.HandleFunc("POST /setup/breaks", web.breaksPostHandler)
-	mux.HandleFunc("POST /setup/db/clear/{type}", web.clearDbHandler)
-	mux.HandleFunc("POST /setup/db/restore", web.restoreDbHandler)
-	mux.HandleFunc("GET /setup/db/save", web.saveDbHandler)
-	mux.HandleFunc("GET /setup/displays", web.displaysGetHandler)
-	mux.HandleFunc("GET /setup/displays/websocket", web.displaysWebsocketHandler)
-	mux.HandleFunc("GET /setup/field_testing", web.fieldTestingGetHandler)
-	mux.HandleFunc("GET /setup/field_testing/websocket", web.fieldTestingWebsocketHandler)
-	mux.HandleFunc("GET /setup/judging", web.judgingGetHandler)
-	mux.HandleFunc("POST /setup/judging/clear", web.judgingClearPostHandler)
-	mux.HandleFunc("POST /setup/judging/generate", web.judgingGeneratePostHandler)
-	mux.HandleFunc("GET /setup/lower_thirds", web.lowerThirdsGetHandler)
-	mux.HandleFunc("GET /setup/lower_thirds/websocket", web.lowerThirdsWebsocketHandler)
-	mux.HandleFunc("GET /setup/schedule", web.scheduleGetHandler)
-	mux.HandleFunc("POST /setup/schedule/generate", web.scheduleGeneratePostHandler)
-	mux.HandleFunc("POST /setup/schedule/save", web.scheduleSavePostHandler)
-	mux.HandleFunc("GET /setup/settings", web.settingsGetHandler)
-	mux.HandleFunc("POST /setup/settings", web.settingsPostHandler)
-	mux.HandleFunc("GET /setup/settings/publish_alliances", web.settingsPublishAlliancesHandler)
-	mux.HandleFunc("GET /setup/settings/publish_awards", web.settingsPublishAwardsHandler)
-	mux.HandleFunc("GET /setup/settings/publish_matches", web.settingsPublishMatchesHandler)
-	mux.HandleFunc("GET /setup/settings/publish_rankings", web.settingsPublishRankingsHandler)
-	mux.HandleFunc("GET /setup/settings/publish_teams", web.settingsPublishTeamsHandler)
-	mux.HandleFunc("GET /setup/sponsor_slides", web.sponsorSlidesGetHandler)
-	mux.HandleFunc("POST /setup/sponsor_slides", web.sponsorSlidesPostHandler)
-	mux.HandleFunc("GET /setup/teams", web.teamsGetHandler)
-	mux.HandleFunc("POST /setup/teams", web.teamsPostHandler)
-	mux.HandleFunc("POST /setup/teams/{id}/delete", web.teamDeletePostHandler)
-	mux.HandleFunc("GET /setup/teams/{id}/edit", web.teamEditGetHandler)
-	mux.HandleFunc("POST /setup/teams/{id}/edit", web.teamEditPostHandler)
-	mux.HandleFunc("POST /setup/teams/clear", web.teamsClearHandler)
-	mux.HandleFunc("GET /setup/teams/generate_wpa_keys", web.teamsGenerateWpaKeysHandler)
+	mux.HandleFunc("GET /setup/api_tokens", web.authorize(adminRoles, web.apiTokensGetHandler))
+	mux.HandleFunc("POST /setup/api_tokens", web.authorize(adminRoles, web.apiTokensPostHandler))
+	mux.HandleFunc("GET /setup/audit", web.authorize(adminRoles, web.auditGetHandler))
+	mux.HandleFunc("GET /setup/audit/csv", web.authorize(adminRoles, web.auditCsvHandler))
+	mux.HandleFunc("GET /setup/awards", web.authorize(scorekeeperRoles, web.awardsGetHandler))
+	mux.HandleFunc("POST /setup/awards", web.authorize(scorekeeperRoles, web.awardsPostHandler))
+	mux.HandleFunc("GET /setup/breaks", web.authorize(scorekeeperRoles, web.breaksGetHandler))
+	mux.HandleFunc("POST /setup/breaks", web.authorize(scorekeeperRoles, web.breaksPostHandler))
+	mux.HandleFunc("POST /setup/db/clear/{type}", web.authorize(adminRoles, web.clearDbHandler))
+	mux.HandleFunc("POST /setup/db/restore", web.authorize(adminRoles, web.restoreDbHandler))
+	mux.HandleFunc("GET /setup/db/save", web.authorize(adminRoles, web.saveDbHandler))
+	mux.HandleFunc("GET /setup/displays", web.authorize(scorekeeperRoles, web.displaysGetHandler))
+	mux.HandleFunc("GET /setup/displays/websocket", web.authorize(scorekeeperRoles, web.displaysWebsocketHandler))
+	mux.HandleFunc("GET /setup/field_testing", web.authorize(adminRoles, web.fieldTestingGetHandler))
+	mux.HandleFunc("GET /setup/field_testing/websocket", web.authorize(adminRoles, web.fieldTestingWebsocketHandler))
+	mux.HandleFunc("GET /setup/inspection", web.authorize(scorekeeperRoles, web.inspectionGetHandler))
+	mux.HandleFunc("POST /setup/inspection/{teamId}", web.authorize(scorekeeperRoles, web.inspectionPostHandler))
+	mux.HandleFunc("GET /setup/judging", web.authorize(adminRoles, web.judgingGetHandler))
+	mux.HandleFunc("POST /setup/judging/clear", web.authorize(adminRoles, web.judgingClearPostHandler))
+	mux.HandleFunc("POST /setup/judging/generate", web.authorize(adminRoles, web.judgingGeneratePostHandler))
+	mux.HandleFunc("GET /setup/lower_thirds", web.authorize(scorekeeperRoles, web.lowerThirdsGetHandler))
+	mux.HandleFunc(
+		"GET /setup/lower_thirds/websocket", web.authorize(scorekeeperRoles, web.lowerThirdsWebsocketHandler),
+	)
+	mux.HandleFunc("GET /setup/replication", web.authorize(adminRoles, web.replicationGetHandler))
+	mux.HandleFunc("POST /setup/replication/promote", web.authorize(adminRoles, web.replicationPromotePostHandler))
+	mux.HandleFunc("POST /setup/replication/start", web.authorize(adminRoles, web.replicationStartPostHandler))
+	mux.HandleFunc(
+		"GET /setup/replication/websocket", web.authorize(adminRoles, web.replicationWebsocketHandler),
+	)
+	mux.HandleFunc("GET /setup/retiming", web.authorize(scorekeeperRoles, web.retimingGetHandler))
+	mux.HandleFunc("POST /setup/retiming/apply", web.authorize(scorekeeperRoles, web.retimingApplyPostHandler))
+	mux.HandleFunc("POST /setup/retiming/preview", web.authorize(scorekeeperRoles, web.retimingPreviewPostHandler))
+	mux.HandleFunc("GET /setup/schedule", web.authorize(adminRoles, web.scheduleGetHandler))
+	mux.HandleFunc("POST /setup/schedule/generate", web.authorize(adminRoles, web.scheduleGeneratePostHandler))
+	mux.HandleFunc("POST /setup/schedule/import", web.authorize(adminRoles, web.scheduleImportPostHandler))
+	mux.HandleFunc("POST /setup/schedule/save", web.authorize(adminRoles, web.scheduleSavePostHandler))
+	mux.HandleFunc("GET /setup/settings", web.authorize(adminRoles, web.settingsGetHandler))
+	mux.HandleFunc("POST /setup/settings", web.authorize(adminRoles, web.settingsPostHandler))
+	mux.HandleFunc(
+		"GET /setup/settings/publish_alliances", web.authorize(adminRoles, web.settingsPublishAlliancesHandler),
+	)
+	mux.HandleFunc("GET /setup/settings/publish_awards", web.authorize(adminRoles, web.settingsPublishAwardsHandler))
+	mux.HandleFunc("GET /setup/settings/publish_matches", web.authorize(adminRoles, web.settingsPublishMatchesHandler))
+	mux.HandleFunc(
+		"GET /setup/settings/publish_rankings", web.authorize(adminRoles, web.settingsPublishRankingsHandler),
+	)
+	mux.HandleFunc("GET /setup/settings/publish_teams", web.authorize(adminRoles, web.settingsPublishTeamsHandler))
+	mux.HandleFunc("GET /setup/sponsor_slides", web.authorize(scorekeeperRoles, web.sponsorSlidesGetHandler))
+	mux.HandleFunc("POST /setup/sponsor_slides", web.authorize(scorekeeperRoles, web.sponsorSlidesPostHandler))
+	mux.HandleFunc("GET /setup/tba_publishing", web.authorize(adminRoles, web.tbaPublishingGetHandler))
+	mux.HandleFunc("POST /setup/tba_publishing/retry", web.authorize(adminRoles, web.tbaPublishingRetryPostHandler))
+	mux.HandleFunc("GET /setup/teams", web.authorize(adminRoles, web.teamsGetHandler))
+	mux.HandleFunc("POST /setup/teams", web.authorize(adminRoles, web.teamsPostHandler))
+	mux.HandleFunc("POST /setup/teams/{id}/delete", web.authorize(adminRoles, web.teamDeletePostHandler))
+	mux.HandleFunc("GET /setup/teams/{id}/edit", web.authorize(adminRoles, web.teamEditGetHandler))
+	mux.HandleFunc("POST /setup/teams/{id}/edit", web.authorize(adminRoles, web.teamEditPostHandler))
+	mux.HandleFunc(
+		"POST /setup/teams/{id}/unavailability", web.authorize(adminRoles, web.teamUnavailabilityPostHandler),
+	)
+	mux.HandleFunc(
+		"POST /setup/teams/{id}/unavailability/{unavailabilityId}/delete",
+		web.authorize(adminRoles, web.teamUnavailabilityDeletePostHandler),
+	)
+	mux.HandleFunc("POST /setup/teams/clear", web.authorize(adminRoles, web.teamsClearHandler))
+	mux.HandleFunc("GET /setup/teams/generate_wpa_keys", web.authorize(adminRoles, web.teamsGenerateWpaKeysHandler))
 	mux.HandleFunc("GET /setup/teams/progress", web.teamsUpdateProgressBarHandler)
-	mux.HandleFunc("GET /setup/teams/refresh", web.teamsRefreshHandler)
-	return mux
+	mux.HandleFunc("GET /setup/teams/refresh", web.authorize(adminRoles, web.teamsRefreshHandler))
+	mux.HandleFunc("GET /setup/users", web.authorize(adminRoles, web.usersGetHandler))
+	mux.HandleFunc("POST /setup/users", web.authorize(adminRoles, web.usersPostHandler))
+	mux.HandleFunc("GET /setup/webhooks", web.authorize(adminRoles, web.webhooksGetHandler))
+	mux.HandleFunc("POST /setup/webhooks", web.authorize(adminRoles, web.webhooksPostHandler))
+	mux.HandleFunc(
+		"POST /setup/webhooks/deliveries/{deliveryId}/redeliver",
+		web.authorize(adminRoles, web.webhookRedeliverPostHandler),
+	)
+	mux.HandleFunc("GET /team_telemetry", web.authorize(scorekeeperRoles, web.teamTelemetryHandler))
+	mux.HandleFunc("GET /team_telemetry/{teamId}", web.authorize(scorekeeperRoles, web.teamTelemetryViewHandler))
+	web.addApiV1Routes(mux)
+	return web.rejectWritesOnStandby(mux)
+}
+
+// Wraps the given handler so that requests that would change data are refused while this instance is a standby
+// replicating from a primary, other than those needed to log in and promote it.
+func (web *Web) rejectWritesOnStandby(handler http.Handler) http.Handler {
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			replication := web.arena.Replication
+			if replication != nil && r.Method != http.MethodGet && r.Method != http.MethodHead &&
+				!slices.Contains(standbyWritablePaths, r.URL.Path) {
+				http.Error(
+					w,
+					fmt.Sprintf(
+						"This instance is a standby replicating from %s; promote it to primary before making changes.",
+						replication.PrimaryAddress,
+					),
+					http.StatusServiceUnavailable,
+				)
+				return
+			}
+			handler.ServeHTTP(w, r)
+		},
+	)
+}
+
+// Wraps the given handler so that it is only invoked for users holding one of the given roles (or admins).
+func (web *Web) authorize(roles []model.UserRole, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if web.userHasRole(w, r, roles...) {
+			handler(w, r)
+		}
+	}
 }
 
 // Writes the given error out as plain text with a status code of 500.
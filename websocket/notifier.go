@@ -7,11 +7,18 @@ package websocket
 
 import (
 	"log"
+	"reflect"
 	"sync"
 )
 
-// Allow the listeners to buffer a small number of notifications to streamline delivery.
-const notifyBufferSize = 5
+const (
+	// Allow the listeners to buffer a small number of notifications to streamline delivery.
+	notifyBufferSize = 5
+
+	// Allow server-side subscribers more leeway, since they may do slower work (e.g. writing to the database) for each
+	// message and there is no client to resynchronize them if a message is dropped.
+	subscribeBufferSize = 50
+)
 
 type Notifier struct {
 	messageType     string
@@ -31,6 +38,11 @@ func NewNotifier(messageType string, messageProducer func() any) *Notifier {
 	return notifier
 }
 
+// Returns the type of the messages sent by the notifier.
+func (notifier *Notifier) MessageType() string {
+	return notifier.messageType
+}
+
 // Calls the messageProducer function and sends a message containing the results to all registered listeners, and cleans
 // up any listeners that have closed.
 func (notifier *Notifier) Notify() {
@@ -70,10 +82,14 @@ func (notifier *Notifier) notifyListener(listener chan messageEnvelope, message
 // Registers and returns a channel that can be read from to receive notification messages. The caller is
 // responsible for closing the channel, which will cause it to be reaped from the list of listeners.
 func (notifier *Notifier) listen() chan messageEnvelope {
+	return notifier.listenWithBufferSize(notifyBufferSize)
+}
+
+func (notifier *Notifier) listenWithBufferSize(bufferSize int) chan messageEnvelope {
 	notifier.mutex.Lock()
 	defer notifier.mutex.Unlock()
 
-	listener := make(chan messageEnvelope, notifyBufferSize)
+	listener := make(chan messageEnvelope, bufferSize)
 	notifier.listeners[listener] = struct{}{}
 	return listener
 }
@@ -86,3 +102,28 @@ func (notifier *Notifier) getMessageBody() any {
 		return notifier.messageProducer()
 	}
 }
+
+// Subscribes to the given notifiers and calls the given function with the type and body of each message they send, in
+// the order received, until the done channel is closed. Intended for consumers within the server rather than websocket
+// clients; blocks until done, so it should be run in its own goroutine.
+func Subscribe(done <-chan struct{}, handleMessage func(messageType string, messageBody any), notifiers ...*Notifier) {
+	// Use reflection to dynamically build a select/case structure for all the notifiers.
+	cases := make([]reflect.SelectCase, len(notifiers)+1)
+	for i, notifier := range notifiers {
+		listener := notifier.listenWithBufferSize(subscribeBufferSize)
+		defer close(listener)
+		cases[i] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(listener)}
+	}
+	doneIndex := len(notifiers)
+	cases[doneIndex] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(done)}
+
+	for {
+		chosenIndex, value, ok := reflect.Select(cases)
+		if chosenIndex == doneIndex || !ok {
+			return
+		}
+		if message, ok := value.Interface().(messageEnvelope); ok {
+			handleMessage(message.messageType, message.messageBody)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"log"
 	"testing"
+	"time"
 )
 
 func TestNotifier(t *testing.T) {
@@ -88,3 +89,45 @@ func TestNotifyMultipleListeners(t *testing.T) {
 func generateTestMessage() any {
 	return "test message"
 }
+
+func TestSubscribe(t *testing.T) {
+	notifier1 := NewNotifier("type1", nil)
+	notifier2 := NewNotifier("type2", generateTestMessage)
+	done := make(chan struct{})
+	received := make(chan messageEnvelope, 10)
+	finished := make(chan struct{})
+	go func() {
+		Subscribe(
+			done,
+			func(messageType string, messageBody any) {
+				received <- messageEnvelope{messageType, messageBody}
+			},
+			notifier1,
+			notifier2,
+		)
+		close(finished)
+	}()
+
+	// Wait for the subscription to be registered before sending anything.
+	for {
+		notifier2.mutex.Lock()
+		numListeners := len(notifier2.listeners)
+		notifier2.mutex.Unlock()
+		if numListeners > 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	assert.Equal(t, "type2", notifier2.MessageType())
+
+	notifier1.NotifyWithMessage(254)
+	assert.Equal(t, messageEnvelope{"type1", 254}, <-received)
+	notifier2.Notify()
+	assert.Equal(t, messageEnvelope{"type2", "test message"}, <-received)
+
+	// Check that the listeners are cleaned up once the subscription is done.
+	close(done)
+	<-finished
+	notifier1.NotifyWithMessage(1114)
+	assert.Empty(t, notifier1.listeners)
+}